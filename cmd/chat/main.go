@@ -21,22 +21,42 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
 
-	chatdomain "chat-go/internal/chat/domain"
-	chathttp "chat-go/internal/chat/http"
-	chatrepository "chat-go/internal/chat/repository"
-	chatwebsocket "chat-go/internal/chat/websocket"
-	"chat-go/internal/common/repository"
-	"chat-go/internal/infrastructure/api"
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/infrastructure/connector"
-	"chat-go/internal/infrastructure/database/postgres"
-	"chat-go/internal/infrastructure/logger/logrus"
-	"chat-go/internal/infrastructure/validator"
-	usercontract "chat-go/internal/user/contract"
-	userdomain "chat-go/internal/user/domain"
-	userhttp "chat-go/internal/user/http"
+	botdomain "github.com/microcoretech/chat-go/internal/bot/domain"
+	bothttp "github.com/microcoretech/chat-go/internal/bot/http"
+	botrepository "github.com/microcoretech/chat-go/internal/bot/repository"
+	chatcontract "github.com/microcoretech/chat-go/internal/chat/contract"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chatgraphql "github.com/microcoretech/chat-go/internal/chat/graphql"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	chatrepository "github.com/microcoretech/chat-go/internal/chat/repository"
+	chatrpc "github.com/microcoretech/chat-go/internal/chat/rpc"
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/database/postgres"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger/logrus"
+	"github.com/microcoretech/chat-go/internal/infrastructure/rpc"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	notificationdomain "github.com/microcoretech/chat-go/internal/notification/domain"
+	notificationhttp "github.com/microcoretech/chat-go/internal/notification/http"
+	notificationrepository "github.com/microcoretech/chat-go/internal/notification/repository"
+	outboxconstants "github.com/microcoretech/chat-go/internal/outbox/constants"
+	outboxcontract "github.com/microcoretech/chat-go/internal/outbox/contract"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+	outboxrepository "github.com/microcoretech/chat-go/internal/outbox/repository"
+	usercontract "github.com/microcoretech/chat-go/internal/user/contract"
+	userdomain "github.com/microcoretech/chat-go/internal/user/domain"
+	userhttp "github.com/microcoretech/chat-go/internal/user/http"
+	userrepository "github.com/microcoretech/chat-go/internal/user/repository"
+	webhookconstants "github.com/microcoretech/chat-go/internal/webhook/constants"
+	webhookdomain "github.com/microcoretech/chat-go/internal/webhook/domain"
+	webhookhttp "github.com/microcoretech/chat-go/internal/webhook/http"
+	webhookrepository "github.com/microcoretech/chat-go/internal/webhook/repository"
 )
 
 func main() {
@@ -67,22 +87,233 @@ func main() {
 	chatRepo := chatrepository.NewChatRepoImpl(dbConn)
 	userChatRepo := chatrepository.NewUserChatRepoImpl(dbConn)
 	messageRepo := chatrepository.NewMessageRepoImpl(dbConn)
+	outboxRepo := outboxrepository.NewOutboxRepoImpl(dbConn)
+	webhookRepo := webhookrepository.NewWebhookRepoImpl(dbConn)
+	deliveryRepo := webhookrepository.NewDeliveryRepoImpl(dbConn)
+	botRepo := botrepository.NewBotRepoImpl(dbConn)
+	externalCommandRepo := chatrepository.NewExternalCommandRepoImpl(dbConn)
+	folderRepo := chatrepository.NewFolderRepoImpl(dbConn)
+	scheduledMessageRepo := chatrepository.NewScheduledMessageRepoImpl(dbConn)
+	pollRepo := chatrepository.NewPollRepoImpl(dbConn)
+	linkPreviewRepo := chatrepository.NewLinkPreviewRepoImpl(dbConn)
+	notificationRepo := notificationrepository.NewNotificationRepoImpl(dbConn)
+	deviceRepo := notificationrepository.NewDeviceRepoImpl(dbConn)
+	notificationDeliveryRepo := notificationrepository.NewDeliveryRepoImpl(dbConn)
+	presenceRepo := notificationrepository.NewPresenceRepoImpl(dbConn)
 
-	userService := userdomain.NewUserServiceImpl(cfg)
-	userServiceContract := usercontract.NewUserServiceContractImpl(userService)
-	chatService := chatdomain.NewChatServiceImpl(baseRepo, chatRepo, userChatRepo, userServiceContract)
-	messageService := chatdomain.NewMessageServiceImpl(messageRepo, userServiceContract)
+	eventPublisher := outboxcontract.NewEventPublisherContractImpl(outboxRepo)
 
-	eventHandler := chatwebsocket.NewEventHandler(validate, messageService)
+	var (
+		baseUserService  userdomain.UserService = userdomain.NewUserServiceImpl(cfg)
+		localUserService *userdomain.LocalUserServiceImpl
+	)
 
-	connector := connector.NewConnector(log, eventHandler)
+	switch cfg.AuthMode {
+	case configs.RemoteAuthMode:
+	case configs.LocalAuthMode:
+		localUserService = userdomain.NewLocalUserServiceImpl(cfg, userrepository.NewLocalUserRepoImpl(dbConn))
+		baseUserService = localUserService
+	case configs.JWTAuthMode:
+		keySet := userdomain.NewKeySet(cfg)
+		if err := keySet.Refresh(ctx); err != nil {
+			log.Fatal(fmt.Errorf("error on init JWKS: %w", err))
+		}
+
+		baseUserService = userdomain.NewJWTUserServiceImpl(cfg, keySet, baseUserService)
+	default:
+		log.Fatalf("unknown auth mode: %s", cfg.AuthMode)
+	}
+
+	userCache := userdomain.NewUserCache(cfg)
+	tokenCache := userdomain.NewTokenCache(cfg)
+	userService := userdomain.NewCachedUserServiceImpl(
+		baseUserService,
+		userCache,
+		tokenCache,
+		eventPublisher,
+	)
+	botService := botdomain.NewBotServiceImpl(botRepo)
+	userServiceContract := usercontract.NewUserServiceContractImpl(userService, botService)
+	chatService := chatdomain.NewChatServiceImpl(
+		baseRepo,
+		chatRepo,
+		userChatRepo,
+		folderRepo,
+		userServiceContract,
+		eventPublisher,
+	)
+
+	commandRouter := chatdomain.NewCommandRouter(externalCommandRepo, chatdomain.NewExternalCommandCallerImpl(cfg, log))
+	commandRouter.Register(chatdomain.MeCommandName, chatdomain.NewMeCommandHandler())
+	commandRouter.Register(chatdomain.TopicCommandName, chatdomain.NewTopicCommandHandler(chatService))
+	commandRouter.Register(chatdomain.InviteCommandName, chatdomain.NewInviteCommandHandler(chatService, userServiceContract))
+
+	messageService := chatdomain.NewMessageServiceImpl(
+		cfg,
+		baseRepo,
+		chatRepo,
+		userChatRepo,
+		messageRepo,
+		scheduledMessageRepo,
+		pollRepo,
+		userServiceContract,
+		eventPublisher,
+		commandRouter,
+	)
+	externalCommandService := chatdomain.NewExternalCommandServiceImpl(chatRepo, externalCommandRepo, commandRouter)
+	folderService := chatdomain.NewFolderServiceImpl(folderRepo)
+	pollService := chatdomain.NewPollServiceImpl(baseRepo, chatRepo, messageRepo, pollRepo, eventPublisher)
+	chatServiceContract := chatcontract.NewChatServiceContractImpl(chatRepo)
+	webhookService := webhookdomain.NewWebhookServiceImpl(webhookRepo, deliveryRepo)
+
+	var (
+		notificationChannels []notificationdomain.Channel
+		confirmationChannel  notificationdomain.Channel
+	)
+	if cfg.PushGatewayURL != "" {
+		notificationChannels = append(notificationChannels, notificationdomain.NewPushChannel(cfg))
+	}
+	if cfg.SMTPAddr != "" {
+		confirmationChannel = notificationdomain.NewEmailChannel(cfg)
+		notificationChannels = append(notificationChannels, confirmationChannel)
+	}
+
+	notificationService := notificationdomain.NewNotificationServiceImpl(deviceRepo, notificationDeliveryRepo, confirmationChannel)
+
+	eventHandler := chatwebsocket.NewEventHandler(validate, messageService, pollService, userServiceContract)
+
+	wsConnector := connector.NewConnector(log, eventHandler)
+	revalidator := connector.NewRevalidator(cfg, log, wsConnector, userServiceContract)
+
+	// Presences of users are shared with other instances under a random id.
+	presenceTracker := notificationdomain.NewPresenceTracker(
+		cfg,
+		log,
+		uuid.NewString(),
+		baseRepo,
+		presenceRepo,
+		chatwebsocket.NewPresence(wsConnector),
+	)
+
+	outboxWakeup, err := postgres.NewListener(ctx, cfg.PostgresURI, outboxconstants.NotificationChannel)
+	if err != nil {
+		log.Fatal(fmt.Errorf("error on listen to outbox notifications: %w", err))
+	}
+
+	dispatcher := outboxdomain.NewDispatcher(
+		cfg,
+		log,
+		baseRepo,
+		outboxRepo,
+		outboxWakeup,
+		outboxdomain.NewBroadcastSink(outboxRepo),
+		webhookdomain.NewEventSink(webhookRepo, deliveryRepo, chatServiceContract),
+		notificationdomain.NewEventSink(notificationRepo, chatServiceContract, presenceTracker),
+	)
+
+	// Every instance delivers the events to its own connections.
+	outboxBroadcasts, err := postgres.NewPayloadListener(ctx, cfg.PostgresURI, outboxconstants.BroadcastNotificationChannel)
+	if err != nil {
+		log.Fatal(fmt.Errorf("error on listen to outbox broadcasts: %w", err))
+	}
+
+	receiver := outboxdomain.NewReceiver(
+		cfg,
+		log,
+		outboxRepo,
+		outboxBroadcasts,
+		chatwebsocket.NewEventSink(log, wsConnector),
+		userdomain.NewUserCacheSink(userCache, tokenCache, wsConnector),
+	)
+
+	webhookWakeup, err := postgres.NewListener(ctx, cfg.PostgresURI, webhookconstants.NotificationChannel)
+	if err != nil {
+		log.Fatal(fmt.Errorf("error on listen to webhook notifications: %w", err))
+	}
+
+	deliveryWorker := webhookdomain.NewDeliveryWorker(cfg, log, baseRepo, webhookRepo, deliveryRepo, webhookWakeup)
+
+	retentionPurger := chatdomain.NewRetentionPurger(cfg, log, baseRepo, messageRepo, eventPublisher)
+	messageExpirer := chatdomain.NewMessageExpirer(cfg, log, baseRepo, messageRepo, eventPublisher)
+	messageScheduler := chatdomain.NewMessageScheduler(
+		cfg,
+		log,
+		baseRepo,
+		chatRepo,
+		messageRepo,
+		scheduledMessageRepo,
+		eventPublisher,
+	)
+
+	linkUnfurler := chatdomain.NewLinkUnfurler(
+		cfg,
+		log,
+		baseRepo,
+		messageRepo,
+		linkPreviewRepo,
+		chatdomain.NewLinkPreviewFetcherImpl(cfg),
+		eventPublisher,
+	)
+
+	notifier := notificationdomain.NewNotifier(
+		cfg,
+		log,
+		baseRepo,
+		notificationRepo,
+		deviceRepo,
+		notificationDeliveryRepo,
+		notificationChannels...,
+	)
 
 	authMiddleware := userhttp.NewAuthMiddleware(userService)
+	// Bots can use chats and webhooks, but not manage users or other bots.
+	botAuthMiddleware := bothttp.NewBotAuthMiddleware(botService, authMiddleware)
 
 	userController := userhttp.NewUserController(validate, authMiddleware, userService)
-	chatController := chathttp.NewChatController(validate, authMiddleware, chatService, messageService, connector)
+	userWebhookController := userhttp.NewUserWebhookController(cfg, validate, userService)
+	chatController := chathttp.NewChatController(cfg, validate, botAuthMiddleware, chatService, messageService, wsConnector, userServiceContract)
+	webhookController := webhookhttp.NewWebhookController(validate, botAuthMiddleware, webhookService)
+	botController := bothttp.NewBotController(validate, authMiddleware, botService)
+	commandController := chathttp.NewCommandController(validate, botAuthMiddleware, externalCommandService)
+	folderController := chathttp.NewFolderController(validate, authMiddleware, folderService)
+	scheduledMessageController := chathttp.NewScheduledMessageController(validate, authMiddleware, messageService)
+	pollController := chathttp.NewPollController(validate, botAuthMiddleware, pollService)
+	notificationController := notificationhttp.NewNotificationController(validate, authMiddleware, notificationService)
+
+	chatSchema, err := chatgraphql.NewSchema(cfg, log, validate, chatService, messageService, userServiceContract)
+	if err != nil {
+		log.Fatalf("error on create graphql schema: %s", err)
+	}
 
-	server := api.NewHTTPServer(cfg, log, userController, chatController)
+	graphQLController := chatgraphql.NewGraphQLController(cfg, validate, botAuthMiddleware, userServiceContract, wsConnector, chatSchema)
+
+	controllers := []api.Controller{
+		userController,
+		userWebhookController,
+		chatController,
+		webhookController,
+		botController,
+		commandController,
+		notificationController,
+		folderController,
+		scheduledMessageController,
+		pollController,
+		graphQLController,
+	}
+
+	if localUserService != nil {
+		controllers = append(controllers, userhttp.NewAuthController(validate, authMiddleware, localUserService, userService))
+	}
+
+	server := api.NewHTTPServer(cfg, log, controllers...)
+
+	grpcServer := rpc.NewGRPCServer(
+		cfg,
+		log,
+		userServiceContract,
+		chatrpc.NewChatServer(validate, chatService, wsConnector),
+		chatrpc.NewMessageServer(validate, messageService),
+	)
 
 	ctx, cancel = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 	defer cancel()
@@ -90,7 +321,7 @@ func main() {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
-		if err := connector.Start(ctx); err != nil {
+		if err := wsConnector.Start(ctx); err != nil {
 			log.Errorf("Error on running connector: %s", err.Error())
 			return err
 		}
@@ -100,6 +331,116 @@ func main() {
 		return nil
 	})
 
+	eg.Go(func() error {
+		if err := revalidator.Start(ctx); err != nil {
+			log.Errorf("Error on running connection revalidator: %s", err.Error())
+			return err
+		}
+
+		log.Info("Connection revalidator gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := dispatcher.Start(ctx); err != nil {
+			log.Errorf("Error on running outbox dispatcher: %s", err.Error())
+			return err
+		}
+
+		log.Info("Outbox dispatcher gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := receiver.Start(ctx); err != nil {
+			log.Errorf("Error on running outbox receiver: %s", err.Error())
+			return err
+		}
+
+		log.Info("Outbox receiver gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := deliveryWorker.Start(ctx); err != nil {
+			log.Errorf("Error on running webhook delivery worker: %s", err.Error())
+			return err
+		}
+
+		log.Info("Webhook delivery worker gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := notifier.Start(ctx); err != nil {
+			log.Errorf("Error on running notification notifier: %s", err.Error())
+			return err
+		}
+
+		log.Info("Notification notifier gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := presenceTracker.Start(ctx); err != nil {
+			log.Errorf("Error on running presence tracker: %s", err.Error())
+			return err
+		}
+
+		log.Info("Presence tracker gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := retentionPurger.Start(ctx); err != nil {
+			log.Errorf("Error on running retention purger: %s", err.Error())
+			return err
+		}
+
+		log.Info("Retention purger gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := messageExpirer.Start(ctx); err != nil {
+			log.Errorf("Error on running message expirer: %s", err.Error())
+			return err
+		}
+
+		log.Info("Message expirer gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := messageScheduler.Start(ctx); err != nil {
+			log.Errorf("Error on running message scheduler: %s", err.Error())
+			return err
+		}
+
+		log.Info("Message scheduler gracefully stopped")
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := linkUnfurler.Start(ctx); err != nil {
+			log.Errorf("Error on running link unfurler: %s", err.Error())
+			return err
+		}
+
+		log.Info("Link unfurler gracefully stopped")
+
+		return nil
+	})
+
 	eg.Go(func() error {
 		if err := server.Start(ctx); err != nil {
 			log.Errorf("Error on running server: %s", err.Error())
@@ -111,6 +452,32 @@ func main() {
 		return nil
 	})
 
+	if cfg.MetricsServerAddr != "" {
+		metricsServer := api.NewMetricsServer(cfg)
+
+		eg.Go(func() error {
+			if err := metricsServer.Start(ctx); err != nil {
+				log.Errorf("Error on running metrics server: %s", err.Error())
+				return err
+			}
+
+			log.Info("Metrics server gracefully stopped")
+
+			return nil
+		})
+	}
+
+	eg.Go(func() error {
+		if err := grpcServer.Start(ctx); err != nil {
+			log.Errorf("Error on running gRPC server: %s", err.Error())
+			return err
+		}
+
+		log.Info("gRPC server gracefully stopped")
+
+		return nil
+	})
+
 	if err = eg.Wait(); err != nil {
 		log.Error(err)
 	}
@@ -0,0 +1,40 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docs embeds the API documents generated by hack/apidocs, run
+// make docs after changing routes, DTOs or websocket events.
+package docs
+
+import (
+	_ "embed"
+)
+
+//go:embed openapi.json
+var OpenAPI []byte
+
+//go:embed asyncapi.json
+var AsyncAPI []byte
+
+// The page at /docs renders OpenAPI with a script of its own, it doesn't
+// load any third-party assets.
+var (
+	//go:embed ui/index.html
+	DocsPage []byte
+
+	//go:embed ui/docs.js
+	DocsScript []byte
+
+	//go:embed ui/docs.css
+	DocsStyle []byte
+)
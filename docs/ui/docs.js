@@ -0,0 +1,196 @@
+// Renders the OpenAPI document of the service, the page doesn't load any
+// third-party assets. Text is only ever set as textContent.
+"use strict";
+
+const methods = ["get", "post", "put", "patch", "delete"];
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    node.setAttribute(name, value);
+  }
+  for (const child of children) {
+    if (child === null || child === undefined) {
+      continue;
+    }
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function refName(ref) {
+  return ref.substring(ref.lastIndexOf("/") + 1);
+}
+
+function schemaType(schema) {
+  if (!schema) {
+    return "any";
+  }
+  if (schema.$ref) {
+    const name = refName(schema.$ref);
+    return el("a", { href: "#schema-" + name }, name);
+  }
+  if (schema.oneOf) {
+    const node = el("span");
+    schema.oneOf.forEach((option, index) => {
+      if (index > 0) {
+        node.append(" | ");
+      }
+      node.append(schemaType(option));
+    });
+    return node;
+  }
+  const types = [].concat(schema.type || "object");
+  const node = el("span");
+  types.forEach((type, index) => {
+    if (index > 0) {
+      node.append(" | ");
+    }
+    if (type === "array") {
+      node.append(schemaType(schema.items), "[]");
+    } else {
+      node.append(schema.format ? type + " (" + schema.format + ")" : type);
+    }
+  });
+  return node;
+}
+
+function constraints(schema) {
+  if (!schema) {
+    return "";
+  }
+  const keys = ["enum", "minimum", "maximum", "exclusiveMinimum", "minLength", "maxLength", "minItems", "maxItems", "pattern"];
+  return keys
+    .filter((key) => schema[key] !== undefined)
+    .map((key) => key + ": " + JSON.stringify(schema[key]))
+    .join(", ");
+}
+
+function parametersTable(parameters) {
+  const table = el("table", {}, el("tr", {}, el("th", {}, "Name"), el("th", {}, "In"), el("th", {}, "Type"), el("th", {}, "Constraints")));
+  for (const parameter of parameters) {
+    table.append(el("tr", {},
+      el("td", {}, el("code", {}, parameter.name), parameter.required ? " *" : ""),
+      el("td", {}, parameter.in),
+      el("td", {}, schemaType(parameter.schema)),
+      el("td", { class: "muted" }, constraints(parameter.schema))));
+  }
+  return table;
+}
+
+function contentSchema(content) {
+  const media = content && content["application/json"];
+  return media ? schemaType(media.schema) : null;
+}
+
+function operationDetails(path, method, operation) {
+  const body = el("div");
+
+  if (operation.description) {
+    body.append(el("p", {}, operation.description));
+  }
+  if (operation.security && operation.security.length > 0) {
+    body.append(el("p", { class: "muted" }, "Auth: " + operation.security.map((item) => Object.keys(item).join(" + ")).join(" or ")));
+  }
+  if (operation.parameters && operation.parameters.length > 0) {
+    body.append(el("h4", {}, "Parameters"), parametersTable(operation.parameters));
+  }
+  if (operation.requestBody) {
+    body.append(el("h4", {}, "Request body"), el("p", {}, contentSchema(operation.requestBody.content) || "any"));
+  }
+
+  const responses = el("table", {}, el("tr", {}, el("th", {}, "Status"), el("th", {}, "Description"), el("th", {}, "Body")));
+  for (const [status, response] of Object.entries(operation.responses || {})) {
+    responses.append(el("tr", {},
+      el("td", {}, status),
+      el("td", {}, response.description || ""),
+      el("td", {}, contentSchema(response.content))));
+  }
+  body.append(el("h4", {}, "Responses"), responses);
+
+  return el("details", { id: operation.operationId || method + path },
+    el("summary", {}, el("span", { class: "method " + method }, method), el("code", {}, path)),
+    body);
+}
+
+function schemaDetails(name, schema) {
+  const body = el("div");
+
+  if (schema.description) {
+    body.append(el("p", {}, schema.description));
+  }
+
+  if (schema.properties) {
+    const required = schema.required || [];
+    const table = el("table", {}, el("tr", {}, el("th", {}, "Property"), el("th", {}, "Type"), el("th", {}, "Constraints")));
+    for (const [property, propertySchema] of Object.entries(schema.properties)) {
+      table.append(el("tr", {},
+        el("td", {}, el("code", {}, property), required.includes(property) ? " *" : ""),
+        el("td", {}, schemaType(propertySchema), propertySchema.description ? el("div", { class: "muted" }, propertySchema.description) : null),
+        el("td", { class: "muted" }, constraints(propertySchema))));
+    }
+    body.append(table);
+  } else {
+    body.append(el("p", {}, schemaType(schema), " ", el("span", { class: "muted" }, constraints(schema))));
+  }
+
+  return el("details", { id: "schema-" + name }, el("summary", {}, el("code", {}, name)), body);
+}
+
+function render(spec) {
+  const root = el("main", { id: "docs" });
+  const info = spec.info || {};
+
+  root.append(el("h1", {}, (info.title || "API") + " " + (info.version || "")));
+  if (info.description) {
+    root.append(el("p", {}, info.description));
+  }
+  root.append(el("p", { class: "muted" }, "Raw documents: ",
+    el("a", { href: "/openapi.json" }, "openapi.json"), ", ",
+    el("a", { href: "/asyncapi.json" }, "asyncapi.json"), "."));
+
+  const tags = new Map();
+  for (const [path, item] of Object.entries(spec.paths || {})) {
+    for (const method of methods) {
+      const operation = item[method];
+      if (!operation) {
+        continue;
+      }
+      const tag = (operation.tags && operation.tags[0]) || "Other";
+      if (!tags.has(tag)) {
+        tags.set(tag, []);
+      }
+      tags.get(tag).push(operationDetails(path, method, operation));
+    }
+  }
+
+  for (const [tag, operations] of tags) {
+    root.append(el("h2", {}, tag), ...operations);
+  }
+
+  const schemas = (spec.components && spec.components.schemas) || {};
+  root.append(el("h2", {}, "Schemas"));
+  for (const name of Object.keys(schemas).sort()) {
+    root.append(schemaDetails(name, schemas[name]));
+  }
+
+  document.getElementById("docs").replaceWith(root);
+
+  // links to schemas open them.
+  document.addEventListener("click", (event) => {
+    const link = event.target.closest("a[href^='#schema-']");
+    if (link) {
+      const target = document.getElementById(link.getAttribute("href").substring(1));
+      if (target) {
+        target.open = true;
+      }
+    }
+  });
+}
+
+fetch("/openapi.json")
+  .then((response) => response.json())
+  .then(render)
+  .catch((err) => {
+    document.getElementById("docs").textContent = "Failed to load /openapi.json: " + err;
+  });
@@ -0,0 +1,461 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// maxCallDepth limits how far event types and payloads passed as arguments
+// are followed up the callers.
+const maxCallDepth = 4
+
+// eventTypes are the websocket event type constants by value.
+type eventTypes map[int64]string
+
+// events maps event types to the types of their payloads.
+type events map[int64][]types.Type
+
+// add records a payload of an event, a nil type records an event without
+// data.
+func (e events) add(eventType int64, t types.Type) {
+	if _, ok := e[eventType]; !ok {
+		e[eventType] = nil
+	}
+
+	if t == nil {
+		return
+	}
+
+	for _, existing := range e[eventType] {
+		if types.Identical(existing, t) {
+			return
+		}
+	}
+
+	e[eventType] = append(e[eventType], t)
+}
+
+func (s *source) eventTypes() eventTypes {
+	eventTypes := eventTypes{}
+
+	pkg := s.pkg(chatWsPkgPath)
+	if pkg == nil {
+		return eventTypes
+	}
+
+	for _, name := range pkg.Types.Scope().Names() {
+		if c, ok := pkg.Types.Scope().Lookup(name).(*types.Const); ok && strings.HasSuffix(name, "EventType") {
+			value, _ := constantInt(c)
+			eventTypes[value] = name
+		}
+	}
+
+	return eventTypes
+}
+
+// eventConst returns the value of an expression referring to an event type
+// constant.
+func eventConst(info *types.Info, expr ast.Expr) (int64, bool) {
+	var ident *ast.Ident
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = expr
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	}
+
+	if ident == nil {
+		return 0, false
+	}
+
+	c, ok := info.Uses[ident].(*types.Const)
+	if !ok || c.Pkg() == nil || c.Pkg().Path() != chatWsPkgPath || !strings.HasSuffix(c.Name(), "EventType") {
+		return 0, false
+	}
+
+	return constantInt(c)
+}
+
+// clientEvents finds the payloads of the events clients send: the event
+// handlers dispatched by a switch on the event type and the frames checked
+// against an event type before their data is unmarshalled.
+func (s *source) clientEvents() events {
+	events := events{}
+
+	for _, name := range sortedKeys(s.funcs) {
+		fn := s.funcs[name]
+		if fn.decl.Body == nil {
+			continue
+		}
+		info := fn.pkg.TypesInfo
+
+		ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CaseClause:
+				var eventTypes []int64
+				for _, expr := range node.List {
+					if value, ok := eventConst(info, expr); ok {
+						eventTypes = append(eventTypes, value)
+					}
+				}
+				if len(eventTypes) == 0 {
+					return true
+				}
+
+				payloads := unmarshalledTypes(info, node.Body, nil)
+				for _, stmt := range node.Body {
+					ast.Inspect(stmt, func(node ast.Node) bool {
+						if call, ok := node.(*ast.CallExpr); ok {
+							if callee := calledFunc(info, call); callee != nil {
+								if decl, ok := s.funcs[callee.FullName()]; ok && decl.decl.Body != nil {
+									payloads = append(payloads, unmarshalledTypes(decl.pkg.TypesInfo, decl.decl.Body.List, nil)...)
+								}
+							}
+						}
+						return true
+					})
+				}
+
+				for _, eventType := range eventTypes {
+					events.add(eventType, nil)
+					for _, payload := range payloads {
+						events.add(eventType, payload)
+					}
+				}
+			case *ast.BinaryExpr:
+				if node.Op != token.EQL && node.Op != token.NEQ {
+					return true
+				}
+
+				eventType, ok := eventConst(info, node.Y)
+				if !ok {
+					eventType, ok = eventConst(info, node.X)
+				}
+				if !ok {
+					return true
+				}
+
+				for _, payload := range unmarshalledTypes(info, fn.decl.Body.List, isEventData(info)) {
+					events.add(eventType, payload)
+				}
+			}
+			return true
+		})
+	}
+
+	return events
+}
+
+// unmarshalledTypes returns the types json.Unmarshal decodes into, filter
+// restricts the data that is unmarshalled.
+func unmarshalledTypes(info *types.Info, stmts []ast.Stmt, filter func(ast.Expr) bool) []types.Type {
+	var result []types.Type
+
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+
+			fn := calledFunc(info, call)
+			if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "encoding/json" || fn.Name() != "Unmarshal" {
+				return true
+			}
+
+			if filter != nil && !filter(call.Args[0]) {
+				return true
+			}
+
+			if unary, ok := call.Args[1].(*ast.UnaryExpr); ok && unary.Op == token.AND {
+				result = append(result, info.TypeOf(unary.X))
+			}
+			return true
+		})
+	}
+
+	return result
+}
+
+// isEventData matches the data of a connector.Event.
+func isEventData(info *types.Info) func(ast.Expr) bool {
+	return func(expr ast.Expr) bool {
+		selector, ok := expr.(*ast.SelectorExpr)
+		return ok && selector.Sel.Name == "Data" &&
+			types.TypeString(deref(info.TypeOf(selector.X)), nil) == connectorPath+".Event"
+	}
+}
+
+// sendRef is a call sending an event, the event type and the payload are
+// either known or parameters of the function the call is made in.
+type sendRef struct {
+	eventTypes []int64
+	eventParam int
+	data       types.Type
+	dataParam  int
+}
+
+// serverEvents finds the payloads of the events sent to clients by
+// following the calls of SendEvent up to the constants and values passed.
+func (s *source) serverEvents() events {
+	events := events{}
+
+	for _, name := range sortedKeys(s.funcs) {
+		fn := s.funcs[name]
+		if fn.decl.Body == nil {
+			continue
+		}
+		info := fn.pkg.TypesInfo
+
+		ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+
+			callee := calledFunc(info, call)
+			if callee == nil || callee.Name() != "SendEvent" || callee.Pkg() == nil ||
+				(callee.Pkg().Path() != chatWsPkgPath && callee.Pkg().Path() != connectorPath) {
+				return true
+			}
+
+			ref := sendRef{}
+			ref.eventTypes, ref.eventParam = s.eventValues(fn, call.Args[0])
+			ref.data, ref.dataParam = dataValue(fn, call.Args[1])
+			s.collectSends(fn, ref, 0, events)
+
+			return true
+		})
+	}
+
+	return events
+}
+
+func (s *source) collectSends(fn *funcDecl, ref sendRef, depth int, events events) {
+	if ref.eventParam < 0 && ref.dataParam < 0 {
+		if ref.data != nil {
+			for _, eventType := range ref.eventTypes {
+				events.add(eventType, ref.data)
+			}
+		}
+		return
+	}
+
+	if depth >= maxCallDepth {
+		return
+	}
+
+	for _, site := range s.calls[fn.name] {
+		next := ref
+		if ref.eventParam >= 0 && ref.eventParam < len(site.call.Args) {
+			next.eventTypes, next.eventParam = s.eventValues(site.caller, site.call.Args[ref.eventParam])
+		}
+		if ref.dataParam >= 0 && ref.dataParam < len(site.call.Args) {
+			next.data, next.dataParam = dataValue(site.caller, site.call.Args[ref.dataParam])
+		}
+		s.collectSends(site.caller, next, depth+1, events)
+	}
+}
+
+// eventValues returns the event types an expression may hold, or the index
+// of the parameter it refers to.
+func (s *source) eventValues(fn *funcDecl, expr ast.Expr) ([]int64, int) {
+	info := fn.pkg.TypesInfo
+
+	if value, ok := eventConst(info, expr); ok {
+		return []int64{value}, -1
+	}
+
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil, -1
+	}
+
+	obj := info.ObjectOf(ident)
+	if i := paramIndex(fn, obj); i >= 0 {
+		return nil, i
+	}
+
+	// a local variable, e.g. var eventType uint64 = CreateMessageEventType.
+	var values []int64
+	add := func(expr ast.Expr) {
+		if value, ok := eventConst(info, expr); ok && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if info.ObjectOf(name) == obj && i < len(node.Values) {
+					add(node.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if lhsIdent, ok := lhs.(*ast.Ident); ok && info.ObjectOf(lhsIdent) == obj && i < len(node.Rhs) {
+					add(node.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+
+	return values, -1
+}
+
+// dataValue returns the type of a payload, or the index of the parameter it
+// refers to if it's passed as any.
+func dataValue(fn *funcDecl, expr ast.Expr) (types.Type, int) {
+	t := fn.pkg.TypesInfo.TypeOf(expr)
+	if !types.IsInterface(t) {
+		return t, -1
+	}
+
+	if ident, ok := ast.Unparen(expr).(*ast.Ident); ok {
+		return nil, paramIndex(fn, fn.pkg.TypesInfo.ObjectOf(ident))
+	}
+
+	return nil, -1
+}
+
+func paramIndex(fn *funcDecl, obj types.Object) int {
+	i := 0
+	for _, field := range fn.decl.Type.Params.List {
+		for _, name := range field.Names {
+			if fn.pkg.TypesInfo.Defs[name] == obj {
+				return i
+			}
+			i++
+		}
+	}
+
+	return -1
+}
+
+func asyncAPIDocument(src *source, title, version string, routes []*route) map[string]any {
+	schemas := newSchemaBuilder(src, "#/components/schemas/")
+	eventTypes := src.eventTypes()
+	messages := map[string]any{}
+
+	operation := func(direction string, events events) map[string]any {
+		var refs []any
+
+		for _, eventType := range sortedKeys(events) {
+			name := strings.TrimSuffix(eventTypes[eventType], "EventType")
+			key := direction + "." + name
+
+			var payloads []any
+			for _, t := range events[eventType] {
+				payloads = append(payloads, schemas.schema(t))
+			}
+
+			payload := map[string]any{
+				"type":     "object",
+				"required": []string{"type"},
+				"properties": map[string]any{
+					"type": map[string]any{"type": "integer", "const": eventType},
+					"requestId": map[string]any{
+						"type":        "string",
+						"description": "Set by the client on a request, echoed by the server on the reply or the Error event.",
+					},
+				},
+			}
+
+			switch len(payloads) {
+			case 0:
+				payload["description"] = "The event carries no data."
+			case 1:
+				payload["required"] = []string{"type", "data"}
+				payload["properties"].(map[string]any)["data"] = payloads[0]
+			default:
+				payload["required"] = []string{"type", "data"}
+				payload["properties"].(map[string]any)["data"] = map[string]any{"oneOf": payloads}
+			}
+
+			messages[key] = map[string]any{
+				"name":    name,
+				"title":   eventTypes[eventType],
+				"payload": payload,
+			}
+			refs = append(refs, map[string]any{"$ref": "#/components/messages/" + key})
+		}
+
+		return map[string]any{"message": map[string]any{"oneOf": refs}}
+	}
+
+	channel := map[string]any{
+		"description": "Frames are JSON objects with the event type and its data. " +
+			"Clients authenticate with the Authorization header, " +
+			"a \"bearer.<token>\" or \"bot.<token>\" subprotocol with the base64url encoded token, " +
+			"or with an Authenticate event as the first frame. " +
+			"A request that fails is answered with an Error event carrying its requestId.",
+		"publish":   withKeywords(operation("client", src.clientEvents()), map[string]any{"operationId": "sendEvent"}),
+		"subscribe": withKeywords(operation("server", src.serverEvents()), map[string]any{"operationId": "receiveEvent"}),
+	}
+
+	channels := map[string]any{}
+	for _, r := range routes {
+		if r.handler != nil && r.handler.connection == chatWsPkgPath+".NewConnection" {
+			channels[openAPIPath(r.path)] = channel
+		}
+	}
+
+	return map[string]any{
+		"asyncapi": "2.6.0",
+		"info": map[string]any{
+			"title":       title,
+			"version":     version,
+			"description": "Websocket events of the chat service.\n\nClose codes:\n" + closeCodes(src),
+		},
+		"defaultContentType": "application/json",
+		"channels":           channels,
+		"components": map[string]any{
+			"messages": messages,
+			"schemas":  schemas.components,
+		},
+	}
+}
+
+// closeCodes lists the close codes of the websocket protocol with their
+// docs.
+func closeCodes(src *source) string {
+	pkg := src.pkg(chatAPIPath)
+	if pkg == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, name := range pkg.Types.Scope().Names() {
+		c, ok := pkg.Types.Scope().Lookup(name).(*types.Const)
+		if !ok || !strings.HasPrefix(name, "Close") {
+			continue
+		}
+
+		value, _ := constantInt(c)
+		lines = append(lines, fmt.Sprintf("- %d: %s", value, src.docs[chatAPIPath+"."+name]))
+	}
+
+	slices.Sort(lines)
+
+	return strings.Join(lines, "\n")
+}
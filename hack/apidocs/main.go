@@ -0,0 +1,302 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command apidocs generates the OpenAPI document of the REST API and the
+// AsyncAPI document of the chat websocket from the source code: the routes
+// registered by the controllers, the DTOs their handlers parse and return
+// and the events sent over the websocket.
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	modulePath    = "github.com/microcoretech/chat-go"
+	apiPkgPath    = modulePath + "/internal/infrastructure/api"
+	chatWsPkgPath = modulePath + "/internal/chat/websocket"
+	connectorPath = modulePath + "/internal/infrastructure/connector"
+	errorsPkgPath = modulePath + "/internal/common/errors"
+	constantsPath = modulePath + "/internal/common/constants"
+	chatAPIPath   = modulePath + "/pkg/chatapi"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "root directory of the module")
+	out := flag.String("out", "docs", "directory the documents are written to")
+	flag.Parse()
+
+	version, err := os.ReadFile(filepath.Join(*dir, "VERSION"))
+	if err != nil {
+		log.Fatalf("error on read version: %s", err)
+	}
+
+	src, err := loadSource(*dir)
+	if err != nil {
+		log.Fatalf("error on load packages: %s", err)
+	}
+
+	title := src.stringConst(constantsPath, "ServiceName")
+	routes := src.routes()
+
+	if err := writeDocument(filepath.Join(*out, "openapi.json"),
+		openAPIDocument(src, title, strings.TrimSpace(string(version)), routes)); err != nil {
+		log.Fatalf("error on write openapi document: %s", err)
+	}
+
+	if err := writeDocument(filepath.Join(*out, "asyncapi.json"),
+		asyncAPIDocument(src, title, strings.TrimSpace(string(version)), routes)); err != nil {
+		log.Fatalf("error on write asyncapi document: %s", err)
+	}
+}
+
+func writeDocument(path string, document map[string]any) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644) //nolint:gosec // the documents are public
+}
+
+// source holds the loaded packages together with the indexes the generators
+// share.
+type source struct {
+	pkgs []*packages.Package
+	// funcs maps the full name of a function or method to its declaration.
+	funcs map[string]*funcDecl
+	// calls maps the full name of a function or method to its call sites.
+	calls map[string][]callSite
+	// docs maps "pkg.Type" and "pkg.Type.Field" to their doc comments.
+	docs map[string]string
+}
+
+type funcDecl struct {
+	pkg  *packages.Package
+	decl *ast.FuncDecl
+	name string
+}
+
+type callSite struct {
+	caller *funcDecl
+	call   *ast.CallExpr
+}
+
+func loadSource(dir string) (*source, error) {
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+	}
+
+	pkgs, err := packages.Load(cfg, "./internal/...", "./cmd/...", "./pkg/chatapi")
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, os.ErrInvalid
+	}
+
+	src := &source{
+		pkgs:  pkgs,
+		funcs: map[string]*funcDecl{},
+		calls: map[string][]callSite{},
+		docs:  map[string]string{},
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if fn, ok := pkg.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+						src.funcs[fn.FullName()] = &funcDecl{pkg: pkg, decl: decl, name: fn.FullName()}
+					}
+				case *ast.GenDecl:
+					src.indexDocs(pkg, decl)
+				}
+			}
+		}
+	}
+
+	for _, fn := range src.funcs {
+		if fn.decl.Body == nil {
+			continue
+		}
+
+		ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+			if call, ok := node.(*ast.CallExpr); ok {
+				if callee := calledFunc(fn.pkg.TypesInfo, call); callee != nil {
+					src.calls[callee.FullName()] = append(src.calls[callee.FullName()], callSite{caller: fn, call: call})
+				}
+			}
+			return true
+		})
+	}
+
+	return src, nil
+}
+
+func (s *source) indexDocs(pkg *packages.Package, decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+			for _, name := range valueSpec.Names {
+				s.docs[pkg.PkgPath+"."+name.Name] = commentText(valueSpec.Doc)
+			}
+			continue
+		}
+
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		key := pkg.PkgPath + "." + typeSpec.Name.Name
+
+		doc := typeSpec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+		s.docs[key] = commentText(doc)
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range structType.Fields.List {
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+
+			for _, name := range field.Names {
+				s.docs[key+"."+name.Name] = commentText(doc)
+			}
+		}
+	}
+}
+
+func (s *source) pkg(path string) *packages.Package {
+	for _, pkg := range s.pkgs {
+		if pkg.PkgPath == path {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+func (s *source) stringConst(pkgPath, name string) string {
+	if pkg := s.pkg(pkgPath); pkg != nil {
+		if c, ok := pkg.Types.Scope().Lookup(name).(*types.Const); ok {
+			return constant.StringVal(c.Val())
+		}
+	}
+
+	return ""
+}
+
+func (s *source) lookupType(pkgPath, name string) types.Type {
+	if pkg := s.pkg(pkgPath); pkg != nil {
+		if obj := pkg.Types.Scope().Lookup(name); obj != nil {
+			return obj.Type()
+		}
+	}
+
+	return nil
+}
+
+// calledFunc returns the function or method a call expression calls, nil
+// for calls of function values.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.IndexExpr:
+		ident, _ = fun.X.(*ast.Ident)
+	}
+
+	if ident == nil {
+		return nil
+	}
+
+	fn, _ := info.Uses[ident].(*types.Func)
+	if fn == nil {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+func commentText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func constantInt(c *types.Const) (int64, bool) {
+	return constant.Int64Val(constant.ToInt(c.Val()))
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,630 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var routeMethods = map[string]string{
+	"Get":    http.MethodGet,
+	"Post":   http.MethodPost,
+	"Put":    http.MethodPut,
+	"Patch":  http.MethodPatch,
+	"Delete": http.MethodDelete,
+}
+
+// route is an endpoint registered on the fiber app or one of its groups.
+type route struct {
+	method string
+	// path is in the fiber format, e.g. /chats/:id.
+	path string
+	tag  string
+	// name is the name of the handler, empty if it can't be resolved.
+	name string
+	doc  string
+	// authenticated routes are registered behind the auth middleware,
+	// optionalAuth is set when the same route is also registered without it.
+	authenticated bool
+	optionalAuth  bool
+	allowsBots    bool
+	handler       *handlerInfo
+}
+
+// handlerInfo is what a handler parses and returns.
+type handlerInfo struct {
+	body      types.Type
+	query     types.Type
+	intParams map[string]bool
+	responses map[int]types.Type
+	validates bool
+	// connection is the function creating the websocket connection of
+	// websocket handlers.
+	connection string
+}
+
+type group struct {
+	prefix        string
+	authenticated bool
+}
+
+// routes finds the routes registered by NewApp and the SetupRoutes methods
+// of the controllers.
+func (s *source) routes() []*route {
+	botControllers := s.botControllers()
+
+	var routes []*route
+	index := map[string]*route{}
+
+	for _, name := range sortedKeys(s.funcs) {
+		fn := s.funcs[name]
+
+		var tag, controller string
+		switch {
+		case name == apiPkgPath+".NewApp":
+			tag = "Service"
+		case fn.decl.Name.Name == "SetupRoutes" && fn.decl.Recv != nil:
+			recv := deref(fn.pkg.TypesInfo.TypeOf(fn.decl.Recv.List[0].Type))
+			controller = typeKey(recv)
+			tag = strings.TrimSuffix(recv.(*types.Named).Obj().Name(), "Controller")
+		default:
+			continue
+		}
+
+		for _, r := range s.registeredRoutes(fn, tag) {
+			r.allowsBots = botControllers[controller]
+
+			key := r.method + " " + r.path
+			existing, ok := index[key]
+			if !ok {
+				index[key] = r
+				routes = append(routes, r)
+				continue
+			}
+
+			// the websocket routes are registered twice, once ahead of the
+			// auth middleware for clients authenticating after the upgrade.
+			if existing.authenticated != r.authenticated {
+				existing.authenticated = true
+				existing.optionalAuth = true
+				existing.allowsBots = existing.allowsBots || r.allowsBots
+			}
+			if existing.handler == nil {
+				existing.handler = r.handler
+			}
+		}
+	}
+
+	slices.SortFunc(routes, func(a, b *route) int {
+		if c := strings.Compare(a.path, b.path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.method, b.method)
+	})
+
+	return routes
+}
+
+// botControllers returns the controllers main passes the bot auth
+// middleware to.
+func (s *source) botControllers() map[string]bool {
+	controllers := map[string]bool{}
+
+	for name, sites := range s.calls {
+		if !strings.HasSuffix(name, "Controller") {
+			continue
+		}
+
+		for _, site := range sites {
+			if site.caller.pkg.Name != "main" {
+				continue
+			}
+
+			signature, ok := site.caller.pkg.TypesInfo.TypeOf(site.call.Fun).(*types.Signature)
+			if !ok || signature.Results().Len() != 1 {
+				continue
+			}
+
+			for i := range signature.Params().Len() {
+				if i >= len(site.call.Args) || signature.Params().At(i).Name() != "authMiddleware" {
+					continue
+				}
+
+				argType := deref(site.caller.pkg.TypesInfo.TypeOf(site.call.Args[i]))
+				if named, ok := argType.(*types.Named); ok && named.Obj().Name() == "BotAuthMiddleware" {
+					controllers[typeKey(deref(signature.Results().At(0).Type()))] = true
+				}
+			}
+		}
+	}
+
+	return controllers
+}
+
+func (s *source) registeredRoutes(fn *funcDecl, tag string) []*route {
+	info := fn.pkg.TypesInfo
+	groups := map[types.Object]group{}
+
+	groupOf := func(expr ast.Expr) group {
+		if ident, ok := expr.(*ast.Ident); ok {
+			return groups[info.ObjectOf(ident)]
+		}
+		return group{}
+	}
+
+	var routes []*route
+
+	ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+
+			call, ok := node.Rhs[0].(*ast.CallExpr)
+			if !ok || !isRouterCall(info, call, "Group") || len(call.Args) == 0 {
+				return true
+			}
+
+			parent := groupOf(call.Fun.(*ast.SelectorExpr).X)
+			ident, _ := node.Lhs[0].(*ast.Ident)
+			if ident == nil {
+				return true
+			}
+
+			groups[info.ObjectOf(ident)] = group{
+				prefix:        parent.prefix + stringValue(info, call.Args[0]),
+				authenticated: parent.authenticated || slices.ContainsFunc(call.Args[1:], isAuthMiddleware(info)),
+			}
+
+			return false
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || len(node.Args) < 2 {
+				return true
+			}
+
+			method, ok := routeMethods[selector.Sel.Name]
+			if !ok || !isRouterCall(info, node, selector.Sel.Name) {
+				return true
+			}
+
+			parent := groupOf(selector.X)
+			handlers := node.Args[1:]
+			r := &route{
+				method:        method,
+				path:          parent.prefix + stringValue(info, node.Args[0]),
+				tag:           tag,
+				authenticated: parent.authenticated || slices.ContainsFunc(handlers[:len(handlers)-1], isAuthMiddleware(info)),
+			}
+
+			if handler := s.handlerFunc(fn.pkg, handlers[len(handlers)-1]); handler != nil {
+				r.name = handler.name
+				r.doc = handler.doc
+				r.handler = s.analyzeHandler(handler.pkg, handler.body)
+			}
+
+			routes = append(routes, r)
+		}
+
+		return true
+	})
+
+	return routes
+}
+
+func isRouterCall(info *types.Info, call *ast.CallExpr, name string) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+
+	return strings.Contains(types.TypeString(info.TypeOf(selector.X), nil), "github.com/gofiber/fiber/v2.")
+}
+
+func isAuthMiddleware(info *types.Info) func(ast.Expr) bool {
+	return func(expr ast.Expr) bool {
+		selector, ok := expr.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Handler" {
+			return false
+		}
+		return types.TypeString(info.TypeOf(selector.X), nil) == apiPkgPath+".Middleware"
+	}
+}
+
+func stringValue(info *types.Info, expr ast.Expr) string {
+	if value := info.Types[expr].Value; value != nil && value.Kind() == constant.String {
+		return constant.StringVal(value)
+	}
+
+	return ""
+}
+
+func intValue(info *types.Info, expr ast.Expr) (int64, bool) {
+	if value := info.Types[expr].Value; value != nil && value.Kind() == constant.Int {
+		return constant.Int64Val(value)
+	}
+
+	return 0, false
+}
+
+type handlerFunc struct {
+	pkg  *packages.Package
+	name string
+	doc  string
+	body *ast.BlockStmt
+}
+
+// handlerFunc resolves a handler passed to a route: a method value, a
+// function or a call of a function returning a function literal.
+func (s *source) handlerFunc(pkg *packages.Package, expr ast.Expr) *handlerFunc {
+	var fn *types.Func
+
+	switch expr := expr.(type) {
+	case *ast.SelectorExpr:
+		fn, _ = pkg.TypesInfo.Uses[expr.Sel].(*types.Func)
+	case *ast.Ident:
+		fn, _ = pkg.TypesInfo.Uses[expr].(*types.Func)
+	case *ast.CallExpr:
+		fn = calledFunc(pkg.TypesInfo, expr)
+	}
+
+	if fn == nil {
+		return nil
+	}
+
+	decl, ok := s.funcs[fn.FullName()]
+	if !ok || decl.decl.Body == nil {
+		return nil
+	}
+
+	handler := &handlerFunc{
+		pkg:  decl.pkg,
+		name: strings.TrimSuffix(fn.Name(), "Handler"),
+		doc:  commentText(decl.decl.Doc),
+		body: decl.decl.Body,
+	}
+
+	if _, ok := expr.(*ast.CallExpr); ok {
+		handler.body = nil
+		for _, stmt := range decl.decl.Body.List {
+			if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+				if lit, ok := ret.Results[0].(*ast.FuncLit); ok {
+					handler.body = lit.Body
+				}
+			}
+		}
+		if handler.body == nil {
+			return nil
+		}
+	}
+
+	return handler
+}
+
+func (s *source) analyzeHandler(pkg *packages.Package, body *ast.BlockStmt) *handlerInfo {
+	info := pkg.TypesInfo
+	handler := &handlerInfo{
+		intParams: map[string]bool{},
+		responses: map[int]types.Type{},
+	}
+
+	// variables holding path parameters, e.g. idStr := ctx.Params("id").
+	paramVars := map[types.Object]string{}
+	paramName := func(expr ast.Expr) string {
+		if ident, ok := expr.(*ast.Ident); ok {
+			return paramVars[info.ObjectOf(ident)]
+		}
+		if call, ok := expr.(*ast.CallExpr); ok && isCtxCall(info, call, "Params") {
+			return stringValue(info, call.Args[0])
+		}
+		return ""
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+				if ident, ok := node.Lhs[0].(*ast.Ident); ok {
+					if name := paramName(node.Rhs[0]); name != "" {
+						paramVars[info.ObjectOf(ident)] = name
+					}
+				}
+			}
+		case *ast.CallExpr:
+			s.analyzeHandlerCall(info, node, handler, paramName)
+		}
+		return true
+	})
+
+	return handler
+}
+
+func (s *source) analyzeHandlerCall(info *types.Info, call *ast.CallExpr, handler *handlerInfo, paramName func(ast.Expr) string) {
+	if fn := calledFunc(info, call); fn != nil && fn.Pkg() != nil {
+		switch fn.Pkg().Path() + "." + fn.Name() {
+		case "strconv.ParseUint", "strconv.ParseInt":
+			if name := paramName(call.Args[0]); name != "" {
+				handler.intParams[name] = true
+			}
+			return
+		case "github.com/gofiber/contrib/websocket.New":
+			handler.responses[http.StatusSwitchingProtocols] = nil
+			return
+		}
+
+		if strings.HasPrefix(fn.Name(), "New") && strings.HasSuffix(fn.Name(), "Connection") {
+			handler.connection = fn.FullName()
+		}
+
+		if fn.Name() == "Struct" && strings.HasSuffix(types.TypeString(fn.Type().(*types.Signature).Recv().Type(), nil), "validator.Validate") {
+			handler.validates = true
+		}
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isCtxCall(info, call, selector.Sel.Name) {
+		return
+	}
+
+	switch selector.Sel.Name {
+	case "BodyParser", "QueryParser":
+		if unary, ok := call.Args[0].(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			if selector.Sel.Name == "BodyParser" {
+				handler.body = info.TypeOf(unary.X)
+			} else {
+				handler.query = info.TypeOf(unary.X)
+			}
+		}
+		handler.validates = true
+	case "JSON":
+		status := http.StatusOK
+		if inner, ok := selector.X.(*ast.CallExpr); ok && isCtxCall(info, inner, "Status") {
+			if code, ok := intValue(info, inner.Args[0]); ok {
+				status = int(code)
+			}
+		}
+		if _, ok := handler.responses[status]; !ok {
+			handler.responses[status] = info.TypeOf(call.Args[0])
+		}
+	case "SendStatus":
+		if code, ok := intValue(info, call.Args[0]); ok {
+			if _, ok := handler.responses[int(code)]; !ok {
+				handler.responses[int(code)] = nil
+			}
+		}
+	case "Send", "SendString":
+		if _, ok := handler.responses[http.StatusOK]; !ok {
+			handler.responses[http.StatusOK] = nil
+		}
+	}
+}
+
+func isCtxCall(info *types.Info, call *ast.CallExpr, name string) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name || len(call.Args) == 0 && name != "Next" {
+		return false
+	}
+
+	return types.TypeString(info.TypeOf(selector.X), nil) == "*github.com/gofiber/fiber/v2.Ctx"
+}
+
+func openAPIDocument(src *source, title, version string, routes []*route) map[string]any {
+	schemas := newSchemaBuilder(src, "#/components/schemas/")
+	errorSchema := schemas.schema(src.lookupType(errorsPkgPath, "ErrorData"))
+
+	paths := map[string]any{}
+	operationIDs := map[string]bool{}
+
+	for _, r := range routes {
+		path := openAPIPath(r.path)
+
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[path] = item
+		}
+
+		operation := map[string]any{
+			"tags":      []string{r.tag},
+			"responses": map[string]any{},
+		}
+
+		if r.name != "" {
+			operationID := lowerFirst(r.tag) + upperFirst(r.name)
+			for i := 2; operationIDs[operationID]; i++ {
+				operationID = fmt.Sprintf("%s%s%d", lowerFirst(r.tag), upperFirst(r.name), i)
+			}
+			operationIDs[operationID] = true
+			operation["operationId"] = operationID
+		}
+
+		if r.doc != "" {
+			operation["description"] = r.doc
+		}
+
+		handler := r.handler
+		if handler == nil {
+			handler = &handlerInfo{responses: map[int]types.Type{http.StatusOK: nil}}
+		}
+
+		if parameters := openAPIParameters(schemas, r.path, handler); len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if handler.body != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(schemas.schema(deref(handler.body))),
+			}
+		}
+
+		responses := operation["responses"].(map[string]any)
+		for status, t := range handler.responses {
+			response := map[string]any{"description": http.StatusText(status)}
+			if t != nil {
+				response["content"] = jsonContent(schemas.schema(deref(t)))
+			}
+			responses[strconv.Itoa(status)] = response
+		}
+		if handler.validates || len(handler.intParams) > 0 {
+			responses[strconv.Itoa(http.StatusBadRequest)] = errorResponse(http.StatusText(http.StatusBadRequest), errorSchema)
+		}
+		if r.authenticated {
+			responses[strconv.Itoa(http.StatusUnauthorized)] = errorResponse(http.StatusText(http.StatusUnauthorized), errorSchema)
+		}
+		responses["default"] = errorResponse("Error", errorSchema)
+
+		if r.authenticated {
+			security := []any{map[string]any{"bearerAuth": []string{}}}
+			if r.allowsBots {
+				security = append(security, map[string]any{"botAuth": []string{}})
+			}
+			if r.optionalAuth {
+				security = append(security, map[string]any{})
+			}
+			operation["security"] = security
+		}
+
+		item[strings.ToLower(r.method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   title,
+			"version": version,
+			"description": "REST API of the chat service. Users authenticate with a bearer token, " +
+				"bots with a \"Bot <token>\" Authorization header where a route allows them. " +
+				"Errors are returned as ErrorData, devDetails are only set in development.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "Token of a user, may also be passed with the token query parameter.",
+				},
+				"botAuth": map[string]any{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        "Authorization",
+					"description": "\"Bot <token>\".",
+				},
+			},
+		},
+	}
+}
+
+func openAPIParameters(schemas *schemaBuilder, path string, handler *handlerInfo) []any {
+	var parameters []any
+
+	for _, segment := range strings.Split(path, "/") {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+
+		schema := map[string]any{"type": "string"}
+		if handler.intParams[name] {
+			schema = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+		}
+
+		parameters = append(parameters, map[string]any{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   schema,
+		})
+	}
+
+	if handler.query == nil {
+		return parameters
+	}
+
+	structType, ok := deref(handler.query).Underlying().(*types.Struct)
+	if !ok {
+		return parameters
+	}
+
+	seen := map[string]bool{}
+	for i := range structType.NumFields() {
+		field := structType.Field(i)
+		tag := reflect.StructTag(structType.Tag(i))
+
+		name, _, _ := strings.Cut(tag.Get("query"), ",")
+		if !field.Exported() || name == "" || name == "-" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		schema, required := schemas.fieldSchema(schemas.querySchema(field.Type()), field.Type(), tag.Get("validate"))
+
+		parameter := map[string]any{
+			"name":     name,
+			"in":       "query",
+			"required": required,
+			"schema":   schema,
+		}
+		if kindOf(deref(field.Type())) == kindArray {
+			parameter["style"] = "form"
+			parameter["explode"] = true
+		}
+		if doc := schemas.src.docs[typeKey(deref(handler.query))+"."+field.Name()]; doc != "" {
+			parameter["description"] = doc
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	return parameters
+}
+
+// openAPIPath turns /chats/:id into /chats/{id}.
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + strings.TrimSuffix(name, "?") + "}"
+		}
+	}
+
+	if path = strings.Join(segments, "/"); path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+func errorResponse(description string, schema map[string]any) map[string]any {
+	return map[string]any{"description": description, "content": jsonContent(schema)}
+}
@@ -0,0 +1,566 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// schemaBuilder turns Go types into JSON schemas. Named structs become
+// components referenced by name, the validate tags of their fields are
+// turned into the matching keywords.
+type schemaBuilder struct {
+	src *source
+	// refPrefix is prepended to component names in references.
+	refPrefix string
+	// components are the schemas of named structs by component name.
+	components map[string]any
+	// names maps type strings to their component names.
+	names map[string]string
+}
+
+func newSchemaBuilder(src *source, refPrefix string) *schemaBuilder {
+	return &schemaBuilder{
+		src:        src,
+		refPrefix:  refPrefix,
+		components: map[string]any{},
+		names:      map[string]string{},
+	}
+}
+
+func (b *schemaBuilder) schema(t types.Type) map[string]any {
+	return b.schemaOf(t, false)
+}
+
+// querySchema is the schema of a query parameter, the query parser reads
+// []uint8 as a list of numbers.
+func (b *schemaBuilder) querySchema(t types.Type) map[string]any {
+	return b.schemaOf(t, true)
+}
+
+func (b *schemaBuilder) schemaOf(t types.Type, query bool) map[string]any {
+	switch t := t.(type) {
+	case *types.Pointer:
+		return nullable(b.schemaOf(t.Elem(), query))
+	case *types.Alias:
+		return b.schemaOf(types.Unalias(t), query)
+	case *types.Named:
+		return b.namedSchema(t)
+	case *types.Basic:
+		return basicSchema(t)
+	case *types.Slice:
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte && !query {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schemaOf(t.Elem(), query)}
+	case *types.Array:
+		return map[string]any{
+			"type":     "array",
+			"items":    b.schema(t.Elem()),
+			"minItems": t.Len(),
+			"maxItems": t.Len(),
+		}
+	case *types.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case *types.Struct:
+		return b.structSchema(t, "")
+	}
+
+	return map[string]any{}
+}
+
+func (b *schemaBuilder) namedSchema(t *types.Named) map[string]any {
+	obj := t.Obj()
+	if obj.Pkg() == nil {
+		// error and other universe types.
+		return map[string]any{}
+	}
+
+	switch obj.Pkg().Path() + "." + obj.Name() {
+	case "time.Time":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "time.Duration":
+		return map[string]any{"type": "integer", "format": "int64", "description": "Duration in nanoseconds."}
+	case "encoding/json.RawMessage":
+		return map[string]any{}
+	}
+
+	structType, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		schema := b.schema(t.Underlying())
+		if enum := constValues(t); len(enum) > 0 {
+			schema["enum"] = enum
+		}
+		return schema
+	}
+
+	key := types.TypeString(t, nil)
+	if name, ok := b.names[key]; ok {
+		return b.ref(name)
+	}
+
+	name := b.componentName(t)
+	b.names[key] = name
+	// registered before the fields are built so recursive types end up as
+	// references.
+	b.components[name] = map[string]any{}
+	b.components[name] = b.structSchema(structType, obj.Pkg().Path()+"."+obj.Name())
+
+	return b.ref(name)
+}
+
+func (b *schemaBuilder) ref(name string) map[string]any {
+	return map[string]any{"$ref": b.refPrefix + name}
+}
+
+// componentName names a struct after its type, instances of generic types
+// are prefixed with the names of their type arguments, e.g. ChatDtoPage for
+// Page[ChatDto]. Types of different packages sharing a name are prefixed
+// with their package name.
+func (b *schemaBuilder) componentName(t *types.Named) string {
+	var name strings.Builder
+	for i := range t.TypeArgs().Len() {
+		name.WriteString(upperFirst(b.typeArgName(t.TypeArgs().At(i))))
+	}
+	name.WriteString(t.Obj().Name())
+
+	candidates := []string{name.String(), upperFirst(contextName(t.Obj().Pkg())) + name.String()}
+	for _, candidate := range candidates {
+		if _, ok := b.components[candidate]; !ok {
+			return candidate
+		}
+	}
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", candidates[1], i)
+		if _, ok := b.components[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+// contextName is the bounded context a package belongs to, e.g. webhook for
+// github.com/microcoretech/chat-go/internal/webhook/http.
+func contextName(pkg *types.Package) string {
+	if path, ok := strings.CutPrefix(pkg.Path(), modulePath+"/internal/"); ok {
+		context, _, _ := strings.Cut(path, "/")
+		return context
+	}
+
+	return pkg.Name()
+}
+
+func (b *schemaBuilder) typeArgName(t types.Type) string {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			b.namedSchema(t)
+			if name, ok := b.names[types.TypeString(t, nil)]; ok {
+				return name
+			}
+		}
+		return t.Obj().Name()
+	case *types.Pointer:
+		return b.typeArgName(t.Elem())
+	case *types.Slice:
+		return b.typeArgName(t.Elem()) + "List"
+	case *types.Basic:
+		return t.Name()
+	}
+
+	return "Any"
+}
+
+// structSchema builds the object schema of a struct, owner is the key of the
+// named type the struct belongs to and is used to look up field docs.
+func (b *schemaBuilder) structSchema(t *types.Struct, owner string) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	b.addFields(t, owner, "json", properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = required
+	}
+	if doc := b.src.docs[owner]; doc != "" {
+		schema["description"] = doc
+	}
+
+	return schema
+}
+
+// addFields adds the exported fields of a struct as properties named after
+// the given tag, fields of embedded structs are inlined the way
+// encoding/json does.
+func (b *schemaBuilder) addFields(t *types.Struct, owner, tagKey string, properties map[string]any, required *[]string) {
+	for i := range t.NumFields() {
+		field := t.Field(i)
+		if !field.Exported() {
+			continue
+		}
+
+		tag := reflect.StructTag(t.Tag(i))
+
+		name, _, _ := strings.Cut(tag.Get(tagKey), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Embedded() && name == "" {
+			if embedded, ok := deref(field.Type()).Underlying().(*types.Struct); ok {
+				b.addFields(embedded, typeKey(deref(field.Type())), tagKey, properties, required)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name()
+		}
+
+		schema, isRequired := b.fieldSchema(b.schema(field.Type()), field.Type(), tag.Get("validate"))
+		if doc := b.src.docs[owner+"."+field.Name()]; doc != "" {
+			schema = withKeywords(schema, map[string]any{"description": doc})
+		}
+
+		properties[name] = schema
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// fieldSchema returns the schema of a field with its validation rules
+// applied and whether the rules make the field required.
+func (b *schemaBuilder) fieldSchema(schema map[string]any, t types.Type, validate string) (map[string]any, bool) {
+	if validate == "" {
+		return schema, false
+	}
+
+	rules := strings.Split(validate, ",")
+	required := slices.Contains(rules, "required")
+
+	return b.applyRules(schema, t, rules), required
+}
+
+// applyRules adds the keywords matching the validator rules, rules after
+// dive apply to the items of slices and the values of maps.
+func (b *schemaBuilder) applyRules(schema map[string]any, t types.Type, rules []string) map[string]any {
+	t = deref(t)
+
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		schema = b.applyRules(schema, t, rules[:i])
+
+		var elem types.Type
+		switch underlying := t.Underlying().(type) {
+		case *types.Slice:
+			elem = underlying.Elem()
+		case *types.Array:
+			elem = underlying.Elem()
+		case *types.Map:
+			elem = underlying.Elem()
+		default:
+			return schema
+		}
+
+		return updateSchema(schema, func(schema map[string]any) {
+			key := "items"
+			if _, ok := schema[key]; !ok {
+				key = "additionalProperties"
+			}
+			if items, ok := schema[key].(map[string]any); ok {
+				schema[key] = b.applyRules(items, elem, rules[i+1:])
+			}
+		})
+	}
+
+	keywords := map[string]any{}
+	var itemRules []string
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch kindOf(t) {
+		case kindString:
+			addStringRule(keywords, name, param)
+		case kindInteger, kindNumber:
+			addNumberRule(keywords, name, param, kindOf(t) == kindInteger)
+		case kindArray:
+			if !addArrayRule(keywords, name, param) {
+				itemRules = append(itemRules, rule)
+			}
+		}
+	}
+
+	if len(itemRules) > 0 {
+		if slice, ok := t.Underlying().(*types.Slice); ok {
+			schema = updateSchema(schema, func(schema map[string]any) {
+				if items, ok := schema["items"].(map[string]any); ok {
+					schema["items"] = b.applyRules(items, slice.Elem(), itemRules)
+				}
+			})
+		}
+	}
+
+	return withKeywords(schema, keywords)
+}
+
+type kind int
+
+const (
+	kindOther kind = iota
+	kindString
+	kindInteger
+	kindNumber
+	kindArray
+)
+
+func kindOf(t types.Type) kind {
+	switch underlying := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsString != 0:
+			return kindString
+		case underlying.Info()&types.IsInteger != 0:
+			return kindInteger
+		case underlying.Info()&types.IsFloat != 0:
+			return kindNumber
+		}
+	case *types.Slice, *types.Array, *types.Map:
+		return kindArray
+	}
+
+	return kindOther
+}
+
+func addStringRule(keywords map[string]any, name, param string) {
+	n, _ := strconv.Atoi(param)
+
+	switch name {
+	case "min", "gte":
+		keywords["minLength"] = n
+	case "max", "lte":
+		keywords["maxLength"] = n
+	case "gt":
+		keywords["minLength"] = n + 1
+	case "lt":
+		keywords["maxLength"] = n - 1
+	case "len":
+		keywords["minLength"] = n
+		keywords["maxLength"] = n
+	case "oneof":
+		keywords["enum"] = strings.Fields(param)
+	case "email":
+		keywords["format"] = "email"
+	case "url", "http_url", "uri":
+		keywords["format"] = "uri"
+	case "uuid", "uuid4":
+		keywords["format"] = "uuid"
+	case "alphanum":
+		keywords["pattern"] = "^[a-zA-Z0-9]+$"
+	case "username":
+		keywords["pattern"] = "^[a-zA-Z0-9._-]+$"
+	case "command":
+		keywords["pattern"] = "^[a-zA-Z0-9_-]+$"
+	case "startswith":
+		keywords["pattern"] = "^" + regexp.QuoteMeta(param)
+	case "endswith":
+		keywords["pattern"] = regexp.QuoteMeta(param) + "$"
+	}
+}
+
+func addNumberRule(keywords map[string]any, name, param string, integer bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil && name != "oneof" {
+		return
+	}
+
+	switch name {
+	case "min", "gte":
+		keywords["minimum"] = n
+	case "max", "lte":
+		keywords["maximum"] = n
+	case "gt":
+		keywords["exclusiveMinimum"] = n
+	case "lt":
+		keywords["exclusiveMaximum"] = n
+	case "oneof":
+		var enum []any
+		for _, value := range strings.Fields(param) {
+			if integer {
+				if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+					enum = append(enum, v)
+				}
+			} else if v, err := strconv.ParseFloat(value, 64); err == nil {
+				enum = append(enum, v)
+			}
+		}
+		keywords["enum"] = enum
+	}
+}
+
+// addArrayRule adds the keywords of a rule on the length of a slice, it
+// returns false for rules that apply to the items.
+func addArrayRule(keywords map[string]any, name, param string) bool {
+	n, _ := strconv.Atoi(param)
+
+	switch name {
+	case "required", "omitempty", "required_if", "required_with", "required_without":
+	case "min", "gte":
+		keywords["minItems"] = n
+	case "max", "lte":
+		keywords["maxItems"] = n
+	case "gt":
+		keywords["minItems"] = n + 1
+	case "lt":
+		keywords["maxItems"] = n - 1
+	case "len":
+		keywords["minItems"] = n
+		keywords["maxItems"] = n
+	case "unique":
+		keywords["uniqueItems"] = true
+	default:
+		return false
+	}
+
+	return true
+}
+
+func basicSchema(t *types.Basic) map[string]any {
+	switch t.Kind() {
+	case types.Bool:
+		return map[string]any{"type": "boolean"}
+	case types.Int, types.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case types.Int8, types.Int16, types.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case types.Uint, types.Uint64, types.Uintptr:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case types.Uint8, types.Uint16, types.Uint32:
+		return map[string]any{"type": "integer", "format": "int32", "minimum": 0}
+	case types.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case types.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case types.String:
+		return map[string]any{"type": "string"}
+	}
+
+	return map[string]any{}
+}
+
+// nullable allows null in addition to the given schema.
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		return schema
+	}
+
+	if len(schema) == 0 {
+		return schema
+	}
+
+	return map[string]any{"oneOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+// withKeywords adds keywords to a schema, references are wrapped since
+// keywords next to $ref are ignored.
+func withKeywords(schema, keywords map[string]any) map[string]any {
+	if len(keywords) == 0 {
+		return schema
+	}
+
+	if _, ok := schema["$ref"]; ok {
+		schema = map[string]any{"allOf": []any{schema}}
+	}
+
+	for key, value := range keywords {
+		schema[key] = value
+	}
+
+	return schema
+}
+
+// updateSchema calls fn with the schema a nullable wrapper allows.
+func updateSchema(schema map[string]any, fn func(schema map[string]any)) map[string]any {
+	if oneOf, ok := schema["oneOf"].([]any); ok && len(oneOf) == 2 {
+		if inner, ok := oneOf[0].(map[string]any); ok {
+			fn(inner)
+			return schema
+		}
+	}
+
+	fn(schema)
+
+	return schema
+}
+
+// constValues returns the values of the constants declared with a named
+// type in its package.
+func constValues(t *types.Named) []any {
+	scope := t.Obj().Pkg().Scope()
+
+	var values []any
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), t) {
+			continue
+		}
+
+		switch c.Val().Kind() {
+		case constant.Int:
+			v, _ := constant.Int64Val(c.Val())
+			values = append(values, v)
+		case constant.String:
+			values = append(values, constant.StringVal(c.Val()))
+		}
+	}
+
+	slices.SortFunc(values, func(a, b any) int {
+		if a, ok := a.(int64); ok {
+			if b, ok := b.(int64); ok {
+				return cmp.Compare(a, b)
+			}
+		}
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	return values
+}
+
+func deref(t types.Type) types.Type {
+	if pointer, ok := t.(*types.Pointer); ok {
+		return pointer.Elem()
+	}
+
+	return t
+}
+
+func typeKey(t types.Type) string {
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+	}
+
+	return ""
+}
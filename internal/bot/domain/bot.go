@@ -0,0 +1,41 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+type Bot struct {
+	ID        uint64
+	Username  string
+	Name      string
+	TokenHash string
+	CreatedBy uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ToUser returns the bot as a chat participant.
+func (b Bot) ToUser() domain.User {
+	return domain.User{
+		ID:        b.ID,
+		Username:  b.Username,
+		FirstName: b.Name,
+		IsBot:     true,
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+type BotRepo interface {
+	GetBot(ctx context.Context, id uint64) (*Bot, error)
+	GetBots(ctx context.Context, filter *BotFilter) ([]Bot, error)
+	GetBotsCount(ctx context.Context, filter *BotFilter) (uint64, error)
+	CreateBot(ctx context.Context, bot Bot) (*Bot, error)
+	UpdateBot(ctx context.Context, bot Bot) (*Bot, error)
+	DeleteBot(ctx context.Context, id uint64) error
+}
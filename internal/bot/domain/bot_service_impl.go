@@ -0,0 +1,221 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/bot/constants"
+	boterrors "github.com/microcoretech/chat-go/internal/bot/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type BotServiceImpl struct {
+	botRepo BotRepo
+}
+
+func (s *BotServiceImpl) getOwnBot(ctx context.Context, id uint64) (*Bot, error) {
+	user := domain.UserFromContext(ctx)
+
+	bot, err := s.botRepo.GetBot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if bot == nil {
+		return nil, errors.NewNotFoundError(constants.BotDomain)
+	}
+
+	if bot.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return bot, nil
+}
+
+func (s *BotServiceImpl) GetBot(ctx context.Context, id uint64) (*Bot, error) {
+	return s.getOwnBot(ctx, id)
+}
+
+func (s *BotServiceImpl) GetBots(ctx context.Context, filter *BotFilter) ([]Bot, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &BotFilter{}
+	}
+
+	filter.CreatedByIDs = []uint64{user.ID}
+
+	count, err := s.botRepo.GetBotsCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	bots, err := s.botRepo.GetBots(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bots, count, nil
+}
+
+// CreateBot creates the bot and returns it with its token. The token can't be
+// read again later, only regenerated.
+func (s *BotServiceImpl) CreateBot(ctx context.Context, bot Bot) (*Bot, string, error) {
+	user := domain.UserFromContext(ctx)
+
+	count, err := s.botRepo.GetBotsCount(ctx, &BotFilter{Usernames: []string{bot.Username}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if count > 0 {
+		return nil, "", boterrors.NewBotUsernameTakenError(map[string]any{"username": bot.Username})
+	}
+
+	secret, err := newTokenSecret()
+	if err != nil {
+		return nil, "", errors.NewUndefinedError(err, "error on generating bot token")
+	}
+
+	bot.TokenHash = hashTokenSecret(secret)
+	bot.CreatedBy = user.ID
+
+	createdBot, err := s.botRepo.CreateBot(ctx, bot)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return createdBot, formatToken(createdBot.ID, secret), nil
+}
+
+func (s *BotServiceImpl) UpdateBot(ctx context.Context, bot Bot) (*Bot, error) {
+	existingBot, err := s.getOwnBot(ctx, bot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingBot.Name = bot.Name
+
+	updatedBot, err := s.botRepo.UpdateBot(ctx, *existingBot)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedBot == nil {
+		return nil, errors.NewNotFoundError(constants.BotDomain)
+	}
+
+	return updatedBot, nil
+}
+
+func (s *BotServiceImpl) DeleteBot(ctx context.Context, id uint64) error {
+	if _, err := s.getOwnBot(ctx, id); err != nil {
+		return err
+	}
+
+	return s.botRepo.DeleteBot(ctx, id)
+}
+
+// RegenerateToken replaces the bot token, the previous one stops working.
+func (s *BotServiceImpl) RegenerateToken(ctx context.Context, id uint64) (string, error) {
+	bot, err := s.getOwnBot(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := newTokenSecret()
+	if err != nil {
+		return "", errors.NewUndefinedError(err, "error on generating bot token")
+	}
+
+	bot.TokenHash = hashTokenSecret(secret)
+
+	if _, err := s.botRepo.UpdateBot(ctx, *bot); err != nil {
+		return "", err
+	}
+
+	return formatToken(bot.ID, secret), nil
+}
+
+// Authenticate returns the bot owning the token as a user.
+func (s *BotServiceImpl) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	id, secret, ok := parseToken(token)
+	if !ok {
+		return nil, errors.NewUnauthorizedError("invalid bot token")
+	}
+
+	bot, err := s.botRepo.GetBot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if bot == nil || subtle.ConstantTimeCompare([]byte(bot.TokenHash), []byte(hashTokenSecret(secret))) != 1 {
+		return nil, errors.NewUnauthorizedError("invalid bot token")
+	}
+
+	return lo.ToPtr(bot.ToUser()), nil
+}
+
+// GetBotUsers returns the bots matching the IDs or usernames of the filter.
+func (s *BotServiceImpl) GetBotUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, error) {
+	botIDs := lo.Filter(filter.IDs, func(id uint64, _ int) bool {
+		return domain.IsBotID(id)
+	})
+
+	if len(botIDs) == 0 && len(filter.Usernames) == 0 {
+		return nil, nil
+	}
+
+	var users []domain.User
+
+	if len(botIDs) > 0 {
+		bots, err := s.botRepo.GetBots(ctx, &BotFilter{IDs: botIDs})
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, lo.Map(bots, func(bot Bot, _ int) domain.User {
+			return bot.ToUser()
+		})...)
+	}
+
+	if len(filter.Usernames) > 0 {
+		bots, err := s.botRepo.GetBots(ctx, &BotFilter{Usernames: filter.Usernames})
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, lo.Map(bots, func(bot Bot, _ int) domain.User {
+			return bot.ToUser()
+		})...)
+	}
+
+	return lo.UniqBy(users, func(user domain.User) uint64 {
+		return user.ID
+	}), nil
+}
+
+func NewBotServiceImpl(botRepo BotRepo) *BotServiceImpl {
+	return &BotServiceImpl{botRepo: botRepo}
+}
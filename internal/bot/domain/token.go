@@ -0,0 +1,61 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const tokenSecretSize = 32
+
+// Bot tokens look like "<bot id>:<secret>", only the SHA-256 of the secret
+// is stored.
+
+func newTokenSecret() (string, error) {
+	secret := make([]byte, tokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+func hashTokenSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func formatToken(botID uint64, secret string) string {
+	return fmt.Sprintf("%d:%s", botID, secret)
+}
+
+func parseToken(token string) (uint64, string, bool) {
+	idStr, secret, ok := strings.Cut(token, ":")
+	if !ok || secret == "" {
+		return 0, "", false
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return id, secret, true
+}
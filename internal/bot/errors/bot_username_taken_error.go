@@ -0,0 +1,32 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"github.com/microcoretech/chat-go/internal/bot/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+const BotUsernameTakenErrorType = "BotUsernameTakenError"
+
+type BotUsernameTakenError struct {
+	*errors.ErrorData
+}
+
+func NewBotUsernameTakenError(data map[string]any) *BotUsernameTakenError {
+	return &BotUsernameTakenError{
+		ErrorData: errors.NewErrorData(constants.BotDomain, BotUsernameTakenErrorType, nil, data),
+	}
+}
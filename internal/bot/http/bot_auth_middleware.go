@@ -0,0 +1,69 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+)
+
+const (
+	headerAuthorization = "Authorization"
+	botTokenType        = "Bot"
+)
+
+// BotAuthMiddleware authenticates requests carrying a bot token in the
+// "Authorization: Bot <token>" header. Other requests are passed to the next
+// middleware. Tokens are not read from the query, where they end up in logs.
+type BotAuthMiddleware struct {
+	botService BotService
+	next       api.Middleware
+}
+
+func (m *BotAuthMiddleware) Handler(ctx *fiber.Ctx) error {
+	botToken := m.getToken(ctx)
+	if botToken == "" {
+		return m.next.Handler(ctx)
+	}
+
+	user, err := m.botService.Authenticate(ctx.Context(), botToken)
+	if err != nil {
+		return err
+	}
+
+	ctx.Context().SetUserValue("token", botToken)
+	ctx.Context().SetUserValue("user", user)
+
+	return ctx.Next()
+}
+
+func (m *BotAuthMiddleware) getToken(ctx *fiber.Ctx) string {
+	tokenType, token, ok := strings.Cut(ctx.Get(headerAuthorization), " ")
+	if !ok || !strings.EqualFold(tokenType, botTokenType) {
+		return ""
+	}
+
+	return token
+}
+
+func NewBotAuthMiddleware(botService BotService, next api.Middleware) *BotAuthMiddleware {
+	return &BotAuthMiddleware{
+		botService: botService,
+		next:       next,
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/bot/constants"
+	"github.com/microcoretech/chat-go/internal/bot/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+type BotController struct {
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	botService     BotService
+}
+
+func (c *BotController) SetupRoutes(r fiber.Router) {
+	botGroup := r.Group("/bots", c.authMiddleware.Handler)
+	botGroup.Get("", c.getBots)
+	botGroup.Get("/:id", c.getBot)
+	botGroup.Put("/:id", c.update)
+	botGroup.Post("", c.create)
+	botGroup.Post("/:id/token", c.regenerateToken)
+	botGroup.Delete("/:id", c.delete)
+}
+
+func (c *BotController) getBots(ctx *fiber.Ctx) error {
+	var query BotQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.BotDomain, &query); err != nil {
+		return errors.NewValidationError(constants.BotDomain, err, nil)
+	}
+
+	bots, count, err := c.botService.GetBots(ctx.Context(), &domain.BotFilter{
+		IDs:    query.IDs,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(bots, func(bot domain.Bot, _ int) BotDto {
+			return BotToDto(bot)
+		}),
+		count,
+	))
+}
+
+func (c *BotController) getBot(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, map[string]any{"id": idStr})
+	}
+
+	bot, err := c.botService.GetBot(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(BotToDto(*bot))
+}
+
+func (c *BotController) update(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := UpdateBotDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.BotDomain, dto); err != nil {
+		return err
+	}
+
+	bot := BotFromUpdateDto(dto)
+	bot.ID = id
+
+	updatedBot, err := c.botService.UpdateBot(ctx.Context(), bot)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(BotToDto(*updatedBot))
+}
+
+func (c *BotController) create(ctx *fiber.Ctx) error {
+	dto := CreateBotDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.BotDomain, dto); err != nil {
+		return err
+	}
+
+	createdBot, token, err := c.botService.CreateBot(ctx.Context(), BotFromCreateDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(CreatedBotDto{
+		BotDto:      BotToDto(*createdBot),
+		BotTokenDto: BotTokenDto{Token: token},
+	})
+}
+
+func (c *BotController) regenerateToken(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, map[string]any{"id": idStr})
+	}
+
+	token, err := c.botService.RegenerateToken(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(BotTokenDto{Token: token})
+}
+
+func (c *BotController) delete(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.BotDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.botService.DeleteBot(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func NewBotController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	botService BotService,
+) *BotController {
+	return &BotController{
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		botService:     botService,
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type CreateBotDto struct {
+	Username string `json:"username" validate:"required,gte=3,lte=32,username,endswith=bot"`
+	Name     string `json:"name" validate:"lte=255"`
+}
+
+type UpdateBotDto struct {
+	Name string `json:"name" validate:"lte=255"`
+}
+
+type BotDto struct {
+	ID        uint64    `json:"id"`
+	Username  string    `json:"username"`
+	Name      string    `json:"name"`
+	CreatedBy uint64    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type BotTokenDto struct {
+	Token string `json:"token"`
+}
+
+type CreatedBotDto struct {
+	BotDto
+	BotTokenDto
+}
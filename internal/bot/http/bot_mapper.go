@@ -0,0 +1,43 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/microcoretech/chat-go/internal/bot/domain"
+)
+
+func BotFromCreateDto(dto CreateBotDto) domain.Bot {
+	return domain.Bot{
+		Username: dto.Username,
+		Name:     dto.Name,
+	}
+}
+
+func BotFromUpdateDto(dto UpdateBotDto) domain.Bot {
+	return domain.Bot{
+		Name: dto.Name,
+	}
+}
+
+func BotToDto(bot domain.Bot) BotDto {
+	return BotDto{
+		ID:        bot.ID,
+		Username:  bot.Username,
+		Name:      bot.Name,
+		CreatedBy: bot.CreatedBy,
+		CreatedAt: bot.CreatedAt,
+		UpdatedAt: bot.UpdatedAt,
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/microcoretech/chat-go/internal/bot/constants"
+	"github.com/microcoretech/chat-go/internal/bot/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type BotRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *BotRepoImpl) scan(rows *sql.Rows) ([]domain.Bot, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	bots := make([]domain.Bot, 0)
+
+	for rows.Next() {
+		var bot domain.Bot
+
+		var fields = []any{
+			&bot.ID,
+			&bot.Username,
+			&bot.Name,
+			&bot.TokenHash,
+			&bot.CreatedBy,
+			&bot.CreatedAt,
+			&bot.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		bots = append(bots, bot)
+	}
+
+	return bots, nil
+}
+
+func (r *BotRepoImpl) buildFilter(filter domain.BotFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.IDs) > 0 {
+		var params []string
+		for _, id := range filter.IDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"b.id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Usernames) > 0 {
+		var params []string
+		for _, username := range filter.Usernames {
+			values = append(values, strings.ToLower(username))
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"LOWER(b.username) IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.CreatedByIDs) > 0 {
+		var params []string
+		for _, createdByID := range filter.CreatedByIDs {
+			values = append(values, createdByID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"b.created_by IN (%s) ", strings.Join(params, ",")))
+	}
+
+	return values, where
+}
+
+func (r *BotRepoImpl) GetBot(ctx context.Context, id uint64) (*domain.Bot, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS b WHERE b.id = $1`, botFields, botTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	defer rows.Close()
+
+	bots, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	if len(bots) == 0 {
+		return nil, nil
+	}
+
+	return &bots[0], nil
+}
+
+func (r *BotRepoImpl) GetBots(ctx context.Context, filter *domain.BotFilter) ([]domain.Bot, error) {
+	if filter == nil {
+		filter = &domain.BotFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT %s FROM %s AS b", botFields, botTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	query = fmt.Sprintf(`%s
+		ORDER BY b.id`, query)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s
+		LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s
+		OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	defer rows.Close()
+
+	bots, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	return bots, nil
+}
+
+func (r *BotRepoImpl) GetBotsCount(ctx context.Context, filter *domain.BotFilter) (uint64, error) {
+	if filter == nil {
+		filter = &domain.BotFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS b", botTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.BotDomain, err, "error on query bots count")
+	}
+
+	return count, nil
+}
+
+func (r *BotRepoImpl) CreateBot(ctx context.Context, bot domain.Bot) (*domain.Bot, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS b (username, name, token_hash, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING %s
+	`, botTableName, botFields)
+
+	rows, err := r.db.QueryContext(ctx, query, bot.Username, bot.Name, bot.TokenHash, bot.CreatedBy)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	defer rows.Close()
+
+	bots, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	if len(bots) == 0 {
+		return nil, nil
+	}
+
+	return &bots[0], nil
+}
+
+func (r *BotRepoImpl) UpdateBot(ctx context.Context, bot domain.Bot) (*domain.Bot, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS b
+		SET name = $1, token_hash = $2, updated_at = NOW()
+		WHERE b.id = $3
+		RETURNING %s
+	`, botTableName, botFields)
+
+	rows, err := r.db.QueryContext(ctx, query, bot.Name, bot.TokenHash, bot.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	defer rows.Close()
+
+	bots, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	if len(bots) == 0 {
+		return nil, nil
+	}
+
+	return &bots[0], nil
+}
+
+func (r *BotRepoImpl) DeleteBot(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, botTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.BotDomain, err)
+	}
+
+	return nil
+}
+
+func NewBotRepoImpl(db *sql.DB) *BotRepoImpl {
+	return &BotRepoImpl{db: db}
+}
@@ -0,0 +1,63 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+type ChatServiceContractImpl struct {
+	chatRepo ChatRepo
+}
+
+// GetChatMemberIDs returns IDs of the chat members, it's empty when the chat
+// doesn't exist.
+func (c *ChatServiceContractImpl) GetChatMemberIDs(ctx context.Context, chatID uint64) ([]uint64, error) {
+	chat, err := c.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, nil
+	}
+
+	return lo.Map(chat.UserChats, func(userChat domain.UserChat, _ int) uint64 {
+		return userChat.UserID
+	}), nil
+}
+
+// GetChatMembers returns the members of the chat with their settings, it's
+// empty when the chat doesn't exist.
+func (c *ChatServiceContractImpl) GetChatMembers(ctx context.Context, chatID uint64) ([]domain.UserChat, error) {
+	chat, err := c.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, nil
+	}
+
+	return chat.UserChats, nil
+}
+
+func NewChatServiceContractImpl(chatRepo ChatRepo) *ChatServiceContractImpl {
+	return &ChatServiceContractImpl{chatRepo: chatRepo}
+}
@@ -0,0 +1,167 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+const (
+	MeCommandName     = "me"
+	TopicCommandName  = "topic"
+	InviteCommandName = "invite"
+)
+
+type ChatCommandService interface {
+	GetChat(ctx context.Context, id uint64) (*Chat, error)
+	UpdateChat(ctx context.Context, chat Chat) (*Chat, error)
+	AddChatMember(ctx context.Context, chatID uint64, userID uint64) (*Chat, error)
+}
+
+// MeCommandHandler posts an action of the caller: "/me waves" becomes
+// "* alice waves".
+type MeCommandHandler struct{}
+
+func (h *MeCommandHandler) Handle(_ context.Context, command Command) (*CommandResult, error) {
+	if command.Args == "" {
+		return ephemeralResult("Usage: /me <action>"), nil
+	}
+
+	return &CommandResult{
+		Text:   fmt.Sprintf("* %s %s", command.User.Username, command.Args),
+		Public: true,
+	}, nil
+}
+
+func NewMeCommandHandler() *MeCommandHandler {
+	return &MeCommandHandler{}
+}
+
+// TopicCommandHandler shows the chat name or renames the chat.
+type TopicCommandHandler struct {
+	chatService ChatCommandService
+}
+
+func (h *TopicCommandHandler) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	chat, err := h.chatService.GetChat(ctx, command.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if command.Args == "" {
+		return ephemeralResult(fmt.Sprintf("Topic: %s", chat.Name)), nil
+	}
+
+	if chat.Type != GroupChatType {
+		return ephemeralResult("Only group chats have a topic"), nil
+	}
+
+	if chat.CreatedBy != command.User.ID {
+		return ephemeralResult("Only the chat creator can change the topic"), nil
+	}
+
+	if _, err := h.chatService.UpdateChat(ctx, Chat{
+		ID:    chat.ID,
+		Name:  command.Args,
+		Image: chat.Image,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &CommandResult{
+		Text:   fmt.Sprintf("* %s changed the topic to: %s", command.User.Username, command.Args),
+		Public: true,
+	}, nil
+}
+
+func NewTopicCommandHandler(chatService ChatCommandService) *TopicCommandHandler {
+	return &TopicCommandHandler{chatService: chatService}
+}
+
+// InviteCommandHandler adds users to the chat: "/invite @bob @carol".
+type InviteCommandHandler struct {
+	chatService         ChatCommandService
+	userServiceContract UserServiceContract
+}
+
+func (h *InviteCommandHandler) Handle(ctx context.Context, command Command) (*CommandResult, error) {
+	usernames := lo.Uniq(lo.FilterMap(strings.Fields(command.Args), func(field string, _ int) (string, bool) {
+		username := strings.TrimPrefix(field, "@")
+		return strings.ToLower(username), username != ""
+	}))
+
+	if len(usernames) == 0 {
+		return ephemeralResult("Usage: /invite @username [@username...]"), nil
+	}
+
+	users, _, err := h.userServiceContract.GetUsers(ctx, &domain.UserFilter{
+		Usernames: usernames,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return ephemeralResult("No users found"), nil
+	}
+
+	chat, err := h.chatService.GetChat(ctx, command.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat.Type != GroupChatType {
+		return ephemeralResult("Users can only be invited to group chats"), nil
+	}
+
+	var invited []string
+
+	for _, user := range users {
+		if !lo.Contains(usernames, strings.ToLower(user.Username)) || chat.HasMember(user.ID) {
+			continue
+		}
+
+		if _, err := h.chatService.AddChatMember(ctx, command.ChatID, user.ID); err != nil {
+			return nil, err
+		}
+
+		invited = append(invited, "@"+user.Username)
+	}
+
+	if len(invited) == 0 {
+		return ephemeralResult("Everyone is already in the chat"), nil
+	}
+
+	return &CommandResult{
+		Text:   fmt.Sprintf("* %s invited %s", command.User.Username, strings.Join(invited, " ")),
+		Public: true,
+	}, nil
+}
+
+func NewInviteCommandHandler(
+	chatService ChatCommandService,
+	userServiceContract UserServiceContract,
+) *InviteCommandHandler {
+	return &InviteCommandHandler{
+		chatService:         chatService,
+		userServiceContract: userServiceContract,
+	}
+}
@@ -17,18 +17,38 @@ package domain
 import (
 	"time"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type Chat struct {
-	ID          uint64
-	Name        string
-	Type        ChatType
-	Image       domain.Image
-	LastMessage *Message
-	CreatedBy   uint64
-	Creator     *domain.User
-	UserChats   []UserChat
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID          uint64          `json:"id"`
+	Name        string          `json:"name"`
+	Type        ChatType        `json:"type"`
+	Image       domain.Image    `json:"image"`
+	LastMessage *Message        `json:"lastMessage,omitempty"`
+	CreatedBy   uint64          `json:"createdBy"`
+	Creator     *domain.User    `json:"creator,omitempty"`
+	UserChats   []UserChat      `json:"userChats"`
+	Retention   RetentionPolicy `json:"retention"`
+	Expiry      MessageExpiry   `json:"expiry"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+
+	// Settings are the preferences of the current user, nil when the user
+	// isn't a member.
+	Settings *UserChat `json:"-"`
+}
+
+func (c Chat) HasMember(userID uint64) bool {
+	return c.Member(userID) != nil
+}
+
+func (c Chat) Member(userID uint64) *UserChat {
+	for index := range c.UserChats {
+		if c.UserChats[index].UserID == userID {
+			return &c.UserChats[index]
+		}
+	}
+
+	return nil
 }
@@ -14,7 +14,7 @@
 
 package domain
 
-import "chat-go/internal/common/domain"
+import "github.com/microcoretech/chat-go/internal/common/domain"
 
 type ChatFilter struct {
 	IDs          []uint64
@@ -23,6 +23,19 @@ type ChatFilter struct {
 
 	Search string
 
+	// MemberID is the user whose settings the settings filters and the
+	// pinned-first ordering apply to.
+	MemberID    *uint64
+	IsArchived  *bool
+	IsPinned    *bool
+	IsMuted     *bool
+	PinnedFirst bool
+
+	// FolderID limits the chats to a folder of the member, Folder is the
+	// folder itself, resolved by the service.
+	FolderID *uint64
+	Folder   *Folder
+
 	Limit  *uint64
 	Offset *uint64
 
@@ -17,7 +17,7 @@ package domain
 import (
 	"context"
 
-	"chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type ChatRepo interface {
@@ -25,6 +25,8 @@ type ChatRepo interface {
 	GetChats(ctx context.Context, filter *ChatFilter) ([]Chat, error)
 	GetChatsCount(ctx context.Context, filter *ChatFilter) (uint64, error)
 	CreateChat(ctx context.Context, chat Chat, tx repository.Tx) (*Chat, error)
-	UpdateChat(ctx context.Context, chat Chat) (*Chat, error)
-	DeleteChat(ctx context.Context, id uint64) error
+	UpdateChat(ctx context.Context, chat Chat, tx repository.Tx) (*Chat, error)
+	DeleteChat(ctx context.Context, id uint64, tx repository.Tx) error
+	UpdateChatExpiry(ctx context.Context, id uint64, expiry MessageExpiry, tx repository.Tx) error
+	UpdateChatRetention(ctx context.Context, id uint64, retention RetentionPolicy, tx repository.Tx) error
 }
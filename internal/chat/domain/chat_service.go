@@ -17,20 +17,23 @@ package domain
 import (
 	"context"
 
+	"github.com/samber/lo"
 	"golang.org/x/exp/maps"
 
-	"chat-go/internal/chat/constants"
-	chaterrors "chat-go/internal/chat/errors"
-	"chat-go/internal/common/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type ChatServiceImpl struct {
 	baseRepo            repository.BaseRepo
 	chatRepo            ChatRepo
 	userChatRepo        UserChatRepo
+	folderRepo          FolderRepo
 	userServiceContract UserServiceContract
+	eventPublisher      EventPublisher
 }
 
 func (s *ChatServiceImpl) fillChat(ctx context.Context, chat *Chat) error {
@@ -38,21 +41,48 @@ func (s *ChatServiceImpl) fillChat(ctx context.Context, chat *Chat) error {
 		return nil
 	}
 
-	var userIDs []uint64
+	return s.fillChats(ctx, []*Chat{chat})
+}
+
+// fillChats looks the users of all chats up at once, so a page of chats
+// costs a single request to the user service.
+func (s *ChatServiceImpl) fillChats(ctx context.Context, chats []*Chat) error {
+	if len(chats) == 0 {
+		return nil
+	}
 
-	userIDs = append(userIDs, chat.CreatedBy)
+	currentUserID := domain.UserFromContext(ctx).ID
 
-	if chat.LastMessage != nil {
-		userIDs = append(userIDs, chat.LastMessage.CreatedBy)
+	for _, chat := range chats {
+		chat.Settings = chat.Member(currentUserID)
 	}
 
-	for _, userChat := range chat.UserChats {
-		userIDs = append(userIDs, userChat.UserID)
+	if skipsUsers(ctx) {
+		return nil
+	}
+
+	var userIDs []uint64
+
+	for _, chat := range chats {
+		userIDs = append(userIDs, chat.CreatedBy)
+
+		if chat.LastMessage != nil {
+			userIDs = append(userIDs, chat.LastMessage.CreatedBy)
+		}
+
+		for _, userChat := range chat.UserChats {
+			userIDs = append(userIDs, userChat.UserID)
+		}
 	}
 
 	users, _, err := s.userServiceContract.GetUsers(ctx, &domain.UserFilter{
-		IDs: userIDs,
+		IDs: lo.Uniq(userIDs),
 	})
+	if errors.IsServiceUnavailable(err) {
+		// chats are still served, just without users, while the user
+		// service is down.
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -62,14 +92,16 @@ func (s *ChatServiceImpl) fillChat(ctx context.Context, chat *Chat) error {
 		usersMap[user.ID] = &user
 	}
 
-	chat.Creator = usersMap[chat.CreatedBy]
+	for _, chat := range chats {
+		chat.Creator = usersMap[chat.CreatedBy]
 
-	if chat.LastMessage != nil {
-		chat.LastMessage.Creator = usersMap[chat.LastMessage.CreatedBy]
-	}
+		if chat.LastMessage != nil {
+			chat.LastMessage.Creator = usersMap[chat.LastMessage.CreatedBy]
+		}
 
-	for index := range chat.UserChats {
-		chat.UserChats[index].User = usersMap[chat.UserChats[index].UserID]
+		for index := range chat.UserChats {
+			chat.UserChats[index].User = usersMap[chat.UserChats[index].UserID]
+		}
 	}
 
 	return nil
@@ -93,6 +125,24 @@ func (s *ChatServiceImpl) GetChat(ctx context.Context, id uint64) (*Chat, error)
 }
 
 func (s *ChatServiceImpl) GetChats(ctx context.Context, filter *ChatFilter) ([]Chat, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &ChatFilter{}
+	}
+
+	filter.MemberID = &user.ID
+	filter.Folder = nil
+
+	if filter.FolderID != nil {
+		folder, err := getOwnFolder(ctx, s.folderRepo, *filter.FolderID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		filter.Folder = folder
+	}
+
 	count, err := s.chatRepo.GetChatsCount(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -107,15 +157,38 @@ func (s *ChatServiceImpl) GetChats(ctx context.Context, filter *ChatFilter) ([]C
 		return nil, 0, err
 	}
 
-	for index := range chats {
-		if err := s.fillChat(ctx, &chats[index]); err != nil {
-			return nil, 0, err
-		}
+	if err := s.fillChats(ctx, lo.ToSlicePtr(chats)); err != nil {
+		return nil, 0, err
 	}
 
 	return chats, count, nil
 }
 
+// CheckMembership returns a ForbiddenError unless the user is a member of
+// every chat, e.g. before the events of the chats are streamed to them.
+func (s *ChatServiceImpl) CheckMembership(ctx context.Context, chatIDs []uint64) error {
+	user := domain.UserFromContext(ctx)
+
+	chatIDs = lo.Uniq(chatIDs)
+	if len(chatIDs) == 0 {
+		return nil
+	}
+
+	chats, err := s.chatRepo.GetChats(ctx, &ChatFilter{IDs: chatIDs})
+	if err != nil {
+		return err
+	}
+
+	memberChats := lo.CountBy(chats, func(chat Chat) bool {
+		return chat.HasMember(user.ID)
+	})
+	if memberChats != len(chatIDs) {
+		return errors.NewForbiddenError()
+	}
+
+	return nil
+}
+
 func (s *ChatServiceImpl) CreateChat(ctx context.Context, chat Chat) (*Chat, error) {
 	user := domain.UserFromContext(ctx)
 
@@ -147,7 +220,7 @@ func (s *ChatServiceImpl) CreateChat(ctx context.Context, chat Chat) (*Chat, err
 		}
 	}
 
-	createdChat, err := s.chatRepo.CreateChat(ctx, chat, nil)
+	createdChat, err := s.chatRepo.CreateChat(ctx, chat, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -155,9 +228,15 @@ func (s *ChatServiceImpl) CreateChat(ctx context.Context, chat Chat) (*Chat, err
 	userIDs := maps.Keys(uniqueUsers)
 	userChats := make([]UserChat, len(userIDs))
 	for index, id := range userIDs {
+		role := MemberMemberRole
+		if id == chat.CreatedBy {
+			role = OwnerMemberRole
+		}
+
 		userChats[index] = UserChat{
 			UserID: id,
 			ChatID: createdChat.ID,
+			Role:   role,
 		}
 	}
 
@@ -165,6 +244,17 @@ func (s *ChatServiceImpl) CreateChat(ctx context.Context, chat Chat) (*Chat, err
 		return nil, err
 	}
 
+	createdChat.UserChats = userChats
+
+	events := []domain.Event{{Type: ChatCreatedEventType, Payload: createdChat}}
+	for _, userChat := range userChats {
+		events = append(events, domain.Event{Type: MemberAddedEventType, Payload: userChat})
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -188,7 +278,16 @@ func (s *ChatServiceImpl) UpdateChat(ctx context.Context, chat Chat) (*Chat, err
 		return nil, errors.NewForbiddenError()
 	}
 
-	updatedChat, err := s.chatRepo.UpdateChat(ctx, chat)
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	updatedChat, err := s.chatRepo.UpdateChat(ctx, chat, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -197,6 +296,17 @@ func (s *ChatServiceImpl) UpdateChat(ctx context.Context, chat Chat) (*Chat, err
 		return nil, errors.NewNotFoundError(constants.ChatDomain)
 	}
 
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    ChatUpdatedEventType,
+		Payload: updatedChat,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return updatedChat, nil
 }
 
@@ -216,24 +326,271 @@ func (s *ChatServiceImpl) DeleteChat(ctx context.Context, id uint64) error {
 		return errors.NewForbiddenError()
 	}
 
-	err = s.chatRepo.DeleteChat(ctx, id)
+	tx, err := s.baseRepo.BeginContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = s.chatRepo.DeleteChat(ctx, id, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    ChatDeletedEventType,
+		Payload: chat,
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddChatMember adds a user or a bot to a group chat, the caller has to be a
+// member of the chat.
+func (s *ChatServiceImpl) AddChatMember(ctx context.Context, chatID uint64, userID uint64) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	member := chat.Member(user.ID)
+	if member == nil || !member.Role.CanManageMembers() {
+		return nil, errors.NewForbiddenError()
+	}
+
+	if chat.Type != GroupChatType {
+		return nil, chaterrors.NewInvalidChatTypeError()
+	}
+
+	if chat.HasMember(userID) {
+		return s.GetChat(ctx, chatID)
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	userChat := UserChat{
+		UserID: userID,
+		ChatID: chatID,
+		Role:   MemberMemberRole,
+	}
+
+	if err := s.userChatRepo.CreateUserChats(ctx, []UserChat{userChat}, tx); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    MemberAddedEventType,
+		Payload: userChat,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetChat(ctx, chatID)
+}
+
+// UpdateChatMemberRole makes a member an admin or a plain member again, only
+// the owner can do it.
+func (s *ChatServiceImpl) UpdateChatMemberRole(
+	ctx context.Context,
+	chatID uint64,
+	userID uint64,
+	role MemberRole,
+) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	owner := chat.Member(user.ID)
+	if owner == nil || owner.Role != OwnerMemberRole {
+		return nil, errors.NewForbiddenError()
+	}
+
+	member := chat.Member(userID)
+	if member == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if member.Role == OwnerMemberRole || role == OwnerMemberRole {
+		return nil, errors.NewForbiddenError()
+	}
+
+	member.Role = role
+
+	if err := s.userChatRepo.UpdateUserChatRole(ctx, *member, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetChat(ctx, chatID)
+}
+
+// UpdateChatExpiry replaces the default expiry timer of new messages in the
+// chat, any member can change it.
+func (s *ChatServiceImpl) UpdateChatExpiry(ctx context.Context, chatID uint64, expiry MessageExpiry) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !chat.HasMember(user.ID) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.chatRepo.UpdateChatExpiry(ctx, chatID, expiry, tx); err != nil {
+		return nil, err
+	}
+
+	chat.Expiry = expiry
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    ChatUpdatedEventType,
+		Payload: chat,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetChat(ctx, chatID)
+}
+
+// UpdateChatRetention replaces the retention policy of the chat, only the
+// creator of the chat can change it.
+func (s *ChatServiceImpl) UpdateChatRetention(ctx context.Context, chatID uint64, retention RetentionPolicy) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if chat.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.chatRepo.UpdateChatRetention(ctx, chatID, retention, tx); err != nil {
+		return nil, err
+	}
+
+	chat.Retention = retention
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    ChatUpdatedEventType,
+		Payload: chat,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetChat(ctx, chatID)
+}
+
+// UpdateChatSettings replaces the preferences of the current user for the
+// chat, the user has to be a member of the chat.
+func (s *ChatServiceImpl) UpdateChatSettings(ctx context.Context, settings UserChat) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, settings.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !chat.HasMember(user.ID) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	if settings.NotificationLevel == 0 {
+		settings.NotificationLevel = AllNotificationLevel
+	}
+
+	settings.UserID = user.ID
+
+	if err := s.userChatRepo.UpdateUserChat(ctx, settings, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetChat(ctx, settings.ChatID)
 }
 
 func NewChatServiceImpl(
 	baseRepo repository.BaseRepo,
 	charRepo ChatRepo,
 	userChatRepo UserChatRepo,
+	folderRepo FolderRepo,
 	userServiceContract UserServiceContract,
+	eventPublisher EventPublisher,
 ) *ChatServiceImpl {
 	return &ChatServiceImpl{
 		baseRepo:            baseRepo,
 		chatRepo:            charRepo,
 		userChatRepo:        userChatRepo,
+		folderRepo:          folderRepo,
 		userServiceContract: userServiceContract,
+		eventPublisher:      eventPublisher,
 	}
 }
@@ -0,0 +1,112 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type stubChatRepo struct {
+	ChatRepo
+
+	chats []Chat
+}
+
+func (r *stubChatRepo) GetChats(_ context.Context, filter *ChatFilter) ([]Chat, error) {
+	return lo.Filter(r.chats, func(chat Chat, _ int) bool {
+		return lo.Contains(filter.IDs, chat.ID)
+	}), nil
+}
+
+func TestCheckMembership(t *testing.T) {
+	chatRepo := &stubChatRepo{chats: []Chat{
+		{ID: 1, UserChats: []UserChat{{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}}},
+		{ID: 2, UserChats: []UserChat{{ChatID: 2, UserID: 2}}},
+	}}
+	service := NewChatServiceImpl(nil, chatRepo, nil, nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		chatIDs   []uint64
+		forbidden bool
+	}{
+		{name: "no chats", chatIDs: nil},
+		{name: "member of all chats", chatIDs: []uint64{1}},
+		{name: "repeated chats", chatIDs: []uint64{1, 1}},
+		{name: "not a member", chatIDs: []uint64{2}, forbidden: true},
+		{name: "member of some chats", chatIDs: []uint64{1, 2}, forbidden: true},
+		{name: "missing chat", chatIDs: []uint64{1, 3}, forbidden: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1})
+
+			err := service.CheckMembership(ctx, tt.chatIDs)
+
+			var forbiddenErr *commonerrors.ForbiddenError
+			if errors.As(err, &forbiddenErr) != tt.forbidden {
+				t.Fatalf("CheckMembership(%v) error = %v, want forbidden %t", tt.chatIDs, err, tt.forbidden)
+			}
+		})
+	}
+}
+
+func TestGetChatDegraded(t *testing.T) {
+	chatRepo := &stubChatRepo{chats: []Chat{
+		{ID: 1, CreatedBy: 2, UserChats: []UserChat{{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}}},
+	}}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantErr     bool
+		wantCreator bool
+	}{
+		{name: "with users", wantCreator: true},
+		{name: "user service unavailable", err: commonerrors.NewServiceUnavailableError("user", errors.New("down"))},
+		{name: "user service error", err: errors.New("bad response"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userServiceContract := &stubUserServiceContract{
+				users: []domain.User{{ID: 1}, {ID: 2}},
+				err:   tt.err,
+			}
+			service := NewChatServiceImpl(nil, chatRepo, nil, nil, userServiceContract, nil)
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1})
+
+			chat, err := service.GetChat(ctx, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetChat() error = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if (chat.Creator != nil) != tt.wantCreator {
+				t.Errorf("GetChat() creator = %v, want creator %t", chat.Creator, tt.wantCreator)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ package domain
 import (
 	"golang.org/x/exp/slices"
 
-	"chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/chat/errors"
 )
 
 type ChatType uint8
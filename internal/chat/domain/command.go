@@ -0,0 +1,75 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+var commandRegexp = regexp.MustCompile(`(?s)^/([a-zA-Z0-9_-]+)(?:\s+(.*))?$`)
+
+// commandEscape starts a text that is sent as is instead of running a
+// command, "//help" is posted as "/help".
+const commandEscape = "//"
+
+type Command struct {
+	Name   string
+	Args   string
+	ChatID uint64
+	User   *domain.User
+
+	chat *Chat
+}
+
+// CommandResult is the reply of a command. A public reply is posted to the
+// chat as a message of the author, the caller when AuthorID is 0. Other
+// replies are only sent back to the caller and never stored.
+type CommandResult struct {
+	Text     string
+	Public   bool
+	AuthorID uint64
+}
+
+type CommandHandler interface {
+	Handle(ctx context.Context, command Command) (*CommandResult, error)
+}
+
+// parseCommand returns the command name and arguments when the text is a
+// slash command, like "/topic Weekly sync".
+func parseCommand(text string) (string, string, bool) {
+	matches := commandRegexp.FindStringSubmatch(text)
+	if matches == nil {
+		return "", "", false
+	}
+
+	return strings.ToLower(matches[1]), strings.TrimSpace(matches[2]), true
+}
+
+// unescapeCommand strips the escape of a text that looks like a command.
+func unescapeCommand(text string) (string, bool) {
+	if !strings.HasPrefix(text, commandEscape) {
+		return text, false
+	}
+
+	return text[1:], true
+}
+
+func ephemeralResult(text string) *CommandResult {
+	return &CommandResult{Text: text}
+}
@@ -0,0 +1,70 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommandRouter dispatches slash commands to the built-in handlers first and
+// to the registered external commands otherwise.
+type CommandRouter struct {
+	handlers            map[string]CommandHandler
+	externalCommandRepo ExternalCommandRepo
+	externalCaller      ExternalCommandCaller
+}
+
+func (r *CommandRouter) Register(name string, handler CommandHandler) {
+	r.handlers[name] = handler
+}
+
+func (r *CommandRouter) IsBuiltIn(name string) bool {
+	_, ok := r.handlers[name]
+	return ok
+}
+
+func (r *CommandRouter) Route(ctx context.Context, command Command) (*CommandResult, error) {
+	if handler, ok := r.handlers[command.Name]; ok {
+		return handler.Handle(ctx, command)
+	}
+
+	externalCommand, err := r.externalCommandRepo.GetExternalCommandByName(ctx, command.ChatID, command.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// A command whose creator left the chat can't post there anymore.
+	if externalCommand == nil || !command.chat.HasMember(externalCommand.CreatedBy) {
+		return ephemeralResult(fmt.Sprintf("Unknown command /%s", command.Name)), nil
+	}
+
+	result, err := r.externalCaller.Call(ctx, *externalCommand, command)
+	if err != nil {
+		return nil, err
+	}
+
+	result.AuthorID = externalCommand.CreatedBy
+
+	return result, nil
+}
+
+func NewCommandRouter(externalCommandRepo ExternalCommandRepo, externalCaller ExternalCommandCaller) *CommandRouter {
+	return &CommandRouter{
+		handlers:            make(map[string]CommandHandler),
+		externalCommandRepo: externalCommandRepo,
+		externalCaller:      externalCaller,
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "context"
+
+const skipUsersContextKey = "skipUsers"
+
+// ContextWithoutUsers makes the services return chats and messages without
+// looking their users up, for callers which resolve users on their own and
+// only when they are asked for.
+func ContextWithoutUsers(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipUsersContextKey, true) //nolint:staticcheck // see userContextKey
+}
+
+func skipsUsers(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipUsersContextKey).(bool)
+	return skip
+}
@@ -0,0 +1,56 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+const (
+	ChatCreatedEventType           = "chat.created"
+	ChatUpdatedEventType           = "chat.updated"
+	ChatDeletedEventType           = "chat.deleted"
+	MemberAddedEventType           = "member.added"
+	MessageCreatedEventType        = "message.created"
+	MessageUpdatedEventType        = "message.updated"
+	MessageDeletedEventType        = "message.deleted"
+	MessagesStatusUpdatedEventType = "messages.status_updated"
+	PollUpdatedEventType           = "poll.updated"
+)
+
+// Payloads of the chat events are Chat, member events carry UserChat,
+// message events carry Message and poll events carry Poll with results.
+// Deleted messages only carry their IDs, their content is gone.
+
+type MessageDeletedEventPayload struct {
+	ChatID    uint64 `json:"chatId"`
+	MessageID uint64 `json:"messageId"`
+}
+
+func newMessageDeletedEvent(message Message) domain.Event {
+	return domain.Event{
+		Type: MessageDeletedEventType,
+		Payload: MessageDeletedEventPayload{
+			ChatID:    message.ChatID,
+			MessageID: message.ID,
+		},
+	}
+}
+
+type MessagesStatusEventPayload struct {
+	ChatID     uint64        `json:"chatId"`
+	MessageIDs []uint64      `json:"messageIds"`
+	Status     MessageStatus `json:"status"`
+}
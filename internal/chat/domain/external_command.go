@@ -0,0 +1,35 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+// ExternalCommand is a slash command served by a third party, it's called
+// over HTTP with the Token so the receiver can verify the request. A command
+// is available in the chat it was registered in, its public replies are
+// posted as its creator.
+type ExternalCommand struct {
+	ID          uint64
+	ChatID      uint64
+	Name        string
+	Description string
+	URL         string
+	Token       string
+	CreatedBy   uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
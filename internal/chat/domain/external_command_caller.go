@@ -0,0 +1,142 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/dialer"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+const (
+	// CommandTokenHeader carries the token of the external command.
+	CommandTokenHeader = "X-Command-Token"
+
+	maxCommandResponseSize = 64 * 1024
+)
+
+type ExternalCommandCaller interface {
+	Call(ctx context.Context, externalCommand ExternalCommand, command Command) (*CommandResult, error)
+}
+
+type externalCommandUser struct {
+	ID       uint64 `json:"id"`
+	Username string `json:"username"`
+	IsBot    bool   `json:"isBot"`
+}
+
+type externalCommandRequest struct {
+	Command string              `json:"command"`
+	Args    string              `json:"args"`
+	ChatID  uint64              `json:"chatId"`
+	User    externalCommandUser `json:"user"`
+}
+
+type externalCommandResponse struct {
+	Text   string `json:"text"`
+	Public bool   `json:"public"`
+}
+
+type ExternalCommandCallerImpl struct {
+	log    logger.Logger
+	client *http.Client
+}
+
+// Call posts the command to its URL. Failures are reported to the caller as
+// an ephemeral reply instead of failing the message, the details are only
+// logged.
+func (c *ExternalCommandCallerImpl) Call(
+	ctx context.Context,
+	externalCommand ExternalCommand,
+	command Command,
+) (*CommandResult, error) {
+	result, err := c.call(ctx, externalCommand, command)
+	if err != nil {
+		c.log.Warnf("External command failed id=%d name=%s error=%s", externalCommand.ID, command.Name, err)
+		return ephemeralResult(fmt.Sprintf("Command /%s failed", command.Name)), nil
+	}
+
+	return result, nil
+}
+
+func (c *ExternalCommandCallerImpl) call(
+	ctx context.Context,
+	externalCommand ExternalCommand,
+	command Command,
+) (*CommandResult, error) {
+	body, err := json.Marshal(externalCommandRequest{
+		Command: command.Name,
+		Args:    command.Args,
+		ChatID:  command.ChatID,
+		User: externalCommandUser{
+			ID:       command.User.ID,
+			Username: command.User.Username,
+			IsBot:    command.User.IsBot,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, externalCommand.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CommandTokenHeader, externalCommand.Token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxCommandResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var response externalCommandResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, err
+	}
+
+	return &CommandResult{
+		Text:   response.Text,
+		Public: response.Public,
+	}, nil
+}
+
+func NewExternalCommandCallerImpl(cfg *configs.Config, log logger.Logger) *ExternalCommandCallerImpl {
+	return &ExternalCommandCallerImpl{
+		log: log,
+		client: &http.Client{
+			Timeout:   cfg.CommandTimeout,
+			Transport: dialer.NewTransport(cfg.CommandTimeout, cfg.CommandAllowPrivateNetworks),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+type ExternalCommandRepo interface {
+	GetExternalCommand(ctx context.Context, id uint64) (*ExternalCommand, error)
+	GetExternalCommandByName(ctx context.Context, chatID uint64, name string) (*ExternalCommand, error)
+	GetExternalCommands(ctx context.Context, createdBy uint64) ([]ExternalCommand, error)
+	CreateExternalCommand(ctx context.Context, command ExternalCommand) (*ExternalCommand, error)
+	UpdateExternalCommand(ctx context.Context, command ExternalCommand) (*ExternalCommand, error)
+	DeleteExternalCommand(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,150 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+const commandTokenSize = 32
+
+type ExternalCommandServiceImpl struct {
+	chatRepo            ChatRepo
+	externalCommandRepo ExternalCommandRepo
+	commandRouter       *CommandRouter
+}
+
+func (s *ExternalCommandServiceImpl) getOwnExternalCommand(ctx context.Context, id uint64) (*ExternalCommand, error) {
+	user := domain.UserFromContext(ctx)
+
+	command, err := s.externalCommandRepo.GetExternalCommand(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if command == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if command.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return command, nil
+}
+
+func (s *ExternalCommandServiceImpl) GetExternalCommand(ctx context.Context, id uint64) (*ExternalCommand, error) {
+	return s.getOwnExternalCommand(ctx, id)
+}
+
+func (s *ExternalCommandServiceImpl) GetExternalCommands(ctx context.Context) ([]ExternalCommand, error) {
+	user := domain.UserFromContext(ctx)
+
+	return s.externalCommandRepo.GetExternalCommands(ctx, user.ID)
+}
+
+func (s *ExternalCommandServiceImpl) CreateExternalCommand(
+	ctx context.Context,
+	command ExternalCommand,
+) (*ExternalCommand, error) {
+	user := domain.UserFromContext(ctx)
+
+	command.Name = strings.ToLower(command.Name)
+
+	chat, err := s.chatRepo.GetChat(ctx, command.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	// The creator posts the public replies, so it must be a member.
+	if !chat.HasMember(user.ID) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	if s.commandRouter.IsBuiltIn(command.Name) {
+		return nil, chaterrors.NewCommandNameTakenError(map[string]any{"name": command.Name})
+	}
+
+	existingCommand, err := s.externalCommandRepo.GetExternalCommandByName(ctx, command.ChatID, command.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingCommand != nil {
+		return nil, chaterrors.NewCommandNameTakenError(map[string]any{"name": command.Name})
+	}
+
+	token := make([]byte, commandTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, errors.NewUndefinedError(err, "error on generating command token")
+	}
+
+	command.Token = hex.EncodeToString(token)
+	command.CreatedBy = user.ID
+
+	return s.externalCommandRepo.CreateExternalCommand(ctx, command)
+}
+
+func (s *ExternalCommandServiceImpl) UpdateExternalCommand(
+	ctx context.Context,
+	command ExternalCommand,
+) (*ExternalCommand, error) {
+	if _, err := s.getOwnExternalCommand(ctx, command.ID); err != nil {
+		return nil, err
+	}
+
+	updatedCommand, err := s.externalCommandRepo.UpdateExternalCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedCommand == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return updatedCommand, nil
+}
+
+func (s *ExternalCommandServiceImpl) DeleteExternalCommand(ctx context.Context, id uint64) error {
+	if _, err := s.getOwnExternalCommand(ctx, id); err != nil {
+		return err
+	}
+
+	return s.externalCommandRepo.DeleteExternalCommand(ctx, id)
+}
+
+func NewExternalCommandServiceImpl(
+	chatRepo ChatRepo,
+	externalCommandRepo ExternalCommandRepo,
+	commandRouter *CommandRouter,
+) *ExternalCommandServiceImpl {
+	return &ExternalCommandServiceImpl{
+		chatRepo:            chatRepo,
+		externalCommandRepo: externalCommandRepo,
+		commandRouter:       commandRouter,
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+// Folder groups the chats of a user. A chat of the user is in the folder
+// when it's listed in ChatIDs or its type is in ChatTypes, or always when
+// both are empty. UnreadOnly and ExcludeMuted narrow it down further.
+type Folder struct {
+	ID           uint64
+	UserID       uint64
+	Name         string
+	ChatIDs      []uint64
+	ChatTypes    []ChatType
+	UnreadOnly   bool
+	ExcludeMuted bool
+	// UnreadCount is the number of unread messages from other users in the
+	// chats of the folder.
+	UnreadCount uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,31 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+type FolderRepo interface {
+	GetFolder(ctx context.Context, id uint64) (*Folder, error)
+	GetFolders(ctx context.Context, userID uint64) ([]Folder, error)
+	// GetUnreadCounts returns the unread counts of the folders of the user
+	// by folder ID, folders without unread messages are left out. Only the
+	// given folders are counted, all of them when none are given.
+	GetUnreadCounts(ctx context.Context, userID uint64, folderIDs []uint64) (map[uint64]uint64, error)
+	CreateFolder(ctx context.Context, folder Folder) (*Folder, error)
+	UpdateFolder(ctx context.Context, folder Folder) (*Folder, error)
+	DeleteFolder(ctx context.Context, id uint64) error
+}
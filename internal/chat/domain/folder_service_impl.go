@@ -0,0 +1,136 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type FolderServiceImpl struct {
+	folderRepo FolderRepo
+}
+
+func getOwnFolder(ctx context.Context, folderRepo FolderRepo, id uint64) (*Folder, error) {
+	user := domain.UserFromContext(ctx)
+
+	folder, err := folderRepo.GetFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if folder == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if folder.UserID != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return folder, nil
+}
+
+func (s *FolderServiceImpl) fillUnreadCount(ctx context.Context, folder *Folder) error {
+	unreadCounts, err := s.folderRepo.GetUnreadCounts(ctx, folder.UserID, []uint64{folder.ID})
+	if err != nil {
+		return err
+	}
+
+	folder.UnreadCount = unreadCounts[folder.ID]
+
+	return nil
+}
+
+func (s *FolderServiceImpl) GetFolder(ctx context.Context, id uint64) (*Folder, error) {
+	folder, err := getOwnFolder(ctx, s.folderRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillUnreadCount(ctx, folder); err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+func (s *FolderServiceImpl) GetFolders(ctx context.Context) ([]Folder, error) {
+	user := domain.UserFromContext(ctx)
+
+	folders, err := s.folderRepo.GetFolders(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCounts, err := s.folderRepo.GetUnreadCounts(ctx, user.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range folders {
+		folders[index].UnreadCount = unreadCounts[folders[index].ID]
+	}
+
+	return folders, nil
+}
+
+func (s *FolderServiceImpl) CreateFolder(ctx context.Context, folder Folder) (*Folder, error) {
+	user := domain.UserFromContext(ctx)
+
+	folder.UserID = user.ID
+
+	createdFolder, err := s.folderRepo.CreateFolder(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillUnreadCount(ctx, createdFolder); err != nil {
+		return nil, err
+	}
+
+	return createdFolder, nil
+}
+
+func (s *FolderServiceImpl) UpdateFolder(ctx context.Context, folder Folder) (*Folder, error) {
+	if _, err := getOwnFolder(ctx, s.folderRepo, folder.ID); err != nil {
+		return nil, err
+	}
+
+	updatedFolder, err := s.folderRepo.UpdateFolder(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillUnreadCount(ctx, updatedFolder); err != nil {
+		return nil, err
+	}
+
+	return updatedFolder, nil
+}
+
+func (s *FolderServiceImpl) DeleteFolder(ctx context.Context, id uint64) error {
+	if _, err := getOwnFolder(ctx, s.folderRepo, id); err != nil {
+		return err
+	}
+
+	return s.folderRepo.DeleteFolder(ctx, id)
+}
+
+func NewFolderServiceImpl(folderRepo FolderRepo) *FolderServiceImpl {
+	return &FolderServiceImpl{folderRepo: folderRepo}
+}
@@ -0,0 +1,68 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// LinkPreview is the metadata of a link in a message, taken from the
+// OpenGraph tags or the oEmbed endpoint of the page.
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+	SiteName    string `json:"siteName,omitempty"`
+
+	// IsFound is false for a cached page without any metadata, so it
+	// isn't fetched again until the cache expires.
+	IsFound   bool      `json:"-"`
+	FetchedAt time.Time `json:"-"`
+}
+
+// HasLinks tells whether the message needs link previews.
+func (m *Message) HasLinks() bool {
+	return lo.ContainsBy(m.Entities, func(entity MessageEntity) bool {
+		return entity.Type == URLMessageEntityType
+	})
+}
+
+// linkURLs returns the distinct links of the message in the order they
+// appear, at most limit of them.
+func (m *Message) linkURLs(limit uint) []string {
+	runes := []rune(m.Text)
+
+	var urls []string
+
+	for _, entity := range m.Entities {
+		if entity.Type != URLMessageEntityType || entity.Offset+entity.Length > len(runes) {
+			continue
+		}
+
+		url := string(runes[entity.Offset : entity.Offset+entity.Length])
+		if !lo.Contains(urls, url) {
+			urls = append(urls, url)
+		}
+
+		if uint(len(urls)) >= limit {
+			break
+		}
+	}
+
+	return urls
+}
@@ -0,0 +1,275 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/dialer"
+)
+
+const (
+	linkPreviewUserAgent = "chat-go-link-preview/1.0"
+
+	maxLinkPreviewRedirects   = 3
+	maxLinkPreviewTitle       = 300
+	maxLinkPreviewDescription = 1000
+)
+
+type LinkPreviewFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*LinkPreview, error)
+}
+
+// LinkPreviewFetcherImpl reads OpenGraph tags of a page and falls back to
+// its oEmbed endpoint. Addresses are checked right before connecting, so a
+// name resolving to a private network or a redirect there is refused.
+type LinkPreviewFetcherImpl struct {
+	cfg    *configs.Config
+	client *http.Client
+}
+
+func (f *LinkPreviewFetcherImpl) Fetch(ctx context.Context, rawURL string) (*LinkPreview, error) {
+	resp, err := f.get(ctx, rawURL, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	linkPreview := &LinkPreview{URL: rawURL}
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		linkPreview.ImageURL = resp.Request.URL.String()
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		// Pages are transcoded to UTF-8 by the header charset, the meta tag
+		// or a guess from the content.
+		body, err := charset.NewReader(io.LimitReader(resp.Body, f.cfg.UnfurlMaxBodySize), contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		oEmbedURL := f.parseHTML(body, resp.Request.URL, linkPreview)
+
+		if oEmbedURL != "" && (linkPreview.Title == "" || linkPreview.ImageURL == "") {
+			// A broken oEmbed endpoint still leaves the OpenGraph data.
+			_ = f.fetchOEmbed(ctx, oEmbedURL, linkPreview)
+		}
+	}
+
+	linkPreview.Title = truncateRunes(sanitizeText(linkPreview.Title), maxLinkPreviewTitle)
+	linkPreview.Description = truncateRunes(sanitizeText(linkPreview.Description), maxLinkPreviewDescription)
+	linkPreview.ImageURL = sanitizeText(linkPreview.ImageURL)
+	linkPreview.SiteName = sanitizeText(linkPreview.SiteName)
+	linkPreview.IsFound = linkPreview.Title != "" || linkPreview.ImageURL != ""
+
+	return linkPreview, nil
+}
+
+func (f *LinkPreviewFetcherImpl) get(ctx context.Context, rawURL string, accept string) (*http.Response, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, dialer.ErrAddressNotAllowed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", linkPreviewUserAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// parseHTML fills the preview from the head of the page and returns the
+// oEmbed URL, if the page has one.
+func (f *LinkPreviewFetcherImpl) parseHTML(body io.Reader, baseURL *url.URL, linkPreview *LinkPreview) string {
+	var (
+		tokenizer = html.NewTokenizer(body)
+		meta      = make(map[string]string)
+		title     string
+		inTitle   bool
+		oEmbedURL string
+	)
+
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				break loop
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(value)
+			}
+
+			switch string(name) {
+			case "title":
+				inTitle = true
+			case "body":
+				break loop
+			case "meta":
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+
+				key = strings.ToLower(key)
+				if _, ok := meta[key]; !ok && key != "" {
+					meta[key] = strings.TrimSpace(attrs["content"])
+				}
+			case "link":
+				if strings.EqualFold(attrs["rel"], "alternate") && attrs["type"] == "application/json+oembed" {
+					oEmbedURL = resolveURL(baseURL, attrs["href"])
+				}
+			}
+		}
+	}
+
+	linkPreview.Title = firstNotEmpty(meta["og:title"], meta["twitter:title"], strings.TrimSpace(title))
+	linkPreview.Description = firstNotEmpty(meta["og:description"], meta["twitter:description"], meta["description"])
+	linkPreview.ImageURL = resolveURL(baseURL, firstNotEmpty(meta["og:image"], meta["twitter:image"]))
+	linkPreview.SiteName = firstNotEmpty(meta["og:site_name"], baseURL.Hostname())
+
+	return oEmbedURL
+}
+
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func (f *LinkPreviewFetcherImpl) fetchOEmbed(ctx context.Context, oEmbedURL string, linkPreview *LinkPreview) error {
+	resp, err := f.get(ctx, oEmbedURL, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response oEmbedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, f.cfg.UnfurlMaxBodySize)).Decode(&response); err != nil {
+		return err
+	}
+
+	linkPreview.Title = firstNotEmpty(linkPreview.Title, response.Title)
+	linkPreview.Description = firstNotEmpty(linkPreview.Description, response.AuthorName)
+	linkPreview.ImageURL = firstNotEmpty(linkPreview.ImageURL, resolveURL(resp.Request.URL, response.ThumbnailURL))
+	linkPreview.SiteName = firstNotEmpty(response.ProviderName, linkPreview.SiteName)
+
+	return nil
+}
+
+func resolveURL(baseURL *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	refURL, err := baseURL.Parse(ref)
+	if err != nil || (refURL.Scheme != "http" && refURL.Scheme != "https") {
+		return ""
+	}
+
+	return refURL.String()
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// sanitizeText drops what Postgres refuses to store in text and JSONB:
+// invalid UTF-8 sequences and NUL characters.
+func sanitizeText(text string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(text, "\uFFFD"), "\x00", "")
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	return string([]rune(text)[:limit])
+}
+
+func NewLinkPreviewFetcherImpl(cfg *configs.Config) *LinkPreviewFetcherImpl {
+	return &LinkPreviewFetcherImpl{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout:   cfg.UnfurlTimeout,
+			Transport: dialer.NewTransport(cfg.UnfurlTimeout, cfg.UnfurlAllowPrivateNetworks),
+			CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+				if len(via) >= maxLinkPreviewRedirects {
+					return http.ErrUseLastResponse
+				}
+
+				return nil
+			},
+		},
+	}
+}
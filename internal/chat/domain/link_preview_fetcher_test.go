@@ -0,0 +1,205 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/dialer"
+)
+
+func newTestLinkPreviewFetcher(allowPrivateNetworks bool) *LinkPreviewFetcherImpl {
+	return NewLinkPreviewFetcherImpl(&configs.Config{
+		UnfurlTimeout:              time.Second,
+		UnfurlMaxBodySize:          1 << 20,
+		UnfurlAllowPrivateNetworks: allowPrivateNetworks,
+	})
+}
+
+func TestLinkPreviewFetcherFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/og", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head>
+			<title>Fallback</title>
+			<meta property="og:title" content="Title">
+			<meta property="og:description" content="Description">
+			<meta property="og:image" content="/image.png">
+			<meta property="og:site_name" content="Site">
+		</head><body></body></html>`))
+	})
+	mux.HandleFunc("/title", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title> Only title </title></head></html>`))
+	})
+	mux.HandleFunc("/cp1251", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=windows-1251")
+		_, _ = w.Write(append(append([]byte(`<html><head><title>`), 0xcf, 0xf0, 0xe8, 0xe2, 0xe5, 0xf2), `</title></head></html>`...))
+	})
+	mux.HandleFunc("/meta-charset", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write(append(append([]byte(`<html><head><meta charset="windows-1251"><title>`), 0xcf, 0xf0, 0xe8, 0xe2, 0xe5, 0xf2), `</title></head></html>`...))
+	})
+	mux.HandleFunc("/nul", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><head><title>a&#0;b\x00c\xffd</title></head></html>"))
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head>
+			<link rel="alternate" type="application/json+oembed" href="/oembed.json">
+		</head></html>`))
+	})
+	mux.HandleFunc("/oembed.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title":"Video","author_name":"Author","provider_name":"Provider","thumbnail_url":"/thumb.jpg"}`))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	tests := []struct {
+		name    string
+		path    string
+		want    LinkPreview
+		wantErr bool
+	}{
+		{
+			name: "open graph",
+			path: "/og",
+			want: LinkPreview{
+				Title:       "Title",
+				Description: "Description",
+				ImageURL:    server.URL + "/image.png",
+				SiteName:    "Site",
+				IsFound:     true,
+			},
+		},
+		{
+			name: "title fallback",
+			path: "/title",
+			want: LinkPreview{Title: "Only title", SiteName: "127.0.0.1", IsFound: true},
+		},
+		{
+			name: "header charset",
+			path: "/cp1251",
+			want: LinkPreview{Title: "Привет", SiteName: "127.0.0.1", IsFound: true},
+		},
+		{
+			name: "meta charset",
+			path: "/meta-charset",
+			want: LinkPreview{Title: "Привет", SiteName: "127.0.0.1", IsFound: true},
+		},
+		{
+			name: "nul and invalid utf-8",
+			path: "/nul",
+			want: LinkPreview{Title: "a�b�c�d", SiteName: "127.0.0.1", IsFound: true},
+		},
+		{
+			name: "oembed",
+			path: "/oembed",
+			want: LinkPreview{
+				Title:       "Video",
+				Description: "Author",
+				ImageURL:    server.URL + "/thumb.jpg",
+				SiteName:    "Provider",
+				IsFound:     true,
+			},
+		},
+		{
+			name: "image",
+			path: "/image.png",
+			want: LinkPreview{ImageURL: server.URL + "/image.png", IsFound: true},
+		},
+		{
+			name:    "not found",
+			path:    "/missing",
+			wantErr: true,
+		},
+	}
+
+	fetcher := newTestLinkPreviewFetcher(true)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkPreview, err := fetcher.Fetch(context.Background(), server.URL+tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Fetch() error = nil, want error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+
+			tt.want.URL = server.URL + tt.path
+			if *linkPreview != tt.want {
+				t.Errorf("Fetch() = %+v, want %+v", *linkPreview, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "plain", want: "plain"},
+		{text: "a\x00b", want: "ab"},
+		{text: "a\xffb", want: "a\uFFFDb"},
+		{text: "\x00\xc3\x00", want: "\uFFFD"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeText(tt.text); got != tt.want {
+			t.Errorf("sanitizeText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLinkPreviewFetcherRefusesPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Internal</title></head></html>`))
+	}))
+	defer server.Close()
+
+	fetcher := newTestLinkPreviewFetcher(false)
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL); !errors.Is(err, dialer.ErrAddressNotAllowed) {
+		t.Errorf("Fetch() error = %v, want %v", err, dialer.ErrAddressNotAllowed)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), "file:///etc/passwd"); !errors.Is(err, dialer.ErrAddressNotAllowed) {
+		t.Errorf("Fetch() error = %v, want %v", err, dialer.ErrAddressNotAllowed)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LinkPreviewRepo interface {
+	// GetLinkPreview returns the cached preview of the URL if it was
+	// fetched after fetchedAfter.
+	GetLinkPreview(ctx context.Context, url string, fetchedAfter time.Time) (*LinkPreview, error)
+	SaveLinkPreview(ctx context.Context, linkPreview LinkPreview) error
+}
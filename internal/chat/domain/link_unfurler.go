@@ -0,0 +1,210 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrLinkUnfurlerAlreadyStarted = errors.New("link unfurler already started")
+
+// LinkUnfurler attaches link previews to new and edited messages. Pages are
+// fetched outside of a transaction and the previews are only stored if the
+// message wasn't edited in the meantime.
+type LinkUnfurler struct {
+	cfg                *configs.Config
+	log                logger.Logger
+	baseRepo           repository.BaseRepo
+	messageRepo        MessageRepo
+	linkPreviewRepo    LinkPreviewRepo
+	linkPreviewFetcher LinkPreviewFetcher
+	eventPublisher     EventPublisher
+
+	isStarted atomic.Bool
+}
+
+func (u *LinkUnfurler) Start(ctx context.Context) error {
+	if !u.isStarted.CompareAndSwap(false, true) {
+		return ErrLinkUnfurlerAlreadyStarted
+	}
+	defer u.isStarted.Store(false)
+
+	for {
+		u.unfurlPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(u.cfg.UnfurlPollInterval):
+		}
+	}
+}
+
+func (u *LinkUnfurler) unfurlPending(ctx context.Context) {
+	for {
+		count, err := u.unfurlBatch(ctx)
+		if err != nil {
+			u.log.Errorf("error on unfurling links: %s", err)
+			return
+		}
+
+		if count < u.cfg.UnfurlBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (u *LinkUnfurler) unfurlBatch(ctx context.Context) (uint64, error) {
+	// Each link of the batch may take up to the fetch timeout.
+	claimTimeout := u.cfg.UnfurlTimeout * time.Duration(u.cfg.UnfurlBatchSize*uint64(u.cfg.UnfurlMaxLinks)+1)
+
+	messages, err := u.messageRepo.ClaimUnfurlPendingMessages(ctx, u.cfg.UnfurlBatchSize, time.Now().UTC().Add(claimTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			return 0, nil
+		}
+
+		if err := u.unfurlMessage(ctx, message); err != nil {
+			// A failing message must not hold back the rest of the batch
+			// nor be retried forever.
+			u.log.Errorf("error on unfurling links of message %d: %s", message.ID, err)
+
+			if err := u.messageRepo.ClearUnfurlPending(ctx, message); err != nil {
+				u.log.Errorf("error on clearing pending unfurl of message %d: %s", message.ID, err)
+			}
+		}
+	}
+
+	return uint64(len(messages)), nil
+}
+
+func (u *LinkUnfurler) unfurlMessage(ctx context.Context, message Message) error {
+	linkPreviews, err := u.getLinkPreviews(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	message.LinkPreviews = linkPreviews
+
+	return u.updateMessage(ctx, message)
+}
+
+func (u *LinkUnfurler) getLinkPreviews(ctx context.Context, message Message) ([]LinkPreview, error) {
+	linkPreviews := make([]LinkPreview, 0)
+
+	for _, url := range message.linkURLs(u.cfg.UnfurlMaxLinks) {
+		linkPreview, err := u.getLinkPreview(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		if linkPreview.IsFound {
+			linkPreviews = append(linkPreviews, *linkPreview)
+		}
+	}
+
+	return linkPreviews, nil
+}
+
+func (u *LinkUnfurler) getLinkPreview(ctx context.Context, url string) (*LinkPreview, error) {
+	now := time.Now().UTC()
+
+	linkPreview, err := u.linkPreviewRepo.GetLinkPreview(ctx, url, now.Add(-u.cfg.UnfurlCacheTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	if linkPreview != nil {
+		return linkPreview, nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, u.cfg.UnfurlTimeout)
+	defer cancel()
+
+	linkPreview, err = u.linkPreviewFetcher.Fetch(fetchCtx, url)
+	if err != nil {
+		// Unreachable pages are cached as well, so they aren't requested
+		// for every message.
+		u.log.Debugf("error on fetching link preview of %s: %s", url, err)
+		linkPreview = &LinkPreview{URL: url}
+	}
+
+	linkPreview.FetchedAt = now
+
+	if err := u.linkPreviewRepo.SaveLinkPreview(ctx, *linkPreview); err != nil {
+		return nil, err
+	}
+
+	return linkPreview, nil
+}
+
+func (u *LinkUnfurler) updateMessage(ctx context.Context, message Message) error {
+	tx, err := u.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	updatedMessage, err := u.messageRepo.UpdateMessageLinkPreviews(ctx, message, tx)
+	if err != nil {
+		return err
+	}
+
+	if updatedMessage != nil && len(updatedMessage.LinkPreviews) > 0 {
+		if err := u.eventPublisher.Publish(ctx, tx, domain.Event{
+			Type:    MessageUpdatedEventType,
+			Payload: updatedMessage,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func NewLinkUnfurler(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	messageRepo MessageRepo,
+	linkPreviewRepo LinkPreviewRepo,
+	linkPreviewFetcher LinkPreviewFetcher,
+	eventPublisher EventPublisher,
+) *LinkUnfurler {
+	return &LinkUnfurler{
+		cfg:                cfg,
+		log:                log,
+		baseRepo:           baseRepo,
+		messageRepo:        messageRepo,
+		linkPreviewRepo:    linkPreviewRepo,
+		linkPreviewFetcher: linkPreviewFetcher,
+		eventPublisher:     eventPublisher,
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+type stubLogger struct {
+	logger.Logger
+}
+
+func (stubLogger) Debugf(string, ...any) {}
+func (stubLogger) Errorf(string, ...any) {}
+
+type stubTx struct {
+	repository.Tx
+}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
+type stubBaseRepo struct {
+	repository.BaseRepo
+}
+
+func (stubBaseRepo) BeginContext(context.Context) (repository.Tx, error) {
+	return stubTx{}, nil
+}
+
+type stubUnfurlMessageRepo struct {
+	MessageRepo
+
+	pending  []Message
+	updated  map[uint64][]LinkPreview
+	cleared  []uint64
+	failIDs  map[uint64]bool
+	claimed  time.Time
+	claimErr error
+}
+
+func (r *stubUnfurlMessageRepo) ClaimUnfurlPendingMessages(
+	_ context.Context,
+	limit uint64,
+	claimedUntil time.Time,
+) ([]Message, error) {
+	r.claimed = claimedUntil
+
+	if r.claimErr != nil {
+		return nil, r.claimErr
+	}
+
+	messages := r.pending[:min(limit, uint64(len(r.pending)))]
+	r.pending = r.pending[len(messages):]
+
+	return messages, nil
+}
+
+func (r *stubUnfurlMessageRepo) UpdateMessageLinkPreviews(
+	_ context.Context,
+	message Message,
+	_ repository.Tx,
+) (*Message, error) {
+	if r.failIDs[message.ID] {
+		return nil, errors.New("update failed")
+	}
+
+	r.updated[message.ID] = message.LinkPreviews
+
+	return &message, nil
+}
+
+func (r *stubUnfurlMessageRepo) ClearUnfurlPending(_ context.Context, message Message) error {
+	r.cleared = append(r.cleared, message.ID)
+	return nil
+}
+
+type stubLinkPreviewRepo struct {
+	saved []string
+}
+
+func (r *stubLinkPreviewRepo) GetLinkPreview(_ context.Context, url string, _ time.Time) (*LinkPreview, error) {
+	if url == "https://broken.example" {
+		return nil, errors.New("lookup failed")
+	}
+
+	return nil, nil
+}
+
+func (r *stubLinkPreviewRepo) SaveLinkPreview(_ context.Context, linkPreview LinkPreview) error {
+	r.saved = append(r.saved, linkPreview.URL)
+	return nil
+}
+
+type stubLinkPreviewFetcher struct{}
+
+func (stubLinkPreviewFetcher) Fetch(_ context.Context, rawURL string) (*LinkPreview, error) {
+	if rawURL == "https://down.example" {
+		return nil, errors.New("connection refused")
+	}
+
+	return &LinkPreview{URL: rawURL, Title: rawURL, IsFound: true}, nil
+}
+
+type stubEventPublisher struct {
+	events []domain.Event
+}
+
+func (p *stubEventPublisher) Publish(_ context.Context, _ repository.Tx, events ...domain.Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func newLinkMessage(id uint64, urls ...string) Message {
+	message := Message{ID: id}
+
+	for _, url := range urls {
+		if message.Text != "" {
+			message.Text += " "
+		}
+
+		message.Entities = append(message.Entities, MessageEntity{
+			Type:   URLMessageEntityType,
+			Offset: len([]rune(message.Text)),
+			Length: len([]rune(url)),
+		})
+		message.Text += url
+	}
+
+	return message
+}
+
+func TestLinkUnfurlerUnfurlBatch(t *testing.T) {
+	messageRepo := &stubUnfurlMessageRepo{
+		pending: []Message{
+			newLinkMessage(1, "https://a.example", "https://down.example"),
+			newLinkMessage(2, "https://broken.example"),
+			newLinkMessage(3, "https://b.example"),
+			newLinkMessage(4, "https://c.example"),
+		},
+		updated: make(map[uint64][]LinkPreview),
+		failIDs: map[uint64]bool{3: true},
+	}
+	linkPreviewRepo := &stubLinkPreviewRepo{}
+	eventPublisher := &stubEventPublisher{}
+
+	unfurler := NewLinkUnfurler(
+		&configs.Config{UnfurlBatchSize: 10, UnfurlMaxLinks: 3, UnfurlTimeout: time.Second},
+		stubLogger{},
+		stubBaseRepo{},
+		messageRepo,
+		linkPreviewRepo,
+		stubLinkPreviewFetcher{},
+		eventPublisher,
+	)
+
+	before := time.Now().UTC()
+
+	count, err := unfurler.unfurlBatch(context.Background())
+	if err != nil {
+		t.Fatalf("unfurlBatch() error = %v", err)
+	}
+
+	if count != 4 {
+		t.Errorf("unfurlBatch() = %d, want 4", count)
+	}
+
+	if !messageRepo.claimed.After(before) {
+		t.Errorf("claimed until %s, want after %s", messageRepo.claimed, before)
+	}
+
+	wantUpdated := map[uint64][]LinkPreview{
+		1: {{URL: "https://a.example", Title: "https://a.example", IsFound: true}},
+		4: {{URL: "https://c.example", Title: "https://c.example", IsFound: true}},
+	}
+
+	for id, linkPreviews := range messageRepo.updated {
+		for i := range linkPreviews {
+			linkPreviews[i].FetchedAt = time.Time{}
+		}
+
+		messageRepo.updated[id] = linkPreviews
+	}
+
+	if !reflect.DeepEqual(messageRepo.updated, wantUpdated) {
+		t.Errorf("updated = %+v, want %+v", messageRepo.updated, wantUpdated)
+	}
+
+	if !reflect.DeepEqual(messageRepo.cleared, []uint64{2, 3}) {
+		t.Errorf("cleared = %v, want [2 3]", messageRepo.cleared)
+	}
+
+	// Unreachable pages are cached too.
+	wantSaved := []string{"https://a.example", "https://down.example", "https://b.example", "https://c.example"}
+	if !reflect.DeepEqual(linkPreviewRepo.saved, wantSaved) {
+		t.Errorf("saved = %v, want %v", linkPreviewRepo.saved, wantSaved)
+	}
+
+	if len(eventPublisher.events) != 2 {
+		t.Errorf("published %d events, want 2", len(eventPublisher.events))
+	}
+}
+
+func TestLinkUnfurlerUnfurlBatchClaimError(t *testing.T) {
+	claimErr := errors.New("claim failed")
+
+	unfurler := NewLinkUnfurler(
+		&configs.Config{UnfurlBatchSize: 10, UnfurlMaxLinks: 3, UnfurlTimeout: time.Second},
+		stubLogger{},
+		stubBaseRepo{},
+		&stubUnfurlMessageRepo{claimErr: claimErr},
+		&stubLinkPreviewRepo{},
+		stubLinkPreviewFetcher{},
+		&stubEventPublisher{},
+	)
+
+	if _, err := unfurler.unfurlBatch(context.Background()); !errors.Is(err, claimErr) {
+		t.Errorf("unfurlBatch() error = %v, want %v", err, claimErr)
+	}
+}
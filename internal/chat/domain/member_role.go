@@ -0,0 +1,34 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// MemberRole is what a member may do in a chat. The creator of a chat is its
+// owner.
+type MemberRole uint8
+
+const (
+	MemberMemberRole MemberRole = 1
+	AdminMemberRole  MemberRole = 2
+	OwnerMemberRole  MemberRole = 3
+)
+
+func (r MemberRole) Uint8() uint8 {
+	return uint8(r)
+}
+
+// CanManageMembers reports whether the member may add members.
+func (r MemberRole) CanManageMembers() bool {
+	return r == AdminMemberRole || r == OwnerMemberRole
+}
@@ -0,0 +1,56 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const MentionAllUsername = "all"
+
+// mentionRegexp uses the same character set as the username validator. A
+// mention must start the text or follow a character that can't be part of a
+// username, so e-mail addresses are not treated as mentions.
+var mentionRegexp = regexp.MustCompile(`(^|[^a-zA-Z0-9._@-])@([a-zA-Z0-9._-]+)`)
+
+type mention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func parseMentions(text string) []mention {
+	var mentions []mention
+
+	for _, match := range mentionRegexp.FindAllStringSubmatchIndex(text, -1) {
+		username := strings.TrimRight(text[match[4]:match[5]], ".")
+		if len(username) == 0 {
+			continue
+		}
+
+		// match[4] points right after "@", the mention itself starts one byte earlier.
+		start := match[4] - 1
+
+		mentions = append(mentions, mention{
+			Username: username,
+			Offset:   utf8.RuneCountInString(text[:start]),
+			Length:   utf8.RuneCountInString(username) + 1,
+		})
+	}
+
+	return mentions
+}
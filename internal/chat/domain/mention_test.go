@@ -0,0 +1,91 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []mention
+	}{
+		{
+			name: "no mentions",
+			text: "hello world",
+		},
+		{
+			name: "mention at the start",
+			text: "@alice hi",
+			want: []mention{{Username: "alice", Offset: 0, Length: 6}},
+		},
+		{
+			name: "several mentions",
+			text: "hi @alice and @bob_1",
+			want: []mention{
+				{Username: "alice", Offset: 3, Length: 6},
+				{Username: "bob_1", Offset: 14, Length: 6},
+			},
+		},
+		{
+			name: "mention of all",
+			text: "@all meeting",
+			want: []mention{{Username: MentionAllUsername, Offset: 0, Length: 4}},
+		},
+		{
+			name: "trailing dots are punctuation",
+			text: "thanks @john.doe.",
+			want: []mention{{Username: "john.doe", Offset: 7, Length: 9}},
+		},
+		{
+			name: "e-mail addresses are not mentions",
+			text: "write to alice@example.com",
+		},
+		{
+			name: "adjacent mentions need a separator",
+			text: "@alice@bob",
+			want: []mention{{Username: "alice", Offset: 0, Length: 6}},
+		},
+		{
+			name: "mention after punctuation",
+			text: "(@alice)",
+			want: []mention{{Username: "alice", Offset: 1, Length: 6}},
+		},
+		{
+			name: "lone at signs",
+			text: "@ and @. and @@",
+		},
+		{
+			name: "offsets count runes",
+			text: "привет @alice",
+			want: []mention{{Username: "alice", Offset: 7, Length: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMentions(tt.text)
+
+			if len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("parseMentions(%q) = %+v, want %+v", tt.text, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ package domain
 import (
 	"time"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type MessageStatus uint8
@@ -32,13 +32,57 @@ const (
 	ReadMessageStatus   MessageStatus = 3
 )
 
+// maxUndoSendDelay is the longest undo-send window in seconds a message may
+// ask for.
+const maxUndoSendDelay = 30
+
+// maxForwardedMessages caps the copies of one forward, messages times
+// chats. Every copy is sent on its own, with its own outbox event, in a
+// single transaction.
+const maxForwardedMessages = 100
+
 type Message struct {
-	ID        uint64
-	Text      string
-	Status    MessageStatus
-	ChatID    uint64
-	CreatedBy uint64
-	Creator   *domain.User
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID        uint64          `json:"id"`
+	Text      string          `json:"text"`
+	Status    MessageStatus   `json:"status"`
+	ChatID    uint64          `json:"chatId"`
+	Entities  []MessageEntity `json:"entities"`
+	CreatedBy uint64          `json:"createdBy"`
+	Creator   *domain.User    `json:"creator,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+
+	// MentionedUserIDs is filled on creation and edit only and already has
+	// @all expanded to the chat members.
+	MentionedUserIDs []uint64 `json:"mentionedUserIds,omitempty"`
+
+	// IsEphemeral marks a command reply meant for the caller only, it has
+	// no ID and isn't stored.
+	IsEphemeral bool `json:"isEphemeral,omitempty"`
+
+	// ExpiresIn is the expiry timer in seconds counted from ExpireFrom,
+	// ExpiresAt is set once the timer starts. Expired messages are hidden
+	// right away and deleted by the MessageExpirer.
+	ExpiresIn  *uint      `json:"expiresIn,omitempty"`
+	ExpireFrom ExpireFrom `json:"expireFrom,omitempty"`
+	IsViewOnce bool       `json:"isViewOnce,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+
+	// UndoSendDelay holds a new message for that many seconds before it's
+	// sent, nil falls back to the configured delay. A held message is
+	// returned with its ScheduledMessageID and SendAt set.
+	UndoSendDelay      *uint      `json:"-"`
+	ScheduledMessageID uint64     `json:"scheduledMessageId,omitempty"`
+	SendAt             *time.Time `json:"sendAt,omitempty"`
+
+	ForwardedFrom *MessageForward `json:"forwardedFrom,omitempty"`
+
+	// Kind tells plain text from polls, the text of a poll message is its
+	// question.
+	Kind MessageKind `json:"kind"`
+	Poll *Poll       `json:"poll,omitempty"`
+
+	// LinkPreviews are attached by the LinkUnfurler after the message is
+	// sent or edited.
+	LinkPreviews []LinkPreview `json:"linkPreviews,omitempty"`
 }
@@ -0,0 +1,64 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+type MessageEntityType uint8
+
+func (t MessageEntityType) ToUint8() uint8 {
+	return uint8(t)
+}
+
+const (
+	MentionMessageEntityType    MessageEntityType = 1
+	MentionAllMessageEntityType MessageEntityType = 2
+	BoldMessageEntityType       MessageEntityType = 3
+	ItalicMessageEntityType     MessageEntityType = 4
+	CodeMessageEntityType       MessageEntityType = 5
+	PreMessageEntityType        MessageEntityType = 6
+	URLMessageEntityType        MessageEntityType = 7
+	HashtagMessageEntityType    MessageEntityType = 8
+)
+
+// MessageEntity describes a span of the message text. Offset and Length are
+// counted in characters (runes), not bytes.
+type MessageEntity struct {
+	Type   MessageEntityType `json:"type"`
+	Offset int               `json:"offset"`
+	Length int               `json:"length"`
+	UserID *uint64           `json:"userId,omitempty"`
+
+	// Language is the language of a code block, if given.
+	Language string `json:"language,omitempty"`
+}
+
+// overlapsEntity tells whether the span overlaps an entity of one of the
+// types.
+func overlapsEntity(entities []MessageEntity, offset, length int, types ...MessageEntityType) bool {
+	for _, entity := range entities {
+		if !slices.Contains(types, entity.Type) {
+			continue
+		}
+
+		if offset < entity.Offset+entity.Length && entity.Offset < offset+length {
+			return true
+		}
+	}
+
+	return false
+}
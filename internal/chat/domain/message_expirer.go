@@ -0,0 +1,97 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrMessageExpirerAlreadyStarted = errors.New("message expirer already started")
+
+// MessageExpirer deletes disappearing and view-once messages past their
+// expiry. They are hidden from reads as soon as they expire, the expirer
+// removes them and announces the deletion to connected clients. Under legal
+// hold they stay hidden but are kept.
+type MessageExpirer struct {
+	cfg            *configs.Config
+	log            logger.Logger
+	baseRepo       repository.BaseRepo
+	messageRepo    MessageRepo
+	eventPublisher EventPublisher
+
+	isStarted atomic.Bool
+}
+
+func (e *MessageExpirer) Start(ctx context.Context) error {
+	if !e.isStarted.CompareAndSwap(false, true) {
+		return ErrMessageExpirerAlreadyStarted
+	}
+	defer e.isStarted.Store(false)
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.cfg.MessageExpiryPollInterval):
+		}
+	}
+}
+
+func (e *MessageExpirer) expire(ctx context.Context) {
+	if e.cfg.RetentionLegalHold {
+		return
+	}
+
+	for {
+		count, err := purgeMessages(ctx, e.baseRepo, e.messageRepo, e.eventPublisher,
+			func(tx repository.Tx) ([]Message, error) {
+				return e.messageRepo.GetDisappearedMessages(ctx, e.cfg.MessageExpiryBatchSize, tx)
+			},
+		)
+		if err != nil {
+			e.log.Errorf("error on deleting expired messages: %s", err)
+			return
+		}
+
+		if count < e.cfg.MessageExpiryBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func NewMessageExpirer(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	messageRepo MessageRepo,
+	eventPublisher EventPublisher,
+) *MessageExpirer {
+	return &MessageExpirer{
+		cfg:            cfg,
+		log:            log,
+		baseRepo:       baseRepo,
+		messageRepo:    messageRepo,
+		eventPublisher: eventPublisher,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+// ExpireFrom is the moment the expiry timer of a message starts.
+type ExpireFrom uint8
+
+const (
+	SentExpireFrom ExpireFrom = 1
+	ReadExpireFrom ExpireFrom = 2
+)
+
+func (ef ExpireFrom) Uint8() uint8 {
+	return uint8(ef)
+}
+
+func (ef ExpireFrom) Values() []ExpireFrom {
+	return []ExpireFrom{SentExpireFrom, ReadExpireFrom}
+}
+
+func (ef ExpireFrom) IsValid() bool {
+	return slices.Contains(ef.Values(), ef)
+}
+
+// MessageExpiry is the default expiry timer of new messages in a chat, a nil
+// ExpiresIn keeps messages forever.
+type MessageExpiry struct {
+	ExpiresIn  *uint      `json:"expiresIn,omitempty"`
+	ExpireFrom ExpireFrom `json:"expireFrom,omitempty"`
+}
+
+// applyExpiry sets the expiry of a new message. A message without its own
+// timer takes the default of the chat, a view-once message expires right on
+// the first read.
+func (m *Message) applyExpiry(chatExpiry MessageExpiry) {
+	if m.IsViewOnce {
+		m.ExpiresIn = new(uint)
+		m.ExpireFrom = ReadExpireFrom
+
+		return
+	}
+
+	if m.ExpiresIn == nil {
+		m.ExpiresIn = chatExpiry.ExpiresIn
+		m.ExpireFrom = chatExpiry.ExpireFrom
+	}
+
+	if !m.ExpireFrom.IsValid() {
+		m.ExpireFrom = SentExpireFrom
+	}
+}
@@ -14,7 +14,7 @@
 
 package domain
 
-import "chat-go/internal/common/domain"
+import "github.com/microcoretech/chat-go/internal/common/domain"
 
 type MessageFilter struct {
 	IDs          []uint64
@@ -22,6 +22,12 @@ type MessageFilter struct {
 	Statuses     []uint8
 	CreatedByIDs []uint64
 
+	MentionedUserIDs []uint64
+
+	// ReaderID hides the messages that expired for the reader after reading
+	// them.
+	ReaderID uint64
+
 	Search string
 
 	Limit  *uint64
@@ -0,0 +1,305 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// urlRegexp matches http(s) links, trailing punctuation is trimmed
+	// afterwards as it usually ends the sentence, not the link.
+	urlRegexp = regexp.MustCompile(`https?://[^\s<>"]+`)
+	// hashtagRegexp needs at least one letter, so "#1" is not a hashtag.
+	hashtagRegexp = regexp.MustCompile(`(^|[^\p{L}\p{N}_&/#])(#[\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*)`)
+	// languageRegexp is what can follow the opening fence of a code block.
+	languageRegexp = regexp.MustCompile(`^[a-zA-Z0-9_+#.-]+$`)
+)
+
+const urlTrailingPunctuation = `.,;:!?)]}'`
+
+// maxMarkupOverhead bounds the text before parsing: it may be this many times
+// longer than the limit to leave room for markup. Unclosed markers make the
+// parser scan to the end of the text for each of them.
+const maxMarkupOverhead = 2
+
+// normalizeText brings the text to NFC, so the same characters always take
+// the same runes, and drops control characters other than new lines and
+// tabs.
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = norm.NFC.String(text)
+
+	text = strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	return strings.TrimSpace(text)
+}
+
+// formatText parses the markdown of a message. Markup is removed from the
+// text and described by entities instead:
+//
+//	**bold**, *italic* or _italic_, `code` and ```language
+//	code block```
+//
+// A backslash escapes a markup character, unclosed markup is kept as is.
+// Links and hashtags are found in the resulting text, outside of code.
+func formatText(text string) (string, []MessageEntity) {
+	p := &textParser{in: []rune(text)}
+	p.parse(0, len(p.in))
+
+	formattedText := string(p.out)
+	entities := p.entities
+
+	codeTypes := []MessageEntityType{CodeMessageEntityType, PreMessageEntityType}
+
+	for _, match := range urlRegexp.FindAllStringIndex(formattedText, -1) {
+		url := strings.TrimRight(formattedText[match[0]:match[1]], urlTrailingPunctuation)
+
+		offset := utf8.RuneCountInString(formattedText[:match[0]])
+		length := utf8.RuneCountInString(url)
+
+		if !overlapsEntity(entities, offset, length, codeTypes...) {
+			entities = append(entities, MessageEntity{
+				Type:   URLMessageEntityType,
+				Offset: offset,
+				Length: length,
+			})
+		}
+	}
+
+	for _, match := range hashtagRegexp.FindAllStringSubmatchIndex(formattedText, -1) {
+		offset := utf8.RuneCountInString(formattedText[:match[4]])
+		length := utf8.RuneCountInString(formattedText[match[4]:match[5]])
+
+		if !overlapsEntity(entities, offset, length, append(codeTypes, URLMessageEntityType)...) {
+			entities = append(entities, MessageEntity{
+				Type:   HashtagMessageEntityType,
+				Offset: offset,
+				Length: length,
+			})
+		}
+	}
+
+	sortEntities(entities)
+
+	return formattedText, entities
+}
+
+func sortEntities(entities []MessageEntity) {
+	slices.SortStableFunc(entities, func(a, b MessageEntity) int {
+		if a.Offset != b.Offset {
+			return a.Offset - b.Offset
+		}
+
+		// An outer entity comes before the entities inside it.
+		return b.Length - a.Length
+	})
+}
+
+// textParser turns markup runes of in to entities over the runes of out.
+type textParser struct {
+	in       []rune
+	out      []rune
+	entities []MessageEntity
+}
+
+func (p *textParser) parse(start, end int) {
+	for i := start; i < end; {
+		switch {
+		case p.in[i] == '\\' && i+1 < end && isMarkupRune(p.in[i+1]):
+			p.out = append(p.out, p.in[i+1])
+			i += 2
+		case p.isURLStart(i, end):
+			i = p.copyURL(i, end)
+		case p.hasPrefix(i, end, "```"):
+			i = p.parseCodeBlock(i, end)
+		case p.in[i] == '`':
+			i = p.parseCode(i, end)
+		case p.hasPrefix(i, end, "**"):
+			i = p.parseEmphasis(i, end, 2, BoldMessageEntityType)
+		case p.in[i] == '*' || p.in[i] == '_':
+			i = p.parseEmphasis(i, end, 1, ItalicMessageEntityType)
+		default:
+			p.out = append(p.out, p.in[i])
+			i++
+		}
+	}
+}
+
+// isURLStart keeps links out of the markup, underscores and asterisks are
+// common in them.
+func (p *textParser) isURLStart(i, end int) bool {
+	if i > 0 && isWordRune(p.in[i-1]) {
+		return false
+	}
+
+	return p.hasPrefix(i, end, "http://") || p.hasPrefix(i, end, "https://")
+}
+
+func (p *textParser) copyURL(i, end int) int {
+	j := i
+	for j < end && !unicode.IsSpace(p.in[j]) {
+		j++
+	}
+
+	p.out = append(p.out, p.in[i:j]...)
+
+	return j
+}
+
+func (p *textParser) parseCodeBlock(i, end int) int {
+	closing := p.index(i+3, end, "```")
+	if closing < 0 {
+		p.out = append(p.out, p.in[i:i+3]...)
+		return i + 3
+	}
+
+	body := p.in[i+3 : closing]
+	language := ""
+
+	if newLine := slices.Index(body, '\n'); newLine >= 0 {
+		firstLine := strings.TrimSpace(string(body[:newLine]))
+		if firstLine == "" || languageRegexp.MatchString(firstLine) {
+			language = firstLine
+			body = body[newLine+1:]
+		}
+	}
+
+	if len(body) > 0 && body[len(body)-1] == '\n' {
+		body = body[:len(body)-1]
+	}
+
+	p.appendEntity(PreMessageEntityType, body, language)
+
+	return closing + 3
+}
+
+func (p *textParser) parseCode(i, end int) int {
+	closing := p.index(i+1, end, "`")
+	if closing <= i+1 {
+		p.out = append(p.out, p.in[i])
+		return i + 1
+	}
+
+	p.appendEntity(CodeMessageEntityType, p.in[i+1:closing], "")
+
+	return closing + 1
+}
+
+func (p *textParser) parseEmphasis(i, end, size int, entityType MessageEntityType) int {
+	marker := p.in[i]
+
+	closing := -1
+
+	if p.canOpen(i, size) {
+		for j := i + size + 1; j+size <= end; j++ {
+			if p.in[j] == marker && p.canClose(j, size) {
+				closing = j
+				break
+			}
+		}
+	}
+
+	if closing < 0 {
+		p.out = append(p.out, p.in[i:i+size]...)
+		return i + size
+	}
+
+	offset := len(p.out)
+	p.parse(i+size, closing)
+
+	if length := len(p.out) - offset; length > 0 {
+		p.entities = append(p.entities, MessageEntity{
+			Type:   entityType,
+			Offset: offset,
+			Length: length,
+		})
+	}
+
+	return closing + size
+}
+
+func (p *textParser) appendEntity(entityType MessageEntityType, body []rune, language string) {
+	p.entities = append(p.entities, MessageEntity{
+		Type:     entityType,
+		Offset:   len(p.out),
+		Length:   len(body),
+		Language: language,
+	})
+
+	p.out = append(p.out, body...)
+}
+
+// canOpen checks that emphasis starts a word, so snake_case and 2*3*4 stay
+// plain text.
+func (p *textParser) canOpen(i, size int) bool {
+	if i > 0 && isWordRune(p.in[i-1]) {
+		return false
+	}
+
+	return i+size < len(p.in) && !unicode.IsSpace(p.in[i+size]) && p.in[i+size] != p.in[i]
+}
+
+// canClose checks that emphasis ends a word. A single marker next to the
+// same marker belongs to a double one and doesn't close.
+func (p *textParser) canClose(j, size int) bool {
+	if unicode.IsSpace(p.in[j-1]) || p.in[j-1] == p.in[j] {
+		return false
+	}
+
+	return j+size >= len(p.in) || (!isWordRune(p.in[j+size]) && p.in[j+size] != p.in[j])
+}
+
+func (p *textParser) hasPrefix(i, end int, prefix string) bool {
+	for _, r := range prefix {
+		if i >= end || p.in[i] != r {
+			return false
+		}
+
+		i++
+	}
+
+	return true
+}
+
+func (p *textParser) index(start, end int, substr string) int {
+	for i := start; i < end; i++ {
+		if p.hasPrefix(i, end, substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isMarkupRune(r rune) bool {
+	return r == '*' || r == '_' || r == '`' || r == '\\'
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,218 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     string
+		entities []MessageEntity
+	}{
+		{
+			name: "plain text",
+			text: "hello world",
+			want: "hello world",
+		},
+		{
+			name: "bold and italic",
+			text: "**bold** and *italic*",
+			want: "bold and italic",
+			entities: []MessageEntity{
+				{Type: BoldMessageEntityType, Offset: 0, Length: 4},
+				{Type: ItalicMessageEntityType, Offset: 9, Length: 6},
+			},
+		},
+		{
+			name: "italic inside bold",
+			text: "**bold _italic_ bold**",
+			want: "bold italic bold",
+			entities: []MessageEntity{
+				{Type: BoldMessageEntityType, Offset: 0, Length: 16},
+				{Type: ItalicMessageEntityType, Offset: 5, Length: 6},
+			},
+		},
+		{
+			name: "bold inside italic",
+			text: "*a **b** c*",
+			want: "a b c",
+			entities: []MessageEntity{
+				{Type: ItalicMessageEntityType, Offset: 0, Length: 5},
+				{Type: BoldMessageEntityType, Offset: 2, Length: 1},
+			},
+		},
+		{
+			name: "unclosed markers",
+			text: "**unclosed and *unclosed",
+			want: "**unclosed and *unclosed",
+		},
+		{
+			name: "markers inside words",
+			text: "snake_case and 2*3*4",
+			want: "snake_case and 2*3*4",
+		},
+		{
+			name: "markers around spaces",
+			text: "_ spaced _",
+			want: "_ spaced _",
+		},
+		{
+			name: "escaped markers",
+			text: `\*escaped\* and \_too\_`,
+			want: "*escaped* and _too_",
+		},
+		{
+			name: "code span keeps markup",
+			text: "`code *not italic*`",
+			want: "code *not italic*",
+			entities: []MessageEntity{
+				{Type: CodeMessageEntityType, Offset: 0, Length: 17},
+			},
+		},
+		{
+			name: "empty code span",
+			text: "``",
+			want: "``",
+		},
+		{
+			name: "unclosed code span",
+			text: "unclosed `code",
+			want: "unclosed `code",
+		},
+		{
+			name: "code block with language",
+			text: "```go\nfmt.Println(1)\n```",
+			want: "fmt.Println(1)",
+			entities: []MessageEntity{
+				{Type: PreMessageEntityType, Offset: 0, Length: 14, Language: "go"},
+			},
+		},
+		{
+			name: "code block without language",
+			text: "```\nplain\n```",
+			want: "plain",
+			entities: []MessageEntity{
+				{Type: PreMessageEntityType, Offset: 0, Length: 5},
+			},
+		},
+		{
+			name: "link keeps underscores and drops trailing punctuation",
+			text: "see https://example.com/a_b_c. now",
+			want: "see https://example.com/a_b_c. now",
+			entities: []MessageEntity{
+				{Type: URLMessageEntityType, Offset: 4, Length: 25},
+			},
+		},
+		{
+			name: "link in code is not a link",
+			text: "`https://example.com` #tag",
+			want: "https://example.com #tag",
+			entities: []MessageEntity{
+				{Type: CodeMessageEntityType, Offset: 0, Length: 19},
+				{Type: HashtagMessageEntityType, Offset: 20, Length: 4},
+			},
+		},
+		{
+			name: "hashtags need a letter and a word start",
+			text: "#1 and #go_lang and a#b",
+			want: "#1 and #go_lang and a#b",
+			entities: []MessageEntity{
+				{Type: HashtagMessageEntityType, Offset: 7, Length: 8},
+			},
+		},
+		{
+			name: "offsets count runes",
+			text: "привет **мир**",
+			want: "привет мир",
+			entities: []MessageEntity{
+				{Type: BoldMessageEntityType, Offset: 7, Length: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, entities := formatText(tt.text)
+
+			if got != tt.want {
+				t.Errorf("formatText(%q) text = %q, want %q", tt.text, got, tt.want)
+			}
+
+			if len(entities) != 0 || len(tt.entities) != 0 {
+				if !reflect.DeepEqual(entities, tt.entities) {
+					t.Errorf("formatText(%q) entities = %+v, want %+v", tt.text, entities, tt.entities)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatTextUnclosedMarkers(t *testing.T) {
+	// Every marker opens and none closes, each one is scanned to the end.
+	text := strings.Repeat("*a _b ", 1365) + "`"
+
+	got, entities := formatText(text)
+
+	if got != text {
+		t.Errorf("formatText() changed text of unclosed markers")
+	}
+
+	if len(entities) != 0 {
+		t.Errorf("formatText() entities = %+v, want none", entities)
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "trims spaces", text: "  hi \n", want: "hi"},
+		{name: "unifies new lines", text: "a\r\nb", want: "a\nb"},
+		{name: "drops control characters", text: "a\x00b\x1bc\td", want: "abc\td"},
+		{name: "composes characters", text: "e\u0301", want: "\u00e9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeText(tt.text); got != tt.want {
+				t.Errorf("normalizeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionsInCodeAreSkipped(t *testing.T) {
+	text, entities := formatText("@alice `@bob` ```\n@carol\n```")
+
+	var usernames []string
+
+	for _, m := range parseMentions(text) {
+		if !overlapsEntity(entities, m.Offset, m.Length, CodeMessageEntityType, PreMessageEntityType) {
+			usernames = append(usernames, m.Username)
+		}
+	}
+
+	if !reflect.DeepEqual(usernames, []string{"alice"}) {
+		t.Errorf("mentions outside code = %v, want [alice]", usernames)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// MessageForward is the provenance of a forwarded message. It always points
+// at the first message of a forwarding chain, so a message forwarded twice
+// still names its original author.
+type MessageForward struct {
+	MessageID uint64 `json:"messageId"`
+	ChatID    uint64 `json:"chatId"`
+	CreatedBy uint64 `json:"createdBy"`
+}
+
+// isForwardable tells whether the message can be forwarded. Disappearing
+// messages and view-once messages are not, a copy would outlive them.
+func (m Message) isForwardable() bool {
+	return !m.IsViewOnce && m.ExpiresIn == nil && m.ExpiresAt == nil && m.Kind != PollMessageKind
+}
+
+// forward returns a copy of the message to be sent to the chat by the user.
+// Mentions aren't resolved again, so forwarding doesn't notify anybody.
+func (m Message) forward(chat *Chat, forwardedBy uint64) Message {
+	forwardedFrom := m.ForwardedFrom
+	if forwardedFrom == nil {
+		forwardedFrom = &MessageForward{
+			MessageID: m.ID,
+			ChatID:    m.ChatID,
+			CreatedBy: m.CreatedBy,
+		}
+	}
+
+	message := Message{
+		Text:          m.Text,
+		ChatID:        chat.ID,
+		Entities:      m.Entities,
+		CreatedBy:     forwardedBy,
+		ForwardedFrom: forwardedFrom,
+	}
+
+	message.applyExpiry(chat.Expiry)
+
+	return message
+}
@@ -16,18 +16,47 @@ package domain
 
 import (
 	"context"
+	"time"
 
-	"chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type MessageRepo interface {
 	GetMessages(ctx context.Context, filter *MessageFilter) ([]Message, error)
 	GetMessagesCount(ctx context.Context, filter *MessageFilter) (uint64, error)
+	GetMessage(ctx context.Context, id uint64) (*Message, error)
 	CreateMessage(ctx context.Context, message Message, tx repository.Tx) (*Message, error)
+	UpdateMessage(ctx context.Context, message Message, tx repository.Tx) (*Message, error)
+	DeleteMessage(ctx context.Context, id uint64, tx repository.Tx) error
+	// GetExpiredMessages locks and returns messages to purge under the
+	// retention policies of their chats, with fallback to the global one.
+	GetExpiredMessages(ctx context.Context, global RetentionPolicy, limit uint64, tx repository.Tx) ([]Message, error)
+	DeleteMessages(ctx context.Context, ids []uint64, tx repository.Tx) error
+	// GetDisappearedMessages locks and returns messages past their expiry,
+	// messages of chats on legal hold are kept.
+	GetDisappearedMessages(ctx context.Context, limit uint64, tx repository.Tx) ([]Message, error)
+	// ClaimUnfurlPendingMessages returns messages with links not unfurled yet
+	// and claims them until claimedUntil, other unfurlers skip them meanwhile.
+	ClaimUnfurlPendingMessages(ctx context.Context, limit uint64, claimedUntil time.Time) ([]Message, error)
+	// UpdateMessageLinkPreviews returns nil if the message was changed since
+	// it was read, its new links are unfurled on the next round.
+	UpdateMessageLinkPreviews(ctx context.Context, message Message, tx repository.Tx) (*Message, error)
+	// ClearUnfurlPending gives up on the links of the message unless it was
+	// changed since it was read.
+	ClearUnfurlPending(ctx context.Context, message Message) error
+	CreateMessageMentions(ctx context.Context, messageID uint64, userIDs []uint64, tx repository.Tx) error
+	DeleteMessageMentions(ctx context.Context, messageID uint64, tx repository.Tx) error
+	// UpdateMessageStatus updates the status of the messages of others in the
+	// chat and returns the messages updated.
 	UpdateMessageStatus(
 		ctx context.Context,
+		chatID uint64,
+		userID uint64,
 		messageIDs []uint64,
 		messageStatus MessageStatus,
 		tx repository.Tx,
-	) error
+	) ([]uint64, error)
+	// CreateMessageReads records the reads of the messages of others in the
+	// chat by the user and starts the timers counted from read.
+	CreateMessageReads(ctx context.Context, chatID uint64, userID uint64, messageIDs []uint64, tx repository.Tx) error
 }
@@ -0,0 +1,155 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrMessageSchedulerAlreadyStarted = errors.New("message scheduler already started")
+
+const sendSavepoint = "scheduled_message_send"
+
+// MessageScheduler sends scheduled and held messages once they are due.
+// A message is dropped if its author has left the chat in the meantime or
+// if it fails to send, the other messages of the batch are sent anyway.
+type MessageScheduler struct {
+	cfg                  *configs.Config
+	log                  logger.Logger
+	baseRepo             repository.BaseRepo
+	chatRepo             ChatRepo
+	messageRepo          MessageRepo
+	scheduledMessageRepo ScheduledMessageRepo
+	eventPublisher       EventPublisher
+
+	isStarted atomic.Bool
+}
+
+func (s *MessageScheduler) Start(ctx context.Context) error {
+	if !s.isStarted.CompareAndSwap(false, true) {
+		return ErrMessageSchedulerAlreadyStarted
+	}
+	defer s.isStarted.Store(false)
+
+	for {
+		s.sendDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.cfg.ScheduledMessagePollInterval):
+		}
+	}
+}
+
+func (s *MessageScheduler) sendDue(ctx context.Context) {
+	for {
+		count, err := s.sendBatch(ctx)
+		if err != nil {
+			s.log.Errorf("error on sending scheduled messages: %s", err)
+			return
+		}
+
+		if count < s.cfg.ScheduledMessageBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *MessageScheduler) sendBatch(ctx context.Context) (uint64, error) {
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	scheduledMessages, err := s.scheduledMessageRepo.GetDueScheduledMessages(ctx, s.cfg.ScheduledMessageBatchSize, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(scheduledMessages) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uint64, 0, len(scheduledMessages))
+
+	for _, scheduledMessage := range scheduledMessages {
+		ids = append(ids, scheduledMessage.ID)
+
+		err := repository.WithSavepoint(ctx, tx, sendSavepoint, func() error {
+			return s.send(ctx, scheduledMessage.Message, tx)
+		})
+		if err != nil {
+			s.log.Errorf("Scheduled message is dropped id=%d chatId=%d error=%s",
+				scheduledMessage.ID, scheduledMessage.Message.ChatID, err)
+		}
+	}
+
+	if err := s.scheduledMessageRepo.DeleteScheduledMessages(ctx, ids, tx); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(scheduledMessages)), nil
+}
+
+func (s *MessageScheduler) send(ctx context.Context, message Message, tx repository.Tx) error {
+	chat, err := s.chatRepo.GetChat(ctx, message.ChatID)
+	if err != nil {
+		return err
+	}
+
+	if chat == nil || !chat.HasMember(message.CreatedBy) {
+		return nil
+	}
+
+	_, err = sendMessage(ctx, tx, s.messageRepo, s.eventPublisher, message)
+
+	return err
+}
+
+func NewMessageScheduler(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	chatRepo ChatRepo,
+	messageRepo MessageRepo,
+	scheduledMessageRepo ScheduledMessageRepo,
+	eventPublisher EventPublisher,
+) *MessageScheduler {
+	return &MessageScheduler{
+		cfg:                  cfg,
+		log:                  log,
+		baseRepo:             baseRepo,
+		chatRepo:             chatRepo,
+		messageRepo:          messageRepo,
+		scheduledMessageRepo: scheduledMessageRepo,
+		eventPublisher:       eventPublisher,
+	}
+}
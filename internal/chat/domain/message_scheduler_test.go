@@ -0,0 +1,191 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+// recordingTx records the statements run in it, e.g. savepoints.
+type recordingTx struct {
+	repository.Tx
+
+	statements []string
+	committed  bool
+}
+
+func (t *recordingTx) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	t.statements = append(t.statements, query)
+	return nil, nil
+}
+
+func (t *recordingTx) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *recordingTx) Rollback() error { return nil }
+
+type recordingBaseRepo struct {
+	repository.BaseRepo
+
+	tx *recordingTx
+}
+
+func (r *recordingBaseRepo) BeginContext(context.Context) (repository.Tx, error) {
+	return r.tx, nil
+}
+
+type stubScheduledMessageRepo struct {
+	ScheduledMessageRepo
+
+	due     []ScheduledMessage
+	deleted []uint64
+}
+
+func (r *stubScheduledMessageRepo) GetDueScheduledMessages(
+	_ context.Context,
+	limit uint64,
+	_ repository.Tx,
+) ([]ScheduledMessage, error) {
+	return r.due[:min(limit, uint64(len(r.due)))], nil
+}
+
+func (r *stubScheduledMessageRepo) DeleteScheduledMessages(_ context.Context, ids []uint64, _ repository.Tx) error {
+	r.deleted = append(r.deleted, ids...)
+	return nil
+}
+
+type stubSendMessageRepo struct {
+	MessageRepo
+
+	failTexts map[string]bool
+	created   []string
+}
+
+func (r *stubSendMessageRepo) CreateMessage(_ context.Context, message Message, _ repository.Tx) (*Message, error) {
+	if r.failTexts[message.Text] {
+		return nil, errors.New("insert failed")
+	}
+
+	r.created = append(r.created, message.Text)
+
+	return &message, nil
+}
+
+func (r *stubSendMessageRepo) CreateMessageMentions(context.Context, uint64, []uint64, repository.Tx) error {
+	return nil
+}
+
+func (r *stubChatRepo) GetChat(_ context.Context, id uint64) (*Chat, error) {
+	for _, chat := range r.chats {
+		if chat.ID == id {
+			return &chat, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func TestMessageSchedulerSendBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		due         []ScheduledMessage
+		failTexts   map[string]bool
+		wantCreated []string
+	}{
+		{
+			name: "all messages sent",
+			due: []ScheduledMessage{
+				{ID: 1, Message: Message{ChatID: 1, CreatedBy: 1, Text: "first"}},
+				{ID: 2, Message: Message{ChatID: 1, CreatedBy: 1, Text: "second"}},
+			},
+			wantCreated: []string{"first", "second"},
+		},
+		{
+			name: "failed message doesn't abort the batch",
+			due: []ScheduledMessage{
+				{ID: 1, Message: Message{ChatID: 1, CreatedBy: 1, Text: "first"}},
+				{ID: 2, Message: Message{ChatID: 1, CreatedBy: 1, Text: "broken"}},
+				{ID: 3, Message: Message{ChatID: 1, CreatedBy: 1, Text: "third"}},
+			},
+			failTexts:   map[string]bool{"broken": true},
+			wantCreated: []string{"first", "third"},
+		},
+		{
+			name: "author left the chat",
+			due: []ScheduledMessage{
+				{ID: 1, Message: Message{ChatID: 1, CreatedBy: 2, Text: "first"}},
+				{ID: 2, Message: Message{ChatID: 3, CreatedBy: 1, Text: "second"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &recordingTx{}
+			scheduledMessageRepo := &stubScheduledMessageRepo{due: tt.due}
+			messageRepo := &stubSendMessageRepo{failTexts: tt.failTexts}
+			eventPublisher := &stubEventPublisher{}
+			scheduler := NewMessageScheduler(
+				&configs.Config{ScheduledMessageBatchSize: 10},
+				stubLogger{},
+				&recordingBaseRepo{tx: tx},
+				&stubChatRepo{chats: []Chat{{ID: 1, UserChats: []UserChat{{ChatID: 1, UserID: 1}}}}},
+				messageRepo,
+				scheduledMessageRepo,
+				eventPublisher,
+			)
+
+			count, err := scheduler.sendBatch(context.Background())
+			if err != nil {
+				t.Fatalf("sendBatch() error = %v", err)
+			}
+
+			if count != uint64(len(tt.due)) {
+				t.Errorf("sendBatch() = %d, want %d", count, len(tt.due))
+			}
+			if !reflect.DeepEqual(messageRepo.created, tt.wantCreated) {
+				t.Errorf("created %v, want %v", messageRepo.created, tt.wantCreated)
+			}
+			if len(eventPublisher.events) != len(tt.wantCreated) {
+				t.Errorf("published %d events, want %d", len(eventPublisher.events), len(tt.wantCreated))
+			}
+			if len(scheduledMessageRepo.deleted) != len(tt.due) {
+				t.Errorf("deleted %v, want all %d due messages", scheduledMessageRepo.deleted, len(tt.due))
+			}
+			if !tx.committed {
+				t.Error("batch is not committed")
+			}
+
+			rolledBack := 0
+			for _, statement := range tx.statements {
+				if statement == "ROLLBACK TO SAVEPOINT "+sendSavepoint {
+					rolledBack++
+				}
+			}
+			if rolledBack != len(tt.failTexts) {
+				t.Errorf("rolled back %d savepoints, want %d", rolledBack, len(tt.failTexts))
+			}
+		})
+	}
+}
@@ -15,14 +15,34 @@
 package domain
 
 import (
+	"cmp"
 	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
 
-	"chat-go/internal/common/domain"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
 )
 
 type MessageServiceImpl struct {
-	messageRepo         MessageRepo
-	userServiceContract UserServiceContract
+	cfg                  *configs.Config
+	baseRepo             repository.BaseRepo
+	chatRepo             ChatRepo
+	userChatRepo         UserChatRepo
+	messageRepo          MessageRepo
+	scheduledMessageRepo ScheduledMessageRepo
+	pollRepo             PollRepo
+	userServiceContract  UserServiceContract
+	eventPublisher       EventPublisher
+	commandRouter        *CommandRouter
 }
 
 func (s *MessageServiceImpl) fillMessage(ctx context.Context, message *Message) error {
@@ -30,13 +50,28 @@ func (s *MessageServiceImpl) fillMessage(ctx context.Context, message *Message)
 		return nil
 	}
 
-	var userIDs []uint64
+	return s.fillMessages(ctx, []*Message{message})
+}
+
+// fillMessages looks the creators of all messages up at once, so a page of
+// messages costs a single request to the user service.
+func (s *MessageServiceImpl) fillMessages(ctx context.Context, messages []*Message) error {
+	if len(messages) == 0 || skipsUsers(ctx) {
+		return nil
+	}
 
-	userIDs = append(userIDs, message.CreatedBy)
+	userIDs := lo.Uniq(lo.Map(messages, func(message *Message, _ int) uint64 {
+		return message.CreatedBy
+	}))
 
 	users, _, err := s.userServiceContract.GetUsers(ctx, &domain.UserFilter{
 		IDs: userIDs,
 	})
+	if errors.IsServiceUnavailable(err) {
+		// messages are still served, just without creators, while the user
+		// service is down.
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -47,12 +82,20 @@ func (s *MessageServiceImpl) fillMessage(ctx context.Context, message *Message)
 		usersMap[user.ID] = &user
 	}
 
-	message.Creator = usersMap[message.CreatedBy]
+	for _, message := range messages {
+		message.Creator = usersMap[message.CreatedBy]
+	}
 
 	return nil
 }
 
 func (s *MessageServiceImpl) GetMessages(ctx context.Context, filter *MessageFilter) ([]Message, uint64, error) {
+	if filter == nil {
+		filter = &MessageFilter{}
+	}
+
+	filter.ReaderID = domain.UserFromContext(ctx).ID
+
 	count, err := s.messageRepo.GetMessagesCount(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -67,47 +110,774 @@ func (s *MessageServiceImpl) GetMessages(ctx context.Context, filter *MessageFil
 		return nil, 0, err
 	}
 
+	if err := s.fillMessages(ctx, lo.ToSlicePtr(messages)); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.fillPolls(ctx, messages); err != nil {
+		return nil, 0, err
+	}
+
+	return messages, count, nil
+}
+
+// fillPolls attaches the polls with their results and the votes of the
+// current user to poll messages.
+func (s *MessageServiceImpl) fillPolls(ctx context.Context, messages []Message) error {
+	user := domain.UserFromContext(ctx)
+
+	var messageIDs []uint64
+
+	for _, message := range messages {
+		if message.Kind == PollMessageKind {
+			messageIDs = append(messageIDs, message.ID)
+		}
+	}
+
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	polls, err := s.pollRepo.GetPolls(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+
+	votedOptionIDs, err := s.pollRepo.GetVotedOptionIDs(ctx, lo.Map(polls, func(poll Poll, _ int) uint64 {
+		return poll.ID
+	}), user.ID)
+	if err != nil {
+		return err
+	}
+
+	pollsMap := make(map[uint64]*Poll)
+
+	for index := range polls {
+		polls[index].VotedOptionIDs = votedOptionIDs[polls[index].ID]
+		pollsMap[polls[index].MessageID] = &polls[index]
+	}
+
 	for index := range messages {
-		if err := s.fillMessage(ctx, &messages[index]); err != nil {
-			return nil, 0, err
+		messages[index].Poll = pollsMap[messages[index].ID]
+	}
+
+	return nil
+}
+
+// formatMessage normalizes the text of a new or edited message and parses
+// its markup into entities. Text too long to fit even with markup is refused
+// before parsing.
+func (s *MessageServiceImpl) formatMessage(message *Message) error {
+	text := normalizeText(message.Text)
+
+	if uint(utf8.RuneCountInString(text)) > s.cfg.MessageMaxLength*maxMarkupOverhead {
+		return chaterrors.NewMessageTooLongError(s.cfg.MessageMaxLength)
+	}
+
+	message.Text, message.Entities = formatText(text)
+
+	if uint(utf8.RuneCountInString(message.Text)) > s.cfg.MessageMaxLength {
+		return chaterrors.NewMessageTooLongError(s.cfg.MessageMaxLength)
+	}
+
+	return nil
+}
+
+// resolveMentions turns the @username and @all mentions of the message into
+// entities. Only members of the chat can be mentioned, unknown usernames are
+// left as plain text, as are mentions in code and links.
+func (s *MessageServiceImpl) resolveMentions(ctx context.Context, message *Message) error {
+	mentions := lo.Filter(parseMentions(message.Text), func(m mention, _ int) bool {
+		return !overlapsEntity(message.Entities, m.Offset, m.Length,
+			CodeMessageEntityType, PreMessageEntityType, URLMessageEntityType)
+	})
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	chat, err := s.chatRepo.GetChat(ctx, message.ChatID)
+	if err != nil {
+		return err
+	}
+
+	if chat == nil {
+		return errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	memberIDs := lo.Map(chat.UserChats, func(userChat UserChat, _ int) uint64 {
+		return userChat.UserID
+	})
+
+	usernames := lo.Uniq(lo.FilterMap(mentions, func(m mention, _ int) (string, bool) {
+		return strings.ToLower(m.Username), !strings.EqualFold(m.Username, MentionAllUsername)
+	}))
+
+	usersMap := make(map[string]domain.User)
+
+	if len(usernames) > 0 {
+		users, _, err := s.userServiceContract.GetUsers(ctx, &domain.UserFilter{
+			Usernames: usernames,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			if lo.Contains(memberIDs, user.ID) {
+				usersMap[strings.ToLower(user.Username)] = user
+			}
 		}
 	}
 
-	return messages, count, nil
+	mentionedUserIDs := make(map[uint64]struct{})
+
+	for _, m := range mentions {
+		if strings.EqualFold(m.Username, MentionAllUsername) {
+			message.Entities = append(message.Entities, MessageEntity{
+				Type:   MentionAllMessageEntityType,
+				Offset: m.Offset,
+				Length: m.Length,
+			})
+
+			for _, id := range memberIDs {
+				mentionedUserIDs[id] = struct{}{}
+			}
+
+			continue
+		}
+
+		user, ok := usersMap[strings.ToLower(m.Username)]
+		if !ok {
+			continue
+		}
+
+		message.Entities = append(message.Entities, MessageEntity{
+			Type:   MentionMessageEntityType,
+			Offset: m.Offset,
+			Length: m.Length,
+			UserID: lo.ToPtr(user.ID),
+		})
+
+		mentionedUserIDs[user.ID] = struct{}{}
+	}
+
+	sortEntities(message.Entities)
+
+	// Authors are never notified about their own mentions.
+	delete(mentionedUserIDs, message.CreatedBy)
+
+	message.MentionedUserIDs = lo.Keys(mentionedUserIDs)
+
+	return nil
+}
+
+// prepareMessage checks that the author is a member of the chat and
+// resolves the message the way it's stored. A command runs right away, a
+// reply meant for the caller only is returned instead of the message.
+func (s *MessageServiceImpl) prepareMessage(ctx context.Context, message *Message) (*Message, error) {
+	chat, err := s.chatRepo.GetChat(ctx, message.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !chat.HasMember(message.CreatedBy) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	if text, ok := unescapeCommand(message.Text); ok {
+		message.Text = text
+	} else if name, args, ok := parseCommand(message.Text); ok {
+		result, err := s.commandRouter.Route(ctx, Command{
+			Name:   name,
+			Args:   args,
+			ChatID: message.ChatID,
+			User:   domain.UserFromContext(ctx),
+			chat:   chat,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !result.Public || result.Text == "" {
+			return &Message{
+				Text:        result.Text,
+				ChatID:      message.ChatID,
+				CreatedAt:   time.Now().UTC(),
+				IsEphemeral: true,
+			}, nil
+		}
+
+		message.Text = result.Text
+		if result.AuthorID != 0 {
+			message.CreatedBy = result.AuthorID
+		}
+	}
+
+	if err := s.formatMessage(message); err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveMentions(ctx, message); err != nil {
+		return nil, err
+	}
+
+	message.applyExpiry(chat.Expiry)
+
+	return nil, s.fillMessage(ctx, message)
 }
 
 func (s *MessageServiceImpl) CreateMessage(ctx context.Context, newMessage Message) (*Message, error) {
-	message, err := s.messageRepo.CreateMessage(ctx, newMessage, nil)
+	reply, err := s.prepareMessage(ctx, &newMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply != nil {
+		return reply, nil
+	}
+
+	undoSendDelay := s.cfg.UndoSendDelay
+	if newMessage.UndoSendDelay != nil {
+		// A larger delay would overflow the duration.
+		if *newMessage.UndoSendDelay > maxUndoSendDelay {
+			return nil, errors.NewBadRequestError(constants.ChatDomain, nil, map[string]any{"undoSendDelay": *newMessage.UndoSendDelay})
+		}
+		undoSendDelay = time.Duration(*newMessage.UndoSendDelay) * time.Second
+	}
+
+	// Bots have nothing to undo, their messages are sent right away.
+	if undoSendDelay > 0 && !domain.IsBotID(newMessage.CreatedBy) {
+		return s.holdMessage(ctx, newMessage, undoSendDelay)
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	message, err := sendMessage(ctx, tx, s.messageRepo, s.eventPublisher, newMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// holdMessage schedules a new message to be sent after the undo-send delay,
+// it can be cancelled as any scheduled message until then.
+func (s *MessageServiceImpl) holdMessage(ctx context.Context, newMessage Message, delay time.Duration) (*Message, error) {
+	scheduledMessage, err := s.scheduledMessageRepo.CreateScheduledMessage(ctx, ScheduledMessage{
+		Message:    newMessage,
+		IsUndoSend: true,
+		SendAt:     time.Now().UTC().Add(delay),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	heldMessage := scheduledMessage.Message
+	heldMessage.CreatedAt = scheduledMessage.CreatedAt
+	heldMessage.UpdatedAt = scheduledMessage.UpdatedAt
+	heldMessage.ScheduledMessageID = scheduledMessage.ID
+	heldMessage.SendAt = &scheduledMessage.SendAt
+
+	return &heldMessage, nil
+}
+
+// ForwardMessages copies messages of one chat into other chats, the caller
+// must be a member of all of them. Disappearing messages are refused.
+// Copies are sent in the order of the original messages, all at once or
+// none, up to maxForwardedMessages of them.
+func (s *MessageServiceImpl) ForwardMessages(
+	ctx context.Context,
+	fromChatID uint64,
+	messageIDs []uint64,
+	toChatIDs []uint64,
+) ([]Message, error) {
+	user := domain.UserFromContext(ctx)
+
+	messageIDs = lo.Uniq(messageIDs)
+	toChatIDs = lo.Uniq(toChatIDs)
+
+	if len(messageIDs)*len(toChatIDs) > maxForwardedMessages {
+		return nil, errors.NewBadRequestError(constants.ChatDomain, nil, map[string]any{
+			"messageIds": len(messageIDs),
+			"chatIds":    len(toChatIDs),
+		})
+	}
+
+	fromChat, err := s.chatRepo.GetChat(ctx, fromChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromChat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !fromChat.HasMember(user.ID) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	messages, err := s.messageRepo.GetMessages(ctx, &MessageFilter{
+		IDs:      messageIDs,
+		ChatIDs:  []uint64{fromChatID},
+		ReaderID: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) != len(messageIDs) {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	slices.SortFunc(messages, func(a, b Message) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	for _, message := range messages {
+		if !message.isForwardable() {
+			return nil, chaterrors.NewMessageNotForwardableError()
+		}
+	}
+
+	toChats := make([]*Chat, 0, len(toChatIDs))
+
+	for _, toChatID := range toChatIDs {
+		toChat, err := s.chatRepo.GetChat(ctx, toChatID)
+		if err != nil {
+			return nil, err
+		}
+
+		if toChat == nil {
+			return nil, errors.NewNotFoundError(constants.ChatDomain)
+		}
+
+		if !toChat.HasMember(user.ID) {
+			return nil, errors.NewForbiddenError()
+		}
+
+		toChats = append(toChats, toChat)
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	forwardedMessages := make([]Message, 0, len(toChats)*len(messages))
+
+	for _, toChat := range toChats {
+		for _, message := range messages {
+			newMessage := message.forward(toChat, user.ID)
+			newMessage.Creator = user
+
+			forwardedMessage, err := sendMessage(ctx, tx, s.messageRepo, s.eventPublisher, newMessage)
+			if err != nil {
+				return nil, err
+			}
+
+			if forwardedMessage != nil {
+				forwardedMessages = append(forwardedMessages, *forwardedMessage)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return forwardedMessages, nil
+}
+
+// sendMessage stores a prepared message and publishes it.
+func sendMessage(
+	ctx context.Context,
+	tx repository.Tx,
+	messageRepo MessageRepo,
+	eventPublisher EventPublisher,
+	newMessage Message,
+) (*Message, error) {
+	message, err := messageRepo.CreateMessage(ctx, newMessage, tx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.fillMessage(ctx, message); err != nil {
+	if message == nil {
+		return nil, nil
+	}
+
+	if err := messageRepo.CreateMessageMentions(ctx, message.ID, newMessage.MentionedUserIDs, tx); err != nil {
+		return nil, err
+	}
+
+	message.MentionedUserIDs = newMessage.MentionedUserIDs
+	message.Creator = newMessage.Creator
+
+	if err := eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    MessageCreatedEventType,
+		Payload: message,
+	}); err != nil {
 		return nil, err
 	}
 
 	return message, nil
 }
 
+// getOwnScheduledMessage returns the scheduled message if it was written by
+// the current user.
+func (s *MessageServiceImpl) getOwnScheduledMessage(ctx context.Context, id uint64) (*ScheduledMessage, error) {
+	user := domain.UserFromContext(ctx)
+
+	scheduledMessage, err := s.scheduledMessageRepo.GetScheduledMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledMessage == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if scheduledMessage.Message.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return scheduledMessage, nil
+}
+
+func (s *MessageServiceImpl) GetScheduledMessage(ctx context.Context, id uint64) (*ScheduledMessage, error) {
+	return s.getOwnScheduledMessage(ctx, id)
+}
+
+func (s *MessageServiceImpl) GetScheduledMessages(
+	ctx context.Context,
+	filter *ScheduledMessageFilter,
+) ([]ScheduledMessage, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &ScheduledMessageFilter{}
+	}
+
+	filter.CreatedByIDs = []uint64{user.ID}
+
+	count, err := s.scheduledMessageRepo.GetScheduledMessagesCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	scheduledMessages, err := s.scheduledMessageRepo.GetScheduledMessages(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return scheduledMessages, count, nil
+}
+
+// ScheduleMessage prepares a message of the current user to be sent at
+// sendAt. Commands can't be scheduled, they run when they are sent.
+func (s *MessageServiceImpl) ScheduleMessage(ctx context.Context, scheduledMessage ScheduledMessage) (*ScheduledMessage, error) {
+	if !scheduledMessage.SendAt.After(time.Now()) {
+		return nil, chaterrors.NewInvalidSendAtError()
+	}
+
+	if _, _, ok := parseCommand(scheduledMessage.Message.Text); ok {
+		return nil, chaterrors.NewCommandNotSchedulableError()
+	}
+
+	scheduledMessage.Message.CreatedBy = domain.UserFromContext(ctx).ID
+
+	if _, err := s.prepareMessage(ctx, &scheduledMessage.Message); err != nil {
+		return nil, err
+	}
+
+	scheduledMessage.IsUndoSend = false
+
+	return s.scheduledMessageRepo.CreateScheduledMessage(ctx, scheduledMessage)
+}
+
+// UpdateScheduledMessage changes the text and the send time of a pending
+// scheduled message.
+func (s *MessageServiceImpl) UpdateScheduledMessage(
+	ctx context.Context,
+	scheduledMessage ScheduledMessage,
+) (*ScheduledMessage, error) {
+	existingScheduledMessage, err := s.getOwnScheduledMessage(ctx, scheduledMessage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !scheduledMessage.SendAt.After(time.Now()) {
+		return nil, chaterrors.NewInvalidSendAtError()
+	}
+
+	if _, _, ok := parseCommand(scheduledMessage.Message.Text); ok {
+		return nil, chaterrors.NewCommandNotSchedulableError()
+	}
+
+	existingScheduledMessage.Message.Text = scheduledMessage.Message.Text
+	existingScheduledMessage.Message.MentionedUserIDs = nil
+	existingScheduledMessage.SendAt = scheduledMessage.SendAt
+
+	if _, err := s.prepareMessage(ctx, &existingScheduledMessage.Message); err != nil {
+		return nil, err
+	}
+
+	updatedScheduledMessage, err := s.scheduledMessageRepo.UpdateScheduledMessage(ctx, *existingScheduledMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedScheduledMessage == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return updatedScheduledMessage, nil
+}
+
+// CancelScheduledMessage drops a pending scheduled message, it's also how a
+// held message is unsent. It fails with not found once the message is sent.
+func (s *MessageServiceImpl) CancelScheduledMessage(ctx context.Context, id uint64) error {
+	if _, err := s.getOwnScheduledMessage(ctx, id); err != nil {
+		return err
+	}
+
+	ok, err := s.scheduledMessageRepo.DeleteScheduledMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return nil
+}
+
+// getOwnMessage returns the message if it was written by the current user.
+func (s *MessageServiceImpl) getOwnMessage(ctx context.Context, id uint64) (*Message, error) {
+	user := domain.UserFromContext(ctx)
+
+	message, err := s.messageRepo.GetMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if message == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if message.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return message, nil
+}
+
+func (s *MessageServiceImpl) UpdateMessage(ctx context.Context, message Message) (*Message, error) {
+	existingMessage, err := s.getOwnMessage(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The text of a poll message is its question, it's fixed with the
+	// options.
+	if existingMessage.Kind == PollMessageKind {
+		return nil, errors.NewForbiddenError()
+	}
+
+	existingMessage.Text = message.Text
+
+	if err := s.formatMessage(existingMessage); err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveMentions(ctx, existingMessage); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	updatedMessage, err := s.messageRepo.UpdateMessage(ctx, *existingMessage, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedMessage == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	// The mentions are replaced, the edit may have removed some of them.
+	if err := s.messageRepo.DeleteMessageMentions(ctx, updatedMessage.ID, tx); err != nil {
+		return nil, err
+	}
+
+	if err := s.messageRepo.CreateMessageMentions(ctx, updatedMessage.ID, existingMessage.MentionedUserIDs, tx); err != nil {
+		return nil, err
+	}
+
+	if err := s.fillMessage(ctx, updatedMessage); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    MessageUpdatedEventType,
+		Payload: updatedMessage,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updatedMessage, nil
+}
+
+func (s *MessageServiceImpl) DeleteMessage(ctx context.Context, id uint64) error {
+	message, err := s.getOwnMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := s.messageRepo.DeleteMessage(ctx, id, tx); err != nil {
+		return err
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, newMessageDeletedEvent(*message)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateMessageStatus marks messages of others in the chat for the current
+// member. A read is recorded for the member alone: it moves the read state
+// of the member and starts the timers counted from read for the member only.
 func (s *MessageServiceImpl) UpdateMessageStatus(
 	ctx context.Context,
+	chatID uint64,
 	messageIDs []uint64,
 	messageStatus MessageStatus,
 ) error {
-	if err := s.messageRepo.UpdateMessageStatus(
-		ctx, messageIDs, messageStatus, nil); err != nil {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if chat == nil {
+		return errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !chat.HasMember(user.ID) {
+		return errors.NewForbiddenError()
+	}
+
+	messageIDs = lo.Uniq(messageIDs)
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	updatedMessageIDs, err := s.messageRepo.UpdateMessageStatus(ctx, chatID, user.ID, messageIDs, messageStatus, tx)
+	if err != nil {
+		return err
+	}
+
+	if messageStatus >= ReadMessageStatus {
+		if err := s.messageRepo.CreateMessageReads(ctx, chatID, user.ID, messageIDs, tx); err != nil {
+			return err
+		}
+
+		// Unread counts come from the read state of every member, not from
+		// the status shared by all members.
+		if err := s.userChatRepo.UpdateLastReadMessage(ctx, chatID, user.ID, messageIDs, tx); err != nil {
+			return err
+		}
+	}
+
+	if len(updatedMessageIDs) > 0 {
+		if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+			Type: MessagesStatusUpdatedEventType,
+			Payload: MessagesStatusEventPayload{
+				ChatID:     chatID,
+				MessageIDs: updatedMessageIDs,
+				Status:     messageStatus,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func NewMessageServiceImpl(
+	cfg *configs.Config,
+	baseRepo repository.BaseRepo,
+	chatRepo ChatRepo,
+	userChatRepo UserChatRepo,
 	messageRepo MessageRepo,
+	scheduledMessageRepo ScheduledMessageRepo,
+	pollRepo PollRepo,
 	userServiceContract UserServiceContract,
+	eventPublisher EventPublisher,
+	commandRouter *CommandRouter,
 ) *MessageServiceImpl {
 	return &MessageServiceImpl{
-		messageRepo:         messageRepo,
-		userServiceContract: userServiceContract,
+		cfg:                  cfg,
+		baseRepo:             baseRepo,
+		chatRepo:             chatRepo,
+		userChatRepo:         userChatRepo,
+		messageRepo:          messageRepo,
+		scheduledMessageRepo: scheduledMessageRepo,
+		pollRepo:             pollRepo,
+		userServiceContract:  userServiceContract,
+		eventPublisher:       eventPublisher,
+		commandRouter:        commandRouter,
 	}
 }
@@ -0,0 +1,140 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+// stubMentionMessageRepo keeps the mentions of its message like the
+// message_mentions table does.
+type stubMentionMessageRepo struct {
+	MessageRepo
+
+	message  Message
+	mentions []uint64
+}
+
+func (r *stubMentionMessageRepo) GetMessage(context.Context, uint64) (*Message, error) {
+	message := r.message
+	return &message, nil
+}
+
+func (r *stubMentionMessageRepo) UpdateMessage(_ context.Context, message Message, _ repository.Tx) (*Message, error) {
+	r.message = message
+	return &message, nil
+}
+
+func (r *stubMentionMessageRepo) CreateMessageMentions(_ context.Context, _ uint64, userIDs []uint64, _ repository.Tx) error {
+	r.mentions = lo.Uniq(append(r.mentions, userIDs...))
+	return nil
+}
+
+func (r *stubMentionMessageRepo) DeleteMessageMentions(context.Context, uint64, repository.Tx) error {
+	r.mentions = nil
+	return nil
+}
+
+type stubUserServiceContract struct {
+	users []domain.User
+	err   error
+}
+
+func (c *stubUserServiceContract) GetUsers(_ context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
+	if c.err != nil {
+		return nil, 0, c.err
+	}
+
+	users := lo.Filter(c.users, func(user domain.User, _ int) bool {
+		return lo.Contains(filter.Usernames, user.Username) || lo.Contains(filter.IDs, user.ID)
+	})
+
+	return users, uint64(len(users)), nil
+}
+
+func TestUpdateMessageMentions(t *testing.T) {
+	tests := []struct {
+		name         string
+		mentions     []uint64
+		text         string
+		wantMentions []uint64
+	}{
+		{name: "mention added", text: "@alice and @bob", mentions: []uint64{2}, wantMentions: []uint64{2, 3}},
+		{name: "mention removed", text: "only @bob", mentions: []uint64{2, 3}, wantMentions: []uint64{3}},
+		{name: "all mentions removed", text: "nobody", mentions: []uint64{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := &stubMentionMessageRepo{
+				message:  Message{ID: 1, ChatID: 1, CreatedBy: 1, Text: "old"},
+				mentions: tt.mentions,
+			}
+			chatRepo := &stubChatRepo{chats: []Chat{{ID: 1, UserChats: []UserChat{
+				{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}, {ChatID: 1, UserID: 3},
+			}}}}
+			userServiceContract := &stubUserServiceContract{users: []domain.User{
+				{ID: 2, Username: "alice"}, {ID: 3, Username: "bob"},
+			}}
+			service := NewMessageServiceImpl(
+				&configs.Config{MessageMaxLength: 100},
+				&recordingBaseRepo{tx: &recordingTx{}},
+				chatRepo,
+				nil,
+				messageRepo,
+				nil,
+				nil,
+				userServiceContract,
+				&stubEventPublisher{},
+				nil,
+			)
+
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1})
+			if _, err := service.UpdateMessage(ctx, Message{ID: 1, Text: tt.text}); err != nil {
+				t.Fatalf("UpdateMessage() error = %v", err)
+			}
+
+			if !lo.ElementsMatch(messageRepo.mentions, tt.wantMentions) {
+				t.Errorf("mentions = %v, want %v", messageRepo.mentions, tt.wantMentions)
+			}
+		})
+	}
+}
+
+func TestForwardMessagesLimit(t *testing.T) {
+	// The copies are counted before anything is read, no repo is needed.
+	service := NewMessageServiceImpl(&configs.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1})
+
+	messageIDs := lo.RangeFrom(uint64(1), 11)
+	toChatIDs := lo.RangeFrom(uint64(2), 10)
+
+	_, err := service.ForwardMessages(ctx, 1, messageIDs, toChatIDs)
+
+	var badRequestErr *commonerrors.BadRequestError
+	if !errors.As(err, &badRequestErr) {
+		t.Fatalf("ForwardMessages() of %d copies error = %v, want BadRequestError", len(messageIDs)*len(toChatIDs), err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+// NotificationLevel is what a member gets notified about in a chat.
+type NotificationLevel uint8
+
+const (
+	AllNotificationLevel      NotificationLevel = 1
+	MentionsNotificationLevel NotificationLevel = 2
+	NoneNotificationLevel     NotificationLevel = 3
+)
+
+func (l NotificationLevel) Uint8() uint8 {
+	return uint8(l)
+}
+
+func (l NotificationLevel) Levels() []NotificationLevel {
+	return []NotificationLevel{AllNotificationLevel, MentionsNotificationLevel, NoneNotificationLevel}
+}
+
+func (l NotificationLevel) IsValid() bool {
+	return slices.Contains(l.Levels(), l)
+}
@@ -0,0 +1,70 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Poll is attached to a message of PollMessageKind. Votes are counted per
+// option, voters are only listed for public polls. A poll is closed by its
+// author or once ClosesAt has passed.
+type Poll struct {
+	ID               uint64       `json:"id"`
+	MessageID        uint64       `json:"messageId"`
+	ChatID           uint64       `json:"chatId"`
+	Question         string       `json:"question"`
+	Options          []PollOption `json:"options"`
+	IsMultipleChoice bool         `json:"isMultipleChoice"`
+	IsAnonymous      bool         `json:"isAnonymous"`
+	ClosesAt         *time.Time   `json:"closesAt,omitempty"`
+	ClosedAt         *time.Time   `json:"closedAt,omitempty"`
+	VoterCount       uint64       `json:"voterCount"`
+	CreatedBy        uint64       `json:"createdBy"`
+	CreatedAt        time.Time    `json:"createdAt"`
+	UpdatedAt        time.Time    `json:"updatedAt"`
+
+	// VotedOptionIDs are the options chosen by the current user, they
+	// aren't part of the poll events.
+	VotedOptionIDs []uint64 `json:"-"`
+}
+
+type PollOption struct {
+	ID        uint64   `json:"id"`
+	Text      string   `json:"text"`
+	Position  uint     `json:"position"`
+	VoteCount uint64   `json:"voteCount"`
+	VoterIDs  []uint64 `json:"voterIds,omitempty"`
+}
+
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+// isValidVote checks that the options belong to the poll and that only one
+// is chosen in a single choice poll.
+func (p *Poll) isValidVote(optionIDs []uint64) bool {
+	if len(optionIDs) == 0 || (!p.IsMultipleChoice && len(optionIDs) > 1) {
+		return false
+	}
+
+	pollOptionIDs := lo.Map(p.Options, func(option PollOption, _ int) uint64 {
+		return option.ID
+	})
+
+	return lo.Every(pollOptionIDs, optionIDs)
+}
@@ -0,0 +1,35 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type PollRepo interface {
+	// GetPoll locks the poll when called in a transaction, so votes are
+	// counted one after another.
+	GetPoll(ctx context.Context, id uint64, tx repository.Tx) (*Poll, error)
+	GetPolls(ctx context.Context, messageIDs []uint64) ([]Poll, error)
+	// GetVotedOptionIDs returns the options chosen by the user per poll.
+	GetVotedOptionIDs(ctx context.Context, pollIDs []uint64, userID uint64) (map[uint64][]uint64, error)
+	CreatePoll(ctx context.Context, poll Poll, tx repository.Tx) (*Poll, error)
+	ClosePoll(ctx context.Context, id uint64, tx repository.Tx) error
+	// ReplaceVotes drops the previous votes of the user in the poll.
+	ReplaceVotes(ctx context.Context, pollID uint64, userID uint64, optionIDs []uint64, tx repository.Tx) error
+	DeleteVotes(ctx context.Context, pollID uint64, userID uint64, tx repository.Tx) error
+}
@@ -0,0 +1,274 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type PollServiceImpl struct {
+	baseRepo       repository.BaseRepo
+	chatRepo       ChatRepo
+	messageRepo    MessageRepo
+	pollRepo       PollRepo
+	eventPublisher EventPublisher
+}
+
+// getMemberChat returns the chat if the current user is a member of it.
+func (s *PollServiceImpl) getMemberChat(ctx context.Context, chatID uint64) (*Chat, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if !chat.HasMember(user.ID) {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return chat, nil
+}
+
+func (s *PollServiceImpl) fillVotedOptionIDs(ctx context.Context, poll *Poll) error {
+	user := domain.UserFromContext(ctx)
+
+	votedOptionIDs, err := s.pollRepo.GetVotedOptionIDs(ctx, []uint64{poll.ID}, user.ID)
+	if err != nil {
+		return err
+	}
+
+	poll.VotedOptionIDs = votedOptionIDs[poll.ID]
+
+	return nil
+}
+
+func (s *PollServiceImpl) GetPoll(ctx context.Context, id uint64) (*Poll, error) {
+	poll, err := s.pollRepo.GetPoll(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if _, err := s.getMemberChat(ctx, poll.ChatID); err != nil {
+		return nil, err
+	}
+
+	if err := s.fillVotedOptionIDs(ctx, poll); err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+// CreatePoll sends a poll message to the chat, the message carries the
+// question as its text so clients without poll support still show it.
+func (s *PollServiceImpl) CreatePoll(ctx context.Context, poll Poll) (*Message, error) {
+	user := domain.UserFromContext(ctx)
+
+	chat, err := s.getMemberChat(ctx, poll.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.ClosesAt != nil && !poll.ClosesAt.After(time.Now()) {
+		return nil, chaterrors.NewInvalidClosesAtError()
+	}
+
+	newMessage := Message{
+		Kind:      PollMessageKind,
+		Text:      poll.Question,
+		ChatID:    chat.ID,
+		CreatedBy: user.ID,
+	}
+	newMessage.applyExpiry(chat.Expiry)
+
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	message, err := s.messageRepo.CreateMessage(ctx, newMessage, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if message == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	poll.MessageID = message.ID
+	poll.CreatedBy = user.ID
+
+	message.Poll, err = s.pollRepo.CreatePoll(ctx, poll, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Creator = user
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    MessageCreatedEventType,
+		Payload: message,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// updatePoll runs a change of an open poll in a transaction and announces
+// the new results to the chat.
+func (s *PollServiceImpl) updatePoll(
+	ctx context.Context,
+	id uint64,
+	update func(poll *Poll, tx repository.Tx) error,
+) (*Poll, error) {
+	tx, err := s.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	poll, err := s.pollRepo.GetPoll(ctx, id, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	if _, err := s.getMemberChat(ctx, poll.ChatID); err != nil {
+		return nil, err
+	}
+
+	if poll.IsClosed(time.Now()) {
+		return nil, chaterrors.NewPollClosedError()
+	}
+
+	if err := update(poll, tx); err != nil {
+		return nil, err
+	}
+
+	updatedPoll, err := s.pollRepo.GetPoll(ctx, id, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventPublisher.Publish(ctx, tx, domain.Event{
+		Type:    PollUpdatedEventType,
+		Payload: updatedPoll,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updatedPoll, nil
+}
+
+// Vote replaces the previous votes of the current user in the poll.
+func (s *PollServiceImpl) Vote(ctx context.Context, id uint64, optionIDs []uint64) (*Poll, error) {
+	user := domain.UserFromContext(ctx)
+
+	poll, err := s.updatePoll(ctx, id, func(poll *Poll, tx repository.Tx) error {
+		if !poll.isValidVote(optionIDs) {
+			return chaterrors.NewInvalidPollOptionsError()
+		}
+
+		return s.pollRepo.ReplaceVotes(ctx, poll.ID, user.ID, optionIDs, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	poll.VotedOptionIDs = optionIDs
+
+	return poll, nil
+}
+
+func (s *PollServiceImpl) RetractVote(ctx context.Context, id uint64) (*Poll, error) {
+	user := domain.UserFromContext(ctx)
+
+	return s.updatePoll(ctx, id, func(poll *Poll, tx repository.Tx) error {
+		return s.pollRepo.DeleteVotes(ctx, poll.ID, user.ID, tx)
+	})
+}
+
+// ClosePoll stops the voting before ClosesAt, only the author can do it.
+func (s *PollServiceImpl) ClosePoll(ctx context.Context, id uint64) (*Poll, error) {
+	user := domain.UserFromContext(ctx)
+
+	poll, err := s.updatePoll(ctx, id, func(poll *Poll, tx repository.Tx) error {
+		if poll.CreatedBy != user.ID {
+			return errors.NewForbiddenError()
+		}
+
+		return s.pollRepo.ClosePoll(ctx, poll.ID, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fillVotedOptionIDs(ctx, poll); err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+func NewPollServiceImpl(
+	baseRepo repository.BaseRepo,
+	chatRepo ChatRepo,
+	messageRepo MessageRepo,
+	pollRepo PollRepo,
+	eventPublisher EventPublisher,
+) *PollServiceImpl {
+	return &PollServiceImpl{
+		baseRepo:       baseRepo,
+		chatRepo:       chatRepo,
+		messageRepo:    messageRepo,
+		pollRepo:       pollRepo,
+		eventPublisher: eventPublisher,
+	}
+}
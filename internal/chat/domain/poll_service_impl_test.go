@@ -0,0 +1,227 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+// stubPollRepo keeps the votes per user and counts them on GetPoll like
+// the poll tables do.
+type stubPollRepo struct {
+	PollRepo
+
+	poll  Poll
+	votes map[uint64][]uint64
+}
+
+func (r *stubPollRepo) GetPoll(_ context.Context, id uint64, _ repository.Tx) (*Poll, error) {
+	if id != r.poll.ID {
+		return nil, nil
+	}
+
+	poll := r.poll
+	poll.Options = make([]PollOption, len(r.poll.Options))
+	poll.VoterCount = 0
+
+	for userID, optionIDs := range r.votes {
+		if len(optionIDs) > 0 {
+			poll.VoterCount++
+		}
+
+		for _, optionID := range optionIDs {
+			_, index, _ := lo.FindIndexOf(r.poll.Options, func(option PollOption) bool {
+				return option.ID == optionID
+			})
+			poll.Options[index].VoteCount++
+			if !poll.IsAnonymous {
+				poll.Options[index].VoterIDs = append(poll.Options[index].VoterIDs, userID)
+			}
+		}
+	}
+
+	for index, option := range r.poll.Options {
+		poll.Options[index].ID = option.ID
+	}
+
+	return &poll, nil
+}
+
+func (r *stubPollRepo) GetVotedOptionIDs(_ context.Context, _ []uint64, userID uint64) (map[uint64][]uint64, error) {
+	return map[uint64][]uint64{r.poll.ID: r.votes[userID]}, nil
+}
+
+func (r *stubPollRepo) ReplaceVotes(_ context.Context, _ uint64, userID uint64, optionIDs []uint64, _ repository.Tx) error {
+	r.votes[userID] = optionIDs
+	return nil
+}
+
+func (r *stubPollRepo) DeleteVotes(_ context.Context, _ uint64, userID uint64, _ repository.Tx) error {
+	delete(r.votes, userID)
+	return nil
+}
+
+func (r *stubPollRepo) ClosePoll(context.Context, uint64, repository.Tx) error {
+	r.poll.ClosedAt = lo.ToPtr(time.Now())
+	return nil
+}
+
+func TestPollServiceVote(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         uint64
+		poll           Poll
+		optionIDs      []uint64
+		retract        bool
+		wantErr        error
+		wantVoteCounts []uint64
+	}{
+		{name: "vote", userID: 1, optionIDs: []uint64{2}, wantVoteCounts: []uint64{1, 1}},
+		{name: "vote replaces the previous one", userID: 2, optionIDs: []uint64{2}, wantVoteCounts: []uint64{0, 1}},
+		{name: "retract", userID: 2, retract: true, wantVoteCounts: []uint64{0, 0}},
+		{name: "invalid options", userID: 1, optionIDs: []uint64{1, 2}, wantErr: &chaterrors.InvalidPollOptionsError{}},
+		{
+			name:      "closed poll",
+			userID:    1,
+			poll:      Poll{ClosesAt: lo.ToPtr(time.Now().Add(-time.Minute))},
+			optionIDs: []uint64{1},
+			wantErr:   &chaterrors.PollClosedError{},
+		},
+		{name: "not a member", userID: 3, optionIDs: []uint64{1}, wantErr: &commonerrors.ForbiddenError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := tt.poll
+			poll.ID = 1
+			poll.ChatID = 1
+			poll.Options = []PollOption{{ID: 1}, {ID: 2}}
+
+			// The second member already voted for the first option.
+			pollRepo := &stubPollRepo{poll: poll, votes: map[uint64][]uint64{2: {1}}}
+			chatRepo := &stubChatRepo{chats: []Chat{{ID: 1, UserChats: []UserChat{{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}}}}}
+			eventPublisher := &stubEventPublisher{}
+			service := NewPollServiceImpl(stubBaseRepo{}, chatRepo, nil, pollRepo, eventPublisher)
+
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: tt.userID})
+
+			var (
+				updated *Poll
+				err     error
+			)
+			if tt.retract {
+				updated, err = service.RetractVote(ctx, 1)
+			} else {
+				updated, err = service.Vote(ctx, 1, tt.optionIDs)
+			}
+
+			if tt.wantErr != nil {
+				if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+					t.Fatalf("error = %v, want %T", err, tt.wantErr)
+				}
+				if len(eventPublisher.events) != 0 {
+					t.Errorf("published %d events on error", len(eventPublisher.events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			voteCounts := lo.Map(updated.Options, func(option PollOption, _ int) uint64 {
+				return option.VoteCount
+			})
+			if !reflect.DeepEqual(voteCounts, tt.wantVoteCounts) {
+				t.Errorf("vote counts %v, want %v", voteCounts, tt.wantVoteCounts)
+			}
+
+			if len(eventPublisher.events) != 1 || eventPublisher.events[0].Type != PollUpdatedEventType {
+				t.Fatalf("published %+v, want a poll update", eventPublisher.events)
+			}
+
+			if !lo.ElementsMatch(updated.VotedOptionIDs, tt.optionIDs) {
+				t.Errorf("voted options %v, want %v", updated.VotedOptionIDs, tt.optionIDs)
+			}
+		})
+	}
+}
+
+func TestPollServiceClosePoll(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    uint64
+		forbidden bool
+	}{
+		{name: "author", userID: 1},
+		{name: "another member", userID: 2, forbidden: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollRepo := &stubPollRepo{
+				poll:  Poll{ID: 1, ChatID: 1, CreatedBy: 1, Options: []PollOption{{ID: 1}}},
+				votes: map[uint64][]uint64{},
+			}
+			chatRepo := &stubChatRepo{chats: []Chat{{ID: 1, UserChats: []UserChat{{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}}}}}
+			service := NewPollServiceImpl(stubBaseRepo{}, chatRepo, nil, pollRepo, &stubEventPublisher{})
+
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: tt.userID})
+
+			poll, err := service.ClosePoll(ctx, 1)
+
+			var forbiddenErr *commonerrors.ForbiddenError
+			if errors.As(err, &forbiddenErr) != tt.forbidden {
+				t.Fatalf("ClosePoll() error = %v, want forbidden %t", err, tt.forbidden)
+			}
+			if tt.forbidden {
+				return
+			}
+
+			if !poll.IsClosed(time.Now()) {
+				t.Error("poll isn't closed")
+			}
+
+			var pollClosedErr *chaterrors.PollClosedError
+			if _, err := service.Vote(ctx, 1, []uint64{1}); !errors.As(err, &pollClosedErr) {
+				t.Errorf("Vote() on closed poll error = %v, want PollClosedError", err)
+			}
+		})
+	}
+}
+
+func TestPollServiceCreatePollClosesAt(t *testing.T) {
+	chatRepo := &stubChatRepo{chats: []Chat{{ID: 1, UserChats: []UserChat{{ChatID: 1, UserID: 1}}}}}
+	service := NewPollServiceImpl(stubBaseRepo{}, chatRepo, nil, &stubPollRepo{}, &stubEventPublisher{})
+
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1})
+
+	_, err := service.CreatePoll(ctx, Poll{ChatID: 1, Question: "?", ClosesAt: lo.ToPtr(time.Now().Add(-time.Second))})
+
+	var invalidClosesAtErr *chaterrors.InvalidClosesAtError
+	if !errors.As(err, &invalidClosesAtErr) {
+		t.Fatalf("CreatePoll() error = %v, want InvalidClosesAtError", err)
+	}
+}
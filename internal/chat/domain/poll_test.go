@@ -0,0 +1,73 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+func TestPollIsClosed(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		poll Poll
+		want bool
+	}{
+		{name: "open", poll: Poll{}},
+		{name: "closes later", poll: Poll{ClosesAt: lo.ToPtr(now.Add(time.Minute))}},
+		{name: "closes now", poll: Poll{ClosesAt: lo.ToPtr(now)}, want: true},
+		{name: "closed by time", poll: Poll{ClosesAt: lo.ToPtr(now.Add(-time.Minute))}, want: true},
+		{name: "closed by the author", poll: Poll{ClosedAt: lo.ToPtr(now.Add(-time.Minute))}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poll.IsClosed(now); got != tt.want {
+				t.Errorf("IsClosed() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollIsValidVote(t *testing.T) {
+	options := []PollOption{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name           string
+		multipleChoice bool
+		optionIDs      []uint64
+		want           bool
+	}{
+		{name: "single option", optionIDs: []uint64{1}, want: true},
+		{name: "no options", optionIDs: nil},
+		{name: "several options of a single choice poll", optionIDs: []uint64{1, 2}},
+		{name: "several options of a multiple choice poll", multipleChoice: true, optionIDs: []uint64{1, 3}, want: true},
+		{name: "option of another poll", optionIDs: []uint64{4}},
+		{name: "some options of another poll", multipleChoice: true, optionIDs: []uint64{1, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := Poll{Options: options, IsMultipleChoice: tt.multipleChoice}
+			if got := poll.isValidVote(tt.optionIDs); got != tt.want {
+				t.Errorf("isValidVote(%v) = %t, want %t", tt.optionIDs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// RetentionPolicy tells which messages of a chat are purged: messages older
+// than Days and messages beyond the last MaxMessages. A nil rule of a chat
+// falls back to the global one, zero disables it. LegalHold keeps every
+// message regardless of the rules.
+type RetentionPolicy struct {
+	Days        *uint `json:"days,omitempty"`
+	MaxMessages *uint `json:"maxMessages,omitempty"`
+	LegalHold   bool  `json:"legalHold,omitempty"`
+}
@@ -0,0 +1,158 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrRetentionPurgerAlreadyStarted = errors.New("retention purger already started")
+
+// RetentionPurger deletes messages expired under the retention policies in
+// batches. Every purged message is published as a deleted message, so
+// connected clients drop it as well. Nothing is purged under legal hold.
+type RetentionPurger struct {
+	cfg            *configs.Config
+	log            logger.Logger
+	baseRepo       repository.BaseRepo
+	messageRepo    MessageRepo
+	eventPublisher EventPublisher
+
+	isStarted atomic.Bool
+}
+
+func (p *RetentionPurger) Start(ctx context.Context) error {
+	if !p.isStarted.CompareAndSwap(false, true) {
+		return ErrRetentionPurgerAlreadyStarted
+	}
+	defer p.isStarted.Store(false)
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.cfg.RetentionPollInterval):
+		}
+	}
+}
+
+func (p *RetentionPurger) purge(ctx context.Context) {
+	if p.cfg.RetentionLegalHold {
+		return
+	}
+
+	for {
+		count, err := p.purgeBatch(ctx)
+		if err != nil {
+			p.log.Errorf("error on purging messages: %s", err)
+			return
+		}
+
+		if count > 0 {
+			p.log.Debugf("purged %d messages", count)
+		}
+
+		if count < p.cfg.RetentionBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (p *RetentionPurger) purgeBatch(ctx context.Context) (uint64, error) {
+	global := RetentionPolicy{
+		Days:        &p.cfg.RetentionDays,
+		MaxMessages: &p.cfg.RetentionMaxMessages,
+	}
+
+	return purgeMessages(ctx, p.baseRepo, p.messageRepo, p.eventPublisher,
+		func(tx repository.Tx) ([]Message, error) {
+			return p.messageRepo.GetExpiredMessages(ctx, global, p.cfg.RetentionBatchSize, tx)
+		},
+	)
+}
+
+// purgeMessages deletes the messages returned by get in one transaction and
+// publishes them as deleted messages.
+func purgeMessages(
+	ctx context.Context,
+	baseRepo repository.BaseRepo,
+	messageRepo MessageRepo,
+	eventPublisher EventPublisher,
+	get func(tx repository.Tx) ([]Message, error),
+) (uint64, error) {
+	tx, err := baseRepo.BeginContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	messages, err := get(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	if err := messageRepo.DeleteMessages(ctx, lo.Map(messages, func(message Message, _ int) uint64 {
+		return message.ID
+	}), tx); err != nil {
+		return 0, err
+	}
+
+	if err := eventPublisher.Publish(ctx, tx, lo.Map(messages, func(message Message, _ int) domain.Event {
+		return newMessageDeletedEvent(message)
+	})...); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(messages)), nil
+}
+
+func NewRetentionPurger(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	messageRepo MessageRepo,
+	eventPublisher EventPublisher,
+) *RetentionPurger {
+	return &RetentionPurger{
+		cfg:            cfg,
+		log:            log,
+		baseRepo:       baseRepo,
+		messageRepo:    messageRepo,
+		eventPublisher: eventPublisher,
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+type stubPurgeMessageRepo struct {
+	MessageRepo
+
+	expired     []Message
+	disappeared []Message
+	deleted     []uint64
+	global      RetentionPolicy
+}
+
+func (r *stubPurgeMessageRepo) GetExpiredMessages(
+	_ context.Context,
+	global RetentionPolicy,
+	limit uint64,
+	_ repository.Tx,
+) ([]Message, error) {
+	r.global = global
+
+	messages := r.expired[:min(limit, uint64(len(r.expired)))]
+	r.expired = r.expired[len(messages):]
+
+	return messages, nil
+}
+
+func (r *stubPurgeMessageRepo) GetDisappearedMessages(_ context.Context, limit uint64, _ repository.Tx) ([]Message, error) {
+	messages := r.disappeared[:min(limit, uint64(len(r.disappeared)))]
+	r.disappeared = r.disappeared[len(messages):]
+
+	return messages, nil
+}
+
+func (r *stubPurgeMessageRepo) DeleteMessages(_ context.Context, ids []uint64, _ repository.Tx) error {
+	r.deleted = append(r.deleted, ids...)
+	return nil
+}
+
+func TestPurgeMessages(t *testing.T) {
+	messages := []Message{
+		{ID: 1, ChatID: 1, Text: "first"},
+		{ID: 2, ChatID: 1, Text: "second"},
+		{ID: 3, ChatID: 2, Text: "third"},
+	}
+
+	tests := []struct {
+		name        string
+		legalHold   bool
+		wantDeleted []uint64
+	}{
+		{name: "purged in batches", wantDeleted: []uint64{1, 2, 3}},
+		{name: "legal hold", legalHold: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{
+				RetentionLegalHold:     tt.legalHold,
+				RetentionBatchSize:     2,
+				MessageExpiryBatchSize: 2,
+			}
+
+			runs := map[string]func(repo *stubPurgeMessageRepo, eventPublisher EventPublisher){
+				"retention purger": func(repo *stubPurgeMessageRepo, eventPublisher EventPublisher) {
+					repo.expired = messages
+					NewRetentionPurger(cfg, stubLogger{}, stubBaseRepo{}, repo, eventPublisher).purge(context.Background())
+				},
+				"message expirer": func(repo *stubPurgeMessageRepo, eventPublisher EventPublisher) {
+					repo.disappeared = messages
+					NewMessageExpirer(cfg, stubLogger{}, stubBaseRepo{}, repo, eventPublisher).expire(context.Background())
+				},
+			}
+
+			for name, run := range runs {
+				repo := &stubPurgeMessageRepo{}
+				eventPublisher := &stubEventPublisher{}
+
+				run(repo, eventPublisher)
+
+				if !lo.ElementsMatch(repo.deleted, tt.wantDeleted) {
+					t.Errorf("%s deleted %v, want %v", name, repo.deleted, tt.wantDeleted)
+				}
+
+				// Deleted messages are announced without their content.
+				wantEvents := lo.FilterMap(messages, func(message Message, _ int) (domain.Event, bool) {
+					return domain.Event{
+						Type:    MessageDeletedEventType,
+						Payload: MessageDeletedEventPayload{ChatID: message.ChatID, MessageID: message.ID},
+					}, lo.Contains(tt.wantDeleted, message.ID)
+				})
+				if !lo.ElementsMatch(eventPublisher.events, wantEvents) {
+					t.Errorf("%s published %+v, want %+v", name, eventPublisher.events, wantEvents)
+				}
+			}
+		})
+	}
+}
+
+func TestRetentionPurgerGlobalPolicy(t *testing.T) {
+	cfg := &configs.Config{
+		RetentionDays:        30,
+		RetentionMaxMessages: 1000,
+		RetentionBatchSize:   10,
+	}
+	repo := &stubPurgeMessageRepo{expired: []Message{{ID: 1, ChatID: 1}}}
+
+	NewRetentionPurger(cfg, stubLogger{}, stubBaseRepo{}, repo, &stubEventPublisher{}).purge(context.Background())
+
+	// Chats without a policy of their own fall back to the configured one.
+	if repo.global.Days == nil || *repo.global.Days != 30 || repo.global.MaxMessages == nil || *repo.global.MaxMessages != 1000 {
+		t.Errorf("purged under global policy %+v, want the configured one", repo.global)
+	}
+	if !lo.ElementsMatch(repo.deleted, []uint64{1}) {
+		t.Errorf("deleted %v, want [1]", repo.deleted)
+	}
+}
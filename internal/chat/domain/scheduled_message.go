@@ -0,0 +1,32 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+// ScheduledMessage is a message prepared on behalf of its author and sent
+// by the MessageScheduler at SendAt. Mentions, entities and the creator are
+// resolved when it's scheduled, as the author isn't around on sending.
+// Undo-send messages are held the same way for a few seconds.
+type ScheduledMessage struct {
+	ID         uint64
+	Message    Message
+	IsUndoSend bool
+	SendAt     time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
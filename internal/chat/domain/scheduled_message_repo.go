@@ -0,0 +1,34 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type ScheduledMessageRepo interface {
+	GetScheduledMessage(ctx context.Context, id uint64) (*ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, filter *ScheduledMessageFilter) ([]ScheduledMessage, error)
+	GetScheduledMessagesCount(ctx context.Context, filter *ScheduledMessageFilter) (uint64, error)
+	CreateScheduledMessage(ctx context.Context, scheduledMessage ScheduledMessage) (*ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, scheduledMessage ScheduledMessage) (*ScheduledMessage, error)
+	// DeleteScheduledMessage reports whether the message was still pending.
+	DeleteScheduledMessage(ctx context.Context, id uint64) (bool, error)
+	// GetDueScheduledMessages locks and returns messages to send now.
+	GetDueScheduledMessages(ctx context.Context, limit uint64, tx repository.Tx) ([]ScheduledMessage, error)
+	DeleteScheduledMessages(ctx context.Context, ids []uint64, tx repository.Tx) error
+}
@@ -14,11 +14,50 @@
 
 package domain
 
-import "chat-go/internal/common/domain"
+import (
+	"time"
 
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+// UserChat is a membership of a user in a chat along with the preferences
+// of the member for this chat.
 type UserChat struct {
-	UserID uint64 `json:"userId"`
-	ChatID uint64 `json:"chatId"`
+	UserID            uint64            `json:"userId"`
+	ChatID            uint64            `json:"chatId"`
+	Role              MemberRole        `json:"role"`
+	MutedUntil        *time.Time        `json:"mutedUntil,omitempty"`
+	IsArchived        bool              `json:"isArchived,omitempty"`
+	PinOrder          *uint             `json:"pinOrder,omitempty"`
+	NotificationLevel NotificationLevel `json:"notificationLevel,omitempty"`
+	// LastReadMessageID is the last message the member has read, later
+	// messages of others are unread for the member.
+	LastReadMessageID uint64 `json:"lastReadMessageId,omitempty"`
+
+	User *domain.User `json:"user,omitempty"`
+}
+
+func (uc UserChat) IsMuted(now time.Time) bool {
+	return uc.MutedUntil != nil && uc.MutedUntil.After(now)
+}
+
+func (uc UserChat) IsPinned() bool {
+	return uc.PinOrder != nil
+}
+
+// ShouldNotify reports whether the member is notified of a message, given
+// whether the member is mentioned in it.
+func (uc UserChat) ShouldNotify(now time.Time, isMention bool) bool {
+	if uc.IsMuted(now) {
+		return false
+	}
 
-	User *domain.User
+	switch uc.NotificationLevel {
+	case NoneNotificationLevel:
+		return false
+	case MentionsNotificationLevel:
+		return isMention
+	default:
+		return true
+	}
 }
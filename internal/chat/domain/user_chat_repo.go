@@ -17,10 +17,15 @@ package domain
 import (
 	"context"
 
-	"chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type UserChatRepo interface {
 	CreateUserChats(ctx context.Context, userChats []UserChat, tx repository.Tx) error
 	DeleteUserChats(ctx context.Context, userChats []UserChat, tx repository.Tx) error
+	UpdateUserChat(ctx context.Context, userChat UserChat, tx repository.Tx) error
+	UpdateUserChatRole(ctx context.Context, userChat UserChat, tx repository.Tx) error
+	// UpdateLastReadMessage moves the read state of the member forward to the
+	// last of the messages in the chat, it never moves back.
+	UpdateLastReadMessage(ctx context.Context, chatID uint64, userID uint64, messageIDs []uint64, tx repository.Tx) error
 }
@@ -17,7 +17,7 @@ package domain
 import (
 	"context"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type UserServiceContract interface {
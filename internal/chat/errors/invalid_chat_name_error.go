@@ -15,8 +15,8 @@
 package errors
 
 import (
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 const InvalidChatNameErrorType = "InvalidChatNameError"
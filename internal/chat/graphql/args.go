@@ -0,0 +1,118 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+// idArg parses an ID argument. IDs are strings in the schema, bot IDs don't
+// fit into a GraphQL Int.
+func idArg(args map[string]any, name string) (uint64, error) {
+	value := fmt.Sprint(args[name])
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{name: value})
+	}
+
+	return id, nil
+}
+
+func optionalIDArg(args map[string]any, name string) (*uint64, error) {
+	if args[name] == nil {
+		return nil, nil
+	}
+
+	id, err := idArg(args, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func idsArg(args map[string]any, name string) ([]uint64, error) {
+	values, _ := args[name].([]any)
+
+	ids := make([]uint64, 0, len(values))
+
+	for _, value := range values {
+		id, err := idArg(map[string]any{name: value}, name)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// optionalUintArg parses a non-negative Int argument like limit and offset.
+func optionalUintArg(args map[string]any, name string) (*uint64, error) {
+	value, ok := args[name].(int)
+	if !ok {
+		return nil, nil
+	}
+
+	if value < 0 {
+		return nil, errors.NewBadRequestError(
+			constants.ChatDomain, fmt.Errorf("%s must not be negative", name), map[string]any{name: value})
+	}
+
+	result := uint64(value)
+
+	return &result, nil
+}
+
+func optionalBoolArg(args map[string]any, name string) *bool {
+	value, ok := args[name].(bool)
+	if !ok {
+		return nil
+	}
+
+	return &value
+}
+
+func stringArg(args map[string]any, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
+
+// uint8sArg parses a list of small Int arguments like chat types, values out
+// of range fail the same way as invalid values.
+func uint8sArg(args map[string]any, name string) ([]uint8, error) {
+	values, _ := args[name].([]any)
+
+	result := make([]uint8, 0, len(values))
+
+	for _, value := range values {
+		number, _ := value.(int)
+		if number < 0 || number > math.MaxUint8 {
+			return nil, errors.NewBadRequestError(
+				constants.ChatDomain, fmt.Errorf("%s is out of range", name), map[string]any{name: value})
+		}
+
+		result = append(result, uint8(number))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,301 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	userhttp "github.com/microcoretech/chat-go/internal/user/http"
+)
+
+// Subprotocol is the graphql-transport-ws protocol of the graphql-ws
+// library, which the websocket speaks.
+const Subprotocol = "graphql-transport-ws"
+
+const (
+	connectionInitMessageType = "connection_init"
+	connectionAckMessageType  = "connection_ack"
+	pingMessageType           = "ping"
+	pongMessageType           = "pong"
+	subscribeMessageType      = "subscribe"
+	nextMessageType           = "next"
+	errorMessageType          = "error"
+	completeMessageType       = "complete"
+)
+
+const (
+	closeInvalidMessage                = 4400
+	closeSubscriberAlreadyExists       = 4409
+	closeTooManyInitialisationRequests = 4429
+)
+
+const tokenQueryParam = "token"
+
+type GraphQLController struct {
+	cfg            *configs.Config
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	authenticator  Authenticator
+	connector      connector.Connector
+	schema         *Schema
+}
+
+func (c *GraphQLController) SetupRoutes(r fiber.Router) {
+	// registered ahead of the group so websocket clients may authenticate
+	// with the connection_init message, see wsHandshake.
+	r.Get("/graphql", c.wsHandshake)
+
+	graphQLGroup := r.Group("/graphql", c.authMiddleware.Handler)
+	graphQLGroup.Post("", c.query)
+	graphQLGroup.Get("", c.ws)
+}
+
+func (c *GraphQLController) query(ctx *fiber.Ctx) error {
+	dto := RequestDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	return ctx.JSON(c.schema.Do(ctx.Context(), dto))
+}
+
+// wsHandshake passes requests with an Authorization header on to the auth
+// middleware, others are upgraded and have to authenticate with the
+// connection_init message. The token query param is refused as on the chat
+// websocket, it would end up in the logs of proxies.
+func (c *GraphQLController) wsHandshake(ctx *fiber.Ctx) error {
+	if ctx.Query(tokenQueryParam) != "" {
+		return errors.NewUnauthorizedError("token query param is not supported, use the connection_init message")
+	}
+
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return ctx.Next()
+	}
+
+	if ctx.Get(fiber.HeaderAuthorization) != "" {
+		return ctx.Next()
+	}
+
+	return websocket.New(func(conn *websocket.Conn) {
+		c.serve(newWebsocketConnection(conn.Conn, c.cfg.GraphQLMaxOperations), nil, "")
+	}, wsConfig())(ctx)
+}
+
+func (c *GraphQLController) ws(ctx *fiber.Ctx) error {
+	user := domain.UserFromContext(ctx.Context())
+	token := domain.TokenFromContext(ctx.Context())
+	return websocket.New(func(conn *websocket.Conn) {
+		c.serve(newWebsocketConnection(conn.Conn, c.cfg.GraphQLMaxOperations), user, token)
+	}, wsConfig())(ctx)
+}
+
+// serve runs the protocol until the client leaves or the connector closes
+// the connection, e.g. once its token is revoked.
+func (c *GraphQLController) serve(connection *websocketConnection, user *domain.User, token string) {
+	defer connection.Close()
+	defer connection.stopOperations()
+
+	if !c.initConnection(connection, user, token) {
+		return
+	}
+
+	c.connector.AddConnection(connection)
+
+	for {
+		_, data, err := connection.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var message wsMessageDto
+		if err := json.Unmarshal(data, &message); err != nil {
+			connection.CloseWithReason(closeInvalidMessage, "Invalid message received")
+			return
+		}
+
+		c.handleMessage(connection, message)
+	}
+}
+
+// initConnection waits for the connection_init message, its authorization
+// takes precedence over the credentials of the handshake.
+func (c *GraphQLController) initConnection(connection *websocketConnection, user *domain.User, token string) bool {
+	_ = connection.conn.SetReadDeadline(time.Now().Add(c.cfg.WebsocketAuthTimeout))
+
+	_, data, err := connection.conn.ReadMessage()
+	if err != nil {
+		if os.IsTimeout(err) {
+			connection.CloseWithReason(connector.CloseAuthenticationTimeout, "Connection initialisation timeout")
+		}
+		return false
+	}
+
+	_ = connection.conn.SetReadDeadline(time.Time{})
+
+	var message wsMessageDto
+	if err := json.Unmarshal(data, &message); err != nil || message.Type != connectionInitMessageType {
+		connection.CloseWithReason(connector.CloseAuthenticationFailed, "Unauthorized")
+		return false
+	}
+
+	var payload connectionInitPayloadDto
+	if len(message.Payload) > 0 {
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			connection.CloseWithReason(closeInvalidMessage, "Invalid message received")
+			return false
+		}
+	}
+
+	if payload.Authorization != "" {
+		user, token, err = c.authenticate(payload.Authorization)
+		if err != nil {
+			connection.CloseWithReason(connector.CloseAuthenticationFailed, "invalid credentials")
+			return false
+		}
+	}
+
+	if user == nil {
+		connection.CloseWithReason(connector.CloseAuthenticationFailed, "authentication required")
+		return false
+	}
+
+	connection.SetCredentials(user, token)
+
+	return connection.send(wsMessageDto{Type: connectionAckMessageType}) == nil
+}
+
+func (c *GraphQLController) authenticate(authorization string) (*domain.User, string, error) {
+	token, isBot, err := userhttp.ParseAuthorization(authorization)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := c.authenticator.Authenticate(context.Background(), token, isBot)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user == nil || user.ID == 0 {
+		return nil, "", errors.NewUnauthorizedError("user not found")
+	}
+
+	return user, token, nil
+}
+
+func (c *GraphQLController) handleMessage(connection *websocketConnection, message wsMessageDto) {
+	switch message.Type {
+	case pingMessageType:
+		_ = connection.send(wsMessageDto{Type: pongMessageType})
+	case pongMessageType:
+	case subscribeMessageType:
+		var request RequestDto
+		if message.ID == "" || json.Unmarshal(message.Payload, &request) != nil {
+			connection.CloseWithReason(closeInvalidMessage, "Invalid message received")
+			return
+		}
+
+		ctx, err := connection.startOperation(message.ID)
+		switch {
+		case err == errOperationExists:
+			connection.CloseWithReason(closeSubscriberAlreadyExists, fmt.Sprintf("Subscriber for %s already exists", message.ID))
+			return
+		case err != nil:
+			// the other operations go on, the client may retry once one
+			// of them is completed.
+			_ = connection.sendPayload(message.ID, errorMessageType, gqlerrors.FormatErrors(err))
+			return
+		}
+
+		go c.execute(ctx, connection, message.ID, request)
+	case completeMessageType:
+		connection.stopOperation(message.ID)
+	case connectionInitMessageType:
+		connection.CloseWithReason(closeTooManyInitialisationRequests, "Too many initialisation requests")
+	default:
+		connection.CloseWithReason(closeInvalidMessage, "Invalid message received")
+	}
+}
+
+// execute sends the results of an operation until it's done or the client
+// completes it.
+func (c *GraphQLController) execute(ctx context.Context, connection *websocketConnection, id string, request RequestDto) {
+	if err := c.validate.Struct(constants.ChatDomain, request); err != nil {
+		connection.stopOperation(id)
+		_ = connection.sendPayload(id, errorMessageType, gqlerrors.FormatErrors(err))
+		return
+	}
+
+	ctx = domain.ContextWithUser(ctx, connection.GetUser())
+	ctx = domain.ContextWithToken(ctx, connection.GetToken())
+	ctx = contextWithConnection(ctx, connection)
+
+	// results are drained after the client completes the operation, so
+	// the executor isn't left blocked.
+	for result := range c.schema.Subscribe(ctx, request) {
+		if ctx.Err() == nil {
+			_ = connection.sendPayload(id, nextMessageType, result)
+		}
+	}
+
+	isCompleted := ctx.Err() != nil
+	connection.stopOperation(id)
+
+	if !isCompleted {
+		_ = connection.send(wsMessageDto{ID: id, Type: completeMessageType})
+	}
+}
+
+func wsConfig() websocket.Config {
+	return websocket.Config{
+		Subprotocols: []string{Subprotocol},
+	}
+}
+
+func NewGraphQLController(
+	cfg *configs.Config,
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	authenticator Authenticator,
+	connector connector.Connector,
+	schema *Schema,
+) *GraphQLController {
+	return &GraphQLController{
+		cfg:            cfg,
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		authenticator:  authenticator,
+		connector:      connector,
+		schema:         schema,
+	}
+}
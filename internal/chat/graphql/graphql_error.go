@@ -0,0 +1,101 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+// graphQLError carries the ErrorData of an error as the extensions of the
+// GraphQL error, the message is the error type.
+type graphQLError struct {
+	message    string
+	extensions map[string]any
+}
+
+func (e *graphQLError) Error() string {
+	return e.message
+}
+
+func (e *graphQLError) Extensions() map[string]any {
+	return e.extensions
+}
+
+// resolve wraps a resolver, so its errors are logged and reported as the
+// REST API does.
+func (s *Schema) resolve(fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s\n%s", r, string(debug.Stack()))
+			}
+
+			if err != nil {
+				result, err = nil, s.toError(p, err)
+			}
+		}()
+
+		return fn(p)
+	}
+}
+
+func (s *Schema) toError(p graphql.ResolveParams, err error) error {
+	statusCode, baseError := api.ResolveError(err)
+
+	errorData := baseError.GetErrorData()
+	errorData.Data["path"] = "graphql " + strings.Join(pathKeys(p.Info.Path), ".")
+
+	jsonErr, _ := json.Marshal(baseError)
+	if statusCode < 500 {
+		s.log.Debug(string(jsonErr))
+	} else {
+		s.log.Error(string(jsonErr))
+	}
+
+	extensions := map[string]any{
+		"status": statusCode,
+		"domain": errorData.Domain,
+		"type":   errorData.ErrorType,
+	}
+
+	if s.cfg.Environment != configs.DevelopmentEnvironment {
+		extensions["data"] = errors.TruncateErrorData(errorData).Data
+	} else {
+		extensions["data"] = errorData.Data
+		extensions["devDetails"] = errorData.DevDetails
+	}
+
+	return &graphQLError{
+		message:    errorData.ErrorType,
+		extensions: extensions,
+	}
+}
+
+func pathKeys(path *graphql.ResponsePath) []string {
+	if path == nil {
+		return nil
+	}
+
+	return append(pathKeys(path.Prev), fmt.Sprint(path.Key))
+}
@@ -0,0 +1,94 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"golang.org/x/exp/slices"
+)
+
+// queryLimits measures an operation before it's executed, fragments count
+// where they are spread. The walk stops at the first limit exceeded, so
+// fragments spread over and over don't make it expensive.
+type queryLimits struct {
+	fragments     map[string]*ast.FragmentDefinition
+	maxDepth      int
+	maxComplexity int
+	complexity    int
+}
+
+// checkQueryLimits rejects operations nested deeper than maxDepth fields or
+// selecting more than maxComplexity fields.
+func checkQueryLimits(document *ast.Document, operation *ast.OperationDefinition, maxDepth, maxComplexity int) error {
+	if document == nil || operation == nil {
+		return nil
+	}
+
+	l := &queryLimits{
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+	}
+
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			l.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	return l.walk(operation.SelectionSet, 0, nil)
+}
+
+func (l *queryLimits) walk(selectionSet *ast.SelectionSet, depth int, spreads []string) error {
+	if selectionSet == nil {
+		return nil
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if depth+1 > l.maxDepth {
+				return fmt.Errorf("query is nested deeper than %d fields", l.maxDepth)
+			}
+
+			l.complexity++
+			if l.complexity > l.maxComplexity {
+				return fmt.Errorf("query selects more than %d fields", l.maxComplexity)
+			}
+
+			if err := l.walk(selection.SelectionSet, depth+1, spreads); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			if err := l.walk(selection.SelectionSet, depth, spreads); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			// fragment cycles are reported by the validation.
+			fragment := l.fragments[selection.Name.Value]
+			if fragment == nil || slices.Contains(spreads, selection.Name.Value) {
+				continue
+			}
+
+			if err := l.walk(fragment.SelectionSet, depth, append(spreads, selection.Name.Value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestCheckQueryLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		maxDepth      int
+		maxComplexity int
+		wantErr       bool
+	}{
+		{
+			name:          "within limits",
+			query:         `{ chats { items { id name } } }`,
+			maxDepth:      3,
+			maxComplexity: 4,
+		},
+		{
+			name:          "too deep",
+			query:         `{ chats { items { creator { id } } } }`,
+			maxDepth:      3,
+			maxComplexity: 10,
+			wantErr:       true,
+		},
+		{
+			name:          "too complex",
+			query:         `{ chats { items { id name } } }`,
+			maxDepth:      3,
+			maxComplexity: 3,
+			wantErr:       true,
+		},
+		{
+			name:          "fragments count where spread",
+			query:         `{ chats { items { ...chat } } } fragment chat on Chat { creator { id } }`,
+			maxDepth:      3,
+			maxComplexity: 10,
+			wantErr:       true,
+		},
+		{
+			name:          "inline fragments don't nest",
+			query:         `{ chats { ... on ChatPage { items { id } } } }`,
+			maxDepth:      3,
+			maxComplexity: 10,
+		},
+		{
+			name: "repeated spreads add up",
+			query: `{ a: me { ...user } b: me { ...user } c: me { ...user } }
+				fragment user on User { id username email }`,
+			maxDepth:      2,
+			maxComplexity: 10,
+			wantErr:       true,
+		},
+		{
+			name:          "fragment cycles are left to the validation",
+			query:         `{ me { ...a } } fragment a on User { id ...b } fragment b on User { ...a }`,
+			maxDepth:      2,
+			maxComplexity: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			operation := document.Definitions[0].(*ast.OperationDefinition)
+
+			err = checkQueryLimits(document, operation, tt.maxDepth, tt.maxComplexity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkQueryLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
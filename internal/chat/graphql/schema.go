@@ -0,0 +1,581 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+// Schema serves GraphQL requests over chats, members, messages and users.
+// The services are called without looking users up, users are only fetched
+// when a query selects them, batched per request by the userLoader.
+type Schema struct {
+	cfg                 *configs.Config
+	log                 logger.Logger
+	validate            validator.Validate
+	chatService         ChatService
+	messageService      MessageService
+	userServiceContract chatdomain.UserServiceContract
+
+	schema graphql.Schema
+
+	chatType        *graphql.Object
+	messageType     *graphql.Object
+	userPageType    *graphql.Object
+	chatPageType    *graphql.Object
+	messagePageType *graphql.Object
+}
+
+// Do executes a query or mutation, subscriptions are only served over the
+// websocket.
+func (s *Schema) Do(ctx context.Context, request RequestDto) *graphql.Result {
+	operation, err := s.checkOperation(request)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	if operation == ast.OperationTypeSubscription {
+		return errorResult(fmt.Errorf("subscriptions are served over the websocket"))
+	}
+
+	return graphql.Do(s.params(ctx, request))
+}
+
+// Subscribe executes any operation, the channel gets a result per event of a
+// subscription and a single result otherwise. It's closed once the
+// operation is done or ctx is canceled.
+func (s *Schema) Subscribe(ctx context.Context, request RequestDto) chan *graphql.Result {
+	operation, err := s.checkOperation(request)
+	if err == nil && operation == ast.OperationTypeSubscription {
+		return graphql.Subscribe(s.params(ctx, request))
+	}
+
+	results := make(chan *graphql.Result, 1)
+	if err != nil {
+		results <- errorResult(err)
+	} else {
+		results <- graphql.Do(s.params(ctx, request))
+	}
+	close(results)
+
+	return results
+}
+
+func (s *Schema) params(ctx context.Context, request RequestDto) graphql.Params {
+	ctx = chatdomain.ContextWithoutUsers(ctx)
+	ctx = contextWithUserLoader(ctx, newUserLoader(ctx, s.userServiceContract))
+
+	return graphql.Params{
+		Schema:         s.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        ctx,
+	}
+}
+
+// checkOperation returns the type of the operation to execute, once it's
+// within the query limits. Queries which don't parse are left to the
+// executor to report.
+func (s *Schema) checkOperation(request RequestDto) (string, error) {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return "", nil
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if request.OperationName == "" || (operation.Name != nil && operation.Name.Value == request.OperationName) {
+			err := checkQueryLimits(document, operation, s.cfg.GraphQLMaxDepth, s.cfg.GraphQLMaxComplexity)
+			return operation.Operation, err
+		}
+	}
+
+	return "", nil
+}
+
+func errorResult(err error) *graphql.Result {
+	return &graphql.Result{
+		Errors: gqlerrors.FormatErrors(err),
+	}
+}
+
+func (s *Schema) queryType() *graphql.Object {
+	pageArgs := graphql.FieldConfigArgument{
+		"search": &graphql.ArgumentConfig{Type: graphql.String},
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int},
+		"sort":   &graphql.ArgumentConfig{Type: graphql.String},
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return domain.UserFromContext(p.Context), nil
+				},
+			},
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.resolve(s.getUser),
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(s.userPageType),
+				Args: withArgs(pageArgs, graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+				}),
+				Resolve: s.resolve(s.getUsers),
+			},
+			"chat": &graphql.Field{
+				Type: graphql.NewNonNull(s.chatType),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.resolve(s.getChat),
+			},
+			"chats": &graphql.Field{
+				Type: graphql.NewNonNull(s.chatPageType),
+				Args: withArgs(pageArgs, graphql.FieldConfigArgument{
+					"ids":         &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+					"types":       &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+					"isArchived":  &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"isPinned":    &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"isMuted":     &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"pinnedFirst": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"folderId":    &graphql.ArgumentConfig{Type: graphql.ID},
+				}),
+				Resolve: s.resolve(s.getChats),
+			},
+			"messages": &graphql.Field{
+				Type: graphql.NewNonNull(s.messagePageType),
+				Args: withArgs(pageArgs, graphql.FieldConfigArgument{
+					"chatId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				}),
+				Resolve: s.resolve(s.getMessages),
+			},
+		},
+	})
+}
+
+func (s *Schema) mutationType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createChat": &graphql.Field{
+				Type: graphql.NewNonNull(s.chatType),
+				Args: graphql.FieldConfigArgument{
+					"name":    &graphql.ArgumentConfig{Type: graphql.String},
+					"type":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"userIds": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+				},
+				Resolve: s.resolve(s.createChat),
+			},
+			"addChatMember": &graphql.Field{
+				Type: graphql.NewNonNull(s.chatType),
+				Args: graphql.FieldConfigArgument{
+					"chatId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.resolve(s.addChatMember),
+			},
+			"createMessage": &graphql.Field{
+				Type: graphql.NewNonNull(s.messageType),
+				Args: graphql.FieldConfigArgument{
+					"chatId":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"text":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"expiresIn":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"isViewOnce": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: s.resolve(s.createMessage),
+			},
+		},
+	})
+}
+
+func (s *Schema) subscriptionType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"messageCreated": &graphql.Field{
+				Type:        s.messageType,
+				Description: "New messages of the chats, chats the user isn't a member of are left out.",
+				Args: graphql.FieldConfigArgument{
+					"chatIds": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID)))},
+				},
+				Subscribe: func(p graphql.ResolveParams) (any, error) {
+					// the executor reports subscribe errors without their
+					// original error, so the extensions are kept here.
+					result, err := s.resolve(s.subscribeMessageCreated)(p)
+					if gqlErr, ok := err.(*graphQLError); ok {
+						return nil, gqlerrors.FormattedError{Message: gqlErr.message, Extensions: gqlErr.extensions}
+					}
+
+					return result, err
+				},
+				Resolve: s.resolve(s.getCreatedMessage),
+			},
+		},
+	})
+}
+
+func withArgs(args ...graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	result := graphql.FieldConfigArgument{}
+	for _, arg := range args {
+		for name, config := range arg {
+			result[name] = config
+		}
+	}
+
+	return result
+}
+
+func (s *Schema) getUser(p graphql.ResolveParams) (any, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return userLoaderFromContext(p.Context).Load(id), nil
+}
+
+func (s *Schema) getUsers(p graphql.ResolveParams) (any, error) {
+	ids, err := idsArg(p.Args, "ids")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := optionalUintArg(p.Args, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := optionalUintArg(p.Args, "offset")
+	if err != nil {
+		return nil, err
+	}
+
+	users, count, err := s.userServiceContract.GetUsers(p.Context, &domain.UserFilter{
+		IDs:    ids,
+		Search: stringArg(p.Args, "search"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(users, count), nil
+}
+
+func (s *Schema) getChat(p graphql.ResolveParams) (any, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.chatService.GetChat(p.Context, id)
+}
+
+func (s *Schema) getChats(p graphql.ResolveParams) (any, error) {
+	query := chathttp.ChatQuery{
+		Search:     stringArg(p.Args, "search"),
+		IsArchived: optionalBoolArg(p.Args, "isArchived"),
+		IsPinned:   optionalBoolArg(p.Args, "isPinned"),
+		IsMuted:    optionalBoolArg(p.Args, "isMuted"),
+		Sort:       stringArg(p.Args, "sort"),
+	}
+
+	if pinnedFirst := optionalBoolArg(p.Args, "pinnedFirst"); pinnedFirst != nil {
+		query.PinnedFirst = *pinnedFirst
+	}
+
+	var err error
+
+	if query.IDs, err = idsArg(p.Args, "ids"); err != nil {
+		return nil, err
+	}
+
+	if query.Types, err = uint8sArg(p.Args, "types"); err != nil {
+		return nil, err
+	}
+
+	if query.FolderID, err = optionalIDArg(p.Args, "folderId"); err != nil {
+		return nil, err
+	}
+
+	if query.Limit, err = optionalUintArg(p.Args, "limit"); err != nil {
+		return nil, err
+	}
+
+	if query.Offset, err = optionalUintArg(p.Args, "offset"); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return nil, err
+	}
+
+	chatFilter, err := chathttp.ChatFilterFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, count, err := s.chatService.GetChats(p.Context, &chatFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(chats, count), nil
+}
+
+func (s *Schema) getMessages(p graphql.ResolveParams) (any, error) {
+	chatID, err := idArg(p.Args, "chatId")
+	if err != nil {
+		return nil, err
+	}
+
+	query := chathttp.MessageQuery{
+		Search: stringArg(p.Args, "search"),
+		Sort:   stringArg(p.Args, "sort"),
+	}
+
+	if query.Limit, err = optionalUintArg(p.Args, "limit"); err != nil {
+		return nil, err
+	}
+
+	if query.Offset, err = optionalUintArg(p.Args, "offset"); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return nil, err
+	}
+
+	messageFilter, err := chathttp.MessageFilterFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	messageFilter.ChatIDs = []uint64{chatID}
+
+	messages, count, err := s.messageService.GetMessages(p.Context, &messageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(messages, count), nil
+}
+
+func (s *Schema) createChat(p graphql.ResolveParams) (any, error) {
+	userIDs, err := idsArg(p.Args, "userIds")
+	if err != nil {
+		return nil, err
+	}
+
+	chatType, _ := p.Args["type"].(int)
+
+	dto := chathttp.CreateChatDto{
+		Name: stringArg(p.Args, "name"),
+		Type: uint8(min(max(chatType, 0), 255)),
+		UserChats: lo.Map(userIDs, func(userID uint64, _ int) chathttp.UserChatDto {
+			return chathttp.UserChatDto{UserID: userID}
+		}),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat, err := chathttp.ChatFromCreateDto(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.chatService.CreateChat(p.Context, *chat)
+}
+
+func (s *Schema) addChatMember(p graphql.ResolveParams) (any, error) {
+	chatID, err := idArg(p.Args, "chatId")
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := idArg(p.Args, "userId")
+	if err != nil {
+		return nil, err
+	}
+
+	dto := chathttp.AddChatMemberDto{
+		UserID: userID,
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	return s.chatService.AddChatMember(p.Context, chatID, dto.UserID)
+}
+
+func (s *Schema) createMessage(p graphql.ResolveParams) (any, error) {
+	user := domain.UserFromContext(p.Context)
+
+	chatID, err := idArg(p.Args, "chatId")
+	if err != nil {
+		return nil, err
+	}
+
+	expiresIn, err := optionalUintArg(p.Args, "expiresIn")
+	if err != nil {
+		return nil, err
+	}
+
+	dto := chathttp.CreateMessageDto{
+		Text: stringArg(p.Args, "text"),
+	}
+
+	if expiresIn != nil {
+		dto.ExpiresIn = lo.ToPtr(uint(*expiresIn))
+	}
+
+	if isViewOnce := optionalBoolArg(p.Args, "isViewOnce"); isViewOnce != nil {
+		dto.IsViewOnce = *isViewOnce
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	message := chathttp.MessageFromCreateDto(dto)
+	message.ChatID = chatID
+	message.CreatedBy = user.ID
+
+	createdMessage, err := s.messageService.CreateMessage(p.Context, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdMessage == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return createdMessage, nil
+}
+
+// subscribeMessageCreated follows the chats of the request through the
+// websocket connection, which gets the new messages from the connector. The
+// user must be a member of every chat.
+func (s *Schema) subscribeMessageCreated(p graphql.ResolveParams) (any, error) {
+	connection := connectionFromContext(p.Context)
+	if connection == nil {
+		return nil, errors.NewBadRequestError(
+			constants.ChatDomain, fmt.Errorf("subscriptions are served over the websocket"), nil)
+	}
+
+	chatIDs, err := idsArg(p.Args, "chatIds")
+	if err != nil {
+		return nil, err
+	}
+
+	chatIDs = lo.Uniq(chatIDs)
+	if len(chatIDs) == 0 {
+		return nil, errors.NewBadRequestError(
+			constants.ChatDomain, fmt.Errorf("chatIds must not be empty"), nil)
+	}
+
+	if err := s.chatService.CheckMembership(p.Context, chatIDs); err != nil {
+		return nil, err
+	}
+
+	return connection.subscribe(p.Context, chatIDs), nil
+}
+
+// getCreatedMessage loads a message of the subscription, the message is
+// gone if it was deleted or has expired in the meantime. It's resolved
+// first for every event, so the users of the event get a new loader.
+func (s *Schema) getCreatedMessage(p graphql.ResolveParams) (any, error) {
+	messageID, ok := p.Source.(uint64)
+	if !ok {
+		return nil, nil
+	}
+
+	renewUserLoader(p.Context)
+
+	messages, _, err := s.messageService.GetMessages(p.Context, &chatdomain.MessageFilter{
+		IDs: []uint64{messageID},
+	})
+	if err != nil || len(messages) == 0 {
+		return nil, err
+	}
+
+	return &messages[0], nil
+}
+
+func NewSchema(
+	cfg *configs.Config,
+	log logger.Logger,
+	validate validator.Validate,
+	chatService ChatService,
+	messageService MessageService,
+	userServiceContract chatdomain.UserServiceContract,
+) (*Schema, error) {
+	s := &Schema{
+		cfg:                 cfg,
+		log:                 log,
+		validate:            validate,
+		chatService:         chatService,
+		messageService:      messageService,
+		userServiceContract: userServiceContract,
+	}
+
+	s.newTypes()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:        s.queryType(),
+		Mutation:     s.mutationType(),
+		Subscription: s.subscriptionType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.schema = schema
+
+	return s, nil
+}
@@ -0,0 +1,109 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+// stubChatService makes the user a member of memberChatIDs only.
+type stubChatService struct {
+	ChatService
+
+	memberChatIDs map[uint64]bool
+}
+
+func (s *stubChatService) CheckMembership(_ context.Context, chatIDs []uint64) error {
+	for _, id := range chatIDs {
+		if !s.memberChatIDs[id] {
+			return commonerrors.NewForbiddenError()
+		}
+	}
+
+	return nil
+}
+
+func TestSubscribeMessageCreated(t *testing.T) {
+	tests := []struct {
+		name        string
+		chatIDs     []any
+		wantChatIDs []uint64
+		forbidden   bool
+		badRequest  bool
+	}{
+		{
+			name:        "member chats",
+			chatIDs:     []any{"1", "1"},
+			wantChatIDs: []uint64{1},
+		},
+		{
+			name:      "chat of another user",
+			chatIDs:   []any{"2"},
+			forbidden: true,
+		},
+		{
+			name:      "member of some chats",
+			chatIDs:   []any{"1", "2"},
+			forbidden: true,
+		},
+		{
+			name:       "no chats",
+			chatIDs:    []any{},
+			badRequest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schema{chatService: &stubChatService{memberChatIDs: map[uint64]bool{1: true}}}
+			connection := newWebsocketConnection(nil, 1)
+
+			ctx, cancel := context.WithCancel(contextWithConnection(context.Background(), connection))
+			defer cancel()
+
+			_, err := s.subscribeMessageCreated(graphql.ResolveParams{
+				Context: ctx,
+				Args:    map[string]any{"chatIds": tt.chatIDs},
+			})
+
+			var forbiddenErr *commonerrors.ForbiddenError
+			var badRequestErr *commonerrors.BadRequestError
+			if errors.As(err, &forbiddenErr) != tt.forbidden || errors.As(err, &badRequestErr) != tt.badRequest ||
+				(err != nil) != (tt.forbidden || tt.badRequest) {
+				t.Fatalf("subscribeMessageCreated() error = %v", err)
+			}
+
+			connection.subscriptionsMtx.Lock()
+			defer connection.subscriptionsMtx.Unlock()
+
+			if tt.wantChatIDs == nil {
+				if len(connection.subscriptions) != 0 {
+					t.Fatalf("subscribed to %v", connection.subscriptions[0].chatIDs)
+				}
+				return
+			}
+
+			if len(connection.subscriptions) != 1 || len(connection.subscriptions[0].chatIDs) != len(tt.wantChatIDs) {
+				t.Fatalf("subscriptions = %+v, want chats %v", connection.subscriptions, tt.wantChatIDs)
+			}
+		})
+	}
+}
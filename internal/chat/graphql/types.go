@@ -0,0 +1,217 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+var imageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Image",
+	Fields: graphql.Fields{
+		"url":    &graphql.Field{Type: graphql.String},
+		"base64": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"email":     &graphql.Field{Type: graphql.String},
+		"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"firstName": &graphql.Field{Type: graphql.String},
+		"lastName":  &graphql.Field{Type: graphql.String},
+		"aboutMe":   &graphql.Field{Type: graphql.String},
+		"image":     &graphql.Field{Type: graphql.NewNonNull(imageType)},
+		"isBot":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+	},
+})
+
+var messageEntityType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "MessageEntity",
+	Fields: graphql.Fields{
+		"type": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return int(p.Source.(*domain.MessageEntity).Type), nil
+			},
+		},
+		"offset": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"length": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"userId": &graphql.Field{
+			Type: graphql.ID,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				if userID := p.Source.(*domain.MessageEntity).UserID; userID != nil {
+					return *userID, nil
+				}
+
+				return nil, nil
+			},
+		},
+		"language": &graphql.Field{Type: graphql.String},
+	},
+})
+
+// settingsFields are shared by the members of a chat and the settings of the
+// current user.
+func settingsFields() graphql.Fields {
+	return graphql.Fields{
+		"mutedUntil": &graphql.Field{Type: graphql.DateTime},
+		"isArchived": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"pinOrder":   &graphql.Field{Type: graphql.Int},
+		"notificationLevel": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return int(p.Source.(*domain.UserChat).NotificationLevel), nil
+			},
+		},
+	}
+}
+
+var chatSettingsType = graphql.NewObject(graphql.ObjectConfig{
+	Name:   "ChatSettings",
+	Fields: settingsFields(),
+})
+
+// newTypes builds the types whose fields resolve users through the loader.
+func (s *Schema) newTypes() {
+	memberFields := settingsFields()
+	memberFields["userId"] = &graphql.Field{Type: graphql.NewNonNull(graphql.ID)}
+	memberFields["user"] = &graphql.Field{
+		Type: userType,
+		Resolve: s.resolve(func(p graphql.ResolveParams) (any, error) {
+			return userLoaderFromContext(p.Context).Load(p.Source.(*domain.UserChat).UserID), nil
+		}),
+	}
+
+	memberType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Member",
+		Fields: memberFields,
+	})
+
+	s.messageType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Message",
+		Fields: graphql.Fields{
+			"id":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"chatId": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"text":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return int(p.Source.(*domain.Message).Status), nil
+				},
+			},
+			"kind": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return int(p.Source.(*domain.Message).Kind), nil
+				},
+			},
+			"entities": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(messageEntityType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return lo.ToSlicePtr(p.Source.(*domain.Message).Entities), nil
+				},
+			},
+			"createdBy": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"creator": &graphql.Field{
+				Type: userType,
+				Resolve: s.resolve(func(p graphql.ResolveParams) (any, error) {
+					return userLoaderFromContext(p.Context).Load(p.Source.(*domain.Message).CreatedBy), nil
+				}),
+			},
+			"isViewOnce": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"expiresAt":  &graphql.Field{Type: graphql.DateTime},
+			"createdAt":  &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":  &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	s.chatType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Chat",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return int(p.Source.(*domain.Chat).Type), nil
+				},
+			},
+			"image":     &graphql.Field{Type: graphql.NewNonNull(imageType)},
+			"createdBy": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"creator": &graphql.Field{
+				Type: userType,
+				Resolve: s.resolve(func(p graphql.ResolveParams) (any, error) {
+					return userLoaderFromContext(p.Context).Load(p.Source.(*domain.Chat).CreatedBy), nil
+				}),
+			},
+			"members": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(memberType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return lo.ToSlicePtr(p.Source.(*domain.Chat).UserChats), nil
+				},
+			},
+			"lastMessage": &graphql.Field{
+				Type: s.messageType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if lastMessage := p.Source.(*domain.Chat).LastMessage; lastMessage != nil {
+						return lastMessage, nil
+					}
+
+					return nil, nil
+				},
+			},
+			"settings": &graphql.Field{
+				Type:        chatSettingsType,
+				Description: "The preferences of the current user, null when the user isn't a member.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if settings := p.Source.(*domain.Chat).Settings; settings != nil {
+						return settings, nil
+					}
+
+					return nil, nil
+				},
+			},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	s.userPageType = newPageType("UserPage", userType)
+	s.chatPageType = newPageType("ChatPage", s.chatType)
+	s.messagePageType = newPageType("MessagePage", s.messageType)
+}
+
+func newPageType(name string, itemType graphql.Type) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType)))},
+			"total": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+}
+
+func newPage[T any](items []T, total uint64) map[string]any {
+	return map[string]any{
+		"items": lo.ToSlicePtr(items),
+		"total": total,
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/samber/lo"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+const userLoaderContextKey = "userLoader"
+
+// userLoader batches the user lookups of a request. Resolvers only queue
+// the user IDs and return a thunk, the executor calls the thunks once a
+// level of the query is resolved, so the first one fetches every queued user
+// with a single request to the user service.
+type userLoader struct {
+	ctx                 context.Context
+	userServiceContract chatdomain.UserServiceContract
+
+	mtx     sync.Mutex
+	pending []uint64
+	users   map[uint64]*domain.User
+	errs    map[uint64]error
+}
+
+func (l *userLoader) Load(id uint64) func() (any, error) {
+	l.mtx.Lock()
+	if _, ok := l.users[id]; !ok {
+		l.pending = append(l.pending, id)
+	}
+	l.mtx.Unlock()
+
+	return func() (any, error) {
+		user, err := l.get(id)
+		if err != nil || user == nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+}
+
+func (l *userLoader) get(id uint64) (*domain.User, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if len(l.pending) > 0 {
+		l.fetch(lo.Uniq(l.pending))
+		l.pending = nil
+	}
+
+	return l.users[id], l.errs[id]
+}
+
+func (l *userLoader) fetch(ids []uint64) {
+	users, _, err := l.userServiceContract.GetUsers(l.ctx, &domain.UserFilter{
+		IDs: ids,
+	})
+	if errors.IsServiceUnavailable(err) {
+		// users resolve to null while the user service is down, as they
+		// are left out of REST responses.
+		err = nil
+	}
+
+	for _, id := range ids {
+		l.users[id] = nil
+		if err != nil {
+			l.errs[id] = err
+		}
+	}
+
+	for index := range users {
+		l.users[users[index].ID] = &users[index]
+	}
+}
+
+// userLoaderRef holds the loader of a request. Subscriptions start a new
+// loader for every event, users aren't kept for the lifetime of the
+// subscription and are fetched again once they changed.
+type userLoaderRef struct {
+	loader atomic.Pointer[userLoader]
+}
+
+func newUserLoader(ctx context.Context, userServiceContract chatdomain.UserServiceContract) *userLoader {
+	return &userLoader{
+		ctx:                 ctx,
+		userServiceContract: userServiceContract,
+		users:               make(map[uint64]*domain.User),
+		errs:                make(map[uint64]error),
+	}
+}
+
+func contextWithUserLoader(ctx context.Context, loader *userLoader) context.Context {
+	ref := &userLoaderRef{}
+	ref.loader.Store(loader)
+
+	return context.WithValue(ctx, userLoaderContextKey, ref) //nolint:staticcheck // see userContextKey
+}
+
+func userLoaderFromContext(ctx context.Context) *userLoader {
+	return ctx.Value(userLoaderContextKey).(*userLoaderRef).loader.Load()
+}
+
+// renewUserLoader replaces the loader of the request with a new one, the
+// events of a subscription are resolved one after another, each with its
+// own loader.
+func renewUserLoader(ctx context.Context) {
+	ref := ctx.Value(userLoaderContextKey).(*userLoaderRef)
+	loader := ref.loader.Load()
+
+	ref.loader.Store(newUserLoader(loader.ctx, loader.userServiceContract))
+}
@@ -0,0 +1,327 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+)
+
+const (
+	connectionContextKey = "graphqlConnection"
+
+	// subscriptionBufferSize is the number of events a subscription may
+	// fall behind, events beyond are dropped.
+	subscriptionBufferSize = 64
+
+	closeWriteTimeout = time.Second
+)
+
+var (
+	errOperationExists   = errors.New("operation already exists")
+	errTooManyOperations = errors.New("too many operations")
+)
+
+type subscription struct {
+	chatIDs []uint64
+	events  chan any
+}
+
+// websocketConnection is a GraphQL websocket registered with the connector.
+// The EventSink sees it as a chat connection following the chats of its
+// subscriptions, the events are passed on to the subscriptions.
+type websocketConnection struct {
+	connectionID string
+
+	conn      *websocket.Conn
+	connector connector.Connector
+
+	credentialsMtx sync.RWMutex
+	user           *domain.User
+	token          string
+
+	// writeMtx serializes writes, results are sent from the goroutines of
+	// the operations.
+	writeMtx sync.Mutex
+
+	subscriptionsMtx sync.RWMutex
+	subscriptions    []*subscription
+
+	operationsMtx sync.Mutex
+	operations    map[string]context.CancelFunc
+	maxOperations int
+
+	messageChan chan []byte
+	closeChan   chan struct{}
+	closeOnce   sync.Once
+	isClosed    atomic.Bool
+}
+
+func (c *websocketConnection) IsClosed() bool {
+	return c.isClosed.Load()
+}
+
+func (c *websocketConnection) GetConnectionID() string {
+	return c.connectionID
+}
+
+func (c *websocketConnection) GetConnector() connector.Connector {
+	return c.connector
+}
+
+func (c *websocketConnection) SetConnector(connector connector.Connector) {
+	c.connector = connector
+}
+
+// GetMessageChan stays empty, the GraphQL messages are read by the
+// controller rather than the connector.
+func (c *websocketConnection) GetMessageChan() chan []byte {
+	return c.messageChan
+}
+
+func (c *websocketConnection) GetCloseChan() chan struct{} {
+	return c.closeChan
+}
+
+// SendEvent passes new messages on to the subscriptions following their
+// chat, other events aren't part of the schema yet.
+func (c *websocketConnection) SendEvent(eventType uint64, data any) error {
+	message, ok := data.(chatwebsocket.MessageDto)
+	if eventType != chatwebsocket.CreateMessageEventType || !ok {
+		return nil
+	}
+
+	c.subscriptionsMtx.RLock()
+	defer c.subscriptionsMtx.RUnlock()
+
+	isDropped := false
+
+	for _, sub := range c.subscriptions {
+		if !slices.Contains(sub.chatIDs, message.ChatID) {
+			continue
+		}
+
+		select {
+		case sub.events <- message.ID:
+		default:
+			isDropped = true
+		}
+	}
+
+	if isDropped {
+		return fmt.Errorf("subscription buffer is full, message_id=%d dropped", message.ID)
+	}
+
+	return nil
+}
+
+// SendReply passes the event on like SendEvent, GraphQL operations are
+// answered by the controller.
+func (c *websocketConnection) SendReply(_ string, eventType uint64, data any) error {
+	return c.SendEvent(eventType, data)
+}
+
+func (c *websocketConnection) Connect() {}
+
+func (c *websocketConnection) Close() {
+	c.closeOnce.Do(func() {
+		c.isClosed.Store(true)
+		close(c.closeChan)
+		_ = c.conn.Close()
+	})
+}
+
+func (c *websocketConnection) CloseWithReason(code int, reason string) {
+	if c.IsClosed() {
+		return
+	}
+
+	c.writeMtx.Lock()
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(closeWriteTimeout))
+	c.writeMtx.Unlock()
+
+	c.Close()
+}
+
+func (c *websocketConnection) GetUser() *domain.User {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
+	return c.user
+}
+
+func (c *websocketConnection) GetToken() string {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
+	return c.token
+}
+
+func (c *websocketConnection) SetCredentials(user *domain.User, token string) {
+	c.credentialsMtx.Lock()
+	defer c.credentialsMtx.Unlock()
+
+	c.user = user
+	c.token = token
+}
+
+func (c *websocketConnection) GetSubscribedChats() []uint64 {
+	c.subscriptionsMtx.RLock()
+	defer c.subscriptionsMtx.RUnlock()
+
+	return lo.Uniq(lo.FlatMap(c.subscriptions, func(sub *subscription, _ int) []uint64 {
+		return sub.chatIDs
+	}))
+}
+
+// SetSubscribedChats is a no-op, the chats are followed by subscription
+// operations.
+func (c *websocketConnection) SetSubscribedChats(_ []uint64) {}
+
+func (c *websocketConnection) IsSubscribed(chatID uint64) bool {
+	return slices.Contains(c.GetSubscribedChats(), chatID)
+}
+
+func (c *websocketConnection) GetCurrentChat() *uint64 {
+	return nil
+}
+
+func (c *websocketConnection) SetCurrentChat(_ *uint64) {}
+
+func (c *websocketConnection) IsCurrentChat(_ uint64) bool {
+	return false
+}
+
+func (c *websocketConnection) send(message wsMessageDto) error {
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+
+	return c.conn.WriteJSON(message)
+}
+
+func (c *websocketConnection) sendPayload(id string, messageType string, payload any) error {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return c.send(wsMessageDto{
+		ID:      id,
+		Type:    messageType,
+		Payload: rawPayload,
+	})
+}
+
+// subscribe follows the chats until ctx is done, the channel gets the IDs of
+// new messages.
+func (c *websocketConnection) subscribe(ctx context.Context, chatIDs []uint64) chan any {
+	sub := &subscription{
+		chatIDs: chatIDs,
+		events:  make(chan any, subscriptionBufferSize),
+	}
+
+	c.subscriptionsMtx.Lock()
+	c.subscriptions = append(c.subscriptions, sub)
+	c.subscriptionsMtx.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.closeChan:
+		}
+
+		c.subscriptionsMtx.Lock()
+		defer c.subscriptionsMtx.Unlock()
+
+		c.subscriptions = lo.Without(c.subscriptions, sub)
+		close(sub.events)
+	}()
+
+	return sub.events
+}
+
+// startOperation returns the context of a new operation. It fails if an
+// operation with the ID is already running or maxOperations are running.
+func (c *websocketConnection) startOperation(id string) (context.Context, error) {
+	c.operationsMtx.Lock()
+	defer c.operationsMtx.Unlock()
+
+	if _, ok := c.operations[id]; ok {
+		return nil, errOperationExists
+	}
+
+	if len(c.operations) >= c.maxOperations {
+		return nil, errTooManyOperations
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.operations[id] = cancel
+
+	return ctx, nil
+}
+
+func (c *websocketConnection) stopOperation(id string) {
+	c.operationsMtx.Lock()
+	defer c.operationsMtx.Unlock()
+
+	if cancel, ok := c.operations[id]; ok {
+		cancel()
+		delete(c.operations, id)
+	}
+}
+
+func (c *websocketConnection) stopOperations() {
+	c.operationsMtx.Lock()
+	defer c.operationsMtx.Unlock()
+
+	for id, cancel := range c.operations {
+		cancel()
+		delete(c.operations, id)
+	}
+}
+
+func newWebsocketConnection(conn *websocket.Conn, maxOperations int) *websocketConnection {
+	return &websocketConnection{
+		connectionID:  uuid.NewString(),
+		conn:          conn,
+		operations:    make(map[string]context.CancelFunc),
+		maxOperations: maxOperations,
+		messageChan:   make(chan []byte),
+		closeChan:     make(chan struct{}),
+	}
+}
+
+func contextWithConnection(ctx context.Context, connection *websocketConnection) context.Context {
+	return context.WithValue(ctx, connectionContextKey, connection) //nolint:staticcheck // see userContextKey
+}
+
+func connectionFromContext(ctx context.Context) *websocketConnection {
+	connection, _ := ctx.Value(connectionContextKey).(*websocketConnection)
+	return connection
+}
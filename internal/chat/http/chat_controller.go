@@ -18,35 +18,48 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/samber/lo"
 
-	"chat-go/internal/chat/constants"
-	chatdomain "chat-go/internal/chat/domain"
-	chatwebsocket "chat-go/internal/chat/websocket"
-	"chat-go/internal/common/domain"
-	"chat-go/internal/common/errors"
-	commonhttp "chat-go/internal/common/http"
-	"chat-go/internal/infrastructure/api"
-	"chat-go/internal/infrastructure/connector"
-	"chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
 )
 
 type ChatController struct {
+	cfg            *configs.Config
 	validate       validator.Validate
 	authMiddleware api.Middleware
 	chatService    ChatService
 	messageService MessageService
 	connector      connector.Connector
+	authenticator  connector.Authenticator
 }
 
 func (c *ChatController) SetupRoutes(r fiber.Router) {
+	// registered ahead of the group so websocket clients may authenticate
+	// after the upgrade, see wsHandshake.
+	r.Get("/chats/ws", c.wsHandshake)
+
 	chatGroup := r.Group("/chats", c.authMiddleware.Handler)
 	chatGroup.Get("", c.getChats)
 	chatGroup.Get("/ws", c.ws)
+	chatGroup.Get("/mentions", c.getMentions)
 	chatGroup.Get("/:id", c.getChat)
 	chatGroup.Get("/:id/messages", c.getChatMessages)
+	chatGroup.Post("/:id/messages", c.createMessage)
+	chatGroup.Post("/:id/messages/forward", c.forwardMessages)
+	chatGroup.Post("/:id/members", c.addMember)
+	chatGroup.Put("/:id/members/:userId", c.updateMember)
+	chatGroup.Put("/:id/settings", c.updateSettings)
+	chatGroup.Put("/:id/retention", c.updateRetention)
+	chatGroup.Put("/:id/expiry", c.updateExpiry)
 	chatGroup.Put("/:id", c.update)
 	chatGroup.Post("", c.create)
 	chatGroup.Delete("/:id", c.delete)
@@ -135,6 +148,240 @@ func (c *ChatController) getChatMessages(ctx *fiber.Ctx) error {
 	))
 }
 
+func (c *ChatController) createMessage(ctx *fiber.Ctx) error {
+	user := domain.UserFromContext(ctx.Context())
+
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := CreateMessageDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	message := MessageFromCreateDto(dto)
+	message.ChatID = id
+	message.CreatedBy = user.ID
+
+	createdMessage, err := c.messageService.CreateMessage(ctx.Context(), message)
+	if err != nil {
+		return err
+	}
+
+	if createdMessage == nil {
+		return errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return ctx.JSON(MessageToDto(*createdMessage))
+}
+
+func (c *ChatController) forwardMessages(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := ForwardMessagesDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	messages, err := c.messageService.ForwardMessages(ctx.Context(), id, dto.MessageIDs, dto.ChatIDs)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(lo.Map(messages, func(message chatdomain.Message, _ int) MessageDto {
+		return MessageToDto(message)
+	}))
+}
+
+func (c *ChatController) addMember(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := AddChatMemberDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	chat, err := c.chatService.AddChatMember(ctx.Context(), id, dto.UserID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ChatToDto(*chat))
+}
+
+func (c *ChatController) updateMember(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	userIDStr := ctx.Params("userId")
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"userId": userIDStr})
+	}
+
+	dto := UpdateChatMemberDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	chat, err := c.chatService.UpdateChatMemberRole(ctx.Context(), id, userID, chatdomain.MemberRole(dto.Role))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ChatToDto(*chat))
+}
+
+func (c *ChatController) updateSettings(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := UpdateChatSettingsDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	settings := ChatSettingsFromUpdateDto(dto)
+	settings.ChatID = id
+
+	chat, err := c.chatService.UpdateChatSettings(ctx.Context(), settings)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ChatToDto(*chat))
+}
+
+func (c *ChatController) updateRetention(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := RetentionDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	chat, err := c.chatService.UpdateChatRetention(ctx.Context(), id, RetentionFromDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ChatToDto(*chat))
+}
+
+func (c *ChatController) updateExpiry(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := MessageExpiryDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	chat, err := c.chatService.UpdateChatExpiry(ctx.Context(), id, MessageExpiryFromDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ChatToDto(*chat))
+}
+
+func (c *ChatController) getMentions(ctx *fiber.Ctx) error {
+	user := domain.UserFromContext(ctx.Context())
+
+	var query MessageQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return errors.NewValidationError(constants.ChatDomain, err, nil)
+	}
+
+	messageFilter, err := MessageFilterFromQuery(query)
+	if err != nil {
+		return err
+	}
+
+	messageFilter.MentionedUserIDs = []uint64{user.ID}
+
+	if messageFilter.Sort == nil {
+		messageFilter.Sort = &domain.Sort{SortBy: "createdAt", SortDir: domain.Desc}
+	}
+
+	messages, count, err := c.messageService.GetMessages(ctx.Context(), &messageFilter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(messages, func(message chatdomain.Message, _ int) MessageDto {
+			return MessageToDto(message)
+		}),
+		count,
+	))
+}
+
 func (c *ChatController) update(ctx *fiber.Ctx) error {
 	idStr := ctx.Params("id")
 
@@ -202,27 +449,22 @@ func (c *ChatController) delete(ctx *fiber.Ctx) error {
 	return ctx.SendStatus(http.StatusOK)
 }
 
-func (c *ChatController) ws(ctx *fiber.Ctx) error {
-	user := domain.UserFromContext(ctx.Context())
-	return websocket.New(func(conn *websocket.Conn) {
-		connection := chatwebsocket.NewConnection(conn.Conn, user)
-		c.connector.AddConnection(connection)
-		<-connection.GetCloseChan()
-	})(ctx)
-}
-
 func NewChatController(
+	cfg *configs.Config,
 	validate validator.Validate,
 	authMiddleware api.Middleware,
 	chatService ChatService,
 	messageService MessageService,
 	connector connector.Connector,
+	authenticator connector.Authenticator,
 ) *ChatController {
 	return &ChatController{
+		cfg:            cfg,
 		validate:       validate,
 		authMiddleware: authMiddleware,
 		chatService:    chatService,
 		messageService: messageService,
 		connector:      connector,
+		authenticator:  authenticator,
 	}
 }
@@ -15,33 +15,17 @@
 package http
 
 import (
-	"time"
-
-	"chat-go/internal/common/domain"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
-type CreateChatDto struct {
-	Name      string        `json:"name" validate:"lte=255"`
-	Type      uint8         `json:"type" validate:"required,oneof=1 2"`
-	Image     domain.Image  `json:"image"`
-	UserChats []UserChatDto `json:"users" validate:"dive,gte=0"`
-}
-
-type UpdateChatDto struct {
-	Name  string       `json:"name" validate:"lte=255"`
-	Image domain.Image `json:"image"`
-}
-
-type ChatDto struct {
-	ID          uint64        `json:"id"`
-	Name        string        `json:"name"`
-	Type        uint8         `json:"type"`
-	Image       domain.Image  `json:"image"`
-	LastMessage *MessageDto   `json:"lastMessage"`
-	CreatedBy   uint64        `json:"createdBy"`
-	Creator     *http.UserDto `json:"creator"`
-	UserChats   []UserChatDto `json:"userChats"`
-	CreatedAt   time.Time     `json:"createdAt"`
-	UpdatedAt   time.Time     `json:"updatedAt"`
-}
+type (
+	CreateChatDto         = chatapi.CreateChatDto
+	UpdateChatDto         = chatapi.UpdateChatDto
+	UpdateChatSettingsDto = chatapi.UpdateChatSettingsDto
+	ChatSettingsDto       = chatapi.ChatSettingsDto
+	RetentionDto          = chatapi.RetentionDto
+	MessageExpiryDto      = chatapi.MessageExpiryDto
+	ChatDto               = chatapi.ChatDto
+	AddChatMemberDto      = chatapi.AddChatMemberDto
+	UpdateChatMemberDto   = chatapi.UpdateChatMemberDto
+)
@@ -15,10 +15,10 @@
 package http
 
 import (
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/http"
 )
 
 var chatSortFields = []string{
@@ -28,6 +28,8 @@ var chatSortFields = []string{
 	"createdBy",
 	"createdAt",
 	"updatedAt",
+	"pinOrder",
+	"lastMessageAt",
 }
 
 func ChatFilterFromQuery(query ChatQuery) (domain.ChatFilter, error) {
@@ -42,6 +44,11 @@ func ChatFilterFromQuery(query ChatQuery) (domain.ChatFilter, error) {
 		Types:        query.Types,
 		CreatedByIDs: query.CreatedByIDs,
 		Search:       query.Search,
+		IsArchived:   query.IsArchived,
+		IsPinned:     query.IsPinned,
+		IsMuted:      query.IsMuted,
+		PinnedFirst:  query.PinnedFirst,
+		FolderID:     query.FolderID,
 		Limit:        query.Limit,
 		Offset:       query.Offset,
 		Sort:         sort,
@@ -17,8 +17,10 @@ package http
 import (
 	"github.com/samber/lo"
 
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
 func ChatFromCreateDto(dto CreateChatDto) (*domain.Chat, error) {
@@ -30,7 +32,7 @@ func ChatFromCreateDto(dto CreateChatDto) (*domain.Chat, error) {
 	return &domain.Chat{
 		Name:  dto.Name,
 		Type:  chatType,
-		Image: dto.Image,
+		Image: commondomain.Image(dto.Image),
 		UserChats: lo.Map(dto.UserChats, func(userChat UserChatDto, _ int) domain.UserChat {
 			return domain.UserChat{
 				UserID: userChat.UserID,
@@ -43,7 +45,7 @@ func ChatFromCreateDto(dto CreateChatDto) (*domain.Chat, error) {
 func ChatFromUpdateDto(dto UpdateChatDto) domain.Chat {
 	return domain.Chat{
 		Name:  dto.Name,
-		Image: dto.Image,
+		Image: commondomain.Image(dto.Image),
 	}
 }
 
@@ -59,18 +61,79 @@ func ChatToDto(chat domain.Chat) ChatDto {
 		creator = lo.ToPtr(http.UserToDto(*chat.Creator))
 	}
 
+	var settings *ChatSettingsDto
+	if chat.Settings != nil {
+		settings = lo.ToPtr(ChatSettingsToDto(*chat.Settings))
+	}
+
 	return ChatDto{
 		ID:          chat.ID,
 		Name:        chat.Name,
 		Type:        chat.Type.Uint8(),
-		Image:       chat.Image,
+		Image:       chatapi.Image(chat.Image),
 		LastMessage: messageDto,
 		CreatedBy:   chat.CreatedBy,
 		Creator:     creator,
 		UserChats: lo.Map(chat.UserChats, func(userChat domain.UserChat, _ int) UserChatDto {
 			return UserChatToDto(userChat)
 		}),
+		Settings:  settings,
+		Retention: RetentionToDto(chat.Retention),
+		Expiry:    MessageExpiryToDto(chat.Expiry),
 		CreatedAt: chat.CreatedAt,
 		UpdatedAt: chat.UpdatedAt,
 	}
 }
+
+func ChatSettingsToDto(userChat domain.UserChat) ChatSettingsDto {
+	return ChatSettingsDto{
+		MutedUntil:        userChat.MutedUntil,
+		IsArchived:        userChat.IsArchived,
+		PinOrder:          userChat.PinOrder,
+		NotificationLevel: userChat.NotificationLevel.Uint8(),
+	}
+}
+
+func ChatSettingsFromUpdateDto(dto UpdateChatSettingsDto) domain.UserChat {
+	return domain.UserChat{
+		MutedUntil:        dto.MutedUntil,
+		IsArchived:        dto.IsArchived,
+		PinOrder:          dto.PinOrder,
+		NotificationLevel: domain.NotificationLevel(dto.NotificationLevel),
+	}
+}
+
+func RetentionToDto(retention domain.RetentionPolicy) RetentionDto {
+	return RetentionDto{
+		Days:        retention.Days,
+		MaxMessages: retention.MaxMessages,
+		LegalHold:   retention.LegalHold,
+	}
+}
+
+func RetentionFromDto(dto RetentionDto) domain.RetentionPolicy {
+	return domain.RetentionPolicy{
+		Days:        dto.Days,
+		MaxMessages: dto.MaxMessages,
+		LegalHold:   dto.LegalHold,
+	}
+}
+
+func MessageExpiryToDto(expiry domain.MessageExpiry) MessageExpiryDto {
+	return MessageExpiryDto{
+		ExpiresIn:  expiry.ExpiresIn,
+		ExpireFrom: expiry.ExpireFrom.Uint8(),
+	}
+}
+
+func MessageExpiryFromDto(dto MessageExpiryDto) domain.MessageExpiry {
+	expireFrom := domain.ExpireFrom(dto.ExpireFrom)
+	if !expireFrom.IsValid() {
+		expireFrom = domain.SentExpireFrom
+	}
+
+	return domain.MessageExpiry{
+		ExpiresIn:  dto.ExpiresIn,
+		ExpireFrom: expireFrom,
+	}
+}
@@ -17,7 +17,7 @@ package http
 import (
 	"context"
 
-	"chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
 )
 
 type ChatService interface {
@@ -26,4 +26,9 @@ type ChatService interface {
 	CreateChat(ctx context.Context, chat domain.Chat) (*domain.Chat, error)
 	UpdateChat(ctx context.Context, chat domain.Chat) (*domain.Chat, error)
 	DeleteChat(ctx context.Context, id uint64) error
+	AddChatMember(ctx context.Context, chatID uint64, userID uint64) (*domain.Chat, error)
+	UpdateChatMemberRole(ctx context.Context, chatID uint64, userID uint64, role domain.MemberRole) (*domain.Chat, error)
+	UpdateChatExpiry(ctx context.Context, chatID uint64, expiry domain.MessageExpiry) (*domain.Chat, error)
+	UpdateChatRetention(ctx context.Context, chatID uint64, retention domain.RetentionPolicy) (*domain.Chat, error)
+	UpdateChatSettings(ctx context.Context, settings domain.UserChat) (*domain.Chat, error)
 }
@@ -0,0 +1,161 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	userhttp "github.com/microcoretech/chat-go/internal/user/http"
+)
+
+const tokenQueryParam = "token"
+
+// wsHandshake lets websocket clients authenticate without putting the token
+// into the URL. A token offered as a subprotocol is moved to the
+// Authorization header and the request goes on through the auth middleware,
+// requests without any credentials are upgraded and have to send an
+// authenticate event as the first frame. The token query param is refused,
+// it would end up in the logs of proxies.
+func (c *ChatController) wsHandshake(ctx *fiber.Ctx) error {
+	if ctx.Query(tokenQueryParam) != "" {
+		return errors.NewUnauthorizedError("token query param is not supported, use a subprotocol or the authenticate event")
+	}
+
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return ctx.Next()
+	}
+
+	if authorization, ok := authorizationFromSubprotocols(ctx.Get(fiber.HeaderSecWebSocketProtocol)); ok {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, authorization)
+	}
+
+	if ctx.Get(fiber.HeaderAuthorization) != "" {
+		return ctx.Next()
+	}
+
+	return websocket.New(func(conn *websocket.Conn) {
+		user, token, ok := c.authenticateFirstFrame(conn)
+		if !ok {
+			return
+		}
+
+		connection := chatwebsocket.NewConnection(conn.Conn, user, token)
+		if err := connection.SendEvent(chatwebsocket.AuthenticatedEventType, chatwebsocket.AuthenticatedEventData{UserID: user.ID}); err != nil {
+			return
+		}
+
+		c.connector.AddConnection(connection)
+		<-connection.GetCloseChan()
+	}, wsConfig())(ctx)
+}
+
+func (c *ChatController) ws(ctx *fiber.Ctx) error {
+	user := domain.UserFromContext(ctx.Context())
+	token := domain.TokenFromContext(ctx.Context())
+	return websocket.New(func(conn *websocket.Conn) {
+		connection := chatwebsocket.NewConnection(conn.Conn, user, token)
+		c.connector.AddConnection(connection)
+		<-connection.GetCloseChan()
+	}, wsConfig())(ctx)
+}
+
+// authenticateFirstFrame waits for the authenticate event and closes the
+// connection if it doesn't come in time or carries invalid credentials.
+func (c *ChatController) authenticateFirstFrame(conn *websocket.Conn) (*domain.User, string, bool) {
+	closeWithReason := func(code int, reason string) {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+		_ = conn.Close()
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(c.cfg.WebsocketAuthTimeout))
+
+	var event connector.Event
+	if err := conn.ReadJSON(&event); err != nil {
+		if os.IsTimeout(err) {
+			closeWithReason(connector.CloseAuthenticationTimeout, "authentication timeout")
+		} else {
+			closeWithReason(connector.CloseAuthenticationFailed, "authentication required")
+		}
+		return nil, "", false
+	}
+
+	_ = conn.SetReadDeadline(time.Time{})
+
+	var data chatwebsocket.AuthenticateEventData
+	if event.Type != chatwebsocket.AuthenticateEventType || json.Unmarshal(event.Data, &data) != nil ||
+		c.validate.Struct(constants.ChatDomain, data) != nil {
+		closeWithReason(connector.CloseAuthenticationFailed, "authentication required")
+		return nil, "", false
+	}
+
+	user, err := c.authenticator.Authenticate(context.Background(), data.Token, data.IsBot)
+	if err != nil || user == nil || user.ID == 0 {
+		closeWithReason(connector.CloseAuthenticationFailed, "invalid credentials")
+		return nil, "", false
+	}
+
+	return user, data.Token, true
+}
+
+// authorizationFromSubprotocols turns a "bearer.<token>" or "bot.<token>"
+// subprotocol into an Authorization header value. Tokens are base64url
+// encoded since subprotocols can't contain every character of a token.
+func authorizationFromSubprotocols(header string) (string, bool) {
+	for _, protocol := range strings.Split(header, ",") {
+		protocol = strings.TrimSpace(protocol)
+
+		var (
+			encodedToken string
+			isBot        bool
+		)
+		if token, ok := strings.CutPrefix(protocol, chatwebsocket.BearerSubprotocolPrefix); ok {
+			encodedToken = token
+		} else if token, ok := strings.CutPrefix(protocol, chatwebsocket.BotSubprotocolPrefix); ok {
+			encodedToken, isBot = token, true
+		} else {
+			continue
+		}
+
+		token, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedToken, "="))
+		if err != nil || len(token) == 0 {
+			continue
+		}
+
+		return userhttp.FormatAuthorization(string(token), isBot), true
+	}
+
+	return "", false
+}
+
+// wsConfig only ever selects the chat-go subprotocol, so token subprotocols
+// are never echoed back to the client.
+func wsConfig() websocket.Config {
+	return websocket.Config{
+		Subprotocols: []string{chatwebsocket.Subprotocol},
+	}
+}
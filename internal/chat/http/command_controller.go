@@ -0,0 +1,144 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+type CommandController struct {
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	commandService CommandService
+}
+
+func (c *CommandController) SetupRoutes(r fiber.Router) {
+	commandGroup := r.Group("/commands", c.authMiddleware.Handler)
+	commandGroup.Get("", c.getCommands)
+	commandGroup.Get("/:id", c.getCommand)
+	commandGroup.Put("/:id", c.update)
+	commandGroup.Post("", c.create)
+	commandGroup.Delete("/:id", c.delete)
+}
+
+func (c *CommandController) getCommands(ctx *fiber.Ctx) error {
+	commands, err := c.commandService.GetExternalCommands(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(lo.Map(commands, func(command chatdomain.ExternalCommand, _ int) CommandDto {
+		return CommandToDto(command)
+	}))
+}
+
+func (c *CommandController) getCommand(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	command, err := c.commandService.GetExternalCommand(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(CommandToDto(*command))
+}
+
+func (c *CommandController) update(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := UpdateCommandDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	command := CommandFromUpdateDto(dto)
+	command.ID = id
+
+	updatedCommand, err := c.commandService.UpdateExternalCommand(ctx.Context(), command)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(CommandToDto(*updatedCommand))
+}
+
+func (c *CommandController) create(ctx *fiber.Ctx) error {
+	dto := CreateCommandDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	createdCommand, err := c.commandService.CreateExternalCommand(ctx.Context(), CommandFromCreateDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(CommandToDto(*createdCommand))
+}
+
+func (c *CommandController) delete(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.commandService.DeleteExternalCommand(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func NewCommandController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	commandService CommandService,
+) *CommandController {
+	return &CommandController{
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		commandService: commandService,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type CreateCommandDto struct {
+	ChatID      uint64 `json:"chatId" validate:"required"`
+	Name        string `json:"name" validate:"required,lte=32,command"`
+	Description string `json:"description" validate:"lte=255"`
+	URL         string `json:"url" validate:"required,http_url,lte=2048"`
+}
+
+type UpdateCommandDto struct {
+	Description string `json:"description" validate:"lte=255"`
+	URL         string `json:"url" validate:"required,http_url,lte=2048"`
+}
+
+type CommandDto struct {
+	ID          uint64    `json:"id"`
+	ChatID      uint64    `json:"chatId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	URL         string    `json:"url"`
+	Token       string    `json:"token"`
+	CreatedBy   uint64    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,49 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+func CommandFromCreateDto(dto CreateCommandDto) domain.ExternalCommand {
+	return domain.ExternalCommand{
+		ChatID:      dto.ChatID,
+		Name:        dto.Name,
+		Description: dto.Description,
+		URL:         dto.URL,
+	}
+}
+
+func CommandFromUpdateDto(dto UpdateCommandDto) domain.ExternalCommand {
+	return domain.ExternalCommand{
+		Description: dto.Description,
+		URL:         dto.URL,
+	}
+}
+
+func CommandToDto(command domain.ExternalCommand) CommandDto {
+	return CommandDto{
+		ID:          command.ID,
+		ChatID:      command.ChatID,
+		Name:        command.Name,
+		Description: command.Description,
+		URL:         command.URL,
+		Token:       command.Token,
+		CreatedBy:   command.CreatedBy,
+		CreatedAt:   command.CreatedAt,
+		UpdatedAt:   command.UpdatedAt,
+	}
+}
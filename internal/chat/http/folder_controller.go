@@ -0,0 +1,144 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+type FolderController struct {
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	folderService  FolderService
+}
+
+func (c *FolderController) SetupRoutes(r fiber.Router) {
+	folderGroup := r.Group("/folders", c.authMiddleware.Handler)
+	folderGroup.Get("", c.getFolders)
+	folderGroup.Get("/:id", c.getFolder)
+	folderGroup.Put("/:id", c.update)
+	folderGroup.Post("", c.create)
+	folderGroup.Delete("/:id", c.delete)
+}
+
+func (c *FolderController) getFolders(ctx *fiber.Ctx) error {
+	folders, err := c.folderService.GetFolders(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(lo.Map(folders, func(folder chatdomain.Folder, _ int) FolderDto {
+		return FolderToDto(folder)
+	}))
+}
+
+func (c *FolderController) getFolder(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	folder, err := c.folderService.GetFolder(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(FolderToDto(*folder))
+}
+
+func (c *FolderController) update(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := SaveFolderDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	folder := FolderFromSaveDto(dto)
+	folder.ID = id
+
+	updatedFolder, err := c.folderService.UpdateFolder(ctx.Context(), folder)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(FolderToDto(*updatedFolder))
+}
+
+func (c *FolderController) create(ctx *fiber.Ctx) error {
+	dto := SaveFolderDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	createdFolder, err := c.folderService.CreateFolder(ctx.Context(), FolderFromSaveDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(FolderToDto(*createdFolder))
+}
+
+func (c *FolderController) delete(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.folderService.DeleteFolder(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func NewFolderController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	folderService FolderService,
+) *FolderController {
+	return &FolderController{
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		folderService:  folderService,
+	}
+}
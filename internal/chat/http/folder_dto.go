@@ -0,0 +1,39 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type SaveFolderDto struct {
+	Name         string   `json:"name" validate:"required,lte=64"`
+	ChatIDs      []uint64 `json:"chatIds" validate:"omitempty,dive,gte=0"`
+	ChatTypes    []uint8  `json:"chatTypes" validate:"omitempty,dive,oneof=1 2"`
+	UnreadOnly   bool     `json:"unreadOnly"`
+	ExcludeMuted bool     `json:"excludeMuted"`
+}
+
+type FolderDto struct {
+	ID           uint64    `json:"id"`
+	Name         string    `json:"name"`
+	ChatIDs      []uint64  `json:"chatIds"`
+	ChatTypes    []uint8   `json:"chatTypes"`
+	UnreadOnly   bool      `json:"unreadOnly"`
+	ExcludeMuted bool      `json:"excludeMuted"`
+	UnreadCount  uint64    `json:"unreadCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,49 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+func FolderToDto(folder domain.Folder) FolderDto {
+	return FolderDto{
+		ID:      folder.ID,
+		Name:    folder.Name,
+		ChatIDs: folder.ChatIDs,
+		ChatTypes: lo.Map(folder.ChatTypes, func(t domain.ChatType, _ int) uint8 {
+			return t.Uint8()
+		}),
+		UnreadOnly:   folder.UnreadOnly,
+		ExcludeMuted: folder.ExcludeMuted,
+		UnreadCount:  folder.UnreadCount,
+		CreatedAt:    folder.CreatedAt,
+		UpdatedAt:    folder.UpdatedAt,
+	}
+}
+
+func FolderFromSaveDto(dto SaveFolderDto) domain.Folder {
+	return domain.Folder{
+		Name:    dto.Name,
+		ChatIDs: lo.Uniq(dto.ChatIDs),
+		ChatTypes: lo.Map(lo.Uniq(dto.ChatTypes), func(t uint8, _ int) domain.ChatType {
+			return domain.ChatType(t)
+		}),
+		UnreadOnly:   dto.UnreadOnly,
+		ExcludeMuted: dto.ExcludeMuted,
+	}
+}
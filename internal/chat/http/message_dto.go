@@ -15,17 +15,13 @@
 package http
 
 import (
-	"time"
-
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
-type MessageDto struct {
-	ID        uint64        `json:"id"`
-	Text      string        `json:"text"`
-	Status    uint8         `json:"status"`
-	CreatedBy uint64        `json:"createdBy"`
-	Creator   *http.UserDto `json:"creator"`
-	CreatedAt time.Time     `json:"createdAt"`
-	UpdatedAt time.Time     `json:"updatedAt"`
-}
+type (
+	CreateMessageDto   = chatapi.CreateMessageDto
+	LinkPreviewDto     = chatapi.LinkPreviewDto
+	MessageForwardDto  = chatapi.MessageForwardDto
+	MessageDto         = chatapi.MessageDto
+	ForwardMessagesDto = chatapi.ForwardMessagesDto
+)
@@ -15,10 +15,10 @@
 package http
 
 import (
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/http"
 )
 
 var messageSortFields = []string{
@@ -17,23 +17,86 @@ package http
 import (
 	"github.com/samber/lo"
 
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/http"
 )
 
+func MessageFromCreateDto(dto CreateMessageDto) domain.Message {
+	return domain.Message{
+		Text:       dto.Text,
+		ExpiresIn:  dto.ExpiresIn,
+		ExpireFrom: domain.ExpireFrom(dto.ExpireFrom),
+		IsViewOnce: dto.IsViewOnce,
+
+		UndoSendDelay: dto.UndoSendDelay,
+	}
+}
+
 func MessageToDto(message domain.Message) MessageDto {
 	var creatorDto *http.UserDto
 	if message.Creator != nil {
 		creatorDto = lo.ToPtr(http.UserToDto(*message.Creator))
 	}
 
+	var pollDto *PollDto
+	if message.Poll != nil {
+		pollDto = lo.ToPtr(PollToDto(*message.Poll))
+	}
+
 	return MessageDto{
-		ID:        message.ID,
-		Text:      message.Text,
-		Status:    message.Status.ToUint8(),
+		ID:     message.ID,
+		Text:   message.Text,
+		Status: message.Status.ToUint8(),
+		ChatID: message.ChatID,
+		Entities: lo.Map(message.Entities, func(entity domain.MessageEntity, _ int) MessageEntityDto {
+			return MessageEntityToDto(entity)
+		}),
 		Creator:   creatorDto,
 		CreatedBy: message.CreatedBy,
+		IsBot:     commondomain.IsBotID(message.CreatedBy),
 		CreatedAt: message.CreatedAt,
 		UpdatedAt: message.UpdatedAt,
+
+		IsEphemeral: message.IsEphemeral,
+
+		ExpiresIn:  message.ExpiresIn,
+		ExpireFrom: message.ExpireFrom.Uint8(),
+		IsViewOnce: message.IsViewOnce,
+		ExpiresAt:  message.ExpiresAt,
+
+		ScheduledMessageID: message.ScheduledMessageID,
+		SendAt:             message.SendAt,
+
+		ForwardedFrom: MessageForwardToDto(message.ForwardedFrom),
+
+		Kind: message.Kind.Uint8(),
+		Poll: pollDto,
+
+		LinkPreviews: lo.Map(message.LinkPreviews, func(linkPreview domain.LinkPreview, _ int) LinkPreviewDto {
+			return LinkPreviewToDto(linkPreview)
+		}),
+	}
+}
+
+func LinkPreviewToDto(linkPreview domain.LinkPreview) LinkPreviewDto {
+	return LinkPreviewDto{
+		URL:         linkPreview.URL,
+		Title:       linkPreview.Title,
+		Description: linkPreview.Description,
+		ImageURL:    linkPreview.ImageURL,
+		SiteName:    linkPreview.SiteName,
+	}
+}
+
+func MessageForwardToDto(forward *domain.MessageForward) *MessageForwardDto {
+	if forward == nil {
+		return nil
+	}
+
+	return &MessageForwardDto{
+		MessageID: forward.MessageID,
+		ChatID:    forward.ChatID,
+		CreatedBy: forward.CreatedBy,
 	}
 }
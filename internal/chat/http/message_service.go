@@ -17,9 +17,19 @@ package http
 import (
 	"context"
 
-	"chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
 )
 
 type MessageService interface {
 	GetMessages(ctx context.Context, filter *domain.MessageFilter) ([]domain.Message, uint64, error)
+	CreateMessage(ctx context.Context, message domain.Message) (*domain.Message, error)
+	ForwardMessages(ctx context.Context, fromChatID uint64, messageIDs []uint64, toChatIDs []uint64) ([]domain.Message, error)
+}
+
+type ScheduledMessageService interface {
+	GetScheduledMessage(ctx context.Context, id uint64) (*domain.ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, filter *domain.ScheduledMessageFilter) ([]domain.ScheduledMessage, uint64, error)
+	ScheduleMessage(ctx context.Context, scheduledMessage domain.ScheduledMessage) (*domain.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, scheduledMessage domain.ScheduledMessage) (*domain.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id uint64) error
 }
@@ -0,0 +1,146 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+// PollController creates poll messages and takes votes. Results of a poll
+// are also pushed to the chat subscribers over the WebSocket.
+type PollController struct {
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	pollService    PollService
+}
+
+func (c *PollController) SetupRoutes(r fiber.Router) {
+	pollGroup := r.Group("/polls", c.authMiddleware.Handler)
+	pollGroup.Get("/:id", c.getPoll)
+	pollGroup.Post("", c.create)
+	pollGroup.Post("/:id/votes", c.vote)
+	pollGroup.Delete("/:id/votes", c.retractVote)
+	pollGroup.Post("/:id/close", c.close)
+}
+
+func (c *PollController) getPoll(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	poll, err := c.pollService.GetPoll(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(PollToDto(*poll))
+}
+
+func (c *PollController) create(ctx *fiber.Ctx) error {
+	dto := CreatePollDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	message, err := c.pollService.CreatePoll(ctx.Context(), PollFromCreateDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(MessageToDto(*message))
+}
+
+func (c *PollController) vote(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := VotePollDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	poll, err := c.pollService.Vote(ctx.Context(), id, dto.OptionIDs)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(PollToDto(*poll))
+}
+
+func (c *PollController) retractVote(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	poll, err := c.pollService.RetractVote(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(PollToDto(*poll))
+}
+
+func (c *PollController) close(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	poll, err := c.pollService.ClosePoll(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(PollToDto(*poll))
+}
+
+func NewPollController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	pollService PollService,
+) *PollController {
+	return &PollController{
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		pollService:    pollService,
+	}
+}
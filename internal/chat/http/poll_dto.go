@@ -0,0 +1,39 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+type CreatePollDto struct {
+	ChatID           uint64     `json:"chatId" validate:"required"`
+	Question         string     `json:"question" validate:"required,max=300"`
+	Options          []string   `json:"options" validate:"required,min=2,max=10,unique,dive,required,max=100"`
+	IsMultipleChoice bool       `json:"isMultipleChoice"`
+	IsAnonymous      bool       `json:"isAnonymous"`
+	ClosesAt         *time.Time `json:"closesAt"`
+}
+
+type VotePollDto struct {
+	OptionIDs []uint64 `json:"optionIds" validate:"required,min=1,unique,dive,gt=0"`
+}
+
+type (
+	PollOptionDto = chatapi.PollOptionDto
+	PollDto       = chatapi.PollDto
+)
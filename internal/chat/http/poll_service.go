@@ -0,0 +1,29 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+type PollService interface {
+	GetPoll(ctx context.Context, id uint64) (*domain.Poll, error)
+	CreatePoll(ctx context.Context, poll domain.Poll) (*domain.Message, error)
+	Vote(ctx context.Context, id uint64, optionIDs []uint64) (*domain.Poll, error)
+	RetractVote(ctx context.Context, id uint64) (*domain.Poll, error)
+	ClosePoll(ctx context.Context, id uint64) (*domain.Poll, error)
+}
@@ -0,0 +1,163 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+// ScheduledMessageController manages pending messages of the current user,
+// including messages held by the undo-send window. Cancelling a held
+// message unsends it.
+type ScheduledMessageController struct {
+	validate                validator.Validate
+	authMiddleware          api.Middleware
+	scheduledMessageService ScheduledMessageService
+}
+
+func (c *ScheduledMessageController) SetupRoutes(r fiber.Router) {
+	scheduledMessageGroup := r.Group("/scheduled-messages", c.authMiddleware.Handler)
+	scheduledMessageGroup.Get("", c.getScheduledMessages)
+	scheduledMessageGroup.Get("/:id", c.getScheduledMessage)
+	scheduledMessageGroup.Put("/:id", c.update)
+	scheduledMessageGroup.Post("", c.create)
+	scheduledMessageGroup.Delete("/:id", c.cancel)
+}
+
+func (c *ScheduledMessageController) getScheduledMessages(ctx *fiber.Ctx) error {
+	var query ScheduledMessageQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return errors.NewValidationError(constants.ChatDomain, err, nil)
+	}
+
+	filter := ScheduledMessageFilterFromQuery(query)
+
+	scheduledMessages, count, err := c.scheduledMessageService.GetScheduledMessages(ctx.Context(), &filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(scheduledMessages, func(scheduledMessage chatdomain.ScheduledMessage, _ int) ScheduledMessageDto {
+			return ScheduledMessageToDto(scheduledMessage)
+		}),
+		count,
+	))
+}
+
+func (c *ScheduledMessageController) getScheduledMessage(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	scheduledMessage, err := c.scheduledMessageService.GetScheduledMessage(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ScheduledMessageToDto(*scheduledMessage))
+}
+
+func (c *ScheduledMessageController) update(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := UpdateScheduledMessageDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	scheduledMessage := ScheduledMessageFromUpdateDto(dto)
+	scheduledMessage.ID = id
+
+	updatedScheduledMessage, err := c.scheduledMessageService.UpdateScheduledMessage(ctx.Context(), scheduledMessage)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ScheduledMessageToDto(*updatedScheduledMessage))
+}
+
+func (c *ScheduledMessageController) create(ctx *fiber.Ctx) error {
+	dto := CreateScheduledMessageDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return err
+	}
+
+	scheduledMessage, err := c.scheduledMessageService.ScheduleMessage(ctx.Context(), ScheduledMessageFromCreateDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(ScheduledMessageToDto(*scheduledMessage))
+}
+
+func (c *ScheduledMessageController) cancel(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.scheduledMessageService.CancelScheduledMessage(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func NewScheduledMessageController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	scheduledMessageService ScheduledMessageService,
+) *ScheduledMessageController {
+	return &ScheduledMessageController{
+		validate:                validate,
+		authMiddleware:          authMiddleware,
+		scheduledMessageService: scheduledMessageService,
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/http"
+)
+
+type CreateScheduledMessageDto struct {
+	ChatID uint64    `json:"chatId" validate:"required"`
+	Text   string    `json:"text" validate:"required"`
+	SendAt time.Time `json:"sendAt" validate:"required"`
+
+	ExpiresIn  *uint `json:"expiresIn" validate:"omitempty,lte=31536000"`
+	ExpireFrom uint8 `json:"expireFrom" validate:"omitempty,oneof=1 2"`
+	IsViewOnce bool  `json:"isViewOnce"`
+}
+
+type UpdateScheduledMessageDto struct {
+	Text   string    `json:"text" validate:"required"`
+	SendAt time.Time `json:"sendAt" validate:"required"`
+}
+
+type ScheduledMessageDto struct {
+	ID         uint64             `json:"id"`
+	ChatID     uint64             `json:"chatId"`
+	Text       string             `json:"text"`
+	Entities   []MessageEntityDto `json:"entities"`
+	CreatedBy  uint64             `json:"createdBy"`
+	Creator    *http.UserDto      `json:"creator"`
+	IsUndoSend bool               `json:"isUndoSend"`
+	SendAt     time.Time          `json:"sendAt"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+
+	ExpiresIn  *uint `json:"expiresIn,omitempty"`
+	ExpireFrom uint8 `json:"expireFrom,omitempty"`
+	IsViewOnce bool  `json:"isViewOnce,omitempty"`
+}
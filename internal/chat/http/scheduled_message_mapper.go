@@ -0,0 +1,81 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/http"
+)
+
+func ScheduledMessageFromCreateDto(dto CreateScheduledMessageDto) domain.ScheduledMessage {
+	return domain.ScheduledMessage{
+		Message: domain.Message{
+			ChatID:     dto.ChatID,
+			Text:       dto.Text,
+			ExpiresIn:  dto.ExpiresIn,
+			ExpireFrom: domain.ExpireFrom(dto.ExpireFrom),
+			IsViewOnce: dto.IsViewOnce,
+		},
+		SendAt: dto.SendAt.UTC(),
+	}
+}
+
+func ScheduledMessageFromUpdateDto(dto UpdateScheduledMessageDto) domain.ScheduledMessage {
+	return domain.ScheduledMessage{
+		Message: domain.Message{
+			Text: dto.Text,
+		},
+		SendAt: dto.SendAt.UTC(),
+	}
+}
+
+func ScheduledMessageFilterFromQuery(query ScheduledMessageQuery) domain.ScheduledMessageFilter {
+	return domain.ScheduledMessageFilter{
+		IDs:     query.IDs,
+		ChatIDs: query.ChatIDs,
+		Limit:   query.Limit,
+		Offset:  query.Offset,
+	}
+}
+
+func ScheduledMessageToDto(scheduledMessage domain.ScheduledMessage) ScheduledMessageDto {
+	message := scheduledMessage.Message
+
+	var creatorDto *http.UserDto
+	if message.Creator != nil {
+		creatorDto = lo.ToPtr(http.UserToDto(*message.Creator))
+	}
+
+	return ScheduledMessageDto{
+		ID:     scheduledMessage.ID,
+		ChatID: message.ChatID,
+		Text:   message.Text,
+		Entities: lo.Map(message.Entities, func(entity domain.MessageEntity, _ int) MessageEntityDto {
+			return MessageEntityToDto(entity)
+		}),
+		CreatedBy:  message.CreatedBy,
+		Creator:    creatorDto,
+		IsUndoSend: scheduledMessage.IsUndoSend,
+		SendAt:     scheduledMessage.SendAt,
+		CreatedAt:  scheduledMessage.CreatedAt,
+		UpdatedAt:  scheduledMessage.UpdatedAt,
+
+		ExpiresIn:  message.ExpiresIn,
+		ExpireFrom: message.ExpireFrom.Uint8(),
+		IsViewOnce: message.IsViewOnce,
+	}
+}
@@ -15,12 +15,7 @@
 package http
 
 import (
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
-type UserChatDto struct {
-	UserID uint64 `json:"userId"`
-	ChatID uint64 `json:"chatId"`
-
-	User *http.UserDto `json:"user"`
-}
+type UserChatDto = chatapi.UserChatDto
@@ -17,8 +17,8 @@ package http
 import (
 	"github.com/samber/lo"
 
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/http"
 )
 
 func UserChatFromDto(userChatDto UserChatDto) domain.UserChat {
@@ -37,6 +37,7 @@ func UserChatToDto(userChat domain.UserChat) UserChatDto {
 	return UserChatDto{
 		UserID: userChat.UserID,
 		ChatID: userChat.ChatID,
+		Role:   userChat.Role.Uint8(),
 		User:   userDto,
 	}
 }
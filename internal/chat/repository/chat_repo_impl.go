@@ -20,10 +20,10 @@ import (
 	"fmt"
 	"strings"
 
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type ChatRepoImpl struct {
@@ -38,11 +38,14 @@ var (
 		"createdBy": "created_by",
 		"createdAt": "created_at",
 		"updatedAt": "updated_at",
+		// Only available in GetChats.
+		"pinOrder":      "pin_order",
+		"lastMessageAt": "last_message->>'createdAt'",
 	}
 )
 
 const (
-	chatFields        = `c.id, c.name, c.type, c.image_url, c.created_by, c.created_at, c.updated_at`
+	chatFields        = `c.id, c.name, c.type, c.image_url, c.created_by, c.retention_days, c.retention_max_messages, c.legal_hold, c.default_expires_in, c.default_expire_from, c.created_at, c.updated_at`
 	lastMessageFields = `
 		(
 			SELECT
@@ -51,21 +54,46 @@ const (
 					'text', m.text,
 					'status', m.status,
 					'chatId', m.chat_id,
+					'entities', m.entities,
 					'createdBy', m.created_by,
 					'createdAt', CAST(m.created_at as timestamp) AT time zone 'UTC',
-					'updatedAt', CAST(m.updated_at AS timestamp) AT time zone 'UTC'
+					'updatedAt', CAST(m.updated_at AS timestamp) AT time zone 'UTC',
+					'expiresIn', m.expires_in,
+					'expireFrom', m.expire_from,
+					'isViewOnce', m.is_view_once,
+					'expiresAt', CAST(m.expires_at AS timestamp) AT time zone 'UTC',
+					'kind', m.kind,
+					'linkPreviews', m.link_previews,
+					'forwardedFrom', CASE WHEN m.forwarded_from_message_id IS NOT NULL THEN
+						JSONB_BUILD_OBJECT(
+							'messageId', m.forwarded_from_message_id,
+							'chatId', m.forwarded_from_chat_id,
+							'createdBy', m.forwarded_from_user_id
+						)
+					END
 				)
-			FROM messages AS m WHERE m.chat_id = c.id ORDER BY m.updated_at DESC LIMIT 1
+			FROM messages AS m
+			WHERE m.chat_id = c.id AND ` + notExpiredCondition + `
+			ORDER BY m.updated_at DESC LIMIT 1
 		) as last_message
 	`
 	userChatFields = `COALESCE(
 		JSON_AGG(
 			JSON_BUILD_OBJECT(
 				'userId', uc.user_id,
-				'chatId', uc.chat_id
+				'chatId', uc.chat_id,
+				'role', uc.role,
+				'mutedUntil', uc.muted_until AT TIME ZONE 'UTC',
+				'isArchived', uc.is_archived,
+				'pinOrder', uc.pin_order,
+				'notificationLevel', uc.notification_level,
+				'lastReadMessageId', uc.last_read_message_id
 			)
 		) FILTER (WHERE uc.user_id IS NOT NULL), '[]'::JSON) AS user_chats
 	`
+	// chatResultFields are the columns of the chats selected in GetChats,
+	// without the helper columns used for ordering.
+	chatResultFields = `id, name, type, image_url, created_by, retention_days, retention_max_messages, legal_hold, default_expires_in, default_expire_from, created_at, updated_at, last_message, user_chats`
 )
 
 func (r *ChatRepoImpl) scan(rows *sql.Rows) ([]domain.Chat, error) {
@@ -85,6 +113,11 @@ func (r *ChatRepoImpl) scan(rows *sql.Rows) ([]domain.Chat, error) {
 			&chat.Type,
 			&chat.Image.URL,
 			&chat.CreatedBy,
+			&chat.Retention.Days,
+			&chat.Retention.MaxMessages,
+			&chat.Retention.LegalHold,
+			&chat.Expiry.ExpiresIn,
+			&chat.Expiry.ExpireFrom,
 			&chat.CreatedAt,
 			&chat.UpdatedAt,
 			&lastMessage,
@@ -156,9 +189,93 @@ func (r *ChatRepoImpl) buildFilter(filter domain.ChatFilter) ([]any, []string) {
 			"CONCAT(c.id, c.name) ILIKE $%d ", len(values)))
 	}
 
+	if filter.MemberID != nil && (filter.IsArchived != nil || filter.IsPinned != nil || filter.IsMuted != nil) {
+		values = append(values, *filter.MemberID)
+		memberParam := len(values)
+
+		if filter.IsArchived != nil {
+			values = append(values, *filter.IsArchived)
+			where = append(where, fmt.Sprintf(
+				"COALESCE(%s, FALSE) = $%d ", r.buildSetting("s.is_archived", memberParam), len(values)))
+		}
+
+		if filter.IsPinned != nil {
+			values = append(values, *filter.IsPinned)
+			where = append(where, fmt.Sprintf(
+				"(%s IS NOT NULL) = $%d ", r.buildSetting("s.pin_order", memberParam), len(values)))
+		}
+
+		if filter.IsMuted != nil {
+			values = append(values, *filter.IsMuted)
+			where = append(where, fmt.Sprintf(
+				"COALESCE(%s, FALSE) = $%d ", r.buildSetting("s.muted_until > NOW()", memberParam), len(values)))
+		}
+	}
+
+	if filter.Folder != nil {
+		values, where = r.buildFolderFilter(*filter.Folder, values, where)
+	}
+
+	return values, where
+}
+
+func (r *ChatRepoImpl) buildFolderFilter(folder domain.Folder, values []any, where []string) ([]any, []string) {
+	values = append(values, folder.UserID)
+	memberParam := len(values)
+
+	where = append(where, fmt.Sprintf(
+		"%s IS NOT NULL ", r.buildSetting("s.user_id", memberParam)))
+
+	var rules []string
+
+	if len(folder.ChatIDs) > 0 {
+		var params []string
+		for _, id := range folder.ChatIDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		rules = append(rules, fmt.Sprintf("c.id IN (%s)", strings.Join(params, ",")))
+	}
+
+	if len(folder.ChatTypes) > 0 {
+		var params []string
+		for _, t := range folder.ChatTypes {
+			values = append(values, t.Uint8())
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		rules = append(rules, fmt.Sprintf("c.type IN (%s)", strings.Join(params, ",")))
+	}
+
+	if len(rules) > 0 {
+		where = append(where, fmt.Sprintf("(%s) ", strings.Join(rules, " OR ")))
+	}
+
+	if folder.UnreadOnly {
+		values = append(values, domain.DraftMessageStatus)
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %s AS m WHERE m.chat_id = c.id AND m.id > %s AND m.status <> $%d AND m.created_by <> $%d) ",
+			messageTableName, r.buildSetting("s.last_read_message_id", memberParam), len(values), memberParam))
+	}
+
+	if folder.ExcludeMuted {
+		where = append(where, fmt.Sprintf(
+			"NOT COALESCE(%s, FALSE) ", r.buildSetting("s.muted_until > NOW()", memberParam)))
+	}
+
 	return values, where
 }
 
+// buildSetting selects an expression over the user chat of the member passed
+// in the memberParam placeholder, it's NULL when the user isn't a member.
+func (r *ChatRepoImpl) buildSetting(expr string, memberParam int) string {
+	return fmt.Sprintf(
+		"(SELECT %s FROM %s AS s WHERE s.chat_id = c.id AND s.user_id = $%d)",
+		expr,
+		userChatTableName,
+		memberParam,
+	)
+}
+
 func (r *ChatRepoImpl) buildGroupBy() string {
 	return "c.id"
 }
@@ -201,7 +318,13 @@ func (r *ChatRepoImpl) GetChats(ctx context.Context, filter *domain.ChatFilter)
 
 	values, where := r.buildFilter(*filter)
 
-	query := fmt.Sprintf("SELECT %s FROM %s", r.buildChatFields(), r.buildFrom())
+	pinOrderField := "NULL::INTEGER"
+	if filter.MemberID != nil {
+		values = append(values, *filter.MemberID)
+		pinOrderField = r.buildSetting("s.pin_order", len(values))
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s AS pin_order FROM %s", r.buildChatFields(), pinOrderField, r.buildFrom())
 
 	if len(where) > 0 {
 		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
@@ -212,19 +335,23 @@ func (r *ChatRepoImpl) GetChats(ctx context.Context, filter *domain.ChatFilter)
 
 	query = fmt.Sprintf(`
 		WITH r AS (%s)
-		SELECT * FROM r`, query)
+		SELECT %s FROM r`, query, chatResultFields)
 
-	if filter.Sort != nil {
-		query = fmt.Sprintf(`%s
+	query = fmt.Sprintf(`%s
 		ORDER BY `, query)
+
+	if filter.PinnedFirst {
+		query = fmt.Sprintf(`%s pin_order ASC NULLS LAST,`, query)
+	}
+
+	if filter.Sort != nil {
 		query = fmt.Sprintf(`%s %s %s`,
 			query,
 			chatFieldsMapping[filter.Sort.SortBy],
 			filter.Sort.SortDir,
 		)
 	} else {
-		query = fmt.Sprintf(`%s
-		ORDER BY (COALESCE((last_message->'createdAt')::VARCHAR, '""'::VARCHAR)) DESC, updated_at DESC`, query)
+		query = fmt.Sprintf(`%s (COALESCE((last_message->'createdAt')::VARCHAR, '""'::VARCHAR)) DESC, updated_at DESC`, query)
 	}
 
 	if filter.Limit != nil {
@@ -343,7 +470,7 @@ func (r *ChatRepoImpl) CreateChat(ctx context.Context, chat domain.Chat, tx repo
 	return &chats[0], nil
 }
 
-func (r *ChatRepoImpl) UpdateChat(ctx context.Context, chat domain.Chat) (*domain.Chat, error) {
+func (r *ChatRepoImpl) UpdateChat(ctx context.Context, chat domain.Chat, tx repository.Tx) (*domain.Chat, error) {
 	query := fmt.Sprintf(`
 	WITH %[1]s AS (
 		UPDATE %[1]s 
@@ -360,7 +487,17 @@ func (r *ChatRepoImpl) UpdateChat(ctx context.Context, chat domain.Chat) (*domai
 		chatFields,
 	)
 
-	rows, err := r.db.QueryContext(ctx, query, chat.Name, chat.Image.URL, chat.ID)
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, chat.Name, chat.Image.URL, chat.ID)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, chat.Name, chat.Image.URL, chat.ID)
+	}
+
 	if err != nil {
 		return nil, errors.NewDatabaseError(constants.ChatDomain, err, "failed to update chat")
 	}
@@ -378,15 +515,81 @@ func (r *ChatRepoImpl) UpdateChat(ctx context.Context, chat domain.Chat) (*domai
 	return &chats[0], nil
 }
 
-func (r *ChatRepoImpl) DeleteChat(ctx context.Context, id uint64) error {
+func (r *ChatRepoImpl) DeleteChat(ctx context.Context, id uint64, tx repository.Tx) error {
 	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE id = $1`, chatTableName)
-	_, err := r.db.ExecContext(ctx, query, id)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, id)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id)
+	}
+
 	if err != nil {
 		return errors.NewDatabaseError(constants.ChatDomain, err)
 	}
 	return nil
 }
 
+func (r *ChatRepoImpl) UpdateChatExpiry(
+	ctx context.Context,
+	id uint64,
+	expiry domain.MessageExpiry,
+	tx repository.Tx,
+) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET default_expires_in = $1, default_expire_from = $2, updated_at = NOW()
+		WHERE id = $3
+	`, chatTableName)
+
+	values := []any{expiry.ExpiresIn, expiry.ExpireFrom, id}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *ChatRepoImpl) UpdateChatRetention(
+	ctx context.Context,
+	id uint64,
+	retention domain.RetentionPolicy,
+	tx repository.Tx,
+) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET retention_days = $1, retention_max_messages = $2, legal_hold = $3, updated_at = NOW()
+		WHERE id = $4
+	`, chatTableName)
+
+	values := []any{retention.Days, retention.MaxMessages, retention.LegalHold, id}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
 func NewChatRepoImpl(db *sql.DB) *ChatRepoImpl {
 	return &ChatRepoImpl{db: db}
 }
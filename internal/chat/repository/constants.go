@@ -15,12 +15,27 @@
 package repository
 
 const (
-	userTableName     = "users"
-	chatTableName     = "chats"
-	userChatTableName = "user_chats"
-	messageTableName  = "messages"
+	userTableName             = "users"
+	chatTableName             = "chats"
+	userChatTableName         = "user_chats"
+	messageTableName          = "messages"
+	messageMentionTableName   = "message_mentions"
+	messageReadTableName      = "message_reads"
+	commandTableName          = "commands"
+	folderTableName           = "folders"
+	scheduledMessageTableName = "scheduled_messages"
+	pollTableName             = "polls"
+	pollOptionTableName       = "poll_options"
+	pollVoteTableName         = "poll_votes"
+	linkPreviewTableName      = "link_previews"
 )
 
+// notExpiredCondition hides expired messages before they are deleted.
+const notExpiredCondition = `(m.expires_at IS NULL OR m.expires_at > NOW())`
+
 const (
-	messageFields = `m.id, m.text, m.status, m.chat_id, m.created_by, m.created_at, m.updated_at`
+	messageFields          = `m.id, m.text, m.status, m.chat_id, m.entities, m.created_by, m.created_at, m.updated_at, m.expires_in, m.expire_from, m.is_view_once, m.expires_at, m.forwarded_from_message_id, m.forwarded_from_chat_id, m.forwarded_from_user_id, m.kind, m.link_previews`
+	commandFields          = `c.id, c.chat_id, c.name, c.description, c.url, c.token, c.created_by, c.created_at, c.updated_at`
+	scheduledMessageFields = `sm.id, sm.chat_id, sm.text, sm.entities, sm.mentioned_user_ids, sm.expires_in, sm.expire_from, sm.is_view_once, sm.is_undo_send, sm.creator, sm.created_by, sm.send_at, sm.created_at, sm.updated_at`
+	folderFields           = `f.id, f.user_id, f.name, f.chat_ids, f.chat_types, f.unread_only, f.exclude_muted, f.created_at, f.updated_at`
 )
@@ -0,0 +1,171 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type ExternalCommandRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *ExternalCommandRepoImpl) scan(rows *sql.Rows) ([]domain.ExternalCommand, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	commands := make([]domain.ExternalCommand, 0)
+
+	for rows.Next() {
+		var command domain.ExternalCommand
+
+		var fields = []any{
+			&command.ID,
+			&command.ChatID,
+			&command.Name,
+			&command.Description,
+			&command.URL,
+			&command.Token,
+			&command.CreatedBy,
+			&command.CreatedAt,
+			&command.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, command)
+	}
+
+	return commands, nil
+}
+
+func (r *ExternalCommandRepoImpl) getOne(ctx context.Context, query string, values ...any) (*domain.ExternalCommand, error) {
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	commands, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(commands) == 0 {
+		return nil, nil
+	}
+
+	return &commands[0], nil
+}
+
+func (r *ExternalCommandRepoImpl) GetExternalCommand(ctx context.Context, id uint64) (*domain.ExternalCommand, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS c WHERE c.id = $1`, commandFields, commandTableName)
+
+	return r.getOne(ctx, query, id)
+}
+
+func (r *ExternalCommandRepoImpl) GetExternalCommandByName(
+	ctx context.Context,
+	chatID uint64,
+	name string,
+) (*domain.ExternalCommand, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS c WHERE c.chat_id = $1 AND c.name = $2`, commandFields, commandTableName)
+
+	return r.getOne(ctx, query, chatID, name)
+}
+
+func (r *ExternalCommandRepoImpl) GetExternalCommands(
+	ctx context.Context,
+	createdBy uint64,
+) ([]domain.ExternalCommand, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS c
+		WHERE c.created_by = $1
+		ORDER BY c.chat_id, c.name
+	`, commandFields, commandTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, createdBy)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	commands, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return commands, nil
+}
+
+func (r *ExternalCommandRepoImpl) CreateExternalCommand(
+	ctx context.Context,
+	command domain.ExternalCommand,
+) (*domain.ExternalCommand, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS c (chat_id, name, description, url, token, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING %s
+	`, commandTableName, commandFields)
+
+	return r.getOne(ctx, query,
+		command.ChatID,
+		command.Name,
+		command.Description,
+		command.URL,
+		command.Token,
+		command.CreatedBy,
+	)
+}
+
+func (r *ExternalCommandRepoImpl) UpdateExternalCommand(
+	ctx context.Context,
+	command domain.ExternalCommand,
+) (*domain.ExternalCommand, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS c
+		SET description = $1, url = $2, updated_at = NOW()
+		WHERE c.id = $3
+		RETURNING %s
+	`, commandTableName, commandFields)
+
+	return r.getOne(ctx, query, command.Description, command.URL, command.ID)
+}
+
+func (r *ExternalCommandRepoImpl) DeleteExternalCommand(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, commandTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func NewExternalCommandRepoImpl(db *sql.DB) *ExternalCommandRepoImpl {
+	return &ExternalCommandRepoImpl{db: db}
+}
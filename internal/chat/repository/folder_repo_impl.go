@@ -0,0 +1,282 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type FolderRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *FolderRepoImpl) scan(rows *sql.Rows) ([]domain.Folder, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	folders := make([]domain.Folder, 0)
+
+	for rows.Next() {
+		var (
+			folder    domain.Folder
+			chatIDs   []int64
+			chatTypes []int64
+		)
+
+		var fields = []any{
+			&folder.ID,
+			&folder.UserID,
+			&folder.Name,
+			pq.Array(&chatIDs),
+			pq.Array(&chatTypes),
+			&folder.UnreadOnly,
+			&folder.ExcludeMuted,
+			&folder.CreatedAt,
+			&folder.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		folder.ChatIDs = lo.Map(chatIDs, func(id int64, _ int) uint64 {
+			return uint64(id)
+		})
+		folder.ChatTypes = lo.Map(chatTypes, func(t int64, _ int) domain.ChatType {
+			return domain.ChatType(t)
+		})
+
+		folders = append(folders, folder)
+	}
+
+	return folders, nil
+}
+
+func (r *FolderRepoImpl) arrays(folder domain.Folder) (any, any) {
+	chatIDs := lo.Map(folder.ChatIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+	chatTypes := lo.Map(folder.ChatTypes, func(t domain.ChatType, _ int) int64 {
+		return int64(t)
+	})
+
+	return pq.Array(chatIDs), pq.Array(chatTypes)
+}
+
+func (r *FolderRepoImpl) GetFolder(ctx context.Context, id uint64) (*domain.Folder, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS f WHERE f.id = $1`, folderFields, folderTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	folders, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(folders) == 0 {
+		return nil, nil
+	}
+
+	return &folders[0], nil
+}
+
+func (r *FolderRepoImpl) GetFolders(ctx context.Context, userID uint64) ([]domain.Folder, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS f
+		WHERE f.user_id = $1
+		ORDER BY f.id
+	`, folderFields, folderTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	folders, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return folders, nil
+}
+
+// GetUnreadCounts applies the same rules as the folder filter of the chats,
+// UnreadOnly doesn't matter here as only unread messages are counted anyway.
+// Messages of others after the last read message of the user are unread.
+func (r *FolderRepoImpl) GetUnreadCounts(
+	ctx context.Context,
+	userID uint64,
+	folderIDs []uint64,
+) (map[uint64]uint64, error) {
+	values := []any{userID, domain.DraftMessageStatus}
+
+	query := fmt.Sprintf(`
+		SELECT f.id, COUNT(m.id)
+		FROM %s AS f
+			JOIN %s AS uc ON uc.user_id = f.user_id
+			JOIN %s AS c ON c.id = uc.chat_id
+			JOIN %s AS m ON m.chat_id = c.id
+				AND m.id > uc.last_read_message_id
+				AND m.status <> $2
+				AND m.created_by <> f.user_id
+		WHERE f.user_id = $1
+			AND (
+				(CARDINALITY(f.chat_ids) = 0 AND CARDINALITY(f.chat_types) = 0)
+				OR c.id = ANY(f.chat_ids)
+				OR c.type = ANY(f.chat_types)
+			)
+			AND (NOT f.exclude_muted OR uc.muted_until IS NULL OR uc.muted_until <= NOW())
+	`,
+		folderTableName,
+		userChatTableName,
+		chatTableName,
+		messageTableName,
+	)
+
+	if len(folderIDs) > 0 {
+		values = append(values, pq.Array(lo.Map(folderIDs, func(id uint64, _ int) int64 {
+			return int64(id)
+		})))
+		query = fmt.Sprintf(`%s
+			AND f.id = ANY($%d)`, query, len(values))
+	}
+
+	query = fmt.Sprintf(`%s
+		GROUP BY f.id`, query)
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	unreadCounts := make(map[uint64]uint64)
+
+	for rows.Next() {
+		var folderID, count uint64
+
+		if err := rows.Scan(&folderID, &count); err != nil {
+			return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+		}
+
+		unreadCounts[folderID] = count
+	}
+
+	return unreadCounts, nil
+}
+
+func (r *FolderRepoImpl) CreateFolder(ctx context.Context, folder domain.Folder) (*domain.Folder, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS f (user_id, name, chat_ids, chat_types, unread_only, exclude_muted)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING %s
+	`, folderTableName, folderFields)
+
+	chatIDs, chatTypes := r.arrays(folder)
+
+	rows, err := r.db.QueryContext(ctx, query,
+		folder.UserID,
+		folder.Name,
+		chatIDs,
+		chatTypes,
+		folder.UnreadOnly,
+		folder.ExcludeMuted,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	folders, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(folders) == 0 {
+		return nil, nil
+	}
+
+	return &folders[0], nil
+}
+
+func (r *FolderRepoImpl) UpdateFolder(ctx context.Context, folder domain.Folder) (*domain.Folder, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS f
+		SET name = $1, chat_ids = $2, chat_types = $3, unread_only = $4, exclude_muted = $5, updated_at = NOW()
+		WHERE f.id = $6
+		RETURNING %s
+	`, folderTableName, folderFields)
+
+	chatIDs, chatTypes := r.arrays(folder)
+
+	rows, err := r.db.QueryContext(ctx, query,
+		folder.Name,
+		chatIDs,
+		chatTypes,
+		folder.UnreadOnly,
+		folder.ExcludeMuted,
+		folder.ID,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	folders, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(folders) == 0 {
+		return nil, nil
+	}
+
+	return &folders[0], nil
+}
+
+func (r *FolderRepoImpl) DeleteFolder(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, folderTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func NewFolderRepoImpl(db *sql.DB) *FolderRepoImpl {
+	return &FolderRepoImpl{db: db}
+}
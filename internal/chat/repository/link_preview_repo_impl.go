@@ -0,0 +1,110 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type LinkPreviewRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *LinkPreviewRepoImpl) GetLinkPreview(
+	ctx context.Context,
+	url string,
+	fetchedAfter time.Time,
+) (*domain.LinkPreview, error) {
+	query := fmt.Sprintf(`
+		SELECT lp.url, lp.title, lp.description, lp.image_url, lp.site_name, lp.is_found, lp.fetched_at
+		FROM %s AS lp
+		WHERE lp.url_hash = $1 AND lp.fetched_at > $2
+	`, linkPreviewTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, hashURL(url), fetchedAfter)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	var linkPreview domain.LinkPreview
+
+	if err := rows.Scan(
+		&linkPreview.URL,
+		&linkPreview.Title,
+		&linkPreview.Description,
+		&linkPreview.ImageURL,
+		&linkPreview.SiteName,
+		&linkPreview.IsFound,
+		&linkPreview.FetchedAt,
+	); err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return &linkPreview, nil
+}
+
+func (r *LinkPreviewRepoImpl) SaveLinkPreview(ctx context.Context, linkPreview domain.LinkPreview) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (url_hash, url, title, description, image_url, site_name, is_found, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (url_hash) DO UPDATE
+		SET url = EXCLUDED.url,
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			image_url = EXCLUDED.image_url,
+			site_name = EXCLUDED.site_name,
+			is_found = EXCLUDED.is_found,
+			fetched_at = EXCLUDED.fetched_at
+	`, linkPreviewTableName)
+
+	if _, err := r.db.ExecContext(ctx, query,
+		hashURL(linkPreview.URL),
+		linkPreview.URL,
+		linkPreview.Title,
+		linkPreview.Description,
+		linkPreview.ImageURL,
+		linkPreview.SiteName,
+		linkPreview.IsFound,
+	); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+// hashURL keys previews by a digest, as long URLs don't fit into a btree
+// index entry.
+func hashURL(url string) []byte {
+	hash := sha256.Sum256([]byte(url))
+	return hash[:]
+}
+
+func NewLinkPreviewRepoImpl(db *sql.DB) *LinkPreviewRepoImpl {
+	return &LinkPreviewRepoImpl{db: db}
+}
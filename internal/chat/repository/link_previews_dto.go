@@ -0,0 +1,42 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+type linkPreviewsDto []domain.LinkPreview
+
+func (lp linkPreviewsDto) Value() (driver.Value, error) {
+	if lp == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(lp)
+}
+
+func (lp *linkPreviewsDto) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &lp)
+}
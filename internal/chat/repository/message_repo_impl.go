@@ -19,11 +19,15 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/repository"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type MessageRepoImpl struct {
@@ -41,6 +45,26 @@ var (
 	}
 )
 
+func (r *MessageRepoImpl) query(ctx context.Context, tx repository.Tx, query string, args ...any) (*sql.Rows, error) {
+	if tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+func (r *MessageRepoImpl) exec(ctx context.Context, tx repository.Tx, query string, args ...any) error {
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+
+	return err
+}
+
 func (r *MessageRepoImpl) scan(rows *sql.Rows) ([]domain.Message, error) {
 	if rows == nil {
 		return nil, nil
@@ -49,22 +73,43 @@ func (r *MessageRepoImpl) scan(rows *sql.Rows) ([]domain.Message, error) {
 	messages := make([]domain.Message, 0)
 
 	for rows.Next() {
-		var message domain.Message
+		var (
+			message                                                          domain.Message
+			forwardedFromMessageID, forwardedFromChatID, forwardedFromUserID *uint64
+		)
 
 		var fields = []any{
 			&message.ID,
 			&message.Text,
 			&message.Status,
 			&message.ChatID,
+			(*messageEntitiesDto)(&message.Entities),
 			&message.CreatedBy,
 			&message.CreatedAt,
 			&message.UpdatedAt,
+			&message.ExpiresIn,
+			&message.ExpireFrom,
+			&message.IsViewOnce,
+			&message.ExpiresAt,
+			&forwardedFromMessageID,
+			&forwardedFromChatID,
+			&forwardedFromUserID,
+			&message.Kind,
+			(*linkPreviewsDto)(&message.LinkPreviews),
 		}
 
 		if err := rows.Scan(fields...); err != nil {
 			return nil, err
 		}
 
+		if forwardedFromMessageID != nil {
+			message.ForwardedFrom = &domain.MessageForward{
+				MessageID: *forwardedFromMessageID,
+				ChatID:    lo.FromPtr(forwardedFromChatID),
+				CreatedBy: lo.FromPtr(forwardedFromUserID),
+			}
+		}
+
 		messages = append(messages, message)
 	}
 
@@ -73,7 +118,14 @@ func (r *MessageRepoImpl) scan(rows *sql.Rows) ([]domain.Message, error) {
 
 func (r *MessageRepoImpl) buildFilter(filter domain.MessageFilter) ([]any, []string) {
 	values := make([]any, 0)
-	where := make([]string, 0)
+	where := []string{notExpiredCondition}
+
+	if filter.ReaderID != 0 {
+		values = append(values, filter.ReaderID)
+		where = append(where, fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM %s AS r WHERE r.message_id = m.id AND r.user_id = $%d AND r.expires_at <= NOW()) ",
+			messageReadTableName, len(values)))
+	}
 
 	if len(filter.IDs) > 0 {
 		var params []string
@@ -115,6 +167,17 @@ func (r *MessageRepoImpl) buildFilter(filter domain.MessageFilter) ([]any, []str
 			"m.chat_id IN (%s) ", strings.Join(params, ",")))
 	}
 
+	if len(filter.MentionedUserIDs) > 0 {
+		var params []string
+		for _, userID := range filter.MentionedUserIDs {
+			values = append(values, userID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"m.id IN (SELECT mm.message_id FROM %s AS mm WHERE mm.user_id IN (%s)) ",
+			messageMentionTableName, strings.Join(params, ",")))
+	}
+
 	return values, where
 }
 
@@ -197,21 +260,84 @@ func (r *MessageRepoImpl) GetMessagesCount(ctx context.Context, filter *domain.M
 	return count, nil
 }
 
+func (r *MessageRepoImpl) GetMessage(ctx context.Context, id uint64) (*domain.Message, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS m
+		WHERE m.id = $1 AND %s
+	`, messageFields, messageTableName, notExpiredCondition)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return &messages[0], nil
+}
+
 func (r *MessageRepoImpl) CreateMessage(ctx context.Context, message domain.Message, tx repository.Tx) (*domain.Message, error) {
 	values := []any{
 		message.Text,
 		message.ChatID,
+		messageEntitiesDto(message.Entities),
 		message.CreatedBy,
+		message.ExpiresIn,
+		message.ExpireFrom,
+		message.IsViewOnce,
+		domain.SentExpireFrom,
 	}
 
+	var forwardedFromMessageID, forwardedFromChatID, forwardedFromUserID *uint64
+	if message.ForwardedFrom != nil {
+		forwardedFromMessageID = &message.ForwardedFrom.MessageID
+		forwardedFromChatID = &message.ForwardedFrom.ChatID
+		forwardedFromUserID = &message.ForwardedFrom.CreatedBy
+	}
+
+	values = append(values, forwardedFromMessageID, forwardedFromChatID, forwardedFromUserID)
+
+	kind := message.Kind
+	if kind == 0 {
+		kind = domain.TextMessageKind
+	}
+
+	values = append(values, kind, message.HasLinks())
+
 	query := fmt.Sprintf(`
 		WITH %[1]s AS (
 		    INSERT INTO %[1]s (
 				text,
 				chat_id,
-				created_by
+				entities,
+				created_by,
+				expires_in,
+				expire_from,
+				is_view_once,
+				expires_at,
+				forwarded_from_message_id,
+				forwarded_from_chat_id,
+				forwarded_from_user_id,
+				kind,
+				is_unfurl_pending
+			)
+			VALUES (
+				$1, $2, $3, $4, $5::INTEGER, $6::SMALLINT, $7,
+				CASE WHEN $6::SMALLINT = $8::SMALLINT AND $5::INTEGER IS NOT NULL
+					THEN NOW() + MAKE_INTERVAL(secs => $5::INTEGER)
+				END,
+				$9, $10, $11, $12, $13
 			)
-			VALUES ($1, $2, $3)
 			RETURNING *
 		)
 		SELECT %[2]s
@@ -250,43 +376,499 @@ func (r *MessageRepoImpl) CreateMessage(ctx context.Context, message domain.Mess
 	return &messages[0], nil
 }
 
-func (r *MessageRepoImpl) UpdateMessageStatus(
+func (r *MessageRepoImpl) UpdateMessage(ctx context.Context, message domain.Message, tx repository.Tx) (*domain.Message, error) {
+	values := []any{
+		message.Text,
+		messageEntitiesDto(message.Entities),
+		message.ID,
+		message.HasLinks(),
+	}
+
+	// Previews of the old text are dropped, the new links are unfurled again.
+	query := fmt.Sprintf(`
+		WITH %[1]s AS (
+			UPDATE %[1]s
+			SET text = $1, entities = $2, link_previews = '[]', is_unfurl_pending = $4, unfurl_claimed_until = NULL, updated_at = NOW()
+			WHERE id = $3
+			RETURNING *
+		)
+		SELECT %[2]s
+		FROM %[1]s AS m
+	`,
+		messageTableName,
+		messageFields,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, values...)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err, "failed to update message")
+	}
+
+	defer rows.Close()
+
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return &messages[0], nil
+}
+
+func (r *MessageRepoImpl) DeleteMessage(ctx context.Context, id uint64, tx repository.Tx) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, messageTableName)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, id)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepoImpl) GetExpiredMessages(
 	ctx context.Context,
-	messageIDs []uint64,
-	messageStatus domain.MessageStatus,
+	global domain.RetentionPolicy,
+	limit uint64,
 	tx repository.Tx,
-) error {
-	if len(messageIDs) == 0 {
-		return nil
+) ([]domain.Message, error) {
+	var globalDays, globalMaxMessages uint
+	if global.Days != nil {
+		globalDays = *global.Days
+	}
+	if global.MaxMessages != nil {
+		globalMaxMessages = *global.MaxMessages
+	}
+
+	// The last N messages of a chat are the ones with an ID not less than
+	// the ID of the N-th newest message.
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS m
+			JOIN %s AS c ON c.id = m.chat_id
+		WHERE NOT c.legal_hold
+			AND (
+				(
+					COALESCE(c.retention_days, $1) > 0
+					AND m.created_at < NOW() - MAKE_INTERVAL(days => COALESCE(c.retention_days, $1))
+				)
+				OR (
+					COALESCE(c.retention_max_messages, $2) > 0
+					AND m.id < (
+						SELECT lm.id
+						FROM %s AS lm
+						WHERE lm.chat_id = m.chat_id
+						ORDER BY lm.id DESC
+						OFFSET COALESCE(c.retention_max_messages, $2) - 1
+						LIMIT 1
+					)
+				)
+			)
+		ORDER BY m.id
+		LIMIT %d
+		FOR UPDATE OF m SKIP LOCKED
+	`,
+		messageFields,
+		messageTableName,
+		chatTableName,
+		messageTableName,
+		limit,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, globalDays, globalMaxMessages)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, globalDays, globalMaxMessages)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
 	}
 
-	var values []any
+	return messages, nil
+}
+
+func (r *MessageRepoImpl) GetDisappearedMessages(
+	ctx context.Context,
+	limit uint64,
+	tx repository.Tx,
+) ([]domain.Message, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS m
+			JOIN %s AS c ON c.id = m.chat_id
+		WHERE NOT c.legal_hold AND m.expires_at <= NOW()
+		ORDER BY m.expires_at
+		LIMIT %d
+		FOR UPDATE OF m SKIP LOCKED
+	`,
+		messageFields,
+		messageTableName,
+		chatTableName,
+		limit,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query)
+	}
 
-	query := fmt.Sprintf("UPDATE %s", messageTableName)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
 
-	values = append(values, messageStatus)
-	query = fmt.Sprintf("%s SET status = %s", query, fmt.Sprintf("$%d", len(values)))
+	defer rows.Close()
 
-	var placeholders []string
-	for _, id := range messageIDs {
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return messages, nil
+}
+
+func (r *MessageRepoImpl) DeleteMessages(ctx context.Context, ids []uint64, tx repository.Tx) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, id := range ids {
 		values = append(values, id)
-		placeholders = append(placeholders, fmt.Sprintf("$%d", len(values)))
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, messageTableName, strings.Join(params, ","))
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepoImpl) ClaimUnfurlPendingMessages(
+	ctx context.Context,
+	limit uint64,
+	claimedUntil time.Time,
+) ([]domain.Message, error) {
+	query := fmt.Sprintf(`
+		WITH %[1]s AS (
+			UPDATE %[1]s AS l
+			SET unfurl_claimed_until = $1
+			FROM (
+				SELECT m.id
+				FROM %[1]s AS m
+				WHERE m.is_unfurl_pending AND (m.unfurl_claimed_until IS NULL OR m.unfurl_claimed_until <= NOW())
+					AND %[3]s
+				ORDER BY m.id
+				LIMIT %[4]d
+				FOR UPDATE SKIP LOCKED
+			) AS c
+			WHERE l.id = c.id
+			RETURNING l.*
+		)
+		SELECT %[2]s
+		FROM %[1]s AS m
+		ORDER BY m.id
+	`,
+		messageTableName,
+		messageFields,
+		notExpiredCondition,
+		limit,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, claimedUntil)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return messages, nil
+}
+
+func (r *MessageRepoImpl) UpdateMessageLinkPreviews(
+	ctx context.Context,
+	message domain.Message,
+	tx repository.Tx,
+) (*domain.Message, error) {
+	values := []any{
+		linkPreviewsDto(message.LinkPreviews),
+		message.ID,
+		message.UpdatedAt,
+	}
+
+	query := fmt.Sprintf(`
+		WITH %[1]s AS (
+			UPDATE %[1]s
+			SET link_previews = $1, is_unfurl_pending = FALSE
+			WHERE id = $2 AND is_unfurl_pending AND updated_at = $3
+			RETURNING *
+		)
+		SELECT %[2]s
+		FROM %[1]s AS m
+	`,
+		messageTableName,
+		messageFields,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, values...)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	messages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return &messages[0], nil
+}
+
+func (r *MessageRepoImpl) ClearUnfurlPending(ctx context.Context, message domain.Message) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET is_unfurl_pending = FALSE
+		WHERE id = $1 AND is_unfurl_pending AND updated_at = $2
+	`, messageTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, message.ID, message.UpdatedAt); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepoImpl) CreateMessageMentions(
+	ctx context.Context,
+	messageID uint64,
+	userIDs []uint64,
+	tx repository.Tx,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders []string
+		values       []any
+	)
+
+	for _, userID := range userIDs {
+		values = append(values, messageID, userID)
+		placeholders = append(placeholders, fmt.Sprintf("($%d,$%d)", len(values)-1, len(values)))
 	}
-	query = fmt.Sprintf("%s WHERE id IN (%s)", query, strings.Join(placeholders, ", "))
 
-	values = append(values, messageStatus)
-	query = fmt.Sprintf("%s AND status < %s", query, fmt.Sprintf("$%d", len(values)))
+	query := fmt.Sprintf(`
+		INSERT INTO %s (message_id, user_id)
+		VALUES %s
+		ON CONFLICT DO NOTHING
+	`,
+		messageMentionTableName,
+		strings.Join(placeholders, ","),
+	)
 
 	var err error
 
 	if tx != nil {
 		_, err = tx.ExecContext(ctx, query, values...)
 	} else {
-		_, err = r.db.QueryContext(ctx, query, values...)
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepoImpl) DeleteMessageMentions(ctx context.Context, messageID uint64, tx repository.Tx) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE message_id = $1`, messageMentionTableName)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, messageID)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, messageID)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepoImpl) UpdateMessageStatus(
+	ctx context.Context,
+	chatID uint64,
+	userID uint64,
+	messageIDs []uint64,
+	messageStatus domain.MessageStatus,
+	tx repository.Tx,
+) ([]uint64, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
 	}
 
+	query := fmt.Sprintf(`
+		UPDATE %s AS m
+		SET status = $1
+		WHERE m.chat_id = $2 AND m.id = ANY($3) AND m.created_by <> $4 AND m.status < $1 AND %s
+		RETURNING m.id
+	`, messageTableName, notExpiredCondition)
+
+	ids := lo.Map(messageIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+
+	rows, err := r.query(ctx, tx, query, messageStatus, chatID, pq.Array(ids), userID)
 	if err != nil {
-		return err
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	var updatedIDs []uint64
+
+	for rows.Next() {
+		var id uint64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+		}
+
+		updatedIDs = append(updatedIDs, id)
+	}
+
+	return updatedIDs, nil
+}
+
+func (r *MessageRepoImpl) CreateMessageReads(
+	ctx context.Context,
+	chatID uint64,
+	userID uint64,
+	messageIDs []uint64,
+	tx repository.Tx,
+) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	ids := lo.Map(messageIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+
+	// The timer of a message counted from read starts on the first read of
+	// every member.
+	query := fmt.Sprintf(`
+		INSERT INTO %s (message_id, user_id, expires_at)
+		SELECT m.id, $2, CASE
+				WHEN m.expire_from = $4 AND m.expires_in IS NOT NULL
+				THEN NOW() + MAKE_INTERVAL(secs => m.expires_in)
+			END
+		FROM %s AS m
+		WHERE m.chat_id = $1 AND m.id = ANY($3) AND m.created_by <> $2 AND %s
+		ON CONFLICT DO NOTHING
+	`, messageReadTableName, messageTableName, notExpiredCondition)
+
+	if err := r.exec(ctx, tx, query, chatID, userID, pq.Array(ids), domain.ReadExpireFrom); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	// A message expires for all once every other member has read it, at the
+	// end of the last timer.
+	query = fmt.Sprintf(`
+		UPDATE %s AS m
+		SET expires_at = (SELECT MAX(r.expires_at) FROM %s AS r WHERE r.message_id = m.id)
+		WHERE m.chat_id = $1 AND m.id = ANY($2) AND m.expire_from = $3
+			AND m.expires_in IS NOT NULL AND m.expires_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM %s AS uc
+				WHERE uc.chat_id = m.chat_id AND uc.user_id <> m.created_by
+					AND NOT EXISTS (
+						SELECT 1 FROM %s AS r WHERE r.message_id = m.id AND r.user_id = uc.user_id
+					)
+			)
+	`, messageTableName, messageReadTableName, userChatTableName, messageReadTableName)
+
+	if err := r.exec(ctx, tx, query, chatID, pq.Array(ids), domain.ReadExpireFrom); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
 	}
 
 	return nil
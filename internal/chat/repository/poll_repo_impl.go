@@ -0,0 +1,418 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+var pollFields = fmt.Sprintf(`
+	p.id,
+	p.message_id,
+	m.chat_id,
+	p.question,
+	p.is_multiple_choice,
+	p.is_anonymous,
+	p.closes_at,
+	p.closed_at,
+	(SELECT COUNT(DISTINCT v.user_id) FROM %s AS v WHERE v.poll_id = p.id),
+	m.created_by,
+	p.created_at,
+	p.updated_at
+`, pollVoteTableName)
+
+type PollRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *PollRepoImpl) query(ctx context.Context, tx repository.Tx, query string, args ...any) (*sql.Rows, error) {
+	if tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+func (r *PollRepoImpl) exec(ctx context.Context, tx repository.Tx, query string, args ...any) error {
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+
+	return err
+}
+
+func (r *PollRepoImpl) scan(rows *sql.Rows) ([]domain.Poll, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	polls := make([]domain.Poll, 0)
+
+	for rows.Next() {
+		var poll domain.Poll
+
+		var fields = []any{
+			&poll.ID,
+			&poll.MessageID,
+			&poll.ChatID,
+			&poll.Question,
+			&poll.IsMultipleChoice,
+			&poll.IsAnonymous,
+			&poll.ClosesAt,
+			&poll.ClosedAt,
+			&poll.VoterCount,
+			&poll.CreatedBy,
+			&poll.CreatedAt,
+			&poll.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		polls = append(polls, poll)
+	}
+
+	return polls, nil
+}
+
+// fillOptions loads the options with their results, voters of anonymous
+// polls never leave the database.
+func (r *PollRepoImpl) fillOptions(ctx context.Context, polls []domain.Poll, tx repository.Tx) error {
+	if len(polls) == 0 {
+		return nil
+	}
+
+	pollIDs := lo.Map(polls, func(poll domain.Poll, _ int) int64 {
+		return int64(poll.ID)
+	})
+
+	query := fmt.Sprintf(`
+		SELECT
+			o.id,
+			o.poll_id,
+			o.text,
+			o.position,
+			COUNT(v.user_id),
+			COALESCE(
+				ARRAY_AGG(v.user_id ORDER BY v.created_at) FILTER (WHERE v.user_id IS NOT NULL AND NOT p.is_anonymous),
+				'{}'
+			)
+		FROM %s AS o
+			JOIN %s AS p ON p.id = o.poll_id
+			LEFT JOIN %s AS v ON v.option_id = o.id
+		WHERE o.poll_id = ANY($1)
+		GROUP BY o.id, p.is_anonymous
+		ORDER BY o.poll_id, o.position
+	`,
+		pollOptionTableName,
+		pollTableName,
+		pollVoteTableName,
+	)
+
+	rows, err := r.query(ctx, tx, query, pq.Array(pollIDs))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	options := make(map[uint64][]domain.PollOption)
+
+	for rows.Next() {
+		var (
+			option   domain.PollOption
+			pollID   uint64
+			voterIDs []int64
+		)
+
+		if err := rows.Scan(
+			&option.ID,
+			&pollID,
+			&option.Text,
+			&option.Position,
+			&option.VoteCount,
+			pq.Array(&voterIDs),
+		); err != nil {
+			return err
+		}
+
+		if len(voterIDs) > 0 {
+			option.VoterIDs = lo.Map(voterIDs, func(id int64, _ int) uint64 {
+				return uint64(id)
+			})
+		}
+
+		options[pollID] = append(options[pollID], option)
+	}
+
+	for index := range polls {
+		polls[index].Options = options[polls[index].ID]
+	}
+
+	return nil
+}
+
+func (r *PollRepoImpl) GetPoll(ctx context.Context, id uint64, tx repository.Tx) (*domain.Poll, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS p
+			JOIN %s AS m ON m.id = p.message_id
+		WHERE p.id = $1
+	`,
+		pollFields,
+		pollTableName,
+		messageTableName,
+	)
+
+	if tx != nil {
+		query = fmt.Sprintf(`%s FOR UPDATE OF p`, query)
+	}
+
+	rows, err := r.query(ctx, tx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	polls, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(polls) == 0 {
+		return nil, nil
+	}
+
+	if err := r.fillOptions(ctx, polls, tx); err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return &polls[0], nil
+}
+
+func (r *PollRepoImpl) GetPolls(ctx context.Context, messageIDs []uint64) ([]domain.Poll, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS p
+			JOIN %s AS m ON m.id = p.message_id
+		WHERE p.message_id = ANY($1)
+	`,
+		pollFields,
+		pollTableName,
+		messageTableName,
+	)
+
+	ids := lo.Map(messageIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	polls, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if err := r.fillOptions(ctx, polls, nil); err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return polls, nil
+}
+
+func (r *PollRepoImpl) GetVotedOptionIDs(
+	ctx context.Context,
+	pollIDs []uint64,
+	userID uint64,
+) (map[uint64][]uint64, error) {
+	votedOptionIDs := make(map[uint64][]uint64)
+
+	if len(pollIDs) == 0 {
+		return votedOptionIDs, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT v.poll_id, v.option_id
+		FROM %s AS v
+		WHERE v.user_id = $1 AND v.poll_id = ANY($2)
+		ORDER BY v.option_id
+	`, pollVoteTableName)
+
+	ids := lo.Map(pollIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(ids))
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var pollID, optionID uint64
+
+		if err := rows.Scan(&pollID, &optionID); err != nil {
+			return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+		}
+
+		votedOptionIDs[pollID] = append(votedOptionIDs[pollID], optionID)
+	}
+
+	return votedOptionIDs, nil
+}
+
+// CreatePoll inserts the poll together with its options, options keep the
+// order they are given in.
+func (r *PollRepoImpl) CreatePoll(ctx context.Context, poll domain.Poll, tx repository.Tx) (*domain.Poll, error) {
+	query := fmt.Sprintf(`
+		WITH p AS (
+			INSERT INTO %s (message_id, question, is_multiple_choice, is_anonymous, closes_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at, updated_at
+		), o AS (
+			INSERT INTO %s (poll_id, text, position)
+			SELECT p.id, t.text, t.position - 1
+			FROM p, UNNEST($6::TEXT[]) WITH ORDINALITY AS t(text, position)
+			RETURNING id, position
+		)
+		SELECT p.id, p.created_at, p.updated_at, o.id, o.position
+		FROM p, o
+		ORDER BY o.position
+	`, pollTableName, pollOptionTableName)
+
+	texts := lo.Map(poll.Options, func(option domain.PollOption, _ int) string {
+		return option.Text
+	})
+
+	rows, err := r.query(ctx, tx, query,
+		poll.MessageID,
+		poll.Question,
+		poll.IsMultipleChoice,
+		poll.IsAnonymous,
+		poll.ClosesAt,
+		pq.Array(texts),
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	options := make([]domain.PollOption, 0, len(texts))
+
+	for rows.Next() {
+		var option domain.PollOption
+
+		if err := rows.Scan(&poll.ID, &poll.CreatedAt, &poll.UpdatedAt, &option.ID, &option.Position); err != nil {
+			return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+		}
+
+		option.Text = texts[option.Position]
+		options = append(options, option)
+	}
+
+	poll.Options = options
+
+	return &poll, nil
+}
+
+func (r *PollRepoImpl) ClosePoll(ctx context.Context, id uint64, tx repository.Tx) error {
+	query := fmt.Sprintf(`UPDATE %s SET closed_at = NOW(), updated_at = NOW() WHERE id = $1`, pollTableName)
+
+	if err := r.exec(ctx, tx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *PollRepoImpl) ReplaceVotes(
+	ctx context.Context,
+	pollID uint64,
+	userID uint64,
+	optionIDs []uint64,
+	tx repository.Tx,
+) error {
+	if err := r.DeleteVotes(ctx, pollID, userID, tx); err != nil {
+		return err
+	}
+
+	if len(optionIDs) == 0 {
+		return nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, optionID := range optionIDs {
+		values = append(values, pollID, optionID, userID)
+		params = append(params, fmt.Sprintf("($%d, $%d, $%d)", len(values)-2, len(values)-1, len(values)))
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (poll_id, option_id, user_id)
+		VALUES %s
+		ON CONFLICT DO NOTHING
+	`, pollVoteTableName, strings.Join(params, ","))
+
+	if err := r.exec(ctx, tx, query, values...); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *PollRepoImpl) DeleteVotes(ctx context.Context, pollID uint64, userID uint64, tx repository.Tx) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE poll_id = $1 AND user_id = $2`, pollVoteTableName)
+
+	if err := r.exec(ctx, tx, query, pollID, userID); err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func NewPollRepoImpl(db *sql.DB) *PollRepoImpl {
+	return &PollRepoImpl{db: db}
+}
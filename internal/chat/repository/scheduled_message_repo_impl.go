@@ -0,0 +1,415 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type ScheduledMessageRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *ScheduledMessageRepoImpl) scan(rows *sql.Rows) ([]domain.ScheduledMessage, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	scheduledMessages := make([]domain.ScheduledMessage, 0)
+
+	for rows.Next() {
+		var (
+			scheduledMessage domain.ScheduledMessage
+			mentionedUserIDs []int64
+			creator          []byte
+		)
+
+		message := &scheduledMessage.Message
+
+		var fields = []any{
+			&scheduledMessage.ID,
+			&message.ChatID,
+			&message.Text,
+			(*messageEntitiesDto)(&message.Entities),
+			pq.Array(&mentionedUserIDs),
+			&message.ExpiresIn,
+			&message.ExpireFrom,
+			&message.IsViewOnce,
+			&scheduledMessage.IsUndoSend,
+			&creator,
+			&message.CreatedBy,
+			&scheduledMessage.SendAt,
+			&scheduledMessage.CreatedAt,
+			&scheduledMessage.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		if len(mentionedUserIDs) > 0 {
+			message.MentionedUserIDs = lo.Map(mentionedUserIDs, func(id int64, _ int) uint64 {
+				return uint64(id)
+			})
+		}
+
+		if len(creator) > 0 {
+			if err := json.Unmarshal(creator, &message.Creator); err != nil {
+				return nil, err
+			}
+		}
+
+		scheduledMessages = append(scheduledMessages, scheduledMessage)
+	}
+
+	return scheduledMessages, nil
+}
+
+func (r *ScheduledMessageRepoImpl) buildFilter(filter domain.ScheduledMessageFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.IDs) > 0 {
+		var params []string
+		for _, id := range filter.IDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"sm.id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.ChatIDs) > 0 {
+		var params []string
+		for _, chatID := range filter.ChatIDs {
+			values = append(values, chatID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"sm.chat_id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.CreatedByIDs) > 0 {
+		var params []string
+		for _, createdByID := range filter.CreatedByIDs {
+			values = append(values, createdByID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"sm.created_by IN (%s) ", strings.Join(params, ",")))
+	}
+
+	return values, where
+}
+
+// values returns the stored columns of a scheduled message in the order of
+// the insert statement.
+func (r *ScheduledMessageRepoImpl) values(scheduledMessage domain.ScheduledMessage) ([]any, error) {
+	message := scheduledMessage.Message
+
+	creator, err := json.Marshal(message.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	mentionedUserIDs := lo.Map(message.MentionedUserIDs, func(id uint64, _ int) int64 {
+		return int64(id)
+	})
+
+	return []any{
+		message.ChatID,
+		message.Text,
+		messageEntitiesDto(message.Entities),
+		pq.Array(mentionedUserIDs),
+		message.ExpiresIn,
+		message.ExpireFrom,
+		message.IsViewOnce,
+		scheduledMessage.IsUndoSend,
+		creator,
+		message.CreatedBy,
+		scheduledMessage.SendAt,
+	}, nil
+}
+
+func (r *ScheduledMessageRepoImpl) GetScheduledMessage(ctx context.Context, id uint64) (*domain.ScheduledMessage, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS sm WHERE sm.id = $1`, scheduledMessageFields, scheduledMessageTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	scheduledMessages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(scheduledMessages) == 0 {
+		return nil, nil
+	}
+
+	return &scheduledMessages[0], nil
+}
+
+func (r *ScheduledMessageRepoImpl) GetScheduledMessages(
+	ctx context.Context,
+	filter *domain.ScheduledMessageFilter,
+) ([]domain.ScheduledMessage, error) {
+	if filter == nil {
+		filter = &domain.ScheduledMessageFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS sm
+	`, scheduledMessageFields, scheduledMessageTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	query = fmt.Sprintf(`%s ORDER BY sm.send_at, sm.id`, query)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	scheduledMessages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return scheduledMessages, nil
+}
+
+func (r *ScheduledMessageRepoImpl) GetScheduledMessagesCount(
+	ctx context.Context,
+	filter *domain.ScheduledMessageFilter,
+) (uint64, error) {
+	if filter == nil {
+		filter = &domain.ScheduledMessageFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS sm", scheduledMessageTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return count, nil
+}
+
+func (r *ScheduledMessageRepoImpl) CreateScheduledMessage(
+	ctx context.Context,
+	scheduledMessage domain.ScheduledMessage,
+) (*domain.ScheduledMessage, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS sm (
+			chat_id, text, entities, mentioned_user_ids, expires_in, expire_from,
+			is_view_once, is_undo_send, creator, created_by, send_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING %s
+	`, scheduledMessageTableName, scheduledMessageFields)
+
+	values, err := r.values(scheduledMessage)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	scheduledMessages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(scheduledMessages) == 0 {
+		return nil, nil
+	}
+
+	return &scheduledMessages[0], nil
+}
+
+func (r *ScheduledMessageRepoImpl) UpdateScheduledMessage(
+	ctx context.Context,
+	scheduledMessage domain.ScheduledMessage,
+) (*domain.ScheduledMessage, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS sm
+		SET chat_id = $1, text = $2, entities = $3, mentioned_user_ids = $4, expires_in = $5, expire_from = $6,
+			is_view_once = $7, is_undo_send = $8, creator = $9, created_by = $10, send_at = $11, updated_at = NOW()
+		WHERE sm.id = $12
+		RETURNING %s
+	`, scheduledMessageTableName, scheduledMessageFields)
+
+	values, err := r.values(scheduledMessage)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, append(values, scheduledMessage.ID)...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	scheduledMessages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	if len(scheduledMessages) == 0 {
+		return nil, nil
+	}
+
+	return &scheduledMessages[0], nil
+}
+
+func (r *ScheduledMessageRepoImpl) DeleteScheduledMessage(ctx context.Context, id uint64) (bool, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, scheduledMessageTableName)
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return affected > 0, nil
+}
+
+func (r *ScheduledMessageRepoImpl) GetDueScheduledMessages(
+	ctx context.Context,
+	limit uint64,
+	tx repository.Tx,
+) ([]domain.ScheduledMessage, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS sm
+		WHERE sm.send_at <= NOW()
+		ORDER BY sm.send_at, sm.id
+		LIMIT %d
+		FOR UPDATE SKIP LOCKED
+	`,
+		scheduledMessageFields,
+		scheduledMessageTableName,
+		limit,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	defer rows.Close()
+
+	scheduledMessages, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return scheduledMessages, nil
+}
+
+func (r *ScheduledMessageRepoImpl) DeleteScheduledMessages(ctx context.Context, ids []uint64, tx repository.Tx) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, id := range ids {
+		values = append(values, id)
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, scheduledMessageTableName, strings.Join(params, ","))
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func NewScheduledMessageRepoImpl(db *sql.DB) *ScheduledMessageRepoImpl {
+	return &ScheduledMessageRepoImpl{db: db}
+}
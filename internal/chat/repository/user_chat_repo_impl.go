@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"strings"
 
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/repository"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
 )
 
 type UserChatRepoImpl struct {
@@ -40,7 +43,7 @@ func (r *UserChatRepoImpl) CreateUserChats(ctx context.Context, userChats []doma
 		values       []interface{}
 	)
 
-	const colsNum = 2
+	const colsNum = 3
 
 	for i, userChat := range userChats {
 		var indexes []any
@@ -49,18 +52,19 @@ func (r *UserChatRepoImpl) CreateUserChats(ctx context.Context, userChats []doma
 			indexes = append(indexes, i*colsNum+j)
 		}
 
-		placeholder := fmt.Sprintf("($%d,$%d)", indexes...)
+		placeholder := fmt.Sprintf("($%d,$%d,$%d)", indexes...)
 
 		placeholders = append(placeholders, placeholder)
 
 		values = append(values,
 			userChat.UserID,
 			userChat.ChatID,
+			userChat.Role,
 		)
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO %s
+		INSERT INTO %s (user_id, chat_id, role)
 		VALUES %s
 		ON CONFLICT DO NOTHING
 	`,
@@ -93,6 +97,108 @@ func (r *UserChatRepoImpl) DeleteUserChats(ctx context.Context, userChat []domai
 	panic("implement me")
 }
 
+func (r *UserChatRepoImpl) UpdateUserChat(ctx context.Context, userChat domain.UserChat, tx repository.Tx) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET muted_until = $1, is_archived = $2, pin_order = $3, notification_level = $4
+		WHERE user_id = $5 AND chat_id = $6
+	`, userChatTableName)
+
+	values := []any{
+		userChat.MutedUntil,
+		userChat.IsArchived,
+		userChat.PinOrder,
+		userChat.NotificationLevel,
+		userChat.UserID,
+		userChat.ChatID,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *UserChatRepoImpl) UpdateUserChatRole(ctx context.Context, userChat domain.UserChat, tx repository.Tx) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET role = $1
+		WHERE user_id = $2 AND chat_id = $3
+	`, userChatTableName)
+
+	values := []any{
+		userChat.Role,
+		userChat.UserID,
+		userChat.ChatID,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
+func (r *UserChatRepoImpl) UpdateLastReadMessage(
+	ctx context.Context,
+	chatID uint64,
+	userID uint64,
+	messageIDs []uint64,
+	tx repository.Tx,
+) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s AS uc
+		SET last_read_message_id = GREATEST(
+			uc.last_read_message_id,
+			COALESCE((SELECT MAX(m.id) FROM %s AS m WHERE m.chat_id = uc.chat_id AND m.id = ANY($1)), 0)
+		)
+		WHERE uc.user_id = $2 AND uc.chat_id = $3
+	`, userChatTableName, messageTableName)
+
+	values := []any{
+		pq.Array(lo.Map(messageIDs, func(id uint64, _ int) int64 {
+			return int64(id)
+		})),
+		userID,
+		chatID,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.ChatDomain, err)
+	}
+
+	return nil
+}
+
 func NewUserChatRepoImpl(db *sql.DB) *UserChatRepoImpl {
 	return &UserChatRepoImpl{db: db}
 }
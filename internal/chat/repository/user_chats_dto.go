@@ -19,7 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 
-	"chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
 )
 
 type userChatsDto []domain.UserChat
@@ -0,0 +1,158 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"math"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+)
+
+func ChatToProto(chat domain.Chat) *chatv1.Chat {
+	var lastMessage *chatv1.Message
+	if chat.LastMessage != nil {
+		lastMessage = MessageToProto(*chat.LastMessage)
+	}
+
+	var settings *chatv1.ChatSettings
+	if chat.Settings != nil {
+		settings = ChatSettingsToProto(*chat.Settings)
+	}
+
+	return &chatv1.Chat{
+		Id:          chat.ID,
+		Name:        chat.Name,
+		Type:        uint32(chat.Type.Uint8()),
+		Image:       ImageToProto(chat.Image),
+		LastMessage: lastMessage,
+		CreatedBy:   chat.CreatedBy,
+		Creator:     UserToProto(chat.Creator),
+		Members: lo.Map(chat.UserChats, func(userChat domain.UserChat, _ int) *chatv1.Member {
+			return MemberToProto(userChat)
+		}),
+		Settings:  settings,
+		Retention: RetentionToProto(chat.Retention),
+		Expiry:    MessageExpiryToProto(chat.Expiry),
+		CreatedAt: timestamppb.New(chat.CreatedAt),
+		UpdatedAt: timestamppb.New(chat.UpdatedAt),
+	}
+}
+
+func MemberToProto(userChat domain.UserChat) *chatv1.Member {
+	return &chatv1.Member{
+		UserId: userChat.UserID,
+		ChatId: userChat.ChatID,
+		User:   UserToProto(userChat.User),
+	}
+}
+
+func ChatSettingsToProto(userChat domain.UserChat) *chatv1.ChatSettings {
+	return &chatv1.ChatSettings{
+		MutedUntil:        timeToProto(userChat.MutedUntil),
+		IsArchived:        userChat.IsArchived,
+		PinOrder:          uintToProto(userChat.PinOrder),
+		NotificationLevel: uint32(userChat.NotificationLevel.Uint8()),
+	}
+}
+
+func RetentionToProto(retention domain.RetentionPolicy) *chatv1.RetentionPolicy {
+	return &chatv1.RetentionPolicy{
+		Days:        uintToProto(retention.Days),
+		MaxMessages: uintToProto(retention.MaxMessages),
+		LegalHold:   retention.LegalHold,
+	}
+}
+
+func MessageExpiryToProto(expiry domain.MessageExpiry) *chatv1.MessageExpiry {
+	return &chatv1.MessageExpiry{
+		ExpiresIn:  uintToProto(expiry.ExpiresIn),
+		ExpireFrom: uint32(expiry.ExpireFrom.Uint8()),
+	}
+}
+
+func UserToProto(user *commondomain.User) *chatv1.User {
+	if user == nil {
+		return nil
+	}
+
+	return &chatv1.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		AboutMe:   user.AboutMe,
+		Image:     ImageToProto(user.Image),
+		IsBot:     user.IsBot,
+	}
+}
+
+func ImageToProto(image commondomain.Image) *chatv1.Image {
+	return &chatv1.Image{
+		Url:    image.URL,
+		Base64: image.Base64,
+	}
+}
+
+func ImageFromProto(image *chatv1.Image) commondomain.Image {
+	return commondomain.Image{
+		URL:    image.GetUrl(),
+		Base64: image.GetBase64(),
+	}
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	return lo.ToPtr(t.AsTime())
+}
+
+func uintToProto(value *uint) *uint32 {
+	if value == nil {
+		return nil
+	}
+
+	return lo.ToPtr(uint32(min(*value, math.MaxUint32)))
+}
+
+func uintFromProto(value *uint32) *uint {
+	if value == nil {
+		return nil
+	}
+
+	return lo.ToPtr(uint(*value))
+}
+
+// uint8FromProto saturates instead of wrapping around, so out of range
+// values fail validation rather than turning into valid ones.
+func uint8FromProto(value uint32) uint8 {
+	return uint8(min(value, math.MaxUint8))
+}
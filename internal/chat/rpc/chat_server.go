@@ -0,0 +1,292 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+// ChatServer serves the chats API over gRPC. Requests are mapped to the DTOs
+// of the REST API, so both are validated alike.
+type ChatServer struct {
+	chatv1.UnimplementedChatServiceServer
+
+	validate    validator.Validate
+	chatService ChatService
+	connector   connector.Connector
+}
+
+func (s *ChatServer) Register(registrar grpc.ServiceRegistrar) {
+	chatv1.RegisterChatServiceServer(registrar, s)
+}
+
+func (s *ChatServer) GetChat(ctx context.Context, req *chatv1.GetChatRequest) (*chatv1.Chat, error) {
+	chat, err := s.chatService.GetChat(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*chat), nil
+}
+
+func (s *ChatServer) ListChats(ctx context.Context, req *chatv1.ListChatsRequest) (*chatv1.ListChatsResponse, error) {
+	query := chathttp.ChatQuery{
+		IDs: req.GetIds(),
+		Types: lo.Map(req.GetTypes(), func(chatType uint32, _ int) uint8 {
+			return uint8FromProto(chatType)
+		}),
+		CreatedByIDs: req.GetCreatedByIds(),
+		Search:       req.GetSearch(),
+		IsArchived:   req.IsArchived,
+		IsPinned:     req.IsPinned,
+		IsMuted:      req.IsMuted,
+		PinnedFirst:  req.GetPinnedFirst(),
+		FolderID:     req.FolderId,
+		Limit:        req.Limit,
+		Offset:       req.Offset,
+		Sort:         req.GetSort(),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return nil, errors.NewValidationError(constants.ChatDomain, err, nil)
+	}
+
+	chatFilter, err := chathttp.ChatFilterFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, count, err := s.chatService.GetChats(ctx, &chatFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatv1.ListChatsResponse{
+		Chats: lo.Map(chats, func(chat chatdomain.Chat, _ int) *chatv1.Chat {
+			return ChatToProto(chat)
+		}),
+		Count: count,
+	}, nil
+}
+
+func (s *ChatServer) CreateChat(ctx context.Context, req *chatv1.CreateChatRequest) (*chatv1.Chat, error) {
+	dto := chathttp.CreateChatDto{
+		Name:  req.GetName(),
+		Type:  uint8FromProto(req.GetType()),
+		Image: chatapi.Image(ImageFromProto(req.GetImage())),
+		UserChats: lo.Map(req.GetUserIds(), func(userID uint64, _ int) chathttp.UserChatDto {
+			return chathttp.UserChatDto{UserID: userID}
+		}),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat, err := chathttp.ChatFromCreateDto(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	createdChat, err := s.chatService.CreateChat(ctx, *chat)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*createdChat), nil
+}
+
+func (s *ChatServer) UpdateChat(ctx context.Context, req *chatv1.UpdateChatRequest) (*chatv1.Chat, error) {
+	dto := chathttp.UpdateChatDto{
+		Name:  req.GetName(),
+		Image: chatapi.Image(ImageFromProto(req.GetImage())),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat := chathttp.ChatFromUpdateDto(dto)
+	chat.ID = req.GetId()
+
+	updatedChat, err := s.chatService.UpdateChat(ctx, chat)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*updatedChat), nil
+}
+
+func (s *ChatServer) DeleteChat(ctx context.Context, req *chatv1.DeleteChatRequest) (*chatv1.DeleteChatResponse, error) {
+	if err := s.chatService.DeleteChat(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &chatv1.DeleteChatResponse{}, nil
+}
+
+func (s *ChatServer) AddChatMember(ctx context.Context, req *chatv1.AddChatMemberRequest) (*chatv1.Chat, error) {
+	dto := chathttp.AddChatMemberDto{
+		UserID: req.GetUserId(),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat, err := s.chatService.AddChatMember(ctx, req.GetChatId(), dto.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*chat), nil
+}
+
+func (s *ChatServer) UpdateChatSettings(ctx context.Context, req *chatv1.UpdateChatSettingsRequest) (*chatv1.Chat, error) {
+	settings := req.GetSettings()
+
+	dto := chathttp.UpdateChatSettingsDto{
+		MutedUntil:        timeFromProto(settings.GetMutedUntil()),
+		IsArchived:        settings.GetIsArchived(),
+		PinOrder:          uintFromProto(settings.PinOrder),
+		NotificationLevel: uint8FromProto(settings.GetNotificationLevel()),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	userChat := chathttp.ChatSettingsFromUpdateDto(dto)
+	userChat.ChatID = req.GetChatId()
+
+	chat, err := s.chatService.UpdateChatSettings(ctx, userChat)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*chat), nil
+}
+
+func (s *ChatServer) UpdateChatRetention(ctx context.Context, req *chatv1.UpdateChatRetentionRequest) (*chatv1.Chat, error) {
+	retention := req.GetRetention()
+
+	dto := chathttp.RetentionDto{
+		Days:        uintFromProto(retention.Days),
+		MaxMessages: uintFromProto(retention.MaxMessages),
+		LegalHold:   retention.GetLegalHold(),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat, err := s.chatService.UpdateChatRetention(ctx, req.GetChatId(), chathttp.RetentionFromDto(dto))
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*chat), nil
+}
+
+func (s *ChatServer) UpdateChatExpiry(ctx context.Context, req *chatv1.UpdateChatExpiryRequest) (*chatv1.Chat, error) {
+	expiry := req.GetExpiry()
+
+	dto := chathttp.MessageExpiryDto{
+		ExpiresIn:  uintFromProto(expiry.ExpiresIn),
+		ExpireFrom: uint8FromProto(expiry.GetExpireFrom()),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	chat, err := s.chatService.UpdateChatExpiry(ctx, req.GetChatId(), chathttp.MessageExpiryFromDto(dto))
+	if err != nil {
+		return nil, err
+	}
+
+	return ChatToProto(*chat), nil
+}
+
+// StreamEvents registers the stream with the connector, so it gets the
+// events of a websocket subscribed to the chats of the request. Only chats
+// the user is a member of can be subscribed. The stream ends when the client
+// leaves, falls behind or its credentials expire.
+func (s *ChatServer) StreamEvents(req *chatv1.StreamEventsRequest, stream chatv1.ChatService_StreamEventsServer) error {
+	ctx := stream.Context()
+
+	chatIDs := lo.Uniq(req.GetChatIds())
+	if err := s.chatService.CheckMembership(ctx, chatIDs); err != nil {
+		return err
+	}
+
+	conn := newStreamConnection(domain.UserFromContext(ctx), domain.TokenFromContext(ctx))
+
+	chatConn := chatwebsocket.WrapConnection(conn)
+	chatConn.SetSubscribedChats(chatIDs)
+
+	s.connector.AddConnection(chatConn)
+
+	// Events are sent from here, gRPC streams don't allow concurrent sends.
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil
+		case event := <-conn.events:
+			if err := stream.Send(event); err != nil {
+				conn.Close()
+				return err
+			}
+		case <-conn.GetCloseChan():
+			switch {
+			case conn.GetCloseCode() == connector.CloseCredentialsExpired:
+				return status.Error(codes.Unauthenticated, "credentials expired")
+			case conn.isOverflowed.Load():
+				return status.Error(codes.ResourceExhausted, "stream fell behind")
+			default:
+				return status.Error(codes.Unavailable, "stream closed")
+			}
+		}
+	}
+}
+
+func NewChatServer(
+	validate validator.Validate,
+	chatService ChatService,
+	connector connector.Connector,
+) *ChatServer {
+	return &ChatServer{
+		validate:    validate,
+		chatService: chatService,
+		connector:   connector,
+	}
+}
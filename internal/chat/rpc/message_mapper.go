@@ -0,0 +1,93 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+)
+
+func MessageToProto(message domain.Message) *chatv1.Message {
+	return &chatv1.Message{
+		Id:     message.ID,
+		Text:   message.Text,
+		Status: uint32(message.Status.ToUint8()),
+		ChatId: message.ChatID,
+		Entities: lo.Map(message.Entities, func(entity domain.MessageEntity, _ int) *chatv1.MessageEntity {
+			return MessageEntityToProto(entity)
+		}),
+		CreatedBy: message.CreatedBy,
+		IsBot:     commondomain.IsBotID(message.CreatedBy),
+		Creator:   UserToProto(message.Creator),
+		CreatedAt: timestamppb.New(message.CreatedAt),
+		UpdatedAt: timestamppb.New(message.UpdatedAt),
+
+		ExpiresIn:  uintToProto(message.ExpiresIn),
+		ExpireFrom: uint32(message.ExpireFrom.Uint8()),
+		IsViewOnce: message.IsViewOnce,
+		ExpiresAt:  timeToProto(message.ExpiresAt),
+
+		ScheduledMessageId: message.ScheduledMessageID,
+		SendAt:             timeToProto(message.SendAt),
+
+		ForwardedFrom: MessageForwardToProto(message.ForwardedFrom),
+
+		Kind: uint32(message.Kind.Uint8()),
+
+		LinkPreviews: lo.Map(message.LinkPreviews, func(linkPreview domain.LinkPreview, _ int) *chatv1.LinkPreview {
+			return LinkPreviewToProto(linkPreview)
+		}),
+
+		IsEphemeral: message.IsEphemeral,
+	}
+}
+
+func MessageEntityToProto(entity domain.MessageEntity) *chatv1.MessageEntity {
+	return &chatv1.MessageEntity{
+		Type:     uint32(entity.Type.ToUint8()),
+		Offset:   int32(min(entity.Offset, math.MaxInt32)),
+		Length:   int32(min(entity.Length, math.MaxInt32)),
+		UserId:   entity.UserID,
+		Language: entity.Language,
+	}
+}
+
+func MessageForwardToProto(forward *domain.MessageForward) *chatv1.MessageForward {
+	if forward == nil {
+		return nil
+	}
+
+	return &chatv1.MessageForward{
+		MessageId: forward.MessageID,
+		ChatId:    forward.ChatID,
+		CreatedBy: forward.CreatedBy,
+	}
+}
+
+func LinkPreviewToProto(linkPreview domain.LinkPreview) *chatv1.LinkPreview {
+	return &chatv1.LinkPreview{
+		Url:         linkPreview.URL,
+		Title:       linkPreview.Title,
+		Description: linkPreview.Description,
+		ImageUrl:    linkPreview.ImageURL,
+		SiteName:    linkPreview.SiteName,
+	}
+}
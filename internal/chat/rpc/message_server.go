@@ -0,0 +1,140 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+)
+
+type MessageServer struct {
+	chatv1.UnimplementedMessageServiceServer
+
+	validate       validator.Validate
+	messageService MessageService
+}
+
+func (s *MessageServer) Register(registrar grpc.ServiceRegistrar) {
+	chatv1.RegisterMessageServiceServer(registrar, s)
+}
+
+func (s *MessageServer) ListMessages(ctx context.Context, req *chatv1.ListMessagesRequest) (*chatv1.ListMessagesResponse, error) {
+	query := chathttp.MessageQuery{
+		IDs: req.GetIds(),
+		Statuses: lo.Map(req.GetStatuses(), func(status uint32, _ int) uint8 {
+			return uint8FromProto(status)
+		}),
+		Search: req.GetSearch(),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Sort:   req.GetSort(),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, &query); err != nil {
+		return nil, errors.NewValidationError(constants.ChatDomain, err, nil)
+	}
+
+	messageFilter, err := chathttp.MessageFilterFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	messageFilter.ChatIDs = []uint64{req.GetChatId()}
+
+	messages, count, err := s.messageService.GetMessages(ctx, &messageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatv1.ListMessagesResponse{
+		Messages: lo.Map(messages, func(message chatdomain.Message, _ int) *chatv1.Message {
+			return MessageToProto(message)
+		}),
+		Count: count,
+	}, nil
+}
+
+func (s *MessageServer) CreateMessage(ctx context.Context, req *chatv1.CreateMessageRequest) (*chatv1.Message, error) {
+	user := domain.UserFromContext(ctx)
+
+	dto := chathttp.CreateMessageDto{
+		Text:          req.GetText(),
+		ExpiresIn:     uintFromProto(req.ExpiresIn),
+		ExpireFrom:    uint8FromProto(req.GetExpireFrom()),
+		IsViewOnce:    req.GetIsViewOnce(),
+		UndoSendDelay: uintFromProto(req.UndoSendDelay),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	message := chathttp.MessageFromCreateDto(dto)
+	message.ChatID = req.GetChatId()
+	message.CreatedBy = user.ID
+
+	createdMessage, err := s.messageService.CreateMessage(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdMessage == nil {
+		return nil, errors.NewNotFoundError(constants.ChatDomain)
+	}
+
+	return MessageToProto(*createdMessage), nil
+}
+
+func (s *MessageServer) ForwardMessages(ctx context.Context, req *chatv1.ForwardMessagesRequest) (*chatv1.ForwardMessagesResponse, error) {
+	dto := chathttp.ForwardMessagesDto{
+		MessageIDs: req.GetMessageIds(),
+		ChatIDs:    req.GetToChatIds(),
+	}
+
+	if err := s.validate.Struct(constants.ChatDomain, dto); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageService.ForwardMessages(ctx, req.GetChatId(), dto.MessageIDs, dto.ChatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chatv1.ForwardMessagesResponse{
+		Messages: lo.Map(messages, func(message chatdomain.Message, _ int) *chatv1.Message {
+			return MessageToProto(message)
+		}),
+	}, nil
+}
+
+func NewMessageServer(
+	validate validator.Validate,
+	messageService MessageService,
+) *MessageServer {
+	return &MessageServer{
+		validate:       validate,
+		messageService: messageService,
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+)
+
+// streamBufferSize is the number of events a stream may fall behind, the
+// stream is closed beyond, so the client resubscribes and catches up.
+const streamBufferSize = 64
+
+var errStreamBufferFull = errors.New("stream buffer is full")
+
+// streamConnection is a connection of an event stream, the connector
+// delivers events to it as to a websocket. Events are queued and sent by
+// StreamEvents, so a slow client doesn't block the delivery to others.
+// Streams don't receive events, so its message chan stays empty.
+type streamConnection struct {
+	connectionID string
+
+	connector connector.Connector
+
+	credentialsMtx sync.RWMutex
+	user           *domain.User
+	token          string
+
+	events       chan *chatv1.Event
+	isOverflowed atomic.Bool
+
+	messageChan chan []byte
+	closeChan   chan struct{}
+	closeOnce   sync.Once
+	closeCode   atomic.Int64
+	isClosed    atomic.Bool
+}
+
+func (c *streamConnection) IsClosed() bool {
+	return c.isClosed.Load()
+}
+
+func (c *streamConnection) GetConnectionID() string {
+	return c.connectionID
+}
+
+func (c *streamConnection) GetConnector() connector.Connector {
+	return c.connector
+}
+
+func (c *streamConnection) SetConnector(connector connector.Connector) {
+	c.connector = connector
+}
+
+func (c *streamConnection) GetMessageChan() chan []byte {
+	return c.messageChan
+}
+
+func (c *streamConnection) GetCloseChan() chan struct{} {
+	return c.closeChan
+}
+
+// SendReply sends the event without the request ID, streams don't take
+// requests.
+func (c *streamConnection) SendReply(_ string, eventType uint64, data any) error {
+	return c.SendEvent(eventType, data)
+}
+
+func (c *streamConnection) SendEvent(eventType uint64, data any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if c.IsClosed() {
+		return nil
+	}
+
+	select {
+	case c.events <- &chatv1.Event{Type: eventType, Data: rawData}:
+		return nil
+	default:
+		c.isOverflowed.Store(true)
+		c.Close()
+
+		return errStreamBufferFull
+	}
+}
+
+func (c *streamConnection) Connect() {}
+
+func (c *streamConnection) Close() {
+	c.closeOnce.Do(func() {
+		c.isClosed.Store(true)
+		close(c.closeChan)
+	})
+}
+
+func (c *streamConnection) CloseWithReason(code int, _ string) {
+	c.closeCode.CompareAndSwap(0, int64(code))
+	c.Close()
+}
+
+// GetCloseCode returns the code the connection was closed with, zero if it
+// was closed without a reason.
+func (c *streamConnection) GetCloseCode() int {
+	return int(c.closeCode.Load())
+}
+
+func (c *streamConnection) GetUser() *domain.User {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
+	return c.user
+}
+
+func (c *streamConnection) GetToken() string {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
+	return c.token
+}
+
+func (c *streamConnection) SetCredentials(user *domain.User, token string) {
+	c.credentialsMtx.Lock()
+	defer c.credentialsMtx.Unlock()
+
+	c.user = user
+	c.token = token
+}
+
+func newStreamConnection(user *domain.User, token string) *streamConnection {
+	return &streamConnection{
+		connectionID: uuid.NewString(),
+		user:         user,
+		token:        token,
+		events:       make(chan *chatv1.Event, streamBufferSize),
+		messageChan:  make(chan []byte),
+		closeChan:    make(chan struct{}),
+	}
+}
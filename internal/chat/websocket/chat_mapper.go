@@ -0,0 +1,45 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+func ChatToDto(chat domain.Chat) ChatDto {
+	return ChatDto{
+		ID:        chat.ID,
+		Name:      chat.Name,
+		Type:      chat.Type.Uint8(),
+		Image:     chatapi.Image(chat.Image),
+		CreatedBy: chat.CreatedBy,
+		UserChats: lo.Map(chat.UserChats, func(userChat domain.UserChat, _ int) UserChatDto {
+			return UserChatToDto(userChat)
+		}),
+		CreatedAt: chat.CreatedAt,
+		UpdatedAt: chat.UpdatedAt,
+	}
+}
+
+func UserChatToDto(userChat domain.UserChat) UserChatDto {
+	return UserChatDto{
+		UserID: userChat.UserID,
+		ChatID: userChat.ChatID,
+		Role:   userChat.Role.Uint8(),
+	}
+}
@@ -18,8 +18,8 @@ import (
 	"github.com/fasthttp/websocket"
 	"golang.org/x/exp/slices"
 
-	"chat-go/internal/common/domain"
-	"chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
 )
 
 type Connection interface {
@@ -69,8 +69,28 @@ func (c *connectionImpl) IsCurrentChat(chatID uint64) bool {
 	return *c.GetCurrentChat() == chatID
 }
 
-func NewConnection(conn *websocket.Conn, user *domain.User) connector.Connection {
+// requestConnection replies to a request of the client, the events it sends
+// carry the ID of the request.
+type requestConnection struct {
+	Connection
+
+	requestID string
+	replied   bool
+}
+
+func (c *requestConnection) SendEvent(eventType uint64, data any) error {
+	c.replied = true
+	return c.SendReply(c.requestID, eventType, data)
+}
+
+func NewConnection(conn *websocket.Conn, user *domain.User, token string) connector.Connection {
+	return WrapConnection(connector.NewWebSocketConnection(conn, user, token))
+}
+
+// WrapConnection adds chat subscriptions to a connection of another
+// transport, so the EventSink delivers events to it as to a websocket.
+func WrapConnection(conn connector.Connection) Connection {
 	return &connectionImpl{
-		Connection: connector.NewWebSocketConnection(conn, user),
+		Connection: conn,
 	}
 }
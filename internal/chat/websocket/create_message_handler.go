@@ -15,13 +15,19 @@
 package websocket
 
 import (
-	"context"
 	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 func (e *EventHandler) createMessageHandler(conn Connection, rawData []byte) error {
 	dto := MessageDto{}
 	if err := json.Unmarshal(rawData, &dto); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := e.validate.Struct(constants.ChatDomain, dto); err != nil {
 		return err
 	}
 
@@ -29,42 +35,37 @@ func (e *EventHandler) createMessageHandler(conn Connection, rawData []byte) err
 
 	chatID := conn.GetCurrentChat()
 	if chatID == nil {
-		return nil
+		return newNoCurrentChatError()
 	}
 
 	newMessage := MessageFromCreateDto(dto)
 	newMessage.ChatID = *chatID
 	newMessage.CreatedBy = conn.GetUser().ID
 
-	message, err := e.messageService.CreateMessage(context.Background(), newMessage)
+	message, err := e.messageService.CreateMessage(connectionContext(conn), newMessage)
 	if err != nil {
 		return err
 	}
 
 	if message == nil {
-		return nil
+		return errors.NewNotFoundError(constants.ChatDomain)
 	}
 
+	// Other connections receive the message from the outbox, the sender
+	// gets it right away together with its client UUID.
 	dto = MessageToDto(*message)
+	dto.UUID = uuid
 
-	for _, baseConnection := range conn.GetConnector().GetConnections() {
-		connection := baseConnection.(Connection)
-
-		if connection.GetConnectionID() == conn.GetConnectionID() {
-			continue
-		}
-
-		if !connection.IsCurrentChat(*chatID) && !connection.IsSubscribed(*chatID) {
-			continue
-		}
-
-		if err := connection.SendEvent(CreateMessageEventType, dto); err != nil {
-			return err
-		}
+	// A held message is only announced to its sender, it's sent to the
+	// chat when the undo-send window is over.
+	var eventType uint64 = CreateMessageEventType
+	if message.IsEphemeral {
+		eventType = CommandReplyEventType
+	} else if message.ScheduledMessageID != 0 {
+		eventType = MessageHeldEventType
 	}
 
-	dto.UUID = uuid
-	if err := conn.SendEvent(CreateMessageEventType, dto); err != nil {
+	if err := conn.SendEvent(eventType, dto); err != nil {
 		return err
 	}
 
@@ -0,0 +1,45 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+func (e *EventHandler) editMessageHandler(conn Connection, rawData []byte) error {
+	var data EditMessageEventData
+
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := e.validate.Struct(constants.ChatDomain, data); err != nil {
+		return err
+	}
+
+	message, err := e.messageService.UpdateMessage(connectionContext(conn), domain.Message{
+		ID:   data.MessageID,
+		Text: data.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	return conn.SendEvent(EditMessageEventType, MessageToDto(*message))
+}
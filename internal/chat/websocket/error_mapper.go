@@ -0,0 +1,37 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+// errorToEventData resolves an error as the REST API does, the internal
+// details of the error aren't sent.
+func errorToEventData(err error) ErrorEventData {
+	statusCode, baseError := api.ResolveError(err)
+	errorData := errors.TruncateErrorData(baseError.GetErrorData())
+
+	return ErrorEventData{
+		Status: statusCode,
+		ErrorData: chatapi.ErrorData{
+			Domain:    errorData.Domain,
+			ErrorType: errorData.ErrorType,
+			Data:      errorData.Data,
+		},
+	}
+}
@@ -17,24 +17,60 @@ package websocket
 import (
 	"context"
 
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/infrastructure/connector"
-	"chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
 )
 
 type MessageService interface {
 	CreateMessage(ctx context.Context, message domain.Message) (*domain.Message, error)
-	UpdateMessageStatus(ctx context.Context, messageIDs []uint64, status domain.MessageStatus) error
+	UpdateMessage(ctx context.Context, message domain.Message) (*domain.Message, error)
+	DeleteMessage(ctx context.Context, id uint64) error
+	UpdateMessageStatus(ctx context.Context, chatID uint64, messageIDs []uint64, status domain.MessageStatus) error
+}
+
+type PollService interface {
+	Vote(ctx context.Context, id uint64, optionIDs []uint64) (*domain.Poll, error)
+	RetractVote(ctx context.Context, id uint64) (*domain.Poll, error)
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string, isBot bool) (*commondomain.User, error)
 }
 
 type EventHandler struct {
 	validate       validator.Validate
 	messageService MessageService
+	pollService    PollService
+	authenticator  Authenticator
 }
 
+// HandleEvent handles an event of a client. The replies to the event carry
+// its request ID, if it fails the client gets an error event with the
+// request ID instead. A request the handler has no reply for is
+// acknowledged with an event of its type without data, so the client never
+// waits for a reply that doesn't come.
 func (e *EventHandler) HandleEvent(baseConn connector.Connection, event connector.Event) error {
 	conn := baseConn.(Connection)
 
+	var reqConn *requestConnection
+	if event.RequestID != "" {
+		reqConn = &requestConnection{Connection: conn, requestID: event.RequestID}
+		conn = reqConn
+	}
+
+	err := e.handleEvent(conn, event)
+	if err != nil {
+		_ = conn.SendEvent(ErrorEventType, errorToEventData(err))
+	} else if reqConn != nil && !reqConn.replied {
+		_ = conn.SendEvent(event.Type, nil)
+	}
+
+	return err
+}
+
+func (e *EventHandler) handleEvent(conn Connection, event connector.Event) error {
 	switch event.Type {
 	case SubscribeChatsEventType:
 		return e.subscribeChatHandler(conn, event.Data)
@@ -47,9 +83,17 @@ func (e *EventHandler) HandleEvent(baseConn connector.Connection, event connecto
 	case CreateMessageEventType:
 		return e.createMessageHandler(conn, event.Data)
 	case EditMessageEventType:
+		return e.editMessageHandler(conn, event.Data)
 	case DeleteMessageEventType:
+		return e.deleteMessageHandler(conn, event.Data)
 	case UpdateMessagesStatusEventType:
 		return e.updateMessagesStatusHandler(conn, event.Data)
+	case VotePollEventType:
+		return e.votePollHandler(conn, event.Data)
+	case RetractPollVoteEventType:
+		return e.retractPollVoteHandler(conn, event.Data)
+	case RefreshTokenEventType:
+		return e.refreshTokenHandler(conn, event.Data)
 	}
 
 	return nil
@@ -58,9 +102,13 @@ func (e *EventHandler) HandleEvent(baseConn connector.Connection, event connecto
 func NewEventHandler(
 	validate validator.Validate,
 	messageService MessageService,
+	pollService PollService,
+	authenticator Authenticator,
 ) *EventHandler {
 	return &EventHandler{
 		validate:       validate,
 		messageService: messageService,
+		pollService:    pollService,
+		authenticator:  authenticator,
 	}
 }
@@ -0,0 +1,209 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+)
+
+type sentEvent struct {
+	requestID string
+	eventType uint64
+	data      any
+}
+
+type fakeConnection struct {
+	Connection
+
+	currentChat *uint64
+	sent        []sentEvent
+}
+
+func (c *fakeConnection) GetCurrentChat() *uint64 {
+	return c.currentChat
+}
+
+func (c *fakeConnection) SetCurrentChat(id *uint64) {
+	c.currentChat = id
+}
+
+func (c *fakeConnection) SendEvent(eventType uint64, data any) error {
+	return c.SendReply("", eventType, data)
+}
+
+func (c *fakeConnection) SendReply(requestID string, eventType uint64, data any) error {
+	c.sent = append(c.sent, sentEvent{requestID: requestID, eventType: eventType, data: data})
+	return nil
+}
+
+func (c *fakeConnection) GetUser() *commondomain.User {
+	return &commondomain.User{ID: 1}
+}
+
+func (c *fakeConnection) GetToken() string {
+	return "token"
+}
+
+func (c *fakeConnection) GetConnectionID() string {
+	return "connection"
+}
+
+type fakeMessageService struct {
+	MessageService
+
+	err error
+}
+
+func (s *fakeMessageService) CreateMessage(context.Context, domain.Message) (*domain.Message, error) {
+	return nil, s.err
+}
+
+func (s *fakeMessageService) DeleteMessage(context.Context, uint64) error {
+	return s.err
+}
+
+func (s *fakeMessageService) UpdateMessageStatus(context.Context, uint64, []uint64, domain.MessageStatus) error {
+	return s.err
+}
+
+func TestHandleEventReplies(t *testing.T) {
+	validate, err := validator.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chatID := uint64(1)
+
+	tests := []struct {
+		name        string
+		event       connector.Event
+		currentChat *uint64
+		serviceErr  error
+		wantType    uint64
+		wantStatus  int
+	}{
+		{
+			name:     "reply carries the request ID",
+			event:    connector.Event{Type: DeleteMessageEventType, RequestID: "1", Data: json.RawMessage(`{"messageId":1}`)},
+			wantType: DeleteMessageEventType,
+		},
+		{
+			name:       "malformed data",
+			event:      connector.Event{Type: DeleteMessageEventType, RequestID: "1", Data: json.RawMessage(`"1"`)},
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "undo-send delay out of range",
+			event:      connector.Event{Type: CreateMessageEventType, RequestID: "1", Data: json.RawMessage(`{"text":"hi","undoSendDelay":31}`)},
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "expiry out of range",
+			event:      connector.Event{Type: CreateMessageEventType, RequestID: "1", Data: json.RawMessage(`{"text":"hi","expiresIn":31536001}`)},
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "message without current chat",
+			event:      connector.Event{Type: CreateMessageEventType, RequestID: "1", Data: json.RawMessage(`{"text":"hi"}`)},
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "message not created",
+			event:       connector.Event{Type: CreateMessageEventType, RequestID: "1", Data: json.RawMessage(`{"text":"hi"}`)},
+			currentChat: &chatID,
+			wantType:    ErrorEventType,
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:       "status update without current chat",
+			event:      connector.Event{Type: UpdateMessagesStatusEventType, RequestID: "1", Data: json.RawMessage(`{"status":3,"messageIds":[1]}`)},
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "request without reply is acknowledged",
+			event:       connector.Event{Type: UpdateMessagesStatusEventType, RequestID: "1", Data: json.RawMessage(`{"status":3,"messageIds":[1]}`)},
+			currentChat: &chatID,
+			wantType:    UpdateMessagesStatusEventType,
+		},
+		{
+			name:     "set current chat is acknowledged",
+			event:    connector.Event{Type: SetCurrentChatEventType, RequestID: "1", Data: json.RawMessage(`1`)},
+			wantType: SetCurrentChatEventType,
+		},
+		{
+			name:       "service error",
+			event:      connector.Event{Type: DeleteMessageEventType, RequestID: "1", Data: json.RawMessage(`{"messageId":1}`)},
+			serviceErr: errors.NewNotFoundError(constants.ChatDomain),
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error without request ID",
+			event:      connector.Event{Type: DeleteMessageEventType, Data: json.RawMessage(`{"messageId":1}`)},
+			serviceErr: errors.NewForbiddenError(),
+			wantType:   ErrorEventType,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewEventHandler(validate, &fakeMessageService{err: tt.serviceErr}, nil, nil)
+			conn := &fakeConnection{currentChat: tt.currentChat}
+
+			err := handler.HandleEvent(conn, tt.event)
+			if (err != nil) != (tt.wantStatus != 0) {
+				t.Fatalf("HandleEvent() error = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if len(conn.sent) != 1 {
+				t.Fatalf("sent %d events, want 1", len(conn.sent))
+			}
+
+			sent := conn.sent[0]
+			if sent.requestID != tt.event.RequestID || sent.eventType != tt.wantType {
+				t.Fatalf("sent event %d with request ID %q, want %d with %q",
+					sent.eventType, sent.requestID, tt.wantType, tt.event.RequestID)
+			}
+
+			if tt.wantStatus == 0 {
+				return
+			}
+
+			data, ok := sent.data.(ErrorEventData)
+			if !ok || data.Status != tt.wantStatus || data.ErrorType == "" {
+				t.Fatalf("sent error data %+v, want status %d", sent.data, tt.wantStatus)
+			}
+			if len(data.DevDetails) != 0 {
+				t.Fatalf("sent dev details %v", data.DevDetails)
+			}
+		})
+	}
+}
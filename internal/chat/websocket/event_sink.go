@@ -0,0 +1,206 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+const EventSinkName = "websocket"
+
+// EventSink forwards outbox events to the live WebSocket connections.
+type EventSink struct {
+	log       logger.Logger
+	connector connector.Connector
+}
+
+func (s *EventSink) Name() string {
+	return EventSinkName
+}
+
+func (s *EventSink) Deliver(_ context.Context, event outboxdomain.Event, _ repository.Tx) error {
+	switch event.Type {
+	case domain.ChatCreatedEventType:
+		return s.deliverChat(event, ChatCreatedEventType)
+	case domain.ChatUpdatedEventType:
+		return s.deliverChat(event, ChatUpdatedEventType)
+	case domain.ChatDeletedEventType:
+		return s.deliverChat(event, ChatDeletedEventType)
+	case domain.MemberAddedEventType:
+		return s.deliverMemberAdded(event)
+	case domain.MessageCreatedEventType:
+		return s.deliverMessageCreated(event)
+	case domain.MessageUpdatedEventType:
+		return s.deliverMessageUpdated(event)
+	case domain.MessageDeletedEventType:
+		return s.deliverMessageDeleted(event)
+	case domain.MessagesStatusUpdatedEventType:
+		return s.deliverMessagesStatusUpdated(event)
+	case domain.PollUpdatedEventType:
+		return s.deliverPollUpdated(event)
+	}
+
+	return nil
+}
+
+func (s *EventSink) deliverChat(event outboxdomain.Event, eventType uint64) error {
+	var chat domain.Chat
+	if err := json.Unmarshal(event.Payload, &chat); err != nil {
+		return err
+	}
+
+	memberIDs := lo.Map(chat.UserChats, func(userChat domain.UserChat, _ int) uint64 {
+		return userChat.UserID
+	})
+
+	s.send(eventType, ChatToDto(chat), func(connection Connection) bool {
+		return lo.Contains(memberIDs, connection.GetUser().ID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverMemberAdded(event outboxdomain.Event) error {
+	var userChat domain.UserChat
+	if err := json.Unmarshal(event.Payload, &userChat); err != nil {
+		return err
+	}
+
+	s.send(MemberAddedEventType, UserChatToDto(userChat), func(connection Connection) bool {
+		return connection.GetUser().ID == userChat.UserID || isFollowing(connection, userChat.ChatID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverMessageCreated(event outboxdomain.Event) error {
+	var message domain.Message
+	if err := json.Unmarshal(event.Payload, &message); err != nil {
+		return err
+	}
+
+	dto := MessageToDto(message)
+
+	// The origin connection already got the message in reply to its request.
+	s.send(CreateMessageEventType, dto, func(connection Connection) bool {
+		return connection.GetConnectionID() != event.Origin && isFollowing(connection, message.ChatID)
+	})
+
+	// Mentioned users are notified on every connection, whether they
+	// follow the chat or not.
+	s.send(MentionEventType, dto, func(connection Connection) bool {
+		return lo.Contains(message.MentionedUserIDs, connection.GetUser().ID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverMessageUpdated(event outboxdomain.Event) error {
+	var message domain.Message
+	if err := json.Unmarshal(event.Payload, &message); err != nil {
+		return err
+	}
+
+	s.send(EditMessageEventType, MessageToDto(message), func(connection Connection) bool {
+		return connection.GetConnectionID() != event.Origin && isFollowing(connection, message.ChatID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverMessageDeleted(event outboxdomain.Event) error {
+	var payload domain.MessageDeletedEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return err
+	}
+
+	dto := DeleteMessageEventData{
+		MessageID: payload.MessageID,
+		ChatID:    payload.ChatID,
+	}
+
+	s.send(DeleteMessageEventType, dto, func(connection Connection) bool {
+		return connection.GetConnectionID() != event.Origin && isFollowing(connection, payload.ChatID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverMessagesStatusUpdated(event outboxdomain.Event) error {
+	var payload domain.MessagesStatusEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return err
+	}
+
+	dto := MessagesStatusDto{
+		Status:     payload.Status.ToUint8(),
+		MessageIDs: payload.MessageIDs,
+	}
+
+	s.send(UpdateMessagesStatusEventType, dto, func(connection Connection) bool {
+		return isFollowing(connection, payload.ChatID)
+	})
+
+	return nil
+}
+
+func (s *EventSink) deliverPollUpdated(event outboxdomain.Event) error {
+	var poll domain.Poll
+	if err := json.Unmarshal(event.Payload, &poll); err != nil {
+		return err
+	}
+
+	s.send(PollUpdatedEventType, PollToDto(poll), func(connection Connection) bool {
+		return connection.GetConnectionID() != event.Origin && isFollowing(connection, poll.ChatID)
+	})
+
+	return nil
+}
+
+// send doesn't fail on a single connection, otherwise a retry would deliver
+// the event again to every other recipient.
+func (s *EventSink) send(eventType uint64, data any, filter func(connection Connection) bool) {
+	for _, baseConnection := range s.connector.GetConnections() {
+		connection, ok := baseConnection.(Connection)
+		if !ok || connection.IsClosed() || !filter(connection) {
+			continue
+		}
+
+		if err := connection.SendEvent(eventType, data); err != nil {
+			s.log.Debugf("error on send event event_type=%d connection_id=%s: %s",
+				eventType, connection.GetConnectionID(), err.Error())
+		}
+	}
+}
+
+func isFollowing(connection Connection, chatID uint64) bool {
+	return connection.IsCurrentChat(chatID) || connection.IsSubscribed(chatID)
+}
+
+func NewEventSink(log logger.Logger, connector connector.Connector) *EventSink {
+	return &EventSink{
+		log:       log,
+		connector: connector,
+	}
+}
@@ -14,22 +14,53 @@
 
 package websocket
 
+import (
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+// The event types and their data are part of the protocol shared with
+// clients, see chatapi.
 const (
-	SubscribeChatsEventType       = 1
-	UnsubscribeChatsEventType     = 2
-	SetCurrentChatEventType       = 3
-	UnsetCurrentChatEventType     = 4
-	CreateMessageEventType        = 5
-	EditMessageEventType          = 6
-	DeleteMessageEventType        = 7
-	UpdateMessagesStatusEventType = 8
+	SubscribeChatsEventType       = chatapi.SubscribeChatsEventType
+	UnsubscribeChatsEventType     = chatapi.UnsubscribeChatsEventType
+	SetCurrentChatEventType       = chatapi.SetCurrentChatEventType
+	UnsetCurrentChatEventType     = chatapi.UnsetCurrentChatEventType
+	CreateMessageEventType        = chatapi.CreateMessageEventType
+	EditMessageEventType          = chatapi.EditMessageEventType
+	DeleteMessageEventType        = chatapi.DeleteMessageEventType
+	UpdateMessagesStatusEventType = chatapi.UpdateMessagesStatusEventType
+	MentionEventType              = chatapi.MentionEventType
+	ChatCreatedEventType          = chatapi.ChatCreatedEventType
+	ChatUpdatedEventType          = chatapi.ChatUpdatedEventType
+	ChatDeletedEventType          = chatapi.ChatDeletedEventType
+	MemberAddedEventType          = chatapi.MemberAddedEventType
+	CommandReplyEventType         = chatapi.CommandReplyEventType
+	MessageHeldEventType          = chatapi.MessageHeldEventType
+	VotePollEventType             = chatapi.VotePollEventType
+	RetractPollVoteEventType      = chatapi.RetractPollVoteEventType
+	PollUpdatedEventType          = chatapi.PollUpdatedEventType
+	AuthenticateEventType         = chatapi.AuthenticateEventType
+	AuthenticatedEventType        = chatapi.AuthenticatedEventType
+	RefreshTokenEventType         = chatapi.RefreshTokenEventType
+	TokenRefreshedEventType       = chatapi.TokenRefreshedEventType
+	ErrorEventType                = chatapi.ErrorEventType
 )
 
-type EditMessageEventData struct {
-	MessageID string `json:"messageId"`
-	Text      string `json:"text"`
-}
+// Subprotocol is negotiated by clients passing their token as a
+// subprotocol, see BearerSubprotocolPrefix and BotSubprotocolPrefix.
+const (
+	Subprotocol             = chatapi.Subprotocol
+	BearerSubprotocolPrefix = chatapi.BearerSubprotocolPrefix
+	BotSubprotocolPrefix    = chatapi.BotSubprotocolPrefix
+)
 
-type DeleteMessageEventData struct {
-	MessageID string `json:"messageId"`
-}
+type (
+	AuthenticateEventData    = chatapi.AuthenticateEventData
+	AuthenticatedEventData   = chatapi.AuthenticatedEventData
+	RefreshTokenEventData    = chatapi.RefreshTokenEventData
+	EditMessageEventData     = chatapi.EditMessageEventData
+	VotePollEventData        = chatapi.VotePollEventData
+	RetractPollVoteEventData = chatapi.RetractPollVoteEventData
+	DeleteMessageEventData   = chatapi.DeleteMessageEventData
+	ErrorEventData           = chatapi.ErrorEventData
+)
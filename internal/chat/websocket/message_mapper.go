@@ -17,8 +17,9 @@ package websocket
 import (
 	"github.com/samber/lo"
 
-	"chat-go/internal/chat/domain"
-	"chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/http"
 )
 
 func MessageToDto(message domain.Message) MessageDto {
@@ -27,20 +28,86 @@ func MessageToDto(message domain.Message) MessageDto {
 		creatorDto = lo.ToPtr(http.UserToDto(*message.Creator))
 	}
 
+	var pollDto *PollDto
+	if message.Poll != nil {
+		pollDto = lo.ToPtr(PollToDto(*message.Poll))
+	}
+
 	return MessageDto{
-		ID:        message.ID,
-		Text:      message.Text,
-		Status:    message.Status.ToUint8(),
-		ChatID:    message.ChatID,
+		ID:     message.ID,
+		Text:   message.Text,
+		Status: message.Status.ToUint8(),
+		ChatID: message.ChatID,
+		Entities: lo.Map(message.Entities, func(entity domain.MessageEntity, _ int) MessageEntityDto {
+			return MessageEntityToDto(entity)
+		}),
 		Creator:   creatorDto,
 		CreatedBy: message.CreatedBy,
+		IsBot:     commondomain.IsBotID(message.CreatedBy),
 		CreatedAt: message.CreatedAt,
 		UpdatedAt: message.UpdatedAt,
+
+		IsEphemeral: message.IsEphemeral,
+
+		ExpiresIn:  message.ExpiresIn,
+		ExpireFrom: message.ExpireFrom.Uint8(),
+		IsViewOnce: message.IsViewOnce,
+		ExpiresAt:  message.ExpiresAt,
+
+		ScheduledMessageID: message.ScheduledMessageID,
+		SendAt:             message.SendAt,
+
+		ForwardedFrom: MessageForwardToDto(message.ForwardedFrom),
+
+		Kind: message.Kind.Uint8(),
+		Poll: pollDto,
+
+		LinkPreviews: lo.Map(message.LinkPreviews, func(linkPreview domain.LinkPreview, _ int) LinkPreviewDto {
+			return LinkPreviewToDto(linkPreview)
+		}),
+	}
+}
+
+func LinkPreviewToDto(linkPreview domain.LinkPreview) LinkPreviewDto {
+	return LinkPreviewDto{
+		URL:         linkPreview.URL,
+		Title:       linkPreview.Title,
+		Description: linkPreview.Description,
+		ImageURL:    linkPreview.ImageURL,
+		SiteName:    linkPreview.SiteName,
+	}
+}
+
+func MessageForwardToDto(forward *domain.MessageForward) *MessageForwardDto {
+	if forward == nil {
+		return nil
+	}
+
+	return &MessageForwardDto{
+		MessageID: forward.MessageID,
+		ChatID:    forward.ChatID,
+		CreatedBy: forward.CreatedBy,
+	}
+}
+
+func MessageEntityToDto(entity domain.MessageEntity) MessageEntityDto {
+	return MessageEntityDto{
+		Type:   entity.Type.ToUint8(),
+		Offset: entity.Offset,
+		Length: entity.Length,
+		UserID: entity.UserID,
+
+		Language: entity.Language,
 	}
 }
 
 func MessageFromCreateDto(message MessageDto) domain.Message {
 	return domain.Message{
-		Text: message.Text,
+		Text:       message.Text,
+		ExpiresIn:  message.ExpiresIn,
+		ExpireFrom: domain.ExpireFrom(message.ExpireFrom),
+		IsViewOnce: message.IsViewOnce,
+
+		UndoSendDelay: message.UndoSendDelay,
 	}
 }
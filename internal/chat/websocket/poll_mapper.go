@@ -0,0 +1,50 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+)
+
+func PollToDto(poll domain.Poll) PollDto {
+	return PollDto{
+		ID:        poll.ID,
+		MessageID: poll.MessageID,
+		ChatID:    poll.ChatID,
+		Question:  poll.Question,
+		Options: lo.Map(poll.Options, func(option domain.PollOption, _ int) PollOptionDto {
+			return PollOptionDto{
+				ID:        option.ID,
+				Text:      option.Text,
+				VoteCount: option.VoteCount,
+				VoterIDs:  option.VoterIDs,
+			}
+		}),
+		IsMultipleChoice: poll.IsMultipleChoice,
+		IsAnonymous:      poll.IsAnonymous,
+		IsClosed:         poll.IsClosed(time.Now()),
+		ClosesAt:         poll.ClosesAt,
+		ClosedAt:         poll.ClosedAt,
+		VoterCount:       poll.VoterCount,
+		CreatedBy:        poll.CreatedBy,
+		CreatedAt:        poll.CreatedAt,
+		UpdatedAt:        poll.UpdatedAt,
+		VotedOptionIDs:   poll.VotedOptionIDs,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+)
+
+// Presence tells which users follow chats on live connections of this
+// instance.
+type Presence struct {
+	connector connector.Connector
+}
+
+// GetFollowers returns the followers of every followed chat: the current
+// chat and the subscribed chats of every open connection.
+func (p *Presence) GetFollowers() map[uint64][]uint64 {
+	followers := make(map[uint64][]uint64)
+
+	for _, baseConnection := range p.connector.GetConnections() {
+		connection, ok := baseConnection.(Connection)
+		if !ok || connection.IsClosed() {
+			continue
+		}
+
+		chatIDs := append([]uint64{}, connection.GetSubscribedChats()...)
+		if currentChat := connection.GetCurrentChat(); currentChat != nil {
+			chatIDs = append(chatIDs, *currentChat)
+		}
+
+		for _, chatID := range lo.Uniq(chatIDs) {
+			followers[chatID] = append(followers[chatID], connection.GetUser().ID)
+		}
+	}
+
+	for chatID, userIDs := range followers {
+		followers[chatID] = lo.Uniq(userIDs)
+	}
+
+	return followers
+}
+
+func NewPresence(connector connector.Connector) *Presence {
+	return &Presence{connector: connector}
+}
@@ -0,0 +1,51 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+// refreshTokenHandler replaces the token of the connection before the old
+// one expires. The new token has to belong to the same user, otherwise the
+// connection keeps its credentials.
+func (e *EventHandler) refreshTokenHandler(conn Connection, rawData []byte) error {
+	var data RefreshTokenEventData
+
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
+	}
+
+	if err := e.validate.Struct(constants.ChatDomain, data); err != nil {
+		return err
+	}
+
+	user, err := e.authenticator.Authenticate(context.Background(), data.Token, conn.GetUser().IsBot)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || user.ID != conn.GetUser().ID {
+		return errors.NewUnauthorizedError("token of another user")
+	}
+
+	conn.SetCredentials(user, data.Token)
+
+	return conn.SendEvent(TokenRefreshedEventType, AuthenticatedEventData{UserID: user.ID})
+}
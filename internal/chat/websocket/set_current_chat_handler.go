@@ -16,16 +16,26 @@ package websocket
 
 import (
 	"encoding/json"
+	"fmt"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 func (e *EventHandler) setCurrentChatHandler(conn Connection, rawData []byte) error {
 	var chatID uint64
 
 	if err := json.Unmarshal(rawData, &chatID); err != nil {
-		return err
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
 	}
 
 	conn.SetCurrentChat(&chatID)
 
 	return nil
 }
+
+// newNoCurrentChatError is returned for the events sent to the current chat
+// before the client set one.
+func newNoCurrentChatError() error {
+	return errors.NewBadRequestError(constants.ChatDomain, fmt.Errorf("no current chat is set"), nil)
+}
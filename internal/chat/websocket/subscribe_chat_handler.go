@@ -16,6 +16,9 @@ package websocket
 
 import (
 	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 type SubscribeRoomEventData struct {
@@ -26,7 +29,7 @@ func (e *EventHandler) subscribeChatHandler(conn Connection, rawData []byte) err
 	var chatIDs []uint64
 
 	if err := json.Unmarshal(rawData, &chatIDs); err != nil {
-		return err
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
 	}
 
 	conn.SetSubscribedChats(chatIDs)
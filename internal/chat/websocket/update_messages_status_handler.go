@@ -15,18 +15,18 @@
 package websocket
 
 import (
-	"context"
 	"encoding/json"
 
-	"chat-go/internal/chat/constants"
-	"chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/chat/constants"
+	"github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 func (e *EventHandler) updateMessagesStatusHandler(conn Connection, rawData []byte) error {
 	var dto MessagesStatusDto
 
 	if err := json.Unmarshal(rawData, &dto); err != nil {
-		return err
+		return errors.NewBadRequestError(constants.ChatDomain, err, nil)
 	}
 
 	if err := e.validate.Struct(constants.ChatDomain, dto); err != nil {
@@ -35,32 +35,17 @@ func (e *EventHandler) updateMessagesStatusHandler(conn Connection, rawData []by
 
 	chatID := conn.GetCurrentChat()
 	if chatID == nil {
-		return nil
+		return newNoCurrentChatError()
 	}
 
 	if len(dto.MessageIDs) == 0 {
 		return nil
 	}
 
-	if err := e.messageService.UpdateMessageStatus(
-		context.Background(),
+	return e.messageService.UpdateMessageStatus(
+		connectionContext(conn),
+		*chatID,
 		dto.MessageIDs,
 		domain.MessageStatus(dto.Status),
-	); err != nil {
-		return err
-	}
-
-	for _, baseConnection := range conn.GetConnector().GetConnections() {
-		connection := baseConnection.(Connection)
-
-		if !connection.IsCurrentChat(*chatID) && !connection.IsSubscribed(*chatID) {
-			continue
-		}
-
-		if err := connection.SendEvent(UpdateMessagesStatusEventType, dto); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	)
 }
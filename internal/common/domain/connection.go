@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "context"
+
+const connectionIDContextKey = "connectionId"
+
+// ContextWithConnectionID marks the context with the WebSocket connection the
+// request came from, so events can skip echoing back to it.
+func ContextWithConnectionID(ctx context.Context, connectionID string) context.Context {
+	return context.WithValue(ctx, connectionIDContextKey, connectionID) //nolint:staticcheck // see userContextKey
+}
+
+func ConnectionIDFromContext(ctx context.Context) string {
+	connectionID, _ := ctx.Value(connectionIDContextKey).(string)
+	return connectionID
+}
@@ -14,7 +14,23 @@
 
 package domain
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Context keys are plain strings because the same values are stored as
+// fasthttp user values by the auth middleware.
+const (
+	userContextKey  = "user"
+	tokenContextKey = "token"
+)
+
+// BotIDOffset is the first ID of bot accounts. Users come from an external
+// service, bots get IDs from a range it never reaches, so both can be chat
+// members and message authors side by side.
+const BotIDOffset uint64 = 1 << 62
 
 type User struct {
 	ID        uint64 `json:"id"`
@@ -24,12 +40,32 @@ type User struct {
 	LastName  string `json:"lastName"`
 	AboutMe   string `json:"aboutMe"`
 	Image     Image  `json:"image"`
+	IsBot     bool   `json:"isBot,omitempty"`
+}
+
+func IsBotID(id uint64) bool {
+	return id >= BotIDOffset
 }
 
 func UserFromContext(ctx context.Context) *User {
-	return ctx.Value("user").(*User)
+	return ctx.Value(userContextKey).(*User)
 }
 
 func TokenFromContext(ctx context.Context) string {
-	return ctx.Value("token").(string)
+	return ctx.Value(tokenContextKey).(string)
+}
+
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey, user) //nolint:staticcheck // see userContextKey
+}
+
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey, token) //nolint:staticcheck // see tokenContextKey
+}
+
+// HashToken is how tokens are kept in caches and passed between instances,
+// so the tokens themselves are never stored.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
 }
@@ -0,0 +1,38 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	stderrors "errors"
+)
+
+const ServiceUnavailableErrorType = "ServiceUnavailableError"
+
+// ServiceUnavailableError is returned when a service the request depends on
+// is down, callers may fall back to a degraded response.
+type ServiceUnavailableError struct {
+	*ErrorData
+}
+
+func NewServiceUnavailableError(domain string, err error) *ServiceUnavailableError {
+	return &ServiceUnavailableError{
+		ErrorData: NewErrorData(domain, ServiceUnavailableErrorType, err, nil),
+	}
+}
+
+func IsServiceUnavailable(err error) bool {
+	var serviceUnavailableErr *ServiceUnavailableError
+	return stderrors.As(err, &serviceUnavailableErr)
+}
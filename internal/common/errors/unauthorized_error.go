@@ -15,7 +15,7 @@
 package errors
 
 import (
-	"chat-go/internal/common/constants"
+	"github.com/microcoretech/chat-go/internal/common/constants"
 )
 
 const UnauthorizedErrorType = "UnauthorizedError"
@@ -14,10 +14,11 @@
 
 package http
 
-type Page[T any] struct {
-	Items []T    `json:"items"`
-	Count uint64 `json:"count"`
-}
+import (
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+type Page[T any] = chatapi.Page[T]
 
 func NewPage[T any](items []T, count uint64) Page[T] {
 	return Page[T]{Items: items, Count: count}
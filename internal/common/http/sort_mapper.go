@@ -20,7 +20,7 @@ import (
 
 	"golang.org/x/exp/slices"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 func SortFromDto(querySort string, sortFields []string) (*domain.Sort, error) {
@@ -15,15 +15,7 @@
 package http
 
 import (
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
-type UserDto struct {
-	ID        uint64       `json:"id"`
-	Email     string       `json:"email"`
-	Username  string       `json:"username"`
-	FirstName string       `json:"firstName"`
-	LastName  string       `json:"lastName"`
-	AboutMe   string       `json:"aboutMe"`
-	Image     domain.Image `json:"image"`
-}
+type UserDto = chatapi.UserDto
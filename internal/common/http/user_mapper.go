@@ -15,7 +15,8 @@
 package http
 
 import (
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
 func UserToDto(user domain.User) UserDto {
@@ -26,7 +27,8 @@ func UserToDto(user domain.User) UserDto {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		AboutMe:   user.AboutMe,
-		Image:     user.Image,
+		Image:     chatapi.Image(user.Image),
+		IsBot:     user.IsBot,
 	}
 }
 
@@ -38,6 +40,6 @@ func UserFromDto(user UserDto) domain.User {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		AboutMe:   user.AboutMe,
-		Image:     user.Image,
+		Image:     domain.Image(user.Image),
 	}
 }
@@ -17,6 +17,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 )
 
 type BaseRepo interface {
@@ -45,6 +46,26 @@ func (r *BaseRepoImpl) BeginContext(ctx context.Context) (Tx, error) {
 	return r.db.BeginTx(ctx, nil)
 }
 
+// WithSavepoint runs fn in a savepoint of tx. If fn fails, the writes it
+// made with tx are rolled back and the rest of tx is kept.
+func WithSavepoint(ctx context.Context, tx Tx, name string, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+
+	return err
+}
+
 func NewBaseRepoImpl(db *sql.DB) *BaseRepoImpl {
 	return &BaseRepoImpl{db: db}
 }
@@ -22,8 +22,8 @@ import (
 	fiberlogger "github.com/gofiber/fiber/v2/middleware/logger"
 	fiberrecover "github.com/gofiber/fiber/v2/middleware/recover"
 
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/infrastructure/logger"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
 )
 
 func NewApp(cfg *configs.Config, log logger.Logger, controllers ...Controller) *fiber.App {
@@ -33,7 +33,7 @@ func NewApp(cfg *configs.Config, log logger.Logger, controllers ...Controller) *
 
 	app.Use(fiberlogger.New(fiberlogger.Config{
 		TimeFormat: time.DateTime,
-		Format:     "{\"status\":${status},\"latency\":\"${latency}\",\"method\":\"${method}\",\"url\":\"${url}\",\"ip\":\"${ip}\"}\n",
+		Format:     "{\"status\":${status},\"latency\":\"${latency}\",\"method\":\"${method}\",\"path\":\"${path}\",\"ip\":\"${ip}\"}\n",
 		Output:     log.Writer(),
 	}))
 
@@ -42,6 +42,11 @@ func NewApp(cfg *configs.Config, log logger.Logger, controllers ...Controller) *
 
 	app.Get("/", rootHandler(cfg))
 	app.Get("/healthz", healthzHandler)
+	app.Get("/openapi.json", openAPIHandler)
+	app.Get("/asyncapi.json", asyncAPIHandler)
+	app.Get("/docs", docsHandler)
+	app.Get("/docs/docs.js", docsScriptHandler)
+	app.Get("/docs/docs.css", docsStyleHandler)
 
 	for _, controller := range controllers {
 		controller.SetupRoutes(app)
@@ -21,51 +21,24 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
-	chaterrors "chat-go/internal/chat/errors"
-	"chat-go/internal/common/constants"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/infrastructure/logger"
-	usererrors "chat-go/internal/user/errors"
+	boterrors "github.com/microcoretech/chat-go/internal/bot/errors"
+	chaterrors "github.com/microcoretech/chat-go/internal/chat/errors"
+	"github.com/microcoretech/chat-go/internal/common/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+	notificationerrors "github.com/microcoretech/chat-go/internal/notification/errors"
+	usererrors "github.com/microcoretech/chat-go/internal/user/errors"
 )
 
 func ErrorHandler(log logger.Logger, environment configs.Environment) fiber.ErrorHandler {
 	return func(ctx *fiber.Ctx, err error) error {
-		var statusCode int
-
-		switch errType := err.(type) {
-		case *fiber.Error:
-			fiberErr := errType
-			statusCode = fiberErr.Code
-			if statusCode == http.StatusNotFound {
-				err = errors.NewNotFoundError(constants.CommonDomain)
-			}
-		case
-			*errors.BadRequestError,
-			*errors.ValidationError,
-			*chaterrors.IncorrectUsersCountError,
-			*chaterrors.InvalidChatNameError,
-			*chaterrors.InvalidChatTypeError:
-			statusCode = http.StatusBadRequest
-		case *errors.UnauthorizedError:
-			statusCode = http.StatusUnauthorized
-		case *errors.ForbiddenError:
-			statusCode = http.StatusForbidden
-		case *errors.NotFoundError, *usererrors.UserNotFoundError:
-			statusCode = http.StatusNotFound
-		case *errors.UndefinedError:
-			statusCode = http.StatusInternalServerError
-		default:
-			statusCode = http.StatusInternalServerError
-			err = errors.NewUndefinedError(err)
-		}
-
-		baseError := err.(errors.BaseError)
+		statusCode, baseError := ResolveError(err)
 
 		errorData := baseError.GetErrorData()
 		errorData.Data["path"] = fmt.Sprintf("%s %s", ctx.Method(), ctx.Request().URI().String())
 
-		jsonErr, _ := json.Marshal(err)
+		jsonErr, _ := json.Marshal(baseError)
 		if statusCode < 500 {
 			log.Debug(string(jsonErr))
 		} else {
@@ -81,3 +54,54 @@ func ErrorHandler(log logger.Logger, environment configs.Environment) fiber.Erro
 		return nil
 	}
 }
+
+// ResolveError returns the HTTP status of an error, errors of unknown types
+// are wrapped into an UndefinedError. The gRPC server maps errors the same way.
+func ResolveError(err error) (int, errors.BaseError) {
+	var statusCode int
+
+	switch errType := err.(type) {
+	case *fiber.Error:
+		fiberErr := errType
+		statusCode = fiberErr.Code
+		if statusCode == http.StatusNotFound {
+			err = errors.NewNotFoundError(constants.CommonDomain)
+		} else {
+			err = errors.NewUndefinedError(err)
+		}
+	case
+		*errors.BadRequestError,
+		*errors.ValidationError,
+		*boterrors.BotUsernameTakenError,
+		*chaterrors.CommandNameTakenError,
+		*chaterrors.CommandNotSchedulableError,
+		*chaterrors.IncorrectUsersCountError,
+		*chaterrors.InvalidChatNameError,
+		*chaterrors.InvalidChatTypeError,
+		*chaterrors.InvalidClosesAtError,
+		*chaterrors.InvalidPollOptionsError,
+		*chaterrors.InvalidSendAtError,
+		*chaterrors.MessageNotForwardableError,
+		*chaterrors.MessageTooLongError,
+		*chaterrors.PollClosedError,
+		*notificationerrors.DeviceTakenError,
+		*notificationerrors.InvalidConfirmationCodeError,
+		*usererrors.UserAlreadyExistsError:
+		statusCode = http.StatusBadRequest
+	case *errors.UnauthorizedError:
+		statusCode = http.StatusUnauthorized
+	case *errors.ForbiddenError:
+		statusCode = http.StatusForbidden
+	case *errors.NotFoundError, *usererrors.UserNotFoundError:
+		statusCode = http.StatusNotFound
+	case *errors.ServiceUnavailableError:
+		statusCode = http.StatusServiceUnavailable
+	case *errors.UndefinedError:
+		statusCode = http.StatusInternalServerError
+	default:
+		statusCode = http.StatusInternalServerError
+		err = errors.NewUndefinedError(err)
+	}
+
+	return statusCode, err.(errors.BaseError)
+}
@@ -17,12 +17,13 @@ package api
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/infrastructure/logger"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
 )
 
 var (
@@ -33,7 +34,7 @@ type HTTPServer struct {
 	cfg *configs.Config
 	app *fiber.App
 
-	isStarted bool
+	isStarted atomic.Bool
 }
 
 func (s *HTTPServer) App() *fiber.App {
@@ -41,14 +42,10 @@ func (s *HTTPServer) App() *fiber.App {
 }
 
 func (s *HTTPServer) Start(ctx context.Context) error {
-	if s.isStarted {
+	if !s.isStarted.CompareAndSwap(false, true) {
 		return ErrServerAlreadyStarted
 	}
-
-	s.isStarted = true
-	defer func() {
-		s.isStarted = false
-	}()
+	defer s.isStarted.Store(false)
 
 	go func() {
 		<-ctx.Done()
@@ -0,0 +1,73 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+var (
+	ErrMetricsServerAlreadyStarted = errors.New("metrics server already started")
+)
+
+// MetricsServer serves the Prometheus metrics on an internal address, apart
+// from the public API, as they reveal the load and the internals of the
+// service.
+type MetricsServer struct {
+	cfg *configs.Config
+	app *fiber.App
+
+	isStarted atomic.Bool
+}
+
+func (s *MetricsServer) Start(ctx context.Context) error {
+	if !s.isStarted.CompareAndSwap(false, true) {
+		return ErrMetricsServerAlreadyStarted
+	}
+	defer s.isStarted.Store(false)
+
+	go func() {
+		<-ctx.Done()
+		_ = s.app.ShutdownWithTimeout(time.Minute)
+	}()
+
+	if err := s.app.Listen(s.cfg.MetricsServerAddr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewMetricsServer(cfg *configs.Config) *MetricsServer {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
+	return &MetricsServer{
+		cfg: cfg,
+		app: app,
+	}
+}
@@ -17,8 +17,8 @@ package api
 import (
 	"github.com/gofiber/fiber/v2"
 
-	"chat-go/internal/common/constants"
-	"chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/common/constants"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
 )
 
 type RootResponse struct {
@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configs
+
+// AuthMode selects how user tokens are checked. The remote mode asks the
+// current user endpoint, the JWT mode verifies tokens against a JWKS and the
+// local mode keeps users and sessions in the database.
+type AuthMode string
+
+const (
+	RemoteAuthMode AuthMode = "remote"
+	JWTAuthMode    AuthMode = "jwt"
+	LocalAuthMode  AuthMode = "local"
+)
+
+func (m AuthMode) String() string {
+	return string(m)
+}
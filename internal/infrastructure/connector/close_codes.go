@@ -0,0 +1,27 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+// Close codes sent to websocket clients in the 4000-4999 range reserved
+// for applications, they mirror the matching HTTP statuses.
+const (
+	CloseAuthenticationFailed  = chatapi.CloseAuthenticationFailed
+	CloseAuthenticationTimeout = chatapi.CloseAuthenticationTimeout
+	CloseCredentialsExpired    = chatapi.CloseCredentialsExpired
+)
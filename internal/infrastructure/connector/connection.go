@@ -14,7 +14,7 @@
 
 package connector
 
-import "chat-go/internal/common/domain"
+import "github.com/microcoretech/chat-go/internal/common/domain"
 
 type Connection interface {
 	IsClosed() bool
@@ -28,9 +28,19 @@ type Connection interface {
 	GetCloseChan() chan struct{}
 
 	SendEvent(eventType uint64, data any) error
+	// SendReply sends an event in reply to the request with the ID, the
+	// client matches the two by the ID.
+	SendReply(requestID string, eventType uint64, data any) error
 
 	Connect()
 	Close()
+	// CloseWithReason sends a close frame with the code and reason before
+	// closing the connection.
+	CloseWithReason(code int, reason string)
 
 	GetUser() *domain.User
+	GetToken() string
+	// SetCredentials replaces the credentials of the connection, e.g. after
+	// the client refreshed its token.
+	SetCredentials(user *domain.User, token string)
 }
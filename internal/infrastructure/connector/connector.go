@@ -20,9 +20,11 @@ import (
 	"errors"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"chat-go/internal/infrastructure/logger"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
 )
 
 var ErrConnectorAlreadyStarted = errors.New("connector already started")
@@ -31,25 +33,27 @@ type Connector interface {
 	Start(ctx context.Context) error
 	AddConnection(conn Connection)
 	GetConnections() []Connection
+	// CloseTokenConnections closes the connections opened with a token
+	// which is no longer valid.
+	CloseTokenConnections(token string)
+	// CloseTokenHashConnections closes the connections opened with a token
+	// signed out on any instance, which only passes on its hash.
+	CloseTokenHashConnections(tokenHash string)
 }
 
 type ConnectorImpl struct {
 	mtx          sync.RWMutex
 	log          logger.Logger
 	connections  []Connection
-	isStarted    bool
+	isStarted    atomic.Bool
 	eventHandler EventHandler
 }
 
 func (c *ConnectorImpl) Start(ctx context.Context) error {
-	if c.isStarted {
+	if !c.isStarted.CompareAndSwap(false, true) {
 		return ErrConnectorAlreadyStarted
 	}
-
-	c.isStarted = true
-	defer func() {
-		c.isStarted = false
-	}()
+	defer c.isStarted.Store(false)
 
 	for {
 		select {
@@ -64,7 +68,7 @@ func (c *ConnectorImpl) Start(ctx context.Context) error {
 }
 
 func (c *ConnectorImpl) closeAll() {
-	for _, conn := range c.connections {
+	for _, conn := range c.GetConnections() {
 		conn.Close()
 	}
 }
@@ -131,7 +135,28 @@ func (c *ConnectorImpl) onEvent(conn Connection, data []byte) {
 }
 
 func (c *ConnectorImpl) GetConnections() []Connection {
-	return c.connections
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return append([]Connection(nil), c.connections...)
+}
+
+func (c *ConnectorImpl) CloseTokenConnections(token string) {
+	for _, conn := range c.GetConnections() {
+		if conn.GetToken() == token && !conn.IsClosed() {
+			c.log.Debugf("Close connection of revoked token id=%d", conn.GetUser().ID)
+			conn.CloseWithReason(CloseCredentialsExpired, "credentials expired")
+		}
+	}
+}
+
+func (c *ConnectorImpl) CloseTokenHashConnections(tokenHash string) {
+	for _, conn := range c.GetConnections() {
+		if domain.HashToken(conn.GetToken()) == tokenHash && !conn.IsClosed() {
+			c.log.Debugf("Close connection of signed out token id=%d", conn.GetUser().ID)
+			conn.CloseWithReason(CloseCredentialsExpired, "credentials expired")
+		}
+	}
 }
 
 func NewConnector(
@@ -15,10 +15,7 @@
 package connector
 
 import (
-	"encoding/json"
+	"github.com/microcoretech/chat-go/pkg/chatapi"
 )
 
-type Event struct {
-	Type uint64          `json:"type"`
-	Data json.RawMessage `json:"data"`
-}
+type Event = chatapi.Event
@@ -0,0 +1,105 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrRevalidatorAlreadyStarted = errors.New("revalidator already started")
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string, isBot bool) (*domain.User, error)
+}
+
+// Revalidator periodically checks the tokens of open connections and
+// closes the connections of revoked tokens. Connections are kept if the
+// check itself fails, so an outage of the user service doesn't drop them.
+type Revalidator struct {
+	cfg           *configs.Config
+	log           logger.Logger
+	connector     Connector
+	authenticator Authenticator
+
+	isStarted atomic.Bool
+}
+
+func (r *Revalidator) Start(ctx context.Context) error {
+	if !r.isStarted.CompareAndSwap(false, true) {
+		return ErrRevalidatorAlreadyStarted
+	}
+	defer r.isStarted.Store(false)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.cfg.WebsocketRevalidationInterval):
+			r.revalidate(ctx)
+		}
+	}
+}
+
+func (r *Revalidator) revalidate(ctx context.Context) {
+	checkedTokens := make(map[string]struct{})
+
+	for _, conn := range r.connector.GetConnections() {
+		if conn.IsClosed() {
+			continue
+		}
+
+		token := conn.GetToken()
+		if _, ok := checkedTokens[token]; ok {
+			continue
+		}
+
+		checkedTokens[token] = struct{}{}
+
+		user, err := r.authenticator.Authenticate(ctx, token, conn.GetUser().IsBot)
+		if err != nil {
+			var unauthorizedErr *commonerrors.UnauthorizedError
+			if !errors.As(err, &unauthorizedErr) {
+				r.log.Errorf("error on revalidating token of user %d: %s", conn.GetUser().ID, err)
+				continue
+			}
+		}
+
+		if err != nil || user == nil || user.ID != conn.GetUser().ID {
+			r.connector.CloseTokenConnections(token)
+		}
+	}
+}
+
+func NewRevalidator(
+	cfg *configs.Config,
+	log logger.Logger,
+	connector Connector,
+	authenticator Authenticator,
+) *Revalidator {
+	return &Revalidator{
+		cfg:           cfg,
+		log:           log,
+		connector:     connector,
+		authenticator: authenticator,
+	}
+}
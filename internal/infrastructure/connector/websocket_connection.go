@@ -16,29 +16,44 @@ package connector
 
 import (
 	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/fasthttp/websocket"
 	"github.com/google/uuid"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
+const closeWriteTimeout = time.Second
+
 type WebsocketConnection struct {
 	connectionID string
 
 	conn      *websocket.Conn
 	connector Connector
-	user      *domain.User
+
+	// credentialsMtx guards user and token, they are replaced on token
+	// refresh while the revalidator and event listeners read them.
+	credentialsMtx sync.RWMutex
+	user           *domain.User
+	token          string
+
+	// writeMtx serializes writes, events are sent both from connection
+	// listeners and from the outbox dispatcher.
+	writeMtx sync.Mutex
 
 	messageChan chan []byte
 	closeChan   chan struct{}
+	closeOnce   sync.Once
 
-	isConnected bool
-	isClosed    bool
+	isConnected atomic.Bool
+	isClosed    atomic.Bool
 }
 
 func (c *WebsocketConnection) IsClosed() bool {
-	return c.isClosed
+	return c.isClosed.Load()
 }
 
 func (c *WebsocketConnection) GetConnectionID() string {
@@ -62,10 +77,15 @@ func (c *WebsocketConnection) GetCloseChan() chan struct{} {
 }
 
 func (c *WebsocketConnection) SendEvent(eventType uint64, data any) error {
+	return c.SendReply("", eventType, data)
+}
+
+func (c *WebsocketConnection) SendReply(requestID string, eventType uint64, data any) error {
 	var err error
 
 	event := Event{
-		Type: eventType,
+		Type:      eventType,
+		RequestID: requestID,
 	}
 
 	event.Data, err = json.Marshal(data)
@@ -73,6 +93,9 @@ func (c *WebsocketConnection) SendEvent(eventType uint64, data any) error {
 		return err
 	}
 
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+
 	err = c.conn.WriteJSON(event)
 	if err != nil {
 		return err
@@ -86,49 +109,74 @@ func (c *WebsocketConnection) Connect() {
 }
 
 func (c *WebsocketConnection) connect() {
-	if c.isConnected || c.isClosed {
+	if c.IsClosed() || !c.isConnected.CompareAndSwap(false, true) {
 		return
 	}
 
-	c.isConnected = true
-
-	defer func() {
-		c.isConnected = false
-		c.isClosed = true
-	}()
+	// A read error means the peer is gone, closing releases the listener
+	// and the handler waiting on the close channel.
+	defer c.Close()
 
 	for {
+		_, msgData, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
 		select {
+		case c.messageChan <- msgData:
 		case <-c.closeChan:
 			return
-		default:
-			_, msgData, err := c.conn.ReadMessage()
-			if err != nil {
-				c.isConnected = false
-				return
-			}
-			c.messageChan <- msgData
 		}
 	}
 }
 
+// Close may be called concurrently by the reader, the connector and the
+// revalidator, only the first call closes the connection.
 func (c *WebsocketConnection) Close() {
-	if !c.isConnected {
+	c.closeOnce.Do(func() {
+		c.isClosed.Store(true)
+		close(c.closeChan)
+		_ = c.conn.Close()
+	})
+}
+
+func (c *WebsocketConnection) CloseWithReason(code int, reason string) {
+	if c.IsClosed() {
 		return
 	}
-	close(c.closeChan)
-	c.conn.Close()
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(closeWriteTimeout))
+	c.Close()
 }
 
 func (c *WebsocketConnection) GetUser() *domain.User {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
 	return c.user
 }
 
-func NewWebSocketConnection(conn *websocket.Conn, user *domain.User) *WebsocketConnection {
+func (c *WebsocketConnection) GetToken() string {
+	c.credentialsMtx.RLock()
+	defer c.credentialsMtx.RUnlock()
+
+	return c.token
+}
+
+func (c *WebsocketConnection) SetCredentials(user *domain.User, token string) {
+	c.credentialsMtx.Lock()
+	defer c.credentialsMtx.Unlock()
+
+	c.user = user
+	c.token = token
+}
+
+func NewWebSocketConnection(conn *websocket.Conn, user *domain.User, token string) *WebsocketConnection {
 	return &WebsocketConnection{
 		connectionID: uuid.NewString(),
 		conn:         conn,
 		user:         user,
+		token:        token,
 		messageChan:  make(chan []byte),
 		closeChan:    make(chan struct{}),
 	}
@@ -0,0 +1,130 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+)
+
+// newTestWebsocketConnection connects to a server that echoes messages
+// until closeServer is called, the connection reads from the server side.
+func newTestWebsocketConnection(t *testing.T) (connection *WebsocketConnection, closeServer func()) {
+	t.Helper()
+
+	serverConns := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverConns <- conn
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	serverConn := <-serverConns
+
+	return NewWebSocketConnection(conn, nil, "token"), func() { _ = serverConn.Close() }
+}
+
+func TestWebsocketConnectionConcurrentClose(t *testing.T) {
+	tests := []struct {
+		name        string
+		closePeer   bool
+		withReason  bool
+		readMessage bool
+	}{
+		{name: "close"},
+		{name: "close with reason", withReason: true},
+		{name: "close while the peer disconnects", closePeer: true},
+		{name: "close while a message is pending", readMessage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection, closeServer := newTestWebsocketConnection(t)
+			connection.Connect()
+
+			if tt.readMessage {
+				if err := connection.SendEvent(1, "message"); err != nil {
+					t.Fatalf("send event: %v", err)
+				}
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if tt.withReason {
+						connection.CloseWithReason(websocket.CloseNormalClosure, "bye")
+					} else {
+						connection.Close()
+					}
+				}()
+			}
+			if tt.closePeer {
+				closeServer()
+			}
+			wg.Wait()
+
+			select {
+			case <-connection.GetCloseChan():
+			case <-time.After(time.Second):
+				t.Fatal("close channel is not closed")
+			}
+			if !connection.IsClosed() {
+				t.Error("connection is not closed")
+			}
+		})
+	}
+}
+
+func TestWebsocketConnectionClosedOnPeerDisconnect(t *testing.T) {
+	connection, closeServer := newTestWebsocketConnection(t)
+	connection.Connect()
+
+	closeServer()
+
+	select {
+	case <-connection.GetCloseChan():
+	case <-time.After(time.Second):
+		t.Fatal("close channel is not closed after the peer disconnected")
+	}
+	if !connection.IsClosed() {
+		t.Error("connection is not closed")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	listenerMinReconnectInterval = time.Second
+	listenerMaxReconnectInterval = time.Minute
+	payloadListenerBufferSize    = 64
+)
+
+// NewListener subscribes to a Postgres notification channel. Notifications
+// are coalesced, a receiver only learns that something happened since it
+// last read from the returned channel. The listener is closed with ctx.
+func NewListener(ctx context.Context, connStr string, channel string) (<-chan struct{}, error) {
+	listener := pq.NewListener(connStr, listenerMinReconnectInterval, listenerMaxReconnectInterval, nil)
+
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	notifications := make(chan struct{}, 1)
+
+	go func() {
+		defer listener.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-listener.Notify:
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+// NewPayloadListener subscribes to a Postgres notification channel and
+// passes on the payload of every notification. Notifications sent while the
+// listener is reconnecting are lost. The listener is closed with ctx.
+func NewPayloadListener(ctx context.Context, connStr string, channel string) (<-chan string, error) {
+	listener := pq.NewListener(connStr, listenerMinReconnectInterval, listenerMaxReconnectInterval, nil)
+
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	payloads := make(chan string, payloadListenerBufferSize)
+
+	go func() {
+		defer listener.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-listener.Notify:
+				// A nil notification signals a reconnect.
+				if notification == nil {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case payloads <- notification.Extra:
+				}
+			}
+		}
+	}()
+
+	return payloads, nil
+}
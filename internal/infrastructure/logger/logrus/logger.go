@@ -17,7 +17,7 @@ package logrus
 import (
 	"github.com/sirupsen/logrus"
 
-	"chat-go/internal/infrastructure/logger"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
 )
 
 func NewLogger(lvl logger.Level) (*logrus.Logger, error) {
@@ -0,0 +1,93 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	userhttp "github.com/microcoretech/chat-go/internal/user/http"
+)
+
+const authorizationMetadata = "authorization"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string, isBot bool) (*domain.User, error)
+}
+
+// authenticate resolves the caller from the "authorization" metadata, which
+// takes the same "Bearer <token>" or "Bot <token>" as the REST API.
+func authenticate(ctx context.Context, authenticator Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(authorizationMetadata)
+	if len(values) == 0 {
+		return nil, errors.NewUnauthorizedError("missing authorization metadata")
+	}
+
+	token, isBot, err := userhttp.ParseAuthorization(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := authenticator.Authenticate(ctx, token, isBot)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || user.ID == 0 {
+		return nil, errors.NewUnauthorizedError("user not found")
+	}
+
+	ctx = domain.ContextWithToken(ctx, token)
+
+	return domain.ContextWithUser(ctx, user), nil
+}
+
+func authUnaryInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(authenticator Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
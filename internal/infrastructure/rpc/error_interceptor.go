@@ -0,0 +1,132 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+}
+
+// toStatus maps an error to a gRPC status with the HTTP status of the REST
+// API. The ErrorData is attached as ErrorInfo, its reason is the error type.
+func toStatus(log logger.Logger, environment configs.Environment, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.FromContextError(err).Err()
+	}
+
+	statusCode, baseError := api.ResolveError(err)
+
+	errorData := baseError.GetErrorData()
+	errorData.Data["path"] = method
+
+	jsonErr, _ := json.Marshal(baseError)
+	if statusCode < 500 {
+		log.Debug(string(jsonErr))
+	} else {
+		log.Error(string(jsonErr))
+	}
+
+	code, ok := httpStatusCodes[statusCode]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	data := errorData.Data
+	if environment != configs.DevelopmentEnvironment {
+		data = errors.TruncateErrorData(errorData).Data
+	}
+
+	metadata := make(map[string]string, len(data))
+	for key, value := range data {
+		if str, ok := value.(string); ok {
+			metadata[key] = str
+			continue
+		}
+
+		encoded, _ := json.Marshal(value)
+		metadata[key] = string(encoded)
+	}
+
+	st, detailsErr := status.New(code, errorData.ErrorType).WithDetails(&errdetails.ErrorInfo{
+		Reason:   errorData.ErrorType,
+		Domain:   errorData.Domain,
+		Metadata: metadata,
+	})
+	if detailsErr != nil {
+		return status.Error(code, errorData.ErrorType)
+	}
+
+	return st.Err()
+}
+
+func errorUnaryInterceptor(log logger.Logger, environment configs.Environment) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s\n%s", r, string(debug.Stack()))
+			}
+
+			if err != nil {
+				err = toStatus(log, environment, info.FullMethod, err)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func errorStreamInterceptor(log logger.Logger, environment configs.Environment) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s\n%s", r, string(debug.Stack()))
+			}
+
+			if err != nil {
+				err = toStatus(log, environment, info.FullMethod, err)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+const shutdownTimeout = time.Minute
+
+var (
+	ErrServerAlreadyStarted = errors.New("grpc server already started")
+)
+
+// Service registers the handlers of a gRPC service, like Controller does
+// for the REST API.
+type Service interface {
+	Register(registrar grpc.ServiceRegistrar)
+}
+
+type GRPCServer struct {
+	cfg    *configs.Config
+	server *grpc.Server
+
+	isStarted atomic.Bool
+}
+
+func (s *GRPCServer) Start(ctx context.Context) error {
+	if !s.isStarted.CompareAndSwap(false, true) {
+		return ErrServerAlreadyStarted
+	}
+	defer s.isStarted.Store(false)
+
+	listener, err := net.Listen("tcp", s.cfg.GRPCServerAddr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.stop()
+	}()
+
+	if err := s.server.Serve(listener); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stop waits for running calls to finish, event streams are cut off once
+// the timeout is over.
+func (s *GRPCServer) stop() {
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.server.Stop()
+	}
+}
+
+func NewGRPCServer(
+	cfg *configs.Config,
+	log logger.Logger,
+	authenticator Authenticator,
+	services ...Service,
+) *GRPCServer {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			errorUnaryInterceptor(log, cfg.Environment),
+			authUnaryInterceptor(authenticator),
+		),
+		grpc.ChainStreamInterceptor(
+			errorStreamInterceptor(log, cfg.Environment),
+			authStreamInterceptor(authenticator),
+		),
+	)
+
+	for _, service := range services {
+		service.Register(server)
+	}
+
+	return &GRPCServer{
+		cfg:    cfg,
+		server: server,
+	}
+}
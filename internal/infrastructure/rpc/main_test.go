@@ -0,0 +1,100 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	chatrpc "github.com/microcoretech/chat-go/internal/chat/rpc"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+	chatv1 "github.com/microcoretech/chat-go/pkg/api/chat/v1"
+)
+
+type stubLogger struct {
+	logger.Logger
+}
+
+func (stubLogger) Debug(...any) {}
+func (stubLogger) Error(...any) {}
+
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(context.Context, string, bool) (*domain.User, error) {
+	return &domain.User{ID: 1}, nil
+}
+
+// stubChatService makes the user a member of memberChatIDs only.
+type stubChatService struct {
+	chatrpc.ChatService
+
+	memberChatIDs map[uint64]bool
+}
+
+func (s *stubChatService) CheckMembership(_ context.Context, chatIDs []uint64) error {
+	for _, id := range chatIDs {
+		if !s.memberChatIDs[id] {
+			return errors.NewForbiddenError()
+		}
+	}
+
+	return nil
+}
+
+func TestStreamEventsOfNonMember(t *testing.T) {
+	chatService := &stubChatService{memberChatIDs: map[uint64]bool{1: true}}
+	server := NewGRPCServer(&configs.Config{}, stubLogger{}, stubAuthenticator{},
+		chatrpc.NewChatServer(nil, chatService, nil))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.server.Serve(listener)
+	}()
+	t.Cleanup(server.server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationMetadata, "Bearer token")
+
+	stream, err := chatv1.NewChatServiceClient(conn).StreamEvents(ctx, &chatv1.StreamEventsRequest{ChatIds: []uint64{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stream.Recv()
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Recv() error = %v, want PermissionDenied", err)
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 
-	"chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 type Validate interface {
@@ -78,6 +78,12 @@ func usernameValidator(fl validator.FieldLevel) bool {
 	return usernameRegexp.MatchString(fl.Field().String())
 }
 
+// commandValidator accepts the names the slash command parser accepts.
+func commandValidator(fl validator.FieldLevel) bool {
+	commandRegexp := regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+	return commandRegexp.MatchString(fl.Field().String())
+}
+
 func passwordValidator(fl validator.FieldLevel) bool {
 	const (
 		minPasswordLength = 8
@@ -126,5 +132,9 @@ func New() (Validate, error) {
 		return nil, err
 	}
 
+	if err := v.RegisterValidation("command", commandValidator); err != nil {
+		return nil, err
+	}
+
 	return &validate{validate: v}, nil
 }
@@ -0,0 +1,68 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+const previewLength = 100
+
+// Digest groups the notifications of one user into a single delivery.
+type Digest struct {
+	Title         string         `json:"title"`
+	Body          string         `json:"body"`
+	Notifications []Notification `json:"notifications"`
+}
+
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, device Device, digest Digest) error
+}
+
+func newDigest(notifications []Notification) Digest {
+	digest := Digest{Notifications: notifications}
+
+	if len(notifications) == 1 {
+		digest.Title = "New message"
+		if notifications[0].IsMention {
+			digest.Title = "You were mentioned"
+		}
+
+		digest.Body = preview(notifications[0].Text)
+
+		return digest
+	}
+
+	chats := make(map[uint64]struct{})
+	for _, notification := range notifications {
+		chats[notification.ChatID] = struct{}{}
+	}
+
+	digest.Title = fmt.Sprintf("%d new messages", len(notifications))
+	digest.Body = fmt.Sprintf("%d new messages in %d chats", len(notifications), len(chats))
+
+	return digest
+}
+
+func preview(text string) string {
+	if utf8.RuneCountInString(text) <= previewLength {
+		return text
+	}
+
+	return string([]rune(text)[:previewLength]) + "…"
+}
@@ -0,0 +1,48 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+type DeliveryStatus uint8
+
+func (s DeliveryStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	SucceededDeliveryStatus DeliveryStatus = 1
+	FailedDeliveryStatus    DeliveryStatus = 2
+	PendingDeliveryStatus   DeliveryStatus = 3
+)
+
+// Delivery is a digest sent to one device. Pending deliveries are retried
+// until they succeed or run out of attempts.
+type Delivery struct {
+	ID                 uint64
+	UserID             uint64
+	DeviceID           uint64
+	Channel            string
+	NotificationsCount uint
+	Digest             Digest
+	Status             DeliveryStatus
+	Error              string
+	Attempts           uint
+	NextAttemptAt      time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
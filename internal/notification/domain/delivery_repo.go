@@ -0,0 +1,32 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type DeliveryRepo interface {
+	GetDeliveries(ctx context.Context, filter *DeliveryFilter) ([]Delivery, error)
+	GetDeliveriesCount(ctx context.Context, filter *DeliveryFilter) (uint64, error)
+	CreateDeliveries(ctx context.Context, deliveries []Delivery, tx repository.Tx) error
+	// ClaimPendingDeliveries returns due pending deliveries and postpones
+	// them until claimedUntil, other notifiers skip them meanwhile.
+	ClaimPendingDeliveries(ctx context.Context, limit uint64, claimedUntil time.Time) ([]Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+}
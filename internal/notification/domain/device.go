@@ -0,0 +1,80 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	confirmationCodeMax        = 1000000
+	confirmationCodeTTL        = 15 * time.Minute
+	confirmationResendInterval = time.Minute
+	maxConfirmationAttempts    = 5
+)
+
+// Device is a target of a channel: a push token for "push", an address for
+// "email". Notifications are only sent to confirmed devices, an email
+// address other than the one of the user is confirmed with a code.
+type Device struct {
+	ID                    uint64
+	UserID                uint64
+	Channel               string
+	Token                 string
+	Platform              string
+	ConfirmedAt           *time.Time
+	ConfirmationCode      string
+	ConfirmationExpiresAt *time.Time
+	ConfirmationAttempts  uint
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+func (d Device) IsConfirmed() bool {
+	return d.ConfirmedAt != nil
+}
+
+// canResendConfirmation tells whether a new code can be sent, codes are not
+// sent more often than the resend interval.
+func (d Device) canResendConfirmation(now time.Time) bool {
+	if d.ConfirmationExpiresAt == nil {
+		return true
+	}
+
+	sentAt := d.ConfirmationExpiresAt.Add(-confirmationCodeTTL)
+
+	return !now.Before(sentAt.Add(confirmationResendInterval))
+}
+
+// newConfirmationCode returns a random 6-digit code, only a hash of it is
+// stored.
+func newConfirmationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(confirmationCodeMax))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func hashConfirmationCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,33 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+type DeviceRepo interface {
+	GetDevice(ctx context.Context, id uint64) (*Device, error)
+	GetDevices(ctx context.Context, userIDs []uint64) ([]Device, error)
+	GetDevicesByIDs(ctx context.Context, ids []uint64) ([]Device, error)
+	GetDeviceByToken(ctx context.Context, channel string, token string) (*Device, error)
+	// SaveDevice creates the device or moves an existing token to the user.
+	SaveDevice(ctx context.Context, device Device) (*Device, error)
+	UpdateDevice(ctx context.Context, device Device) error
+	// AddConfirmationAttempt counts an attempt to confirm the device and
+	// returns the attempts so far.
+	AddConfirmationAttempt(ctx context.Context, id uint64) (uint, error)
+	DeleteDevice(ctx context.Context, id uint64) error
+}
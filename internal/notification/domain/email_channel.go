@@ -0,0 +1,76 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+)
+
+// EmailChannel sends notifications over SMTP, the device token is the email
+// address.
+type EmailChannel struct {
+	cfg *configs.Config
+}
+
+func (c *EmailChannel) Name() string {
+	return constants.EmailChannel
+}
+
+func (c *EmailChannel) Send(_ context.Context, device Device, digest Digest) error {
+	var auth smtp.Auth
+
+	if c.cfg.SMTPUsername != "" {
+		host, _, err := net.SplitHostPort(c.cfg.SMTPAddr)
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", c.cfg.SMTPUsername, c.cfg.SMTPPassword, host)
+	}
+
+	var body strings.Builder
+
+	body.WriteString(digest.Body)
+	body.WriteString("\r\n")
+
+	if len(digest.Notifications) > 1 {
+		body.WriteString("\r\n")
+
+		for _, notification := range digest.Notifications {
+			body.WriteString(fmt.Sprintf("- %s\r\n", preview(notification.Text)))
+		}
+	}
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		c.cfg.SMTPFrom,
+		device.Token,
+		digest.Title,
+		body.String(),
+	)
+
+	return smtp.SendMail(c.cfg.SMTPAddr, auth, c.cfg.SMTPFrom, []string{device.Token}, []byte(msg))
+}
+
+func NewEmailChannel(cfg *configs.Config) *EmailChannel {
+	return &EmailChannel{cfg: cfg}
+}
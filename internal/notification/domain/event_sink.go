@@ -0,0 +1,110 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/samber/lo"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+const (
+	EventSinkName = "notification"
+
+	// hiddenMessageText replaces the text of view-once and disappearing
+	// messages so it doesn't outlive them in a push or an email.
+	hiddenMessageText = "New message"
+)
+
+// EventSink queues notifications of new messages for the members who won't
+// see them: members with no live connection following the chat on any
+// instance. Members who muted the chat or lowered its notification level
+// are skipped.
+type EventSink struct {
+	notificationRepo    NotificationRepo
+	chatServiceContract ChatServiceContract
+	presence            Presence
+}
+
+func (s *EventSink) Name() string {
+	return EventSinkName
+}
+
+func (s *EventSink) Deliver(ctx context.Context, event outboxdomain.Event, tx repository.Tx) error {
+	if event.Type != chatdomain.MessageCreatedEventType {
+		return nil
+	}
+
+	var message chatdomain.Message
+	if err := json.Unmarshal(event.Payload, &message); err != nil {
+		return err
+	}
+
+	members, err := s.chatServiceContract.GetChatMembers(ctx, message.ChatID)
+	if err != nil {
+		return err
+	}
+
+	followers, err := s.presence.GetFollowers(ctx, message.ChatID)
+	if err != nil {
+		return err
+	}
+
+	text := message.Text
+	if message.IsViewOnce || message.ExpiresIn != nil || message.ExpiresAt != nil {
+		text = hiddenMessageText
+	}
+
+	now := time.Now().UTC()
+
+	var notifications []Notification
+	for _, member := range members {
+		isMention := lo.Contains(message.MentionedUserIDs, member.UserID)
+
+		if member.UserID == message.CreatedBy ||
+			!member.ShouldNotify(now, isMention) ||
+			lo.Contains(followers, member.UserID) {
+			continue
+		}
+
+		notifications = append(notifications, Notification{
+			UserID:    member.UserID,
+			ChatID:    message.ChatID,
+			MessageID: message.ID,
+			Text:      text,
+			IsMention: isMention,
+		})
+	}
+
+	return s.notificationRepo.CreateNotifications(ctx, notifications, tx)
+}
+
+func NewEventSink(
+	notificationRepo NotificationRepo,
+	chatServiceContract ChatServiceContract,
+	presence Presence,
+) *EventSink {
+	return &EventSink{
+		notificationRepo:    notificationRepo,
+		chatServiceContract: chatServiceContract,
+		presence:            presence,
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+type stubNotificationRepo struct {
+	NotificationRepo
+
+	notifications []Notification
+}
+
+func (r *stubNotificationRepo) CreateNotifications(_ context.Context, notifications []Notification, _ repository.Tx) error {
+	r.notifications = append(r.notifications, notifications...)
+	return nil
+}
+
+type stubChatServiceContract struct {
+	members []chatdomain.UserChat
+}
+
+func (c stubChatServiceContract) GetChatMembers(context.Context, uint64) ([]chatdomain.UserChat, error) {
+	return c.members, nil
+}
+
+type stubPresence struct{}
+
+func (stubPresence) GetFollowers(context.Context, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func TestEventSinkHidesEphemeralText(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  chatdomain.Message
+		wantText string
+	}{
+		{name: "regular", message: chatdomain.Message{Text: "hello"}, wantText: "hello"},
+		{name: "view once", message: chatdomain.Message{Text: "hello", IsViewOnce: true}, wantText: hiddenMessageText},
+		{name: "expiry timer", message: chatdomain.Message{Text: "hello", ExpiresIn: lo.ToPtr(uint(60))}, wantText: hiddenMessageText},
+		{
+			name:     "expiry started",
+			message:  chatdomain.Message{Text: "hello", ExpiresAt: lo.ToPtr(time.Now().Add(time.Minute))},
+			wantText: hiddenMessageText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.message
+			message.ID = 1
+			message.ChatID = 1
+			message.CreatedBy = 1
+
+			payload, err := json.Marshal(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			notificationRepo := &stubNotificationRepo{}
+			sink := NewEventSink(notificationRepo, stubChatServiceContract{
+				members: []chatdomain.UserChat{{ChatID: 1, UserID: 1}, {ChatID: 1, UserID: 2}},
+			}, stubPresence{})
+
+			event := outboxdomain.Event{Type: chatdomain.MessageCreatedEventType, Payload: payload}
+			if err := sink.Deliver(context.Background(), event, nil); err != nil {
+				t.Fatalf("Deliver() error = %v", err)
+			}
+
+			if len(notificationRepo.notifications) != 1 {
+				t.Fatalf("queued %d notifications, want 1", len(notificationRepo.notifications))
+			}
+			if got := notificationRepo.notifications[0].Text; got != tt.wantText {
+				t.Errorf("text = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+)
+
+// Notification is a message waiting to be delivered to an offline member.
+type Notification struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	ChatID    uint64    `json:"chatId"`
+	MessageID uint64    `json:"messageId"`
+	Text      string    `json:"text"`
+	IsMention bool      `json:"isMention"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type NotificationRepo interface {
+	CreateNotifications(ctx context.Context, notifications []Notification, tx repository.Tx) error
+	// GetDueNotifications returns notifications created before the given time
+	// and locks them until tx ends, other notifiers skip them.
+	GetDueNotifications(ctx context.Context, before time.Time, limit uint64, tx repository.Tx) ([]Notification, error)
+	DeleteNotifications(ctx context.Context, ids []uint64, tx repository.Tx) error
+}
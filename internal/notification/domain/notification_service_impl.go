@@ -0,0 +1,230 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+	notificationerrors "github.com/microcoretech/chat-go/internal/notification/errors"
+)
+
+type NotificationServiceImpl struct {
+	deviceRepo   DeviceRepo
+	deliveryRepo DeliveryRepo
+	// confirmationChannel sends confirmation codes of email devices, it is
+	// nil when email is not configured.
+	confirmationChannel Channel
+}
+
+func (s *NotificationServiceImpl) GetDevices(ctx context.Context) ([]Device, error) {
+	user := domain.UserFromContext(ctx)
+
+	return s.deviceRepo.GetDevices(ctx, []uint64{user.ID})
+}
+
+// RegisterDevice saves a device of the current user. An email device
+// without a token gets the email of the user. Push devices and the email of
+// the user are confirmed right away, any other email address gets a
+// confirmation code.
+func (s *NotificationServiceImpl) RegisterDevice(ctx context.Context, device Device) (*Device, error) {
+	user := domain.UserFromContext(ctx)
+
+	if device.Channel == constants.EmailChannel && device.Token == "" {
+		device.Token = user.Email
+	}
+
+	if device.Token == "" {
+		return nil, errors.NewBadRequestError(constants.NotificationDomain, nil, map[string]any{"token": device.Token})
+	}
+
+	device.UserID = user.ID
+
+	now := time.Now().UTC()
+
+	if device.Channel != constants.EmailChannel || strings.EqualFold(device.Token, user.Email) {
+		device.ConfirmedAt = &now
+		return s.deviceRepo.SaveDevice(ctx, device)
+	}
+
+	existingDevice, err := s.deviceRepo.GetDeviceByToken(ctx, device.Channel, device.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingDevice != nil {
+		if existingDevice.UserID != user.ID && existingDevice.IsConfirmed() {
+			return nil, notificationerrors.NewDeviceTakenError(map[string]any{"token": device.Token})
+		}
+
+		if existingDevice.UserID == user.ID &&
+			(existingDevice.IsConfirmed() || !existingDevice.canResendConfirmation(now)) {
+			return existingDevice, nil
+		}
+	}
+
+	return s.sendConfirmation(ctx, device, now)
+}
+
+func (s *NotificationServiceImpl) sendConfirmation(ctx context.Context, device Device, now time.Time) (*Device, error) {
+	if s.confirmationChannel == nil {
+		return nil, errors.NewServiceUnavailableError(constants.NotificationDomain, nil)
+	}
+
+	code, err := newConfirmationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(confirmationCodeTTL)
+
+	device.ConfirmedAt = nil
+	device.ConfirmationCode = hashConfirmationCode(code)
+	device.ConfirmationExpiresAt = &expiresAt
+	device.ConfirmationAttempts = 0
+
+	savedDevice, err := s.deviceRepo.SaveDevice(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.confirmationChannel.Send(ctx, *savedDevice, Digest{
+		Title: "Confirm your email",
+		Body: fmt.Sprintf("Your confirmation code is %s, it expires in %d minutes.",
+			code, int(confirmationCodeTTL.Minutes())),
+	}); err != nil {
+		return nil, errors.NewServiceUnavailableError(constants.NotificationDomain, err)
+	}
+
+	return savedDevice, nil
+}
+
+// ConfirmDevice confirms a device of the current user with the code sent to
+// it. A code can be tried a few times before it expires.
+func (s *NotificationServiceImpl) ConfirmDevice(ctx context.Context, id uint64, code string) (*Device, error) {
+	user := domain.UserFromContext(ctx)
+
+	device, err := s.deviceRepo.GetDevice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if device == nil {
+		return nil, errors.NewNotFoundError(constants.NotificationDomain)
+	}
+
+	if device.UserID != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	if device.IsConfirmed() {
+		return device, nil
+	}
+
+	now := time.Now().UTC()
+
+	if device.ConfirmationExpiresAt == nil || now.After(*device.ConfirmationExpiresAt) {
+		return nil, notificationerrors.NewInvalidConfirmationCodeError(map[string]any{"expired": true})
+	}
+
+	// Attempts are counted before the check, concurrent guesses can't
+	// exceed the limit.
+	attempts, err := s.deviceRepo.AddConfirmationAttempt(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts > maxConfirmationAttempts {
+		return nil, notificationerrors.NewInvalidConfirmationCodeError(map[string]any{"expired": true})
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashConfirmationCode(code)), []byte(device.ConfirmationCode)) != 1 {
+		return nil, notificationerrors.NewInvalidConfirmationCodeError(nil)
+	}
+
+	device.ConfirmedAt = &now
+	device.ConfirmationCode = ""
+	device.ConfirmationExpiresAt = nil
+	device.ConfirmationAttempts = 0
+
+	if err := s.deviceRepo.UpdateDevice(ctx, *device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (s *NotificationServiceImpl) DeleteDevice(ctx context.Context, id uint64) error {
+	user := domain.UserFromContext(ctx)
+
+	device, err := s.deviceRepo.GetDevice(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if device == nil {
+		return errors.NewNotFoundError(constants.NotificationDomain)
+	}
+
+	if device.UserID != user.ID {
+		return errors.NewForbiddenError()
+	}
+
+	return s.deviceRepo.DeleteDevice(ctx, id)
+}
+
+func (s *NotificationServiceImpl) GetDeliveries(ctx context.Context, filter *DeliveryFilter) ([]Delivery, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &DeliveryFilter{}
+	}
+
+	filter.UserIDs = []uint64{user.ID}
+
+	count, err := s.deliveryRepo.GetDeliveriesCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	deliveries, err := s.deliveryRepo.GetDeliveries(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, count, nil
+}
+
+func NewNotificationServiceImpl(
+	deviceRepo DeviceRepo,
+	deliveryRepo DeliveryRepo,
+	confirmationChannel Channel,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		deviceRepo:          deviceRepo,
+		deliveryRepo:        deliveryRepo,
+		confirmationChannel: confirmationChannel,
+	}
+}
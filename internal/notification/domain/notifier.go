@@ -0,0 +1,269 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var (
+	ErrNotifierAlreadyStarted = errors.New("notifier already started")
+
+	errDeviceGone = errors.New("device deleted, moved or unconfirmed")
+)
+
+const (
+	// deliveryBatchSize is the number of deliveries claimed at once, they are
+	// sent one by one.
+	deliveryBatchSize = 20
+	maxRetryBackoff   = time.Hour
+)
+
+// Notifier delivers queued notifications. Notifications of a user are held
+// for the batch window and then queued together, one digest per confirmed
+// device. Queued digests are sent outside of any transaction and retried
+// with backoff until they succeed or run out of attempts.
+type Notifier struct {
+	cfg              *configs.Config
+	log              logger.Logger
+	baseRepo         repository.BaseRepo
+	notificationRepo NotificationRepo
+	deviceRepo       DeviceRepo
+	deliveryRepo     DeliveryRepo
+	channels         map[string]Channel
+
+	isStarted atomic.Bool
+}
+
+func (n *Notifier) Start(ctx context.Context) error {
+	if !n.isStarted.CompareAndSwap(false, true) {
+		return ErrNotifierAlreadyStarted
+	}
+	defer n.isStarted.Store(false)
+
+	for {
+		n.notify(ctx)
+		n.deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(n.cfg.NotificationPollInterval):
+		}
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context) {
+	for {
+		count, err := n.notifyBatch(ctx)
+		if err != nil {
+			n.log.Errorf("error on queueing notifications: %s", err)
+			return
+		}
+
+		if count < n.cfg.NotificationBatchSize {
+			return
+		}
+	}
+}
+
+// notifyBatch turns due notifications into pending deliveries.
+func (n *Notifier) notifyBatch(ctx context.Context) (uint64, error) {
+	tx, err := n.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	before := time.Now().UTC().Add(-n.cfg.NotificationBatchWindow)
+
+	notifications, err := n.notificationRepo.GetDueNotifications(ctx, before, n.cfg.NotificationBatchSize, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+
+	notificationsMap := lo.GroupBy(notifications, func(notification Notification) uint64 {
+		return notification.UserID
+	})
+
+	devices, err := n.deviceRepo.GetDevices(ctx, lo.Keys(notificationsMap))
+	if err != nil {
+		return 0, err
+	}
+
+	var deliveries []Delivery
+
+	for _, device := range devices {
+		if _, ok := n.channels[device.Channel]; !ok || !device.IsConfirmed() {
+			continue
+		}
+
+		userNotifications := notificationsMap[device.UserID]
+
+		deliveries = append(deliveries, Delivery{
+			UserID:             device.UserID,
+			DeviceID:           device.ID,
+			Channel:            device.Channel,
+			NotificationsCount: uint(len(userNotifications)),
+			Digest:             newDigest(userNotifications),
+			Status:             PendingDeliveryStatus,
+		})
+	}
+
+	if err := n.deliveryRepo.CreateDeliveries(ctx, deliveries, tx); err != nil {
+		return 0, err
+	}
+
+	if err := n.notificationRepo.DeleteNotifications(ctx, lo.Map(notifications, func(notification Notification, _ int) uint64 {
+		return notification.ID
+	}), tx); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(notifications)), nil
+}
+
+func (n *Notifier) deliver(ctx context.Context) {
+	for {
+		count, err := n.deliverBatch(ctx)
+		if err != nil {
+			n.log.Errorf("error on sending notifications: %s", err)
+			return
+		}
+
+		if count < deliveryBatchSize {
+			return
+		}
+	}
+}
+
+// deliverBatch claims pending deliveries and sends them. A claim outlives
+// the sends of the whole batch, a delivery of a crashed notifier is sent
+// again once its claim expires.
+func (n *Notifier) deliverBatch(ctx context.Context) (uint64, error) {
+	claimTimeout := n.cfg.PushGatewayTimeout * (deliveryBatchSize + 1)
+
+	deliveries, err := n.deliveryRepo.ClaimPendingDeliveries(ctx, deliveryBatchSize, time.Now().UTC().Add(claimTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	devices, err := n.deviceRepo.GetDevicesByIDs(ctx, lo.Uniq(lo.Map(deliveries, func(delivery Delivery, _ int) uint64 {
+		return delivery.DeviceID
+	})))
+	if err != nil {
+		return 0, err
+	}
+
+	devicesMap := lo.KeyBy(devices, func(device Device) uint64 {
+		return device.ID
+	})
+
+	for _, delivery := range deliveries {
+		delivery.Attempts++
+
+		switch err := n.send(ctx, devicesMap, delivery); {
+		case err == nil:
+			delivery.Status = SucceededDeliveryStatus
+			delivery.Error = ""
+		case errors.Is(err, errDeviceGone) || delivery.Attempts >= n.cfg.NotificationMaxAttempts:
+			delivery.Status = FailedDeliveryStatus
+			delivery.Error = err.Error()
+			n.log.Debugf("error on sending notifications channel=%s device_id=%d: %s",
+				delivery.Channel, delivery.DeviceID, err)
+		default:
+			delivery.Error = err.Error()
+			delivery.NextAttemptAt = time.Now().UTC().Add(n.retryBackoff(delivery.Attempts))
+		}
+
+		if err := n.deliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+
+	return uint64(len(deliveries)), nil
+}
+
+func (n *Notifier) send(ctx context.Context, devicesMap map[uint64]Device, delivery Delivery) error {
+	// The device may have changed since the delivery was queued, a digest is
+	// never sent to another user.
+	device, ok := devicesMap[delivery.DeviceID]
+	if !ok || device.UserID != delivery.UserID || !device.IsConfirmed() {
+		return errDeviceGone
+	}
+
+	channel, ok := n.channels[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("channel %s not configured", delivery.Channel)
+	}
+
+	return channel.Send(ctx, device, delivery.Digest)
+}
+
+func (n *Notifier) retryBackoff(attempts uint) time.Duration {
+	backoff := n.cfg.NotificationRetryBackoff
+	for i := uint(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
+
+func NewNotifier(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	notificationRepo NotificationRepo,
+	deviceRepo DeviceRepo,
+	deliveryRepo DeliveryRepo,
+	channels ...Channel,
+) *Notifier {
+	return &Notifier{
+		cfg:              cfg,
+		log:              log,
+		baseRepo:         baseRepo,
+		notificationRepo: notificationRepo,
+		deviceRepo:       deviceRepo,
+		deliveryRepo:     deliveryRepo,
+		channels: lo.SliceToMap(channels, func(channel Channel) (string, Channel) {
+			return channel.Name(), channel
+		}),
+	}
+}
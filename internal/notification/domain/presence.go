@@ -0,0 +1,31 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+// Presence tells which users follow a chat on a live connection of any
+// instance.
+type Presence interface {
+	GetFollowers(ctx context.Context, chatID uint64) ([]uint64, error)
+}
+
+// LocalPresence tells which users follow chats on live connections of this
+// instance, the followers are grouped by chat.
+type LocalPresence interface {
+	GetFollowers() map[uint64][]uint64
+}
@@ -0,0 +1,37 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type PresenceRepo interface {
+	// CreatePresences saves the followers of chats on the instance until
+	// expiresAt.
+	CreatePresences(
+		ctx context.Context,
+		instanceID string,
+		followers map[uint64][]uint64,
+		expiresAt time.Time,
+		tx repository.Tx,
+	) error
+	DeletePresences(ctx context.Context, instanceID string, tx repository.Tx) error
+	DeleteExpiredPresences(ctx context.Context, before time.Time) error
+	GetFollowers(ctx context.Context, chatID uint64, now time.Time) ([]uint64, error)
+}
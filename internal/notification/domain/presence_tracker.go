@@ -0,0 +1,133 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrPresenceTrackerAlreadyStarted = errors.New("presence tracker already started")
+
+// presenceTTL is the number of refresh intervals the presences of an
+// instance outlive it, a stopped instance drops out after that.
+const presenceTTL = 3
+
+// PresenceTracker shares the local presence with other instances. It saves
+// the followers of this instance on every refresh and answers with the
+// followers of all instances.
+type PresenceTracker struct {
+	cfg           *configs.Config
+	log           logger.Logger
+	instanceID    string
+	baseRepo      repository.BaseRepo
+	presenceRepo  PresenceRepo
+	localPresence LocalPresence
+
+	isStarted atomic.Bool
+}
+
+func (t *PresenceTracker) Start(ctx context.Context) error {
+	if !t.isStarted.CompareAndSwap(false, true) {
+		return ErrPresenceTrackerAlreadyStarted
+	}
+	defer t.isStarted.Store(false)
+
+	ticker := time.NewTicker(t.cfg.PresenceRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.refresh(ctx); err != nil {
+			t.log.Errorf("error on refreshing presences: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			// The context is done, the presences are dropped with a fresh one.
+			if err := t.presenceRepo.DeletePresences(context.Background(), t.instanceID, nil); err != nil {
+				t.log.Errorf("error on deleting presences: %s", err)
+			}
+
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *PresenceTracker) refresh(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	if err := t.presenceRepo.DeleteExpiredPresences(ctx, now); err != nil {
+		return err
+	}
+
+	tx, err := t.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := t.presenceRepo.DeletePresences(ctx, t.instanceID, tx); err != nil {
+		return err
+	}
+
+	expiresAt := now.Add(t.cfg.PresenceRefreshInterval * presenceTTL)
+
+	if err := t.presenceRepo.CreatePresences(ctx, t.instanceID, t.localPresence.GetFollowers(), expiresAt, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetFollowers returns the followers of the chat on any instance. Local
+// followers are always up to date, the others lag by a refresh interval.
+func (t *PresenceTracker) GetFollowers(ctx context.Context, chatID uint64) ([]uint64, error) {
+	followers, err := t.presenceRepo.GetFollowers(ctx, chatID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Uniq(append(followers, t.localPresence.GetFollowers()[chatID]...)), nil
+}
+
+func NewPresenceTracker(
+	cfg *configs.Config,
+	log logger.Logger,
+	instanceID string,
+	baseRepo repository.BaseRepo,
+	presenceRepo PresenceRepo,
+	localPresence LocalPresence,
+) *PresenceTracker {
+	return &PresenceTracker{
+		cfg:           cfg,
+		log:           log,
+		instanceID:    instanceID,
+		baseRepo:      baseRepo,
+		presenceRepo:  presenceRepo,
+		localPresence: localPresence,
+	}
+}
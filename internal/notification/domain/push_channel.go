@@ -0,0 +1,102 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+)
+
+type pushRequest struct {
+	Token    string   `json:"token"`
+	Platform string   `json:"platform"`
+	Title    string   `json:"title"`
+	Body     string   `json:"body"`
+	Data     pushData `json:"data"`
+}
+
+type pushData struct {
+	ChatIDs    []uint64 `json:"chatIds"`
+	MessageIDs []uint64 `json:"messageIds"`
+}
+
+// PushChannel sends notifications through a generic HTTP push gateway, the
+// gateway forwards them to APNs, FCM or any other provider.
+type PushChannel struct {
+	cfg    *configs.Config
+	client *http.Client
+}
+
+func (c *PushChannel) Name() string {
+	return constants.PushChannel
+}
+
+func (c *PushChannel) Send(ctx context.Context, device Device, digest Digest) error {
+	body, err := json.Marshal(pushRequest{
+		Token:    device.Token,
+		Platform: device.Platform,
+		Title:    digest.Title,
+		Body:     digest.Body,
+		Data: pushData{
+			ChatIDs: lo.Uniq(lo.Map(digest.Notifications, func(notification Notification, _ int) uint64 {
+				return notification.ChatID
+			})),
+			MessageIDs: lo.Map(digest.Notifications, func(notification Notification, _ int) uint64 {
+				return notification.MessageID
+			}),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.PushGatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.cfg.PushGatewayToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.cfg.PushGatewayToken))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func NewPushChannel(cfg *configs.Config) *PushChannel {
+	return &PushChannel{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.PushGatewayTimeout},
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+)
+
+const DeviceTakenErrorType = "DeviceTakenError"
+
+// DeviceTakenError is returned when another user has confirmed the device.
+type DeviceTakenError struct {
+	*errors.ErrorData
+}
+
+func NewDeviceTakenError(data map[string]any) *DeviceTakenError {
+	return &DeviceTakenError{
+		ErrorData: errors.NewErrorData(constants.NotificationDomain, DeviceTakenErrorType, nil, data),
+	}
+}
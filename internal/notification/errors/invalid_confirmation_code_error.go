@@ -0,0 +1,34 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+)
+
+const InvalidConfirmationCodeErrorType = "InvalidConfirmationCodeError"
+
+// InvalidConfirmationCodeError is returned for a wrong code and for a code
+// that expired or ran out of attempts, the device has to be registered again.
+type InvalidConfirmationCodeError struct {
+	*errors.ErrorData
+}
+
+func NewInvalidConfirmationCodeError(data map[string]any) *InvalidConfirmationCodeError {
+	return &InvalidConfirmationCodeError{
+		ErrorData: errors.NewErrorData(constants.NotificationDomain, InvalidConfirmationCodeErrorType, nil, data),
+	}
+}
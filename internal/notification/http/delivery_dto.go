@@ -0,0 +1,31 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type DeliveryDto struct {
+	ID                 uint64    `json:"id"`
+	DeviceID           uint64    `json:"deviceId"`
+	Channel            string    `json:"channel"`
+	NotificationsCount uint      `json:"notificationsCount"`
+	Status             uint8     `json:"status"`
+	Error              string    `json:"error"`
+	Attempts           uint      `json:"attempts"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
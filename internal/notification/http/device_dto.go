@@ -0,0 +1,44 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type RegisterDeviceDto struct {
+	Channel string `json:"channel" validate:"required,oneof=push email"`
+	// Token is a push token or an email address. An email device without a
+	// token uses the email of the user.
+	Token    string `json:"token" validate:"required_if=Channel push,lte=512"`
+	Platform string `json:"platform" validate:"lte=32"`
+}
+
+type ConfirmDeviceDto struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// DeviceDto is a registered device. IsConfirmed is false until the code sent
+// to an email device is confirmed, notifications are only sent to confirmed
+// devices.
+type DeviceDto struct {
+	ID          uint64    `json:"id"`
+	Channel     string    `json:"channel"`
+	Token       string    `json:"token"`
+	Platform    string    `json:"platform"`
+	IsConfirmed bool      `json:"isConfirmed"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
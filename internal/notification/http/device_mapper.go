@@ -0,0 +1,39 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/microcoretech/chat-go/internal/notification/domain"
+)
+
+func DeviceToDto(device domain.Device) DeviceDto {
+	return DeviceDto{
+		ID:          device.ID,
+		Channel:     device.Channel,
+		Token:       device.Token,
+		Platform:    device.Platform,
+		IsConfirmed: device.IsConfirmed(),
+		CreatedAt:   device.CreatedAt,
+		UpdatedAt:   device.UpdatedAt,
+	}
+}
+
+func DeviceFromRegisterDto(dto RegisterDeviceDto) domain.Device {
+	return domain.Device{
+		Channel:  dto.Channel,
+		Token:    dto.Token,
+		Platform: dto.Platform,
+	}
+}
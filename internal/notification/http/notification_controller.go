@@ -0,0 +1,160 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+	"github.com/microcoretech/chat-go/internal/notification/domain"
+)
+
+type NotificationController struct {
+	validate            validator.Validate
+	authMiddleware      api.Middleware
+	notificationService NotificationService
+}
+
+func (c *NotificationController) SetupRoutes(r fiber.Router) {
+	notificationGroup := r.Group("/notifications", c.authMiddleware.Handler)
+	notificationGroup.Get("/devices", c.getDevices)
+	notificationGroup.Post("/devices", c.registerDevice)
+	notificationGroup.Post("/devices/:id/confirm", c.confirmDevice)
+	notificationGroup.Delete("/devices/:id", c.deleteDevice)
+	notificationGroup.Get("/deliveries", c.getDeliveries)
+}
+
+func (c *NotificationController) getDevices(ctx *fiber.Ctx) error {
+	devices, err := c.notificationService.GetDevices(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(lo.Map(devices, func(device domain.Device, _ int) DeviceDto {
+		return DeviceToDto(device)
+	}))
+}
+
+func (c *NotificationController) registerDevice(ctx *fiber.Ctx) error {
+	dto := RegisterDeviceDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.NotificationDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.NotificationDomain, dto); err != nil {
+		return err
+	}
+
+	if dto.Channel == constants.EmailChannel && dto.Token != "" {
+		if err := c.validate.Var(constants.NotificationDomain, dto.Token, "email"); err != nil {
+			return err
+		}
+	}
+
+	device, err := c.notificationService.RegisterDevice(ctx.Context(), DeviceFromRegisterDto(dto))
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(DeviceToDto(*device))
+}
+
+func (c *NotificationController) confirmDevice(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.NotificationDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := ConfirmDeviceDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.NotificationDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.NotificationDomain, dto); err != nil {
+		return err
+	}
+
+	device, err := c.notificationService.ConfirmDevice(ctx.Context(), id, dto.Code)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(DeviceToDto(*device))
+}
+
+func (c *NotificationController) deleteDevice(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.NotificationDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.notificationService.DeleteDevice(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func (c *NotificationController) getDeliveries(ctx *fiber.Ctx) error {
+	var query DeliveryQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.NotificationDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.NotificationDomain, &query); err != nil {
+		return errors.NewValidationError(constants.NotificationDomain, err, nil)
+	}
+
+	deliveries, count, err := c.notificationService.GetDeliveries(ctx.Context(), &domain.DeliveryFilter{
+		Channels: query.Channels,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(deliveries, func(delivery domain.Delivery, _ int) DeliveryDto {
+			return DeliveryToDto(delivery)
+		}),
+		count,
+	))
+}
+
+func NewNotificationController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	notificationService NotificationService,
+) *NotificationController {
+	return &NotificationController{
+		validate:            validate,
+		authMiddleware:      authMiddleware,
+		notificationService: notificationService,
+	}
+}
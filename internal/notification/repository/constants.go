@@ -0,0 +1,28 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+const (
+	notificationTableName = "notifications"
+	deviceTableName       = "notification_devices"
+	deliveryTableName     = "notification_deliveries"
+	presenceTableName     = "presences"
+)
+
+const (
+	notificationFields = `n.id, n.user_id, n.chat_id, n.message_id, n.text, n.is_mention, n.created_at`
+	deviceFields       = `d.id, d.user_id, d.channel, d.token, d.platform, d.confirmed_at, d.confirmation_code, d.confirmation_expires_at, d.confirmation_attempts, d.created_at, d.updated_at`
+	deliveryFields     = `l.id, l.user_id, l.device_id, l.channel, l.notifications_count, l.digest, l.status, l.error, l.attempts, l.next_attempt_at, l.created_at, l.updated_at`
+)
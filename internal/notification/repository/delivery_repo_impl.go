@@ -0,0 +1,276 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+	"github.com/microcoretech/chat-go/internal/notification/domain"
+)
+
+type DeliveryRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *DeliveryRepoImpl) scan(rows *sql.Rows) ([]domain.Delivery, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	deliveries := make([]domain.Delivery, 0)
+
+	for rows.Next() {
+		var delivery domain.Delivery
+
+		var fields = []any{
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.DeviceID,
+			&delivery.Channel,
+			&delivery.NotificationsCount,
+			(*digestDto)(&delivery.Digest),
+			&delivery.Status,
+			&delivery.Error,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) buildFilter(filter domain.DeliveryFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.UserIDs) > 0 {
+		var params []string
+		for _, userID := range filter.UserIDs {
+			values = append(values, userID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"l.user_id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Channels) > 0 {
+		var params []string
+		for _, channel := range filter.Channels {
+			values = append(values, channel)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"l.channel IN (%s) ", strings.Join(params, ",")))
+	}
+
+	return values, where
+}
+
+func (r *DeliveryRepoImpl) GetDeliveries(ctx context.Context, filter *domain.DeliveryFilter) ([]domain.Delivery, error) {
+	if filter == nil {
+		filter = &domain.DeliveryFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT %s FROM %s AS l", deliveryFields, deliveryTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	query = fmt.Sprintf(`%s
+		ORDER BY l.id DESC`, query)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s
+		LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s
+		OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	deliveries, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) GetDeliveriesCount(ctx context.Context, filter *domain.DeliveryFilter) (uint64, error) {
+	if filter == nil {
+		filter = &domain.DeliveryFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS l", deliveryTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.NotificationDomain, err, "error on query deliveries count")
+	}
+
+	return count, nil
+}
+
+func (r *DeliveryRepoImpl) CreateDeliveries(ctx context.Context, deliveries []domain.Delivery, tx repository.Tx) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders []string
+		values       []any
+	)
+
+	const colsNum = 7
+
+	for i, delivery := range deliveries {
+		var indexes []any
+
+		for j := 1; j <= colsNum; j++ {
+			indexes = append(indexes, i*colsNum+j)
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d)", indexes...))
+
+		values = append(values,
+			delivery.UserID,
+			delivery.DeviceID,
+			delivery.Channel,
+			delivery.NotificationsCount,
+			digestDto(delivery.Digest),
+			delivery.Status,
+			delivery.Error,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, device_id, channel, notifications_count, digest, status, error)
+		VALUES %s
+	`,
+		deliveryTableName,
+		strings.Join(placeholders, ","),
+	)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepoImpl) ClaimPendingDeliveries(
+	ctx context.Context,
+	limit uint64,
+	claimedUntil time.Time,
+) ([]domain.Delivery, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS l
+		SET next_attempt_at = $1, updated_at = NOW()
+		FROM (
+			SELECT pl.id
+			FROM %s AS pl
+			WHERE pl.status = $2 AND pl.next_attempt_at <= NOW()
+			ORDER BY pl.id
+			LIMIT %d
+			FOR UPDATE SKIP LOCKED
+		) AS c
+		WHERE l.id = c.id
+		RETURNING %s
+	`,
+		deliveryTableName,
+		deliveryTableName,
+		limit,
+		deliveryFields,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, claimedUntil, domain.PendingDeliveryStatus)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	deliveries, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) UpdateDelivery(ctx context.Context, delivery domain.Delivery) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, error = $2, attempts = $3, next_attempt_at = $4, updated_at = NOW()
+		WHERE id = $5
+	`, deliveryTableName)
+
+	if _, err := r.db.ExecContext(ctx, query,
+		delivery.Status,
+		delivery.Error,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ID,
+	); err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func NewDeliveryRepoImpl(db *sql.DB) *DeliveryRepoImpl {
+	return &DeliveryRepoImpl{db: db}
+}
@@ -0,0 +1,290 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+	"github.com/microcoretech/chat-go/internal/notification/domain"
+)
+
+type DeviceRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *DeviceRepoImpl) scan(rows *sql.Rows) ([]domain.Device, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	devices := make([]domain.Device, 0)
+
+	for rows.Next() {
+		var device domain.Device
+
+		var fields = []any{
+			&device.ID,
+			&device.UserID,
+			&device.Channel,
+			&device.Token,
+			&device.Platform,
+			&device.ConfirmedAt,
+			&device.ConfirmationCode,
+			&device.ConfirmationExpiresAt,
+			&device.ConfirmationAttempts,
+			&device.CreatedAt,
+			&device.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepoImpl) GetDevice(ctx context.Context, id uint64) (*domain.Device, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS d WHERE d.id = $1`, deviceFields, deviceTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	devices, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	return &devices[0], nil
+}
+
+func (r *DeviceRepoImpl) GetDevices(ctx context.Context, userIDs []uint64) ([]domain.Device, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, userID := range userIDs {
+		values = append(values, userID)
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS d
+		WHERE d.user_id IN (%s)
+		ORDER BY d.id
+	`,
+		deviceFields,
+		deviceTableName,
+		strings.Join(params, ","),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	devices, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepoImpl) GetDevicesByIDs(ctx context.Context, ids []uint64) ([]domain.Device, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, id := range ids {
+		values = append(values, id)
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS d
+		WHERE d.id IN (%s)
+	`,
+		deviceFields,
+		deviceTableName,
+		strings.Join(params, ","),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	devices, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepoImpl) GetDeviceByToken(ctx context.Context, channel string, token string) (*domain.Device, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS d WHERE d.channel = $1 AND d.token = $2`, deviceFields, deviceTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, channel, token)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	devices, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	return &devices[0], nil
+}
+
+func (r *DeviceRepoImpl) SaveDevice(ctx context.Context, device domain.Device) (*domain.Device, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS d (
+			user_id, channel, token, platform,
+			confirmed_at, confirmation_code, confirmation_expires_at, confirmation_attempts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (channel, token) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			confirmed_at = EXCLUDED.confirmed_at,
+			confirmation_code = EXCLUDED.confirmation_code,
+			confirmation_expires_at = EXCLUDED.confirmation_expires_at,
+			confirmation_attempts = EXCLUDED.confirmation_attempts,
+			updated_at = NOW()
+		RETURNING %s
+	`, deviceTableName, deviceFields)
+
+	rows, err := r.db.QueryContext(ctx, query,
+		device.UserID,
+		device.Channel,
+		device.Token,
+		device.Platform,
+		device.ConfirmedAt,
+		device.ConfirmationCode,
+		device.ConfirmationExpiresAt,
+		device.ConfirmationAttempts,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	devices, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	return &devices[0], nil
+}
+
+func (r *DeviceRepoImpl) UpdateDevice(ctx context.Context, device domain.Device) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET confirmed_at = $1,
+			confirmation_code = $2,
+			confirmation_expires_at = $3,
+			confirmation_attempts = $4,
+			updated_at = NOW()
+		WHERE id = $5
+	`, deviceTableName)
+
+	if _, err := r.db.ExecContext(ctx, query,
+		device.ConfirmedAt,
+		device.ConfirmationCode,
+		device.ConfirmationExpiresAt,
+		device.ConfirmationAttempts,
+		device.ID,
+	); err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func (r *DeviceRepoImpl) AddConfirmationAttempt(ctx context.Context, id uint64) (uint, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET confirmation_attempts = confirmation_attempts + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING confirmation_attempts
+	`, deviceTableName)
+
+	var attempts uint
+
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts); err != nil {
+		return 0, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return attempts, nil
+}
+
+func (r *DeviceRepoImpl) DeleteDevice(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, deviceTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func NewDeviceRepoImpl(db *sql.DB) *DeviceRepoImpl {
+	return &DeviceRepoImpl{db: db}
+}
@@ -0,0 +1,199 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+	"github.com/microcoretech/chat-go/internal/notification/domain"
+)
+
+type NotificationRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *NotificationRepoImpl) scan(rows *sql.Rows) ([]domain.Notification, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	notifications := make([]domain.Notification, 0)
+
+	for rows.Next() {
+		var notification domain.Notification
+
+		var fields = []any{
+			&notification.ID,
+			&notification.UserID,
+			&notification.ChatID,
+			&notification.MessageID,
+			&notification.Text,
+			&notification.IsMention,
+			&notification.CreatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepoImpl) CreateNotifications(
+	ctx context.Context,
+	notifications []domain.Notification,
+	tx repository.Tx,
+) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders []string
+		values       []any
+	)
+
+	const colsNum = 5
+
+	for i, notification := range notifications {
+		var indexes []any
+
+		for j := 1; j <= colsNum; j++ {
+			indexes = append(indexes, i*colsNum+j)
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", indexes...))
+
+		values = append(values,
+			notification.UserID,
+			notification.ChatID,
+			notification.MessageID,
+			notification.Text,
+			notification.IsMention,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, chat_id, message_id, text, is_mention)
+		VALUES %s
+	`,
+		notificationTableName,
+		strings.Join(placeholders, ","),
+	)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepoImpl) GetDueNotifications(
+	ctx context.Context,
+	before time.Time,
+	limit uint64,
+	tx repository.Tx,
+) ([]domain.Notification, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS n
+		WHERE n.created_at <= $1
+		ORDER BY n.user_id, n.id
+		LIMIT %d
+		FOR UPDATE SKIP LOCKED
+	`,
+		notificationFields,
+		notificationTableName,
+		limit,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, before)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, before)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	notifications, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepoImpl) DeleteNotifications(ctx context.Context, ids []uint64, tx repository.Tx) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, id := range ids {
+		values = append(values, id)
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, notificationTableName, strings.Join(params, ","))
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func NewNotificationRepoImpl(db *sql.DB) *NotificationRepoImpl {
+	return &NotificationRepoImpl{db: db}
+}
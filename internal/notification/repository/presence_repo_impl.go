@@ -0,0 +1,123 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/notification/constants"
+)
+
+type PresenceRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *PresenceRepoImpl) exec(ctx context.Context, tx repository.Tx, query string, values ...any) error {
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	return nil
+}
+
+func (r *PresenceRepoImpl) CreatePresences(
+	ctx context.Context,
+	instanceID string,
+	followers map[uint64][]uint64,
+	expiresAt time.Time,
+	tx repository.Tx,
+) error {
+	var userIDs, chatIDs []int64
+
+	for chatID, chatFollowers := range followers {
+		for _, userID := range chatFollowers {
+			userIDs = append(userIDs, int64(userID))
+			chatIDs = append(chatIDs, int64(chatID))
+		}
+	}
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (instance_id, user_id, chat_id, expires_at)
+		SELECT $1, p.user_id, p.chat_id, $4
+		FROM UNNEST($2::BIGINT[], $3::BIGINT[]) AS p (user_id, chat_id)
+		ON CONFLICT DO NOTHING
+	`, presenceTableName)
+
+	return r.exec(ctx, tx, query, instanceID, pq.Array(userIDs), pq.Array(chatIDs), expiresAt)
+}
+
+func (r *PresenceRepoImpl) DeletePresences(ctx context.Context, instanceID string, tx repository.Tx) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE instance_id = $1`, presenceTableName)
+
+	return r.exec(ctx, tx, query, instanceID)
+}
+
+func (r *PresenceRepoImpl) DeleteExpiredPresences(ctx context.Context, before time.Time) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE expires_at <= $1`, presenceTableName)
+
+	return r.exec(ctx, nil, query, before)
+}
+
+func (r *PresenceRepoImpl) GetFollowers(ctx context.Context, chatID uint64, now time.Time) ([]uint64, error) {
+	query := fmt.Sprintf(`
+		SELECT DISTINCT p.user_id
+		FROM %s AS p
+		WHERE p.chat_id = $1 AND p.expires_at > $2
+	`, presenceTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, chatID, now)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+	}
+
+	defer rows.Close()
+
+	var followers []uint64
+
+	for rows.Next() {
+		var userID uint64
+
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errors.NewDatabaseError(constants.NotificationDomain, err)
+		}
+
+		followers = append(followers, userID)
+	}
+
+	return followers, nil
+}
+
+func NewPresenceRepoImpl(db *sql.DB) *PresenceRepoImpl {
+	return &PresenceRepoImpl{db: db}
+}
@@ -0,0 +1,55 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contract
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+type EventPublisherContractImpl struct {
+	outboxRepo outboxdomain.OutboxRepo
+}
+
+// Publish stores the events in the outbox using the caller's transaction, so
+// they are only dispatched if the state change is committed.
+func (c *EventPublisherContractImpl) Publish(ctx context.Context, tx repository.Tx, events ...domain.Event) error {
+	origin := domain.ConnectionIDFromContext(ctx)
+
+	outboxEvents := make([]outboxdomain.Event, 0, len(events))
+
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return err
+		}
+
+		outboxEvents = append(outboxEvents, outboxdomain.Event{
+			Type:    event.Type,
+			Payload: payload,
+			Origin:  origin,
+		})
+	}
+
+	return c.outboxRepo.CreateEvents(ctx, outboxEvents, tx)
+}
+
+func NewEventPublisherContractImpl(outboxRepo outboxdomain.OutboxRepo) *EventPublisherContractImpl {
+	return &EventPublisherContractImpl{outboxRepo: outboxRepo}
+}
@@ -0,0 +1,42 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+const BroadcastSinkName = "broadcast"
+
+// BroadcastSink hands events to the Receivers of all instances. It is used
+// for sinks that only reach the state of one instance, like its WebSocket
+// connections.
+type BroadcastSink struct {
+	outboxRepo OutboxRepo
+}
+
+func (s *BroadcastSink) Name() string {
+	return BroadcastSinkName
+}
+
+func (s *BroadcastSink) Deliver(ctx context.Context, event Event, tx repository.Tx) error {
+	return s.outboxRepo.CreateBroadcast(ctx, event, tx)
+}
+
+func NewBroadcastSink(outboxRepo OutboxRepo) *BroadcastSink {
+	return &BroadcastSink{outboxRepo: outboxRepo}
+}
@@ -0,0 +1,197 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrDispatcherAlreadyStarted = errors.New("dispatcher already started")
+
+const (
+	maxRetryBackoff   = time.Hour
+	deliverySavepoint = "outbox_delivery"
+)
+
+// Dispatcher delivers outbox events to the sinks. Failed events are retried
+// with exponential backoff and marked dead after OutboxMaxAttempts.
+type Dispatcher struct {
+	cfg        *configs.Config
+	log        logger.Logger
+	baseRepo   repository.BaseRepo
+	outboxRepo OutboxRepo
+	wakeup     <-chan struct{}
+	sinks      []Sink
+
+	isStarted atomic.Bool
+}
+
+func (d *Dispatcher) Start(ctx context.Context) error {
+	if !d.isStarted.CompareAndSwap(false, true) {
+		return ErrDispatcherAlreadyStarted
+	}
+	defer d.isStarted.Store(false)
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.wakeup:
+		case <-time.After(d.cfg.OutboxPollInterval):
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for {
+		count, err := d.dispatchBatch(ctx)
+		if err != nil {
+			d.log.Errorf("error on dispatching outbox events: %s", err)
+			return
+		}
+
+		if count < d.cfg.OutboxBatchSize {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (uint64, error) {
+	tx, err := d.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	events, err := d.outboxRepo.GetPendingEvents(ctx, d.cfg.OutboxBatchSize, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := d.deliver(ctx, &event, tx); err != nil {
+			if err := d.fail(ctx, event, err, tx); err != nil {
+				return 0, err
+			}
+
+			continue
+		}
+
+		if err := d.outboxRepo.DeleteEvent(ctx, event.ID, tx); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(events)), nil
+}
+
+// deliver hands the event to the sinks that didn't get it yet. A failing
+// sink doesn't affect the others, only it gets the event again on retry.
+func (d *Dispatcher) deliver(ctx context.Context, event *Event, tx repository.Tx) error {
+	var errs []error
+
+	for _, sink := range d.sinks {
+		if slices.Contains(event.DeliveredSinks, sink.Name()) {
+			continue
+		}
+
+		err := repository.WithSavepoint(ctx, tx, deliverySavepoint, func() error {
+			return d.deliverTo(ctx, sink, *event, tx)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+
+		event.DeliveredSinks = append(event.DeliveredSinks, sink.Name())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) deliverTo(ctx context.Context, sink Sink, event Event, tx repository.Tx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s\n%s", r, string(debug.Stack()))
+		}
+	}()
+
+	return sink.Deliver(ctx, event, tx)
+}
+
+func (d *Dispatcher) fail(ctx context.Context, event Event, deliveryErr error, tx repository.Tx) error {
+	event.Attempts++
+	event.LastError = deliveryErr.Error()
+
+	if event.Attempts >= d.cfg.OutboxMaxAttempts {
+		event.Status = DeadEventStatus
+		d.log.Errorf("Outbox event is dead id=%d type=%s attempts=%d error=%s",
+			event.ID, event.Type, event.Attempts, event.LastError)
+	} else {
+		event.NextAttemptAt = time.Now().UTC().Add(d.retryBackoff(event.Attempts))
+		d.log.Warnf("Outbox event delivery failed id=%d type=%s attempts=%d error=%s",
+			event.ID, event.Type, event.Attempts, event.LastError)
+	}
+
+	return d.outboxRepo.UpdateEvent(ctx, event, tx)
+}
+
+func (d *Dispatcher) retryBackoff(attempts uint) time.Duration {
+	backoff := d.cfg.OutboxRetryBackoff
+	for i := uint(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
+
+// NewDispatcher creates a dispatcher that polls the outbox every
+// OutboxPollInterval and additionally whenever wakeup fires. wakeup may be nil.
+func NewDispatcher(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	outboxRepo OutboxRepo,
+	wakeup <-chan struct{},
+	sinks ...Sink,
+) *Dispatcher {
+	return &Dispatcher{
+		cfg:        cfg,
+		log:        log,
+		baseRepo:   baseRepo,
+		outboxRepo: outboxRepo,
+		wakeup:     wakeup,
+		sinks:      sinks,
+	}
+}
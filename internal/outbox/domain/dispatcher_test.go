@@ -0,0 +1,275 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+type stubLogger struct {
+	logger.Logger
+}
+
+func (stubLogger) Warnf(string, ...any)  {}
+func (stubLogger) Errorf(string, ...any) {}
+
+type stubTx struct {
+	repository.Tx
+
+	committed bool
+}
+
+func (t *stubTx) ExecContext(context.Context, string, ...any) (sql.Result, error) { return nil, nil }
+func (t *stubTx) Rollback() error                                                 { return nil }
+
+func (t *stubTx) Commit() error {
+	t.committed = true
+	return nil
+}
+
+type stubBaseRepo struct {
+	repository.BaseRepo
+
+	txs []*stubTx
+}
+
+func (r *stubBaseRepo) BeginContext(context.Context) (repository.Tx, error) {
+	tx := &stubTx{}
+	r.txs = append(r.txs, tx)
+
+	return tx, nil
+}
+
+type stubOutboxRepo struct {
+	OutboxRepo
+
+	pending []Event
+	limits  []uint64
+	updated []Event
+	deleted []uint64
+}
+
+func (r *stubOutboxRepo) GetPendingEvents(_ context.Context, limit uint64, _ repository.Tx) ([]Event, error) {
+	r.limits = append(r.limits, limit)
+
+	events := r.pending[:min(limit, uint64(len(r.pending)))]
+	r.pending = r.pending[len(events):]
+
+	return events, nil
+}
+
+func (r *stubOutboxRepo) UpdateEvent(_ context.Context, event Event, _ repository.Tx) error {
+	r.updated = append(r.updated, event)
+	return nil
+}
+
+func (r *stubOutboxRepo) DeleteEvent(_ context.Context, id uint64, _ repository.Tx) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// stubSink fails the events of failTypes and panics on panicTypes.
+type stubSink struct {
+	name       string
+	failTypes  []string
+	panicTypes []string
+	delivered  []uint64
+}
+
+func (s *stubSink) Name() string {
+	return s.name
+}
+
+func (s *stubSink) Deliver(_ context.Context, event Event, _ repository.Tx) error {
+	if slices.Contains(s.panicTypes, event.Type) {
+		panic("sink is broken")
+	}
+
+	if slices.Contains(s.failTypes, event.Type) {
+		return errors.New("sink is down")
+	}
+
+	s.delivered = append(s.delivered, event.ID)
+
+	return nil
+}
+
+func TestDispatcherDispatch(t *testing.T) {
+	cfg := &configs.Config{
+		OutboxBatchSize:    2,
+		OutboxMaxAttempts:  3,
+		OutboxRetryBackoff: time.Second,
+	}
+
+	tests := []struct {
+		name            string
+		event           Event
+		failTypes       []string
+		panicTypes      []string
+		wantDelivered   []string
+		wantDeleted     bool
+		wantStatus      EventStatus
+		wantAttempts    uint
+		wantErrContains string
+	}{
+		{
+			name:          "delivered to all sinks",
+			event:         Event{ID: 1, Type: "chat.created", Status: PendingEventStatus},
+			wantDelivered: []string{"first", "second"},
+			wantDeleted:   true,
+		},
+		{
+			name:            "failed sink is retried",
+			event:           Event{ID: 1, Type: "chat.created", Status: PendingEventStatus},
+			failTypes:       []string{"chat.created"},
+			wantDelivered:   []string{"first"},
+			wantStatus:      PendingEventStatus,
+			wantAttempts:    1,
+			wantErrContains: "second: sink is down",
+		},
+		{
+			name:            "panicking sink is retried",
+			event:           Event{ID: 1, Type: "chat.created", Status: PendingEventStatus},
+			panicTypes:      []string{"chat.created"},
+			wantDelivered:   []string{"first"},
+			wantStatus:      PendingEventStatus,
+			wantAttempts:    1,
+			wantErrContains: "sink is broken",
+		},
+		{
+			name:          "retry skips delivered sinks",
+			event:         Event{ID: 1, Type: "chat.created", Status: PendingEventStatus, Attempts: 1, DeliveredSinks: []string{"first"}},
+			wantDelivered: []string{"second"},
+			wantDeleted:   true,
+		},
+		{
+			name:            "dead after the last attempt",
+			event:           Event{ID: 1, Type: "chat.created", Status: PendingEventStatus, Attempts: 2, DeliveredSinks: []string{"first"}},
+			failTypes:       []string{"chat.created"},
+			wantStatus:      DeadEventStatus,
+			wantAttempts:    3,
+			wantErrContains: "sink is down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := &stubSink{name: "first"}
+			second := &stubSink{name: "second", failTypes: tt.failTypes, panicTypes: tt.panicTypes}
+			outboxRepo := &stubOutboxRepo{pending: []Event{tt.event}}
+			baseRepo := &stubBaseRepo{}
+
+			NewDispatcher(cfg, stubLogger{}, baseRepo, outboxRepo, nil, first, second).dispatch(context.Background())
+
+			var delivered []string
+			for _, sink := range []*stubSink{first, second} {
+				if slices.Contains(sink.delivered, tt.event.ID) {
+					delivered = append(delivered, sink.name)
+				}
+			}
+			if !slices.Equal(delivered, tt.wantDelivered) {
+				t.Errorf("delivered to %v, want %v", delivered, tt.wantDelivered)
+			}
+
+			if slices.Contains(outboxRepo.deleted, tt.event.ID) != tt.wantDeleted {
+				t.Errorf("deleted %v, want deleted %t", outboxRepo.deleted, tt.wantDeleted)
+			}
+
+			if len(baseRepo.txs) != 1 || !baseRepo.txs[0].committed {
+				t.Fatalf("batch isn't committed in a single transaction")
+			}
+
+			if tt.wantDeleted {
+				if len(outboxRepo.updated) != 0 {
+					t.Errorf("delivered event is updated %+v", outboxRepo.updated)
+				}
+				return
+			}
+
+			if len(outboxRepo.updated) != 1 {
+				t.Fatalf("updated %d events, want 1", len(outboxRepo.updated))
+			}
+
+			updated := outboxRepo.updated[0]
+			if updated.Status != tt.wantStatus || updated.Attempts != tt.wantAttempts {
+				t.Errorf("updated event status %d after %d attempts, want %d after %d",
+					updated.Status, updated.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if !strings.Contains(updated.LastError, tt.wantErrContains) {
+				t.Errorf("last error %q, want it to contain %q", updated.LastError, tt.wantErrContains)
+			}
+			wantDeliveredSinks := append(slices.Clone(tt.event.DeliveredSinks), tt.wantDelivered...)
+			if !slices.Equal(updated.DeliveredSinks, wantDeliveredSinks) {
+				t.Errorf("delivered sinks %v, want %v", updated.DeliveredSinks, wantDeliveredSinks)
+			}
+			if tt.wantStatus == PendingEventStatus && !updated.NextAttemptAt.After(time.Now()) {
+				t.Errorf("next attempt at %s isn't postponed", updated.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestDispatcherClaimsInBatches(t *testing.T) {
+	outboxRepo := &stubOutboxRepo{}
+	for id := uint64(1); id <= 5; id++ {
+		outboxRepo.pending = append(outboxRepo.pending, Event{ID: id, Type: "chat.created"})
+	}
+	baseRepo := &stubBaseRepo{}
+	sink := &stubSink{name: "sink"}
+
+	NewDispatcher(&configs.Config{OutboxBatchSize: 2}, stubLogger{}, baseRepo, outboxRepo, nil, sink).
+		dispatch(context.Background())
+
+	// Full batches are followed by another claim until a short one.
+	if !slices.Equal(outboxRepo.limits, []uint64{2, 2, 2}) || len(baseRepo.txs) != 3 {
+		t.Errorf("claimed %v in %d transactions, want 3 batches of 2", outboxRepo.limits, len(baseRepo.txs))
+	}
+	if !slices.Equal(sink.delivered, []uint64{1, 2, 3, 4, 5}) {
+		t.Errorf("delivered %v, want all events in order", sink.delivered)
+	}
+	if !slices.Equal(outboxRepo.deleted, []uint64{1, 2, 3, 4, 5}) {
+		t.Errorf("deleted %v, want all events", outboxRepo.deleted)
+	}
+}
+
+func TestDispatcherRetryBackoff(t *testing.T) {
+	dispatcher := NewDispatcher(&configs.Config{OutboxRetryBackoff: time.Second}, stubLogger{}, nil, nil, nil)
+
+	tests := []struct {
+		attempts uint
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 30, want: maxRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := dispatcher.retryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
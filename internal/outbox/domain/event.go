@@ -0,0 +1,47 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventStatus uint8
+
+func (s EventStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	PendingEventStatus EventStatus = 1
+	DeadEventStatus    EventStatus = 2
+)
+
+type Event struct {
+	ID        uint64
+	Type      string
+	Payload   json.RawMessage
+	Origin    string
+	Status    EventStatus
+	Attempts  uint
+	LastError string
+	// DeliveredSinks are the names of the sinks that already got the
+	// event, retries skip them.
+	DeliveredSinks []string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,37 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+type OutboxRepo interface {
+	CreateEvents(ctx context.Context, events []Event, tx repository.Tx) error
+	// GetPendingEvents locks the returned events until tx ends, other
+	// dispatchers skip them.
+	GetPendingEvents(ctx context.Context, limit uint64, tx repository.Tx) ([]Event, error)
+	UpdateEvent(ctx context.Context, event Event, tx repository.Tx) error
+	DeleteEvent(ctx context.Context, id uint64, tx repository.Tx) error
+	// CreateBroadcast stores the event for the Receivers of all instances
+	// and notifies them once tx commits.
+	CreateBroadcast(ctx context.Context, event Event, tx repository.Tx) error
+	// GetBroadcast returns nil if the broadcast was already purged.
+	GetBroadcast(ctx context.Context, id uint64) (*Event, error)
+	DeleteBroadcasts(ctx context.Context, createdBefore time.Time) error
+}
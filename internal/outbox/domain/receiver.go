@@ -0,0 +1,125 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrReceiverAlreadyStarted = errors.New("receiver already started")
+
+// Receiver delivers the events of the BroadcastSink to the local sinks of
+// this instance. Delivery is best effort: broadcasts sent while the
+// instance is down or reconnecting to Postgres are missed, clients catch up
+// when they reconnect.
+type Receiver struct {
+	cfg           *configs.Config
+	log           logger.Logger
+	outboxRepo    OutboxRepo
+	notifications <-chan string
+	sinks         []Sink
+
+	isStarted atomic.Bool
+}
+
+func (r *Receiver) Start(ctx context.Context) error {
+	if !r.isStarted.CompareAndSwap(false, true) {
+		return ErrReceiverAlreadyStarted
+	}
+	defer r.isStarted.Store(false)
+
+	ticker := time.NewTicker(r.cfg.OutboxBroadcastRetention)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case payload := <-r.notifications:
+			r.receive(ctx, payload)
+		case <-ticker.C:
+			r.purge(ctx)
+		}
+	}
+}
+
+func (r *Receiver) receive(ctx context.Context, payload string) {
+	id, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		r.log.Errorf("invalid outbox broadcast id %q: %s", payload, err)
+		return
+	}
+
+	event, err := r.outboxRepo.GetBroadcast(ctx, id)
+	if err != nil {
+		r.log.Errorf("error on getting outbox broadcast id=%d: %s", id, err)
+		return
+	}
+
+	if event == nil {
+		return
+	}
+
+	for _, sink := range r.sinks {
+		if err := r.deliverTo(ctx, sink, *event); err != nil {
+			r.log.Errorf("error on delivering outbox broadcast id=%d sink=%s: %s", id, sink.Name(), err)
+		}
+	}
+}
+
+func (r *Receiver) deliverTo(ctx context.Context, sink Sink, event Event) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%s\n%s", rec, string(debug.Stack()))
+		}
+	}()
+
+	return sink.Deliver(ctx, event, nil)
+}
+
+// purge deletes the broadcasts all instances had time to read. Every
+// instance purges, the deletes are idempotent.
+func (r *Receiver) purge(ctx context.Context) {
+	createdBefore := time.Now().UTC().Add(-r.cfg.OutboxBroadcastRetention)
+
+	if err := r.outboxRepo.DeleteBroadcasts(ctx, createdBefore); err != nil {
+		r.log.Errorf("error on purging outbox broadcasts: %s", err)
+	}
+}
+
+func NewReceiver(
+	cfg *configs.Config,
+	log logger.Logger,
+	outboxRepo OutboxRepo,
+	notifications <-chan string,
+	sinks ...Sink,
+) *Receiver {
+	return &Receiver{
+		cfg:           cfg,
+		log:           log,
+		outboxRepo:    outboxRepo,
+		notifications: notifications,
+		sinks:         sinks,
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+)
+
+// Sink receives the events of the outbox. The writes it makes with tx
+// commit together with the event, and are rolled back if Deliver fails.
+type Sink interface {
+	// Name identifies the sink in the delivered sinks of an event, it must
+	// not change between releases.
+	Name() string
+	Deliver(ctx context.Context, event Event, tx repository.Tx) error
+}
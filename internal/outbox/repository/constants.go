@@ -0,0 +1,25 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+const (
+	outboxTableName    = "outbox"
+	broadcastTableName = "outbox_broadcasts"
+)
+
+const (
+	outboxFields    = `o.id, o.type, o.payload, o.origin, o.status, o.attempts, o.last_error, o.delivered_sinks, o.next_attempt_at, o.created_at, o.updated_at`
+	broadcastFields = `b.event_id, b.type, b.payload, b.origin, b.created_at`
+)
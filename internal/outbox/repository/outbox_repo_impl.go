@@ -0,0 +1,305 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/outbox/constants"
+	"github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+type OutboxRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *OutboxRepoImpl) scan(rows *sql.Rows) ([]domain.Event, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	events := make([]domain.Event, 0)
+
+	for rows.Next() {
+		var event domain.Event
+
+		var fields = []any{
+			&event.ID,
+			&event.Type,
+			(*[]byte)(&event.Payload),
+			&event.Origin,
+			&event.Status,
+			&event.Attempts,
+			&event.LastError,
+			pq.Array(&event.DeliveredSinks),
+			&event.NextAttemptAt,
+			&event.CreatedAt,
+			&event.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepoImpl) CreateEvents(ctx context.Context, events []domain.Event, tx repository.Tx) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders []string
+		values       []any
+	)
+
+	const colsNum = 3
+
+	for i, event := range events {
+		var indexes []any
+
+		for j := 1; j <= colsNum; j++ {
+			indexes = append(indexes, i*colsNum+j)
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d,$%d,$%d)", indexes...))
+
+		values = append(values,
+			event.Type,
+			[]byte(event.Payload),
+			event.Origin,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (type, payload, origin)
+		VALUES %s
+	`,
+		outboxTableName,
+		strings.Join(placeholders, ","),
+	)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	// The notification is only sent once the transaction commits.
+	notifyQuery := "SELECT pg_notify($1, '')"
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, notifyQuery, constants.NotificationChannel)
+	} else {
+		_, err = r.db.ExecContext(ctx, notifyQuery, constants.NotificationChannel)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err, "error on notify outbox listeners")
+	}
+
+	return nil
+}
+
+func (r *OutboxRepoImpl) GetPendingEvents(ctx context.Context, limit uint64, tx repository.Tx) ([]domain.Event, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS o
+		WHERE o.status = $1 AND o.next_attempt_at <= NOW()
+		ORDER BY o.id
+		LIMIT %d
+		FOR UPDATE SKIP LOCKED
+	`,
+		outboxFields,
+		outboxTableName,
+		limit,
+	)
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, domain.PendingEventStatus)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, domain.PendingEventStatus)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	defer rows.Close()
+
+	events, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepoImpl) UpdateEvent(ctx context.Context, event domain.Event, tx repository.Tx) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, attempts = $2, last_error = $3, delivered_sinks = $4, next_attempt_at = $5, updated_at = NOW()
+		WHERE id = $6
+	`, outboxTableName)
+
+	values := []any{
+		event.Status,
+		event.Attempts,
+		event.LastError,
+		pq.Array(event.DeliveredSinks),
+		event.NextAttemptAt,
+		event.ID,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepoImpl) DeleteEvent(ctx context.Context, id uint64, tx repository.Tx) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, outboxTableName)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, id)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepoImpl) CreateBroadcast(ctx context.Context, event domain.Event, tx repository.Tx) error {
+	// The notification carries the ID only, payloads can exceed the 8000
+	// bytes limit of pg_notify.
+	query := fmt.Sprintf(`
+		WITH broadcast AS (
+			INSERT INTO %s (event_id, type, payload, origin)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		)
+		SELECT pg_notify($5, id::TEXT) FROM broadcast
+	`, broadcastTableName)
+
+	values := []any{
+		event.ID,
+		event.Type,
+		[]byte(event.Payload),
+		event.Origin,
+		constants.BroadcastNotificationChannel,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepoImpl) GetBroadcast(ctx context.Context, id uint64) (*domain.Event, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS b
+		WHERE b.id = $1
+	`,
+		broadcastFields,
+		broadcastTableName,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	var event domain.Event
+
+	if err := rows.Scan(
+		&event.ID,
+		&event.Type,
+		(*[]byte)(&event.Payload),
+		&event.Origin,
+		&event.CreatedAt,
+	); err != nil {
+		return nil, errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return &event, nil
+}
+
+func (r *OutboxRepoImpl) DeleteBroadcasts(ctx context.Context, createdBefore time.Time) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE created_at < $1`, broadcastTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, createdBefore); err != nil {
+		return errors.NewDatabaseError(constants.OutboxDomain, err)
+	}
+
+	return nil
+}
+
+func NewOutboxRepoImpl(db *sql.DB) *OutboxRepoImpl {
+	return &OutboxRepoImpl{db: db}
+}
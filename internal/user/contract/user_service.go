@@ -17,9 +17,10 @@ package contract
 import (
 	"context"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type UserService interface {
+	GetCurrentUser(ctx context.Context) (*domain.User, error)
 	GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error)
 }
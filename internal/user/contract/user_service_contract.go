@@ -17,17 +17,57 @@ package contract
 import (
 	"context"
 
-	"chat-go/internal/common/domain"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type UserServiceContractImpl struct {
 	userService UserService
+	botService  BotService
 }
 
+// GetUsers looks users up in the user service and bots locally. The user
+// service only accepts user tokens, so a bot caller can't resolve users.
 func (c *UserServiceContractImpl) GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
-	return c.userService.GetUsers(ctx, filter)
+	bots, err := c.botService.GetBotUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if domain.UserFromContext(ctx).IsBot {
+		return bots, uint64(len(bots)), nil
+	}
+
+	userFilter := *filter
+	userFilter.IDs = lo.Reject(filter.IDs, func(id uint64, _ int) bool {
+		return domain.IsBotID(id)
+	})
+
+	if len(filter.IDs) > 0 && len(userFilter.IDs) == 0 {
+		return bots, uint64(len(bots)), nil
+	}
+
+	users, count, err := c.userService.GetUsers(ctx, &userFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(users, bots...), count + uint64(len(bots)), nil
+}
+
+// Authenticate returns the user of a token, bot tokens are checked locally.
+func (c *UserServiceContractImpl) Authenticate(ctx context.Context, token string, isBot bool) (*domain.User, error) {
+	if isBot {
+		return c.botService.Authenticate(ctx, token)
+	}
+
+	return c.userService.GetCurrentUser(domain.ContextWithToken(ctx, token))
 }
 
-func NewUserServiceContractImpl(userService UserService) *UserServiceContractImpl {
-	return &UserServiceContractImpl{userService: userService}
+func NewUserServiceContractImpl(userService UserService, botService BotService) *UserServiceContractImpl {
+	return &UserServiceContractImpl{
+		userService: userService,
+		botService:  botService,
+	}
 }
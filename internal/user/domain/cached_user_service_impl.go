@@ -0,0 +1,168 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type UserService interface {
+	GetCurrentUser(ctx context.Context) (*domain.User, error)
+	GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error)
+}
+
+// expiringUserService is implemented by user services whose tokens carry
+// their expiry, the token cache doesn't keep their users past it.
+type expiringUserService interface {
+	GetCurrentUserWithExpiry(ctx context.Context) (*domain.User, time.Time, error)
+}
+
+// CachedUserServiceImpl serves lookups by ids from the user cache and only
+// asks the user service for the missing users, all of them in one request.
+// Other lookups go to the user service and refresh the cache. Current users
+// are cached by token, so a request doesn't cost a call to the user service.
+type CachedUserServiceImpl struct {
+	userService    UserService
+	userCache      *UserCache
+	tokenCache     *TokenCache
+	eventPublisher EventPublisher
+}
+
+func (s *CachedUserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, error) {
+	token := domain.TokenFromContext(ctx)
+
+	if user, ok := s.tokenCache.GetUser(token); ok {
+		if user == nil {
+			return nil, commonerrors.NewUnauthorizedError("invalid token")
+		}
+
+		return user, nil
+	}
+
+	user, expiresAt, err := s.getCurrentUser(ctx)
+	if err != nil {
+		var unauthorizedErr *commonerrors.UnauthorizedError
+		if errors.As(err, &unauthorizedErr) {
+			s.tokenCache.AddInvalidToken(token)
+		}
+
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	s.tokenCache.AddUser(token, *user, expiresAt)
+	s.userCache.AddUsers([]domain.User{*user})
+
+	return user, nil
+}
+
+func (s *CachedUserServiceImpl) getCurrentUser(ctx context.Context) (*domain.User, time.Time, error) {
+	if userService, ok := s.userService.(expiringUserService); ok {
+		return userService.GetCurrentUserWithExpiry(ctx)
+	}
+
+	user, err := s.userService.GetCurrentUser(ctx)
+
+	return user, time.Time{}, err
+}
+
+func (s *CachedUserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
+	if !isIDsOnlyFilter(filter) {
+		users, count, err := s.userService.GetUsers(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		s.userCache.AddUsers(users)
+
+		return users, count, nil
+	}
+
+	users, missingIDs := s.userCache.GetUsers(lo.Uniq(filter.IDs))
+
+	if len(missingIDs) > 0 {
+		missingUsers, _, err := s.userService.GetUsers(ctx, &domain.UserFilter{
+			IDs: missingIDs,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		s.userCache.AddUsers(missingUsers)
+
+		users = append(users, missingUsers...)
+	}
+
+	return users, uint64(len(users)), nil
+}
+
+// InvalidateUsers drops changed users from the cache, they are fetched
+// again on the next lookup. The other instances drop them once the
+// broadcast reaches their UserCacheSink.
+func (s *CachedUserServiceImpl) InvalidateUsers(ctx context.Context, ids []uint64) error {
+	s.userCache.RemoveUsers(ids)
+
+	return s.eventPublisher.Publish(ctx, nil, domain.Event{
+		Type:    UsersChangedEventType,
+		Payload: UsersChanged{UserIDs: ids},
+	})
+}
+
+// InvalidateToken rejects a token which was signed out before it expires
+// from the cache. The other instances reject it, and every instance closes
+// its connections, once the broadcast reaches their UserCacheSink.
+func (s *CachedUserServiceImpl) InvalidateToken(ctx context.Context, token string) error {
+	s.tokenCache.AddInvalidToken(token)
+
+	return s.eventPublisher.Publish(ctx, nil, domain.Event{
+		Type:    TokenInvalidatedEventType,
+		Payload: TokenInvalidated{TokenHash: domain.HashToken(token)},
+	})
+}
+
+func isIDsOnlyFilter(filter *domain.UserFilter) bool {
+	return filter != nil &&
+		len(filter.IDs) > 0 &&
+		len(filter.Emails) == 0 &&
+		len(filter.Usernames) == 0 &&
+		filter.Search == "" &&
+		filter.Limit == nil &&
+		filter.Offset == nil &&
+		filter.Sort == nil
+}
+
+func NewCachedUserServiceImpl(
+	userService UserService,
+	userCache *UserCache,
+	tokenCache *TokenCache,
+	eventPublisher EventPublisher,
+) *CachedUserServiceImpl {
+	return &CachedUserServiceImpl{
+		userService:    userService,
+		userCache:      userCache,
+		tokenCache:     tokenCache,
+		eventPublisher: eventPublisher,
+	}
+}
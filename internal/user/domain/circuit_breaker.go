@@ -0,0 +1,89 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker fails calls fast for a cooldown after threshold failures in
+// a row. Once the cooldown is over a single trial call is let through per
+// cooldown, its outcome closes or opens the circuit again.
+type CircuitBreaker struct {
+	mtx       sync.Mutex
+	threshold uint
+	cooldown  time.Duration
+
+	state    circuitState
+	failures uint
+	openedAt time.Time
+
+	now func() time.Time
+}
+
+func (b *CircuitBreaker) Allow() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.state == circuitClosed {
+		return true
+	}
+
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+
+	b.state = circuitHalfOpen
+	b.openedAt = b.now()
+
+	return true
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.failures++
+
+	if b.state == circuitHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+func NewCircuitBreaker(threshold uint, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
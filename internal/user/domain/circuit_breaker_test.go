@@ -0,0 +1,137 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock the tests move by hand.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type breakerStep struct {
+	advance time.Duration
+	call    string
+	want    bool
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	const cooldown = time.Minute
+
+	tests := []struct {
+		name  string
+		steps []breakerStep
+	}{
+		{
+			name: "closed below the threshold",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "allow", want: true},
+			},
+		},
+		{
+			name: "success resets the failures",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "success"},
+				{call: "failure"},
+				{call: "allow", want: true},
+			},
+		},
+		{
+			name: "open at the threshold",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "failure"},
+				{call: "allow", want: false},
+				{advance: cooldown - time.Second, call: "allow", want: false},
+			},
+		},
+		{
+			name: "half-open lets a single trial through",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "failure"},
+				{advance: cooldown, call: "allow", want: true},
+				{call: "allow", want: false},
+			},
+		},
+		{
+			name: "trial success closes",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "failure"},
+				{advance: cooldown, call: "allow", want: true},
+				{call: "success"},
+				{call: "allow", want: true},
+				{call: "allow", want: true},
+			},
+		},
+		{
+			name: "trial failure opens again",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "failure"},
+				{advance: cooldown, call: "allow", want: true},
+				{call: "failure"},
+				{call: "allow", want: false},
+				{advance: cooldown, call: "allow", want: true},
+			},
+		},
+		{
+			name: "trial without outcome is retried after the cooldown",
+			steps: []breakerStep{
+				{call: "failure"},
+				{call: "failure"},
+				{advance: cooldown, call: "allow", want: true},
+				{advance: cooldown, call: "allow", want: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			breaker := NewCircuitBreaker(2, cooldown)
+			breaker.now = clock.Now
+
+			for i, step := range tt.steps {
+				clock.Advance(step.advance)
+
+				switch step.call {
+				case "allow":
+					if got := breaker.Allow(); got != step.want {
+						t.Fatalf("step %d: Allow() = %t, want %t", i, got, step.want)
+					}
+				case "success":
+					breaker.Success()
+				case "failure":
+					breaker.Failure()
+				}
+			}
+		})
+	}
+}
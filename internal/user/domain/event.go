@@ -0,0 +1,31 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// UsersChangedEventType is broadcast to all instances when the user service
+// reports changed users, so every instance drops them from its cache.
+const UsersChangedEventType = "users.changed"
+
+type UsersChanged struct {
+	UserIDs []uint64 `json:"userIds"`
+}
+
+// TokenInvalidatedEventType is broadcast to all instances when a user signs
+// out, so every instance rejects the token and closes its connections.
+const TokenInvalidatedEventType = "token.invalidated"
+
+type TokenInvalidated struct {
+	TokenHash string `json:"tokenHash"`
+}
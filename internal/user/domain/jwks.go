@@ -0,0 +1,306 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+const (
+	jwksTimeout     = 10 * time.Second
+	jwksMaxBodySize = 1 << 20
+
+	// jwksMinRefreshInterval limits reloads caused by tokens with unknown
+	// keys, so forged tokens can't flood the JWKS endpoint.
+	jwksMinRefreshInterval = time.Minute
+
+	minRSAKeyBits = 2048
+)
+
+var (
+	ErrJWKSKeyNotFound    = errors.New("key not found in JWKS")
+	ErrJWKSKeyAlgMismatch = errors.New("token algorithm doesn't match the key")
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC keys.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwksKey is a parsed key with the algorithm it is restricted to, if any.
+type jwksKey struct {
+	publicKey crypto.PublicKey
+	alg       string
+}
+
+// KeySet holds the signing keys of the identity provider. Keys are reloaded
+// periodically and when a token refers to an unknown key, the previous keys
+// are kept if reloading fails. Reloads happen outside of the lock and
+// concurrent ones share a single request.
+type KeySet struct {
+	cfg    *configs.Config
+	client *http.Client
+	group  singleflight.Group
+
+	mtx         sync.RWMutex
+	keys        map[string]jwksKey
+	refreshedAt time.Time
+}
+
+// GetKey returns the key with the given id for a token signed with alg. A
+// token without a key id can only be checked if the set has a single key.
+func (s *KeySet) GetKey(ctx context.Context, kid string, alg string) (crypto.PublicKey, error) {
+	key, ok, refreshedAt := s.findKey(kid)
+
+	// Stale keys are still used while they are reloaded.
+	if ok && time.Since(refreshedAt) > s.cfg.JWKSRefreshInterval {
+		s.startRefresh(ctx)
+	}
+
+	// Tokens with unknown keys reload the set at most once per
+	// jwksMinRefreshInterval, forged ones can't flood the JWKS endpoint.
+	if !ok && time.Since(refreshedAt) > jwksMinRefreshInterval {
+		if err := s.Refresh(ctx); err != nil {
+			return nil, err
+		}
+
+		key, ok, _ = s.findKey(kid)
+	}
+
+	if !ok {
+		return nil, ErrJWKSKeyNotFound
+	}
+
+	if key.alg != "" && key.alg != alg {
+		return nil, ErrJWKSKeyAlgMismatch
+	}
+
+	return key.publicKey, nil
+}
+
+// Refresh loads the keys, it is used to fail fast on startup. Concurrent
+// calls wait for the same request, which isn't canceled with ctx.
+func (s *KeySet) Refresh(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-s.startRefresh(ctx):
+		return result.Err
+	}
+}
+
+func (s *KeySet) startRefresh(ctx context.Context) <-chan singleflight.Result {
+	return s.group.DoChan("refresh", func() (any, error) {
+		return nil, s.refresh(context.WithoutCancel(ctx))
+	})
+}
+
+func (s *KeySet) findKey(kid string) (jwksKey, bool, time.Time) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true, s.refreshedAt
+		}
+	}
+
+	key, ok := s.keys[kid]
+
+	return key, ok, s.refreshedAt
+}
+
+func (s *KeySet) refresh(ctx context.Context) error {
+	// Failed attempts count as well, the next one waits for the interval.
+	defer func() {
+		s.mtx.Lock()
+		s.refreshedAt = time.Now()
+		s.mtx.Unlock()
+	}()
+
+	data, err := s.load(ctx)
+	if err != nil {
+		return fmt.Errorf("error on loading JWKS: %w", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("error on parsing JWKS: %w", err)
+	}
+
+	keys := make(map[string]jwksKey, len(set.Keys))
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := parseJWK(key)
+		if err != nil {
+			// Keys of unsupported types don't invalidate the others.
+			continue
+		}
+
+		keys[key.Kid] = jwksKey{publicKey: publicKey, alg: key.Alg}
+	}
+
+	if len(keys) == 0 {
+		return errors.New("JWKS has no supported signing keys")
+	}
+
+	s.mtx.Lock()
+	s.keys = keys
+	s.mtx.Unlock()
+
+	return nil
+}
+
+func (s *KeySet) load(ctx context.Context) ([]byte, error) {
+	if s.cfg.JWKSURL == "" {
+		return os.ReadFile(s.cfg.JWKSFile)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jwksTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, jwksMaxBodySize))
+}
+
+func parseJWK(key jwk) (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if publicKey.N.BitLen() < minRSAKeyBits {
+			return nil, errors.New("RSA key is too short")
+		}
+
+		return publicKey, nil
+	case "EC":
+		var (
+			curve    elliptic.Curve
+			ecdhKind ecdh.Curve
+		)
+
+		switch key.Crv {
+		case "P-256":
+			curve, ecdhKind = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhKind = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhKind = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(key.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC point")
+		}
+
+		// Parsing as an ECDH key checks that the point is on the curve.
+		if _, err := ecdhKind.NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", key.Kty)
+	}
+}
+
+func NewKeySet(cfg *configs.Config) *KeySet {
+	return &KeySet{
+		cfg:    cfg,
+		client: &http.Client{Timeout: jwksTimeout},
+	}
+}
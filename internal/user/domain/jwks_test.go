@@ -0,0 +1,278 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+func rsaJWK(kid string, alg string, key *rsa.PublicKey) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		Alg: alg,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jwk {
+	size := (key.Curve.Params().BitSize + 7) / 8
+
+	return jwk{
+		Kty: "EC",
+		Kid: kid,
+		Crv: key.Curve.Params().Name,
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+	}
+}
+
+// jwksServer serves the keys it holds and counts the requests.
+type jwksServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	keys     []jwk
+	status   int
+	delay    time.Duration
+	requests atomic.Int64
+}
+
+func newJWKSServer(keys ...jwk) *jwksServer {
+	s := &jwksServer{keys: keys, status: http.StatusOK}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.requests.Add(1)
+
+		s.mtx.Lock()
+		keys, status, delay := s.keys, s.status, s.delay
+		s.mtx.Unlock()
+
+		time.Sleep(delay)
+
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(jwks{Keys: keys})
+	}))
+
+	return s
+}
+
+func (s *jwksServer) set(status int, keys ...jwk) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.status = status
+	s.keys = keys
+}
+
+func newTestKeySet(url string) *KeySet {
+	return NewKeySet(&configs.Config{
+		JWKSURL:             url,
+		JWKSRefreshInterval: 15 * time.Minute,
+	})
+}
+
+func TestKeySetGetKey(t *testing.T) {
+	server := newJWKSServer(
+		rsaJWK("rsa", "RS256", &testRSAKey.PublicKey),
+		ecJWK("ec", &testECKey.PublicKey),
+		// Keys for encryption and too short keys are skipped.
+		func() jwk { key := rsaJWK("enc", "", &testOtherRSA.PublicKey); key.Use = "enc"; return key }(),
+		jwk{Kty: "RSA", Kid: "short", N: "AQAB", E: "AQAB"},
+	)
+	defer server.Close()
+
+	keySet := newTestKeySet(server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		kid     string
+		alg     string
+		want    any
+		wantErr error
+	}{
+		{name: "rsa", kid: "rsa", alg: "RS256", want: &testRSAKey.PublicKey},
+		{name: "ec without alg", kid: "ec", alg: "ES256", want: &testECKey.PublicKey},
+		{name: "alg mismatch", kid: "rsa", alg: "PS256", wantErr: ErrJWKSKeyAlgMismatch},
+		{name: "encryption key", kid: "enc", alg: "RS256", wantErr: ErrJWKSKeyNotFound},
+		{name: "short key", kid: "short", alg: "RS256", wantErr: ErrJWKSKeyNotFound},
+		{name: "no kid with several keys", kid: "", alg: "RS256", wantErr: ErrJWKSKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := keySet.GetKey(ctx, tt.kid, tt.alg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetKey() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			switch want := tt.want.(type) {
+			case *rsa.PublicKey:
+				if got, ok := key.(*rsa.PublicKey); !ok || !got.Equal(want) {
+					t.Errorf("GetKey() = %v, want %v", key, want)
+				}
+			case *ecdsa.PublicKey:
+				if got, ok := key.(*ecdsa.PublicKey); !ok || !got.Equal(want) {
+					t.Errorf("GetKey() = %v, want %v", key, want)
+				}
+			}
+		})
+	}
+
+	// Unknown keys right after a load don't cause another request.
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestKeySetUnknownKeyRefresh(t *testing.T) {
+	server := newJWKSServer(rsaJWK("old", "RS256", &testRSAKey.PublicKey))
+	defer server.Close()
+
+	keySet := newTestKeySet(server.URL)
+	ctx := context.Background()
+
+	if err := keySet.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	server.set(http.StatusOK, rsaJWK("new", "RS256", &testOtherRSA.PublicKey))
+
+	for range 10 {
+		if _, err := keySet.GetKey(ctx, "new", "RS256"); !errors.Is(err, ErrJWKSKeyNotFound) {
+			t.Fatalf("GetKey() error = %v, want %v", err, ErrJWKSKeyNotFound)
+		}
+	}
+
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+
+	keySet.mtx.Lock()
+	keySet.refreshedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+	keySet.mtx.Unlock()
+
+	if _, err := keySet.GetKey(ctx, "new", "RS256"); err != nil {
+		t.Errorf("GetKey() error = %v", err)
+	}
+
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestKeySetConcurrentLoad(t *testing.T) {
+	server := newJWKSServer(rsaJWK("rsa", "RS256", &testRSAKey.PublicKey))
+	server.delay = 50 * time.Millisecond
+	defer server.Close()
+
+	keySet := newTestKeySet(server.URL)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := keySet.GetKey(context.Background(), "rsa", "RS256"); err != nil {
+				t.Errorf("GetKey() error = %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := server.requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestKeySetKeepsStaleKeys(t *testing.T) {
+	server := newJWKSServer(rsaJWK("rsa", "RS256", &testRSAKey.PublicKey))
+	defer server.Close()
+
+	keySet := newTestKeySet(server.URL)
+	ctx := context.Background()
+
+	if err := keySet.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	server.set(http.StatusInternalServerError)
+
+	keySet.mtx.Lock()
+	keySet.refreshedAt = time.Now().Add(-time.Hour)
+	keySet.mtx.Unlock()
+
+	if _, err := keySet.GetKey(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("GetKey() error = %v", err)
+	}
+
+	// The stale keys are reloaded in the background.
+	deadline := time.Now().Add(time.Second)
+	for server.requests.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	if _, err := keySet.GetKey(ctx, "rsa", "RS256"); err != nil {
+		t.Errorf("GetKey() after failed reload error = %v", err)
+	}
+}
+
+func TestKeySetRefreshCanceled(t *testing.T) {
+	server := newJWKSServer(rsaJWK("rsa", "RS256", &testRSAKey.PublicKey))
+	server.delay = 100 * time.Millisecond
+	defer server.Close()
+
+	keySet := newTestKeySet(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := keySet.Refresh(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Refresh() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The shared request isn't canceled with the first caller.
+	if _, err := keySet.GetKey(context.Background(), "rsa", "RS256"); err != nil {
+		t.Errorf("GetKey() error = %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrJWTMalformed        = errors.New("malformed token")
+	ErrJWTUnsupportedAlg   = errors.New("unsupported token algorithm")
+	ErrJWTInvalidSignature = errors.New("invalid token signature")
+	ErrJWTExpired          = errors.New("token is expired")
+	ErrJWTNotValidYet      = errors.New("token is not valid yet")
+	ErrJWTInvalidIssuer    = errors.New("invalid token issuer")
+	ErrJWTInvalidAudience  = errors.New("invalid token audience")
+)
+
+// jwtAlgorithms are the supported asymmetric algorithms. "none" and HMAC
+// would let anyone knowing the public key sign tokens.
+var jwtAlgorithms = map[string]bool{
+	"RS256": true, "RS384": true, "RS512": true,
+	"PS256": true, "PS384": true, "PS512": true,
+	"ES256": true, "ES384": true, "ES512": true,
+}
+
+// ecdsaCurveBits is the curve each ES algorithm is defined for, ES512 uses
+// P-521.
+var ecdsaCurveBits = map[crypto.Hash]int{
+	crypto.SHA256: 256,
+	crypto.SHA384: 384,
+	crypto.SHA512: 521,
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims keeps numbers as json.Number, user ids don't fit a float64.
+type jwtClaims map[string]any
+
+type jwt struct {
+	header       jwtHeader
+	claims       jwtClaims
+	signingInput string
+	signature    []byte
+}
+
+func parseJWT(token string) (*jwt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrJWTMalformed
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	parsed := &jwt{
+		signingInput: parts[0] + "." + parts[1],
+		signature:    signature,
+	}
+
+	if err := json.Unmarshal(headerData, &parsed.header); err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	if !jwtAlgorithms[parsed.header.Alg] {
+		return nil, ErrJWTUnsupportedAlg
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(claimsData))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&parsed.claims); err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	return parsed, nil
+}
+
+// verifySignature checks the signature with a key matching the algorithm.
+func (t *jwt) verifySignature(key crypto.PublicKey) error {
+	if !jwtAlgorithms[t.header.Alg] {
+		return ErrJWTUnsupportedAlg
+	}
+
+	var hash crypto.Hash
+
+	switch t.header.Alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", t.header.Alg)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(t.signingInput))
+	hashed := hasher.Sum(nil)
+
+	switch alg := t.header.Alg[:2]; {
+	case alg == "RS" || alg == "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrJWTInvalidSignature
+		}
+
+		var err error
+		if alg == "RS" {
+			err = rsa.VerifyPKCS1v15(rsaKey, hash, hashed, t.signature)
+		} else {
+			err = rsa.VerifyPSS(rsaKey, hash, hashed, t.signature, &rsa.PSSOptions{
+				SaltLength: rsa.PSSSaltLengthEqualsHash,
+			})
+		}
+
+		if err != nil {
+			return ErrJWTInvalidSignature
+		}
+	case alg == "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrJWTInvalidSignature
+		}
+
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if ecKey.Curve.Params().BitSize != ecdsaCurveBits[hash] || len(t.signature) != 2*size {
+			return ErrJWTInvalidSignature
+		}
+
+		r := new(big.Int).SetBytes(t.signature[:size])
+		s := new(big.Int).SetBytes(t.signature[size:])
+
+		if !ecdsa.Verify(ecKey, hashed, r, s) {
+			return ErrJWTInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %q", t.header.Alg)
+	}
+
+	return nil
+}
+
+// verifyClaims checks the registered claims, exp is required.
+func (t *jwt) verifyClaims(now time.Time, leeway time.Duration, issuer string, audience string) error {
+	expiresAt, ok := t.claims.time("exp")
+	if !ok || now.After(expiresAt.Add(leeway)) {
+		return ErrJWTExpired
+	}
+
+	if notBefore, ok := t.claims.time("nbf"); ok && now.Add(leeway).Before(notBefore) {
+		return ErrJWTNotValidYet
+	}
+
+	if issuer != "" && t.claims.string("iss") != issuer {
+		return ErrJWTInvalidIssuer
+	}
+
+	if audience != "" && !t.claims.hasAudience(audience) {
+		return ErrJWTInvalidAudience
+	}
+
+	return nil
+}
+
+func (c jwtClaims) string(name string) string {
+	switch value := c[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	default:
+		return ""
+	}
+}
+
+func (c jwtClaims) time(name string) (time.Time, bool) {
+	value, ok := c[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	seconds, err := value.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0), true
+}
+
+// hasAudience accepts aud both as a string and as an array.
+func (c jwtClaims) hasAudience(audience string) bool {
+	switch value := c["aud"].(type) {
+	case string:
+		return value == audience
+	case []any:
+		for _, item := range value {
+			if item == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
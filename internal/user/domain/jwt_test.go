@@ -0,0 +1,262 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	testRSAKey, _   = rsa.GenerateKey(rand.Reader, 2048)
+	testECKey, _    = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testECP384, _   = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	testOtherRSA, _ = rsa.GenerateKey(rand.Reader, 2048)
+)
+
+// signTestJWT builds a token signed with key using the algorithm of header.
+func signTestJWT(t *testing.T, header map[string]any, claims map[string]any, key crypto.Signer) string {
+	t.Helper()
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claimsData, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerData) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsData)
+
+	alg, _ := header["alg"].(string)
+
+	var hash crypto.Hash
+
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	default:
+		hash = crypto.SHA512
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	hashed := hasher.Sum(nil)
+
+	var signature []byte
+
+	switch alg[:2] {
+	case "RS":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), hash, hashed)
+	case "PS":
+		signature, err = rsa.SignPSS(rand.Reader, key.(*rsa.PrivateKey), hash, hashed, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+		})
+	case "ES":
+		ecKey := key.(*ecdsa.PrivateKey)
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+
+		r, s, signErr := ecdsa.Sign(rand.Reader, ecKey, hashed)
+		err = signErr
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestParseJWT(t *testing.T) {
+	valid := signTestJWT(t, map[string]any{"alg": "RS256", "kid": "a"}, map[string]any{"sub": "1"}, testRSAKey)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: valid},
+		{name: "two parts", token: "a.b", wantErr: ErrJWTMalformed},
+		{name: "bad base64", token: "!.!.!", wantErr: ErrJWTMalformed},
+		{name: "bad header", token: "bm90LWpzb24.e30.", wantErr: ErrJWTMalformed},
+		// {"alg":"none"}
+		{name: "none alg", token: "eyJhbGciOiJub25lIn0.e30.", wantErr: ErrJWTUnsupportedAlg},
+		// {"alg":"HS256"}
+		{name: "hmac alg", token: "eyJhbGciOiJIUzI1NiJ9.e30.", wantErr: ErrJWTUnsupportedAlg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJWT(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseJWT() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTVerifySignature(t *testing.T) {
+	claims := map[string]any{"sub": "1"}
+
+	tests := []struct {
+		name    string
+		alg     string
+		signKey crypto.Signer
+		key     crypto.PublicKey
+		wantErr error
+	}{
+		{name: "RS256", alg: "RS256", signKey: testRSAKey, key: &testRSAKey.PublicKey},
+		{name: "RS512", alg: "RS512", signKey: testRSAKey, key: &testRSAKey.PublicKey},
+		{name: "PS256", alg: "PS256", signKey: testRSAKey, key: &testRSAKey.PublicKey},
+		{name: "ES256", alg: "ES256", signKey: testECKey, key: &testECKey.PublicKey},
+		{name: "ES384", alg: "ES384", signKey: testECP384, key: &testECP384.PublicKey},
+		{
+			name:    "other RSA key",
+			alg:     "RS256",
+			signKey: testRSAKey,
+			key:     &testOtherRSA.PublicKey,
+			wantErr: ErrJWTInvalidSignature,
+		},
+		{
+			name:    "RSA token with EC key",
+			alg:     "RS256",
+			signKey: testRSAKey,
+			key:     &testECKey.PublicKey,
+			wantErr: ErrJWTInvalidSignature,
+		},
+		{
+			name:    "ES384 with P-256 key",
+			alg:     "ES384",
+			signKey: testECKey,
+			key:     &testECKey.PublicKey,
+			wantErr: ErrJWTInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := parseJWT(signTestJWT(t, map[string]any{"alg": tt.alg}, claims, tt.signKey))
+			if err != nil {
+				t.Fatalf("parseJWT() error = %v", err)
+			}
+
+			if err := token.verifySignature(tt.key); !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTVerifySignatureTampered(t *testing.T) {
+	token, err := parseJWT(signTestJWT(t, map[string]any{"alg": "RS256"}, map[string]any{"sub": "1"}, testRSAKey))
+	if err != nil {
+		t.Fatalf("parseJWT() error = %v", err)
+	}
+
+	// {"sub":"2"}
+	token.signingInput = token.signingInput[:len(token.signingInput)-len("eyJzdWIiOiIxIn0")] + "eyJzdWIiOiIyIn0"
+
+	if err := token.verifySignature(&testRSAKey.PublicKey); !errors.Is(err, ErrJWTInvalidSignature) {
+		t.Errorf("verifySignature() error = %v, want %v", err, ErrJWTInvalidSignature)
+	}
+}
+
+func TestJWTVerifyClaims(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		issuer   string
+		audience string
+		wantErr  error
+	}{
+		{
+			name:   "valid",
+			claims: map[string]any{"exp": now.Unix() + 60},
+		},
+		{
+			name:    "missing exp",
+			claims:  map[string]any{},
+			wantErr: ErrJWTExpired,
+		},
+		{
+			name:    "expired",
+			claims:  map[string]any{"exp": now.Unix() - 60},
+			wantErr: ErrJWTExpired,
+		},
+		{
+			name:   "expired within leeway",
+			claims: map[string]any{"exp": now.Unix() - 10},
+		},
+		{
+			name:    "not valid yet",
+			claims:  map[string]any{"exp": now.Unix() + 120, "nbf": now.Unix() + 60},
+			wantErr: ErrJWTNotValidYet,
+		},
+		{
+			name:    "wrong issuer",
+			claims:  map[string]any{"exp": now.Unix() + 60, "iss": "other"},
+			issuer:  "issuer",
+			wantErr: ErrJWTInvalidIssuer,
+		},
+		{
+			name:     "audience string",
+			claims:   map[string]any{"exp": now.Unix() + 60, "aud": "chat"},
+			audience: "chat",
+		},
+		{
+			name:     "audience array",
+			claims:   map[string]any{"exp": now.Unix() + 60, "aud": []string{"other", "chat"}},
+			audience: "chat",
+		},
+		{
+			name:     "wrong audience",
+			claims:   map[string]any{"exp": now.Unix() + 60, "aud": []string{"other"}},
+			audience: "chat",
+			wantErr:  ErrJWTInvalidAudience,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := parseJWT(signTestJWT(t, map[string]any{"alg": "ES256"}, tt.claims, testECKey))
+			if err != nil {
+				t.Fatalf("parseJWT() error = %v", err)
+			}
+
+			err = token.verifyClaims(now, 30*time.Second, tt.issuer, tt.audience)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifyClaims() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+// JWTUserServiceImpl verifies tokens locally with the keys of the identity
+// provider and takes the current user from the claims. Other users are
+// still looked up in the user service.
+type JWTUserServiceImpl struct {
+	cfg         *configs.Config
+	keySet      *KeySet
+	userService UserService
+}
+
+func (s *JWTUserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, error) {
+	user, _, err := s.GetCurrentUserWithExpiry(ctx)
+	return user, err
+}
+
+// GetCurrentUserWithExpiry returns the exp of the token as well, so the
+// token isn't cached past it.
+func (s *JWTUserServiceImpl) GetCurrentUserWithExpiry(ctx context.Context) (*domain.User, time.Time, error) {
+	token, err := parseJWT(domain.TokenFromContext(ctx))
+	if err != nil {
+		return nil, time.Time{}, commonerrors.NewUnauthorizedError(err.Error())
+	}
+
+	key, err := s.keySet.GetKey(ctx, token.header.Kid, token.header.Alg)
+	if err != nil {
+		return nil, time.Time{}, commonerrors.NewUnauthorizedError(err.Error())
+	}
+
+	if err := token.verifySignature(key); err != nil {
+		return nil, time.Time{}, commonerrors.NewUnauthorizedError(err.Error())
+	}
+
+	if err := token.verifyClaims(time.Now(), s.cfg.JWTLeeway, s.cfg.JWTIssuer, s.cfg.JWTAudience); err != nil {
+		return nil, time.Time{}, commonerrors.NewUnauthorizedError(err.Error())
+	}
+
+	user, err := s.userFromClaims(token.claims)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// verifyClaims makes sure exp is set.
+	expiresAt, _ := token.claims.time("exp")
+
+	return user, expiresAt, nil
+}
+
+func (s *JWTUserServiceImpl) userFromClaims(claims jwtClaims) (*domain.User, error) {
+	id, err := strconv.ParseUint(claims.string(s.cfg.JWTUserIDClaim), 10, 64)
+	if err != nil || id == 0 || domain.IsBotID(id) {
+		return nil, commonerrors.NewUnauthorizedError("invalid user id claim")
+	}
+
+	return &domain.User{
+		ID:        id,
+		Email:     claims.string(s.cfg.JWTEmailClaim),
+		Username:  claims.string(s.cfg.JWTUsernameClaim),
+		FirstName: claims.string(s.cfg.JWTFirstNameClaim),
+		LastName:  claims.string(s.cfg.JWTLastNameClaim),
+		Image: domain.Image{
+			URL: claims.string(s.cfg.JWTImageClaim),
+		},
+	}, nil
+}
+
+func (s *JWTUserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
+	return s.userService.GetUsers(ctx, filter)
+}
+
+func NewJWTUserServiceImpl(cfg *configs.Config, keySet *KeySet, userService UserService) *JWTUserServiceImpl {
+	return &JWTUserServiceImpl{
+		cfg:         cfg,
+		keySet:      keySet,
+		userService: userService,
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+// LocalUser is a user stored by chat-go itself in the local auth mode.
+type LocalUser struct {
+	ID           uint64
+	Email        string
+	Username     string
+	FirstName    string
+	LastName     string
+	AboutMe      string
+	ImageURL     string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (u LocalUser) ToUser() domain.User {
+	return domain.User{
+		ID:        u.ID,
+		Email:     u.Email,
+		Username:  u.Username,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		AboutMe:   u.AboutMe,
+		Image: domain.Image{
+			URL: u.ImageURL,
+		},
+	}
+}
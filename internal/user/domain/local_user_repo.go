@@ -0,0 +1,36 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+)
+
+type LocalUserRepo interface {
+	GetUser(ctx context.Context, id uint64) (*LocalUser, error)
+	// GetUserByLogin finds a user by email or username, case-insensitively.
+	GetUserByLogin(ctx context.Context, login string) (*LocalUser, error)
+	GetUsers(ctx context.Context, filter *domain.UserFilter) ([]LocalUser, error)
+	GetUsersCount(ctx context.Context, filter *domain.UserFilter) (uint64, error)
+	// CreateUser returns nil if the email or username is taken.
+	CreateUser(ctx context.Context, user LocalUser) (*LocalUser, error)
+
+	GetSession(ctx context.Context, id uint64) (*Session, error)
+	CreateSession(ctx context.Context, session Session) (*Session, error)
+	DeleteSession(ctx context.Context, id uint64) error
+	DeleteExpiredSessions(ctx context.Context, userID uint64) error
+}
@@ -0,0 +1,192 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	usererrors "github.com/microcoretech/chat-go/internal/user/errors"
+)
+
+// dummyPasswordHash is checked for unknown logins, so they take as long as
+// wrong passwords and don't reveal which users exist.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	return lo.Must(hashPassword("dummy password"))
+})
+
+// LocalUserServiceImpl keeps users and their sessions in the database, so
+// chat-go can run without an external user service.
+type LocalUserServiceImpl struct {
+	cfg           *configs.Config
+	localUserRepo LocalUserRepo
+}
+
+func (s *LocalUserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, error) {
+	session, err := s.getSession(ctx, domain.TokenFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.localUserRepo.GetUser(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.NewUnauthorizedError("user not found")
+	}
+
+	return lo.ToPtr(user.ToUser()), nil
+}
+
+func (s *LocalUserServiceImpl) getSession(ctx context.Context, token string) (*Session, error) {
+	id, secret, ok := parseSessionToken(token)
+	if !ok {
+		return nil, errors.NewUnauthorizedError("invalid token")
+	}
+
+	session, err := s.localUserRepo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil || subtle.ConstantTimeCompare([]byte(session.TokenHash), []byte(hashSessionSecret(secret))) != 1 {
+		return nil, errors.NewUnauthorizedError("invalid token")
+	}
+
+	if time.Now().UTC().After(session.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("expired token")
+	}
+
+	return session, nil
+}
+
+func (s *LocalUserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
+	count, err := s.localUserRepo.GetUsersCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	users, err := s.localUserRepo.GetUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return lo.Map(users, func(user LocalUser, _ int) domain.User {
+		return user.ToUser()
+	}), count, nil
+}
+
+// Register creates the user and signs it in.
+func (s *LocalUserServiceImpl) Register(ctx context.Context, user LocalUser, password string) (*IssuedSession, error) {
+	if !s.cfg.LocalRegistrationEnabled {
+		return nil, errors.NewForbiddenError()
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, errors.NewUndefinedError(err, "error on hashing password")
+	}
+
+	user.PasswordHash = passwordHash
+
+	createdUser, err := s.localUserRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdUser == nil {
+		return nil, usererrors.NewUserAlreadyExistsError(map[string]any{
+			"email":    user.Email,
+			"username": user.Username,
+		})
+	}
+
+	return s.createSession(ctx, *createdUser)
+}
+
+// Login signs the user in by email or username.
+func (s *LocalUserServiceImpl) Login(ctx context.Context, login string, password string) (*IssuedSession, error) {
+	user, err := s.localUserRepo.GetUserByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		verifyPassword(password, dummyPasswordHash())
+		return nil, errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if !verifyPassword(password, user.PasswordHash) {
+		return nil, errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if err := s.localUserRepo.DeleteExpiredSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return s.createSession(ctx, *user)
+}
+
+// Logout ends the session of the current token.
+func (s *LocalUserServiceImpl) Logout(ctx context.Context) error {
+	session, err := s.getSession(ctx, domain.TokenFromContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	return s.localUserRepo.DeleteSession(ctx, session.ID)
+}
+
+func (s *LocalUserServiceImpl) createSession(ctx context.Context, user LocalUser) (*IssuedSession, error) {
+	secret, err := newSessionSecret()
+	if err != nil {
+		return nil, errors.NewUndefinedError(err, "error on generating session token")
+	}
+
+	session, err := s.localUserRepo.CreateSession(ctx, Session{
+		UserID:    user.ID,
+		TokenHash: hashSessionSecret(secret),
+		ExpiresAt: time.Now().UTC().Add(s.cfg.SessionTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssuedSession{
+		Token:     formatSessionToken(session.ID, secret),
+		ExpiresAt: session.ExpiresAt,
+		User:      user.ToUser(),
+	}, nil
+}
+
+func NewLocalUserServiceImpl(cfg *configs.Config, localUserRepo LocalUserRepo) *LocalUserServiceImpl {
+	return &LocalUserServiceImpl{
+		cfg:           cfg,
+		localUserRepo: localUserRepo,
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := hashPassword("secret password")
+	if err != nil {
+		t.Fatalf("hashPassword() error = %v", err)
+	}
+
+	wantPrefix := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$", argon2.Version, argon2Memory, argon2Time, argon2Threads)
+	if !strings.HasPrefix(hash, wantPrefix) {
+		t.Errorf("hashPassword() = %q, want prefix %q", hash, wantPrefix)
+	}
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		t.Fatalf("hashPassword() = %q, want 6 parts", hash)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) != argon2SaltLen {
+		t.Errorf("salt = %q, want %d bytes", parts[4], argon2SaltLen)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) != argon2KeyLen {
+		t.Errorf("key = %q, want %d bytes", parts[5], argon2KeyLen)
+	}
+
+	otherHash, err := hashPassword("secret password")
+	if err != nil {
+		t.Fatalf("hashPassword() error = %v", err)
+	}
+
+	if hash == otherHash {
+		t.Errorf("hashPassword() returned the same hash twice, salts must differ")
+	}
+}
+
+// testHash builds a hash with the given parameters, like one stored by an
+// older release.
+func testHash(password string, memory uint32, iterations uint32, threads uint8, keyLen uint32) string {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte(password), salt, iterations, memory, threads, keyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		memory,
+		iterations,
+		threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := hashPassword("secret password")
+	if err != nil {
+		t.Fatalf("hashPassword() error = %v", err)
+	}
+
+	salt := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef"))
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+	}{
+		{name: "correct password", password: "secret password", hash: hash, want: true},
+		{name: "wrong password", password: "secret passwore", hash: hash},
+		{name: "empty password", password: "", hash: hash},
+		{
+			name:     "other parameters",
+			password: "old password",
+			hash:     testHash("old password", 8*1024, 1, 2, 16),
+			want:     true,
+		},
+		{
+			name:     "argon2i",
+			password: "secret password",
+			hash:     strings.Replace(hash, "$argon2id$", "$argon2i$", 1),
+		},
+		{
+			name:     "other version",
+			password: "secret password",
+			hash:     strings.Replace(hash, fmt.Sprintf("$v=%d$", argon2.Version), "$v=16$", 1),
+		},
+		{name: "malformed", password: "secret password", hash: "not a hash"},
+		{name: "empty", password: "secret password", hash: ""},
+		{
+			name:     "bad parameters",
+			password: "secret password",
+			hash:     "$argon2id$v=19$m=abc$" + salt + "$" + salt,
+		},
+		{
+			name:     "bad salt",
+			password: "secret password",
+			hash:     fmt.Sprintf("$argon2id$v=19$m=%d,t=2,p=1$!!!$%s", argon2Memory, salt),
+		},
+		{
+			name:     "empty key",
+			password: "anything",
+			hash:     fmt.Sprintf("$argon2id$v=19$m=%d,t=2,p=1$%s$", argon2Memory, salt),
+		},
+		{
+			name:     "zero threads",
+			password: "secret password",
+			hash:     fmt.Sprintf("$argon2id$v=19$m=%d,t=2,p=0$%s$%s", argon2Memory, salt, salt),
+		},
+		{
+			name:     "zero iterations",
+			password: "secret password",
+			hash:     fmt.Sprintf("$argon2id$v=19$m=%d,t=0,p=1$%s$%s", argon2Memory, salt, salt),
+		},
+		{
+			name:     "too much memory",
+			password: "secret password",
+			hash:     fmt.Sprintf("$argon2id$v=19$m=%d,t=2,p=1$%s$%s", uint32(1<<32-1), salt, salt),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyPassword(tt.password, tt.hash); got != tt.want {
+				t.Errorf("verifyPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+var tokenCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_token_cache_lookups_total",
+	Help: "Lookups of current users by token in the token cache, by result (hit or miss).",
+}, []string{"result"})
+
+// TokenCache keeps the users of recently checked tokens. Rejected tokens
+// are kept as well, with a shorter TTL. Tokens are only held as hashes.
+type TokenCache struct {
+	users         *expirable.LRU[string, tokenCacheEntry]
+	invalidTokens *expirable.LRU[string, struct{}]
+}
+
+// tokenCacheEntry is dropped at expiresAt if it's before the cache TTL.
+type tokenCacheEntry struct {
+	user      domain.User
+	expiresAt time.Time
+}
+
+// GetUser returns the user of the token, nil for a rejected token, and
+// whether the token was found at all.
+func (c *TokenCache) GetUser(token string) (*domain.User, bool) {
+	key := domain.HashToken(token)
+
+	if entry, ok := c.users.Get(key); ok {
+		if entry.expiresAt.IsZero() || time.Now().Before(entry.expiresAt) {
+			tokenCacheLookups.WithLabelValues("hit").Inc()
+			return &entry.user, true
+		}
+
+		c.users.Remove(key)
+	}
+
+	if c.invalidTokens.Contains(key) {
+		tokenCacheLookups.WithLabelValues("hit").Inc()
+		return nil, true
+	}
+
+	tokenCacheLookups.WithLabelValues("miss").Inc()
+
+	return nil, false
+}
+
+// AddUser caches the user of the token until the cache TTL or expiresAt,
+// whichever is earlier. A zero expiresAt only applies the TTL.
+func (c *TokenCache) AddUser(token string, user domain.User, expiresAt time.Time) {
+	key := domain.HashToken(token)
+
+	c.invalidTokens.Remove(key)
+	c.users.Add(key, tokenCacheEntry{user: user, expiresAt: expiresAt})
+}
+
+func (c *TokenCache) AddInvalidToken(token string) {
+	c.AddInvalidTokenHash(domain.HashToken(token))
+}
+
+// AddInvalidTokenHash rejects a token known by its hash only, like the
+// tokens invalidated on other instances.
+func (c *TokenCache) AddInvalidTokenHash(tokenHash string) {
+	c.users.Remove(tokenHash)
+	c.invalidTokens.Add(tokenHash, struct{}{})
+}
+
+func NewTokenCache(cfg *configs.Config) *TokenCache {
+	return &TokenCache{
+		users:         expirable.NewLRU[string, tokenCacheEntry](cfg.TokenCacheSize, nil, cfg.TokenCacheTTL),
+		invalidTokens: expirable.NewLRU[string, struct{}](cfg.TokenCacheSize, nil, cfg.TokenCacheNegativeTTL),
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+func TestTokenCacheExpiresAt(t *testing.T) {
+	cache := NewTokenCache(&configs.Config{
+		TokenCacheSize:        10,
+		TokenCacheTTL:         time.Minute,
+		TokenCacheNegativeTTL: time.Minute,
+	})
+
+	user := domain.User{ID: 1}
+
+	cache.AddUser("expired", user, time.Now().Add(-time.Second))
+	cache.AddUser("valid", user, time.Now().Add(time.Hour))
+	cache.AddUser("no-exp", user, time.Time{})
+
+	if _, ok := cache.GetUser("expired"); ok {
+		t.Errorf("GetUser(expired) found a token past its exp")
+	}
+
+	for _, token := range []string{"valid", "no-exp"} {
+		if got, ok := cache.GetUser(token); !ok || got == nil || got.ID != user.ID {
+			t.Errorf("GetUser(%s) = %v, %v, want user %d", token, got, ok, user.ID)
+		}
+	}
+
+	cache.AddInvalidToken("valid")
+
+	if got, ok := cache.GetUser("valid"); !ok || got != nil {
+		t.Errorf("GetUser(valid) after invalidation = %v, %v, want nil, true", got, ok)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+var userCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_user_cache_lookups_total",
+	Help: "Lookups of users by id in the user cache, by result (hit or miss).",
+}, []string{"result"})
+
+// UserCache keeps users of the user service by id. Entries expire after the
+// configured TTL and the least recently used ones are evicted first.
+type UserCache struct {
+	users *expirable.LRU[uint64, domain.User]
+}
+
+// GetUsers returns the cached users and the ids which were not found.
+func (c *UserCache) GetUsers(ids []uint64) ([]domain.User, []uint64) {
+	var (
+		users      []domain.User
+		missingIDs []uint64
+	)
+
+	for _, id := range ids {
+		if user, ok := c.users.Get(id); ok {
+			users = append(users, user)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	userCacheLookups.WithLabelValues("hit").Add(float64(len(users)))
+	userCacheLookups.WithLabelValues("miss").Add(float64(len(missingIDs)))
+
+	return users, missingIDs
+}
+
+func (c *UserCache) AddUsers(users []domain.User) {
+	for _, user := range users {
+		c.users.Add(user.ID, user)
+	}
+}
+
+func (c *UserCache) RemoveUsers(ids []uint64) {
+	for _, id := range ids {
+		c.users.Remove(id)
+	}
+}
+
+func NewUserCache(cfg *configs.Config) *UserCache {
+	return &UserCache{
+		users: expirable.NewLRU[uint64, domain.User](cfg.UserCacheSize, nil, cfg.UserCacheTTL),
+	}
+}
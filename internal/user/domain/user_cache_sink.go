@@ -0,0 +1,91 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+const UserCacheSinkName = "user_cache"
+
+// TokenConnections closes the live connections opened with a token on this
+// instance.
+type TokenConnections interface {
+	CloseTokenHashConnections(tokenHash string)
+}
+
+// UserCacheSink drops changed users from the cache of this instance and
+// rejects invalidated tokens. It is given to the outbox Receiver, so the
+// users changed and the tokens signed out on one instance are dropped on
+// all of them.
+type UserCacheSink struct {
+	userCache        *UserCache
+	tokenCache       *TokenCache
+	tokenConnections TokenConnections
+}
+
+func (s *UserCacheSink) Name() string {
+	return UserCacheSinkName
+}
+
+func (s *UserCacheSink) Deliver(_ context.Context, event outboxdomain.Event, _ repository.Tx) error {
+	switch event.Type {
+	case UsersChangedEventType:
+		return s.deliverUsersChanged(event)
+	case TokenInvalidatedEventType:
+		return s.deliverTokenInvalidated(event)
+	}
+
+	return nil
+}
+
+func (s *UserCacheSink) deliverUsersChanged(event outboxdomain.Event) error {
+	var usersChanged UsersChanged
+	if err := json.Unmarshal(event.Payload, &usersChanged); err != nil {
+		return err
+	}
+
+	s.userCache.RemoveUsers(usersChanged.UserIDs)
+
+	return nil
+}
+
+func (s *UserCacheSink) deliverTokenInvalidated(event outboxdomain.Event) error {
+	var tokenInvalidated TokenInvalidated
+	if err := json.Unmarshal(event.Payload, &tokenInvalidated); err != nil {
+		return err
+	}
+
+	s.tokenCache.AddInvalidTokenHash(tokenInvalidated.TokenHash)
+	s.tokenConnections.CloseTokenHashConnections(tokenInvalidated.TokenHash)
+
+	return nil
+}
+
+func NewUserCacheSink(
+	userCache *UserCache,
+	tokenCache *TokenCache,
+	tokenConnections TokenConnections,
+) *UserCacheSink {
+	return &UserCacheSink{
+		userCache:        userCache,
+		tokenCache:       tokenCache,
+		tokenConnections: tokenConnections,
+	}
+}
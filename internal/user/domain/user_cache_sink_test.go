@@ -0,0 +1,72 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+type stubTokenConnections struct {
+	closed []string
+}
+
+func (c *stubTokenConnections) CloseTokenHashConnections(tokenHash string) {
+	c.closed = append(c.closed, tokenHash)
+}
+
+func TestUserCacheSinkTokenInvalidated(t *testing.T) {
+	cfg := &configs.Config{
+		UserCacheSize:         10,
+		UserCacheTTL:          time.Minute,
+		TokenCacheSize:        10,
+		TokenCacheTTL:         time.Minute,
+		TokenCacheNegativeTTL: time.Minute,
+	}
+	tokenCache := NewTokenCache(cfg)
+	tokenConnections := &stubTokenConnections{}
+	sink := NewUserCacheSink(NewUserCache(cfg), tokenCache, tokenConnections)
+
+	// The token was signed out on another instance after this one cached it.
+	tokenCache.AddUser("signed-out", domain.User{ID: 1}, time.Time{})
+	tokenCache.AddUser("other", domain.User{ID: 1}, time.Time{})
+
+	payload, err := json.Marshal(TokenInvalidated{TokenHash: domain.HashToken("signed-out")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := outboxdomain.Event{Type: TokenInvalidatedEventType, Payload: payload}
+	if err := sink.Deliver(context.Background(), event, nil); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	if got, ok := tokenCache.GetUser("signed-out"); !ok || got != nil {
+		t.Errorf("GetUser(signed-out) = %v, %v, want nil, true", got, ok)
+	}
+	if got, ok := tokenCache.GetUser("other"); !ok || got == nil {
+		t.Errorf("GetUser(other) = %v, %v, want the user", got, ok)
+	}
+
+	if len(tokenConnections.closed) != 1 || tokenConnections.closed[0] != domain.HashToken("signed-out") {
+		t.Errorf("closed connections of %v, want the signed out token only", tokenConnections.closed)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/user/constants"
+)
+
+const userServiceMaxBodySize = 10 << 20
+
+var errUserServiceCircuitOpen = errors.New("user service circuit is open")
+
+// UserServiceClient sends GET requests to the user service. Network errors
+// and 5xx responses are retried with jittered exponential backoff, and while
+// the user service keeps failing the circuit breaker rejects calls right
+// away with a ServiceUnavailableError.
+type UserServiceClient struct {
+	cfg     *configs.Config
+	client  *http.Client
+	breaker *CircuitBreaker
+
+	after func(d time.Duration) <-chan time.Time
+}
+
+func (c *UserServiceClient) Get(ctx context.Context, url string, token string) (int, []byte, error) {
+	if !c.breaker.Allow() {
+		return 0, nil, commonerrors.NewServiceUnavailableError(constants.UserDomain, errUserServiceCircuitOpen)
+	}
+
+	var lastErr error
+
+	for attempt := uint(0); attempt <= c.cfg.UserServiceMaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, nil, ctx.Err()
+			case <-c.after(c.retryDelay(attempt)):
+			}
+		}
+
+		statusCode, body, err := c.get(ctx, url, token)
+		if err == nil && statusCode < http.StatusInternalServerError {
+			c.breaker.Success()
+			return statusCode, body, nil
+		}
+
+		if ctx.Err() != nil {
+			return 0, nil, ctx.Err()
+		}
+
+		if err == nil {
+			err = fmt.Errorf("user service responded with status %d", statusCode)
+		}
+
+		lastErr = err
+	}
+
+	c.breaker.Failure()
+
+	return 0, nil, commonerrors.NewServiceUnavailableError(constants.UserDomain, lastErr)
+}
+
+func (c *UserServiceClient) get(ctx context.Context, url string, token string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, userServiceMaxBodySize))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+// retryDelay picks a random delay up to the exponential backoff of the
+// attempt, so instances don't retry in lockstep.
+func (c *UserServiceClient) retryDelay(attempt uint) time.Duration {
+	backoff := c.cfg.UserServiceRetryBackoff << (attempt - 1)
+	if backoff <= 0 {
+		return 0
+	}
+
+	return rand.N(backoff) + 1
+}
+
+func NewUserServiceClient(cfg *configs.Config) *UserServiceClient {
+	return &UserServiceClient{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.UserServiceTimeout},
+		breaker: NewCircuitBreaker(cfg.UserServiceBreakerThreshold, cfg.UserServiceBreakerCooldown),
+		after:   time.After,
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+)
+
+// fakeTransport answers the requests with responses in order, a zero
+// status code stands for a network error.
+type fakeTransport struct {
+	statusCodes []int
+	calls       int
+}
+
+func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	statusCode := http.StatusOK
+	if t.calls < len(t.statusCodes) {
+		statusCode = t.statusCodes[t.calls]
+	}
+	t.calls++
+
+	if statusCode == 0 {
+		return nil, errors.New("connection refused")
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}, nil
+}
+
+func newTestUserServiceClient(cfg *configs.Config, transport http.RoundTripper, clock *fakeClock) (*UserServiceClient, *[]time.Duration) {
+	client := NewUserServiceClient(cfg)
+	client.client.Transport = transport
+	client.breaker.now = clock.Now
+
+	var delays []time.Duration
+	client.after = func(d time.Duration) <-chan time.Time {
+		delays = append(delays, d)
+		clock.Advance(d)
+
+		ch := make(chan time.Time, 1)
+		ch <- clock.Now()
+
+		return ch
+	}
+
+	return client, &delays
+}
+
+func TestUserServiceClientGet(t *testing.T) {
+	const backoff = 100 * time.Millisecond
+
+	tests := []struct {
+		name           string
+		statusCodes    []int
+		wantStatusCode int
+		wantCalls      int
+		unavailable    bool
+	}{
+		{name: "success", statusCodes: []int{http.StatusOK}, wantStatusCode: http.StatusOK, wantCalls: 1},
+		{name: "client error isn't retried", statusCodes: []int{http.StatusNotFound}, wantStatusCode: http.StatusNotFound, wantCalls: 1},
+		{
+			name:           "server error is retried",
+			statusCodes:    []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			wantStatusCode: http.StatusOK,
+			wantCalls:      3,
+		},
+		{name: "network error is retried", statusCodes: []int{0, http.StatusOK}, wantStatusCode: http.StatusOK, wantCalls: 2},
+		{
+			name:        "retries exhausted",
+			statusCodes: []int{0, http.StatusBadGateway, http.StatusInternalServerError},
+			wantCalls:   3,
+			unavailable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeTransport{statusCodes: tt.statusCodes}
+			client, delays := newTestUserServiceClient(&configs.Config{
+				UserServiceMaxRetries:       2,
+				UserServiceRetryBackoff:     backoff,
+				UserServiceBreakerThreshold: 5,
+				UserServiceBreakerCooldown:  time.Minute,
+			}, transport, &fakeClock{now: time.Unix(0, 0)})
+
+			statusCode, _, err := client.Get(context.Background(), "http://users/me", "token")
+			if commonerrors.IsServiceUnavailable(err) != tt.unavailable || (err != nil && !tt.unavailable) {
+				t.Fatalf("Get() error = %v, want unavailable %t", err, tt.unavailable)
+			}
+
+			if statusCode != tt.wantStatusCode || transport.calls != tt.wantCalls {
+				t.Errorf("Get() = %d after %d calls, want %d after %d", statusCode, transport.calls, tt.wantStatusCode, tt.wantCalls)
+			}
+
+			if len(*delays) != tt.wantCalls-1 {
+				t.Fatalf("waited %d times, want %d", len(*delays), tt.wantCalls-1)
+			}
+
+			// Retry number n waits a jittered delay up to the backoff
+			// doubled n-1 times.
+			for i, delay := range *delays {
+				if maxDelay := backoff << i; delay <= 0 || delay > maxDelay {
+					t.Errorf("retry %d waited %s, want up to %s", i+1, delay, maxDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestUserServiceClientRetryDelayJitter(t *testing.T) {
+	client := NewUserServiceClient(&configs.Config{UserServiceRetryBackoff: time.Second})
+
+	delays := make(map[time.Duration]struct{})
+
+	for i := 0; i < 100; i++ {
+		delay := client.retryDelay(1)
+		if delay <= 0 || delay > time.Second {
+			t.Fatalf("retryDelay(1) = %s, want up to %s", delay, time.Second)
+		}
+
+		delays[delay] = struct{}{}
+	}
+
+	if len(delays) < 2 {
+		t.Errorf("retryDelay(1) isn't jittered, got %d distinct delays", len(delays))
+	}
+
+	if delay := NewUserServiceClient(&configs.Config{}).retryDelay(1); delay != 0 {
+		t.Errorf("retryDelay(1) without backoff = %s, want 0", delay)
+	}
+}
+
+func TestUserServiceClientCircuitBreaker(t *testing.T) {
+	const cooldown = time.Minute
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	transport := &fakeTransport{statusCodes: []int{
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+	}}
+	client, _ := newTestUserServiceClient(&configs.Config{
+		UserServiceBreakerThreshold: 2,
+		UserServiceBreakerCooldown:  cooldown,
+	}, transport, clock)
+
+	get := func() error {
+		_, _, err := client.Get(context.Background(), "http://users/me", "token")
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := get(); !commonerrors.IsServiceUnavailable(err) {
+			t.Fatalf("call %d: Get() error = %v, want unavailable", i+1, err)
+		}
+	}
+
+	// The open circuit fails fast, the user service isn't called.
+	if err := get(); !commonerrors.IsServiceUnavailable(err) || transport.calls != 2 {
+		t.Fatalf("Get() on open circuit error = %v after %d calls, want unavailable after 2", err, transport.calls)
+	}
+
+	// After the cooldown a failed trial opens the circuit again.
+	clock.Advance(cooldown)
+	if err := get(); !commonerrors.IsServiceUnavailable(err) || transport.calls != 3 {
+		t.Fatalf("trial Get() error = %v after %d calls, want unavailable after 3", err, transport.calls)
+	}
+	if err := get(); !commonerrors.IsServiceUnavailable(err) || transport.calls != 3 {
+		t.Fatalf("Get() after failed trial error = %v after %d calls, want unavailable after 3", err, transport.calls)
+	}
+
+	// A successful trial closes it.
+	clock.Advance(cooldown)
+	for i := 0; i < 2; i++ {
+		if statusCode, _, err := client.Get(context.Background(), "http://users/me", "token"); err != nil || statusCode != http.StatusOK {
+			t.Fatalf("Get() after recovery = %d, %v", statusCode, err)
+		}
+	}
+}
@@ -19,40 +19,32 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/samber/lo"
 
-	"chat-go/internal/common/domain"
-	commonerrors "chat-go/internal/common/errors"
-	commonhttp "chat-go/internal/common/http"
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/user/constants"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/user/constants"
 )
 
 type UserServiceImpl struct {
 	config *configs.Config
+	client *UserServiceClient
 }
 
 func (s *UserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, error) {
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", s.config.GetCurrentUserEndpoint, nil)
+	statusCode, body, err := s.client.Get(ctx, s.config.GetCurrentUserEndpoint, domain.TokenFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", domain.TokenFromContext(ctx)))
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
+	switch statusCode {
+	case http.StatusOK:
 	case http.StatusUnauthorized:
 		return nil, commonerrors.NewUnauthorizedError()
 	case http.StatusNotFound:
@@ -60,11 +52,8 @@ func (s *UserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, err
 			errors.New("invalid get current user endpoint"),
 			"endpoint", s.config.GetCurrentUserEndpoint,
 		)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	default:
+		return nil, unexpectedStatusError(statusCode, body)
 	}
 
 	userDto := commonhttp.UserDto{}
@@ -81,16 +70,12 @@ func (s *UserServiceImpl) GetCurrentUser(ctx context.Context) (*domain.User, err
 }
 
 func (s *UserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error) {
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", s.config.GetUsersEndpoint, nil)
+	endpoint, err := url.Parse(s.config.GetUsersEndpoint)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", domain.TokenFromContext(ctx)))
-
-	q := req.URL.Query()
+	q := endpoint.Query()
 
 	for _, id := range filter.IDs {
 		q.Add("ids", strconv.FormatUint(id, 10))
@@ -109,37 +94,34 @@ func (s *UserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilte
 	}
 
 	if filter.Limit != nil {
-		q.Add("limit", fmt.Sprint(filter.Limit))
+		q.Add("limit", strconv.FormatUint(*filter.Limit, 10))
 	}
 
 	if filter.Offset != nil {
-		q.Add("offset", fmt.Sprint(filter.Offset))
+		q.Add("offset", strconv.FormatUint(*filter.Offset, 10))
 	}
 
 	if filter.Sort != nil {
 		q.Add("sort", fmt.Sprintf("%s,%s", filter.Sort.SortBy, filter.Sort.SortDir.String()))
 	}
 
-	req.URL.RawQuery = q.Encode()
+	endpoint.RawQuery = q.Encode()
 
-	resp, err := client.Do(req)
+	statusCode, body, err := s.client.Get(ctx, endpoint.String(), domain.TokenFromContext(ctx))
 	if err != nil {
 		return nil, 0, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	switch resp.StatusCode {
+	switch statusCode {
+	case http.StatusOK:
 	case http.StatusBadRequest:
 		return nil, 0, commonerrors.NewBadRequestError(constants.UserDomain, nil, map[string]any{
 			"body": string(body),
 		})
 	case http.StatusUnauthorized:
 		return nil, 0, commonerrors.NewUnauthorizedError()
+	default:
+		return nil, 0, unexpectedStatusError(statusCode, body)
 	}
 
 	pageDto := &commonhttp.Page[commonhttp.UserDto]{}
@@ -153,8 +135,16 @@ func (s *UserServiceImpl) GetUsers(ctx context.Context, filter *domain.UserFilte
 	}), pageDto.Count, nil
 }
 
+func unexpectedStatusError(statusCode int, body []byte) error {
+	return commonerrors.NewUndefinedError(
+		fmt.Errorf("user service responded with status %d", statusCode),
+		"body", string(body),
+	)
+}
+
 func NewUserServiceImpl(config *configs.Config) *UserServiceImpl {
 	return &UserServiceImpl{
 		config: config,
+		client: NewUserServiceClient(config),
 	}
 }
@@ -15,8 +15,8 @@
 package errors
 
 import (
-	"chat-go/internal/common/errors"
-	"chat-go/internal/user/constants"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/user/constants"
 )
 
 const UserNotFoundErrorType = "UserNotFoundError"
@@ -0,0 +1,104 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/user/constants"
+)
+
+// AuthController signs users in and out in the local auth mode.
+type AuthController struct {
+	validate         validator.Validate
+	authMiddleware   api.Middleware
+	authService      AuthService
+	userCacheService UserCacheService
+}
+
+func (c *AuthController) register(ctx *fiber.Ctx) error {
+	dto := RegisterDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.UserDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.UserDomain, dto); err != nil {
+		return err
+	}
+
+	session, err := c.authService.Register(ctx.Context(), LocalUserFromRegisterDto(dto), dto.Password)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(SessionToDto(*session))
+}
+
+func (c *AuthController) login(ctx *fiber.Ctx) error {
+	dto := LoginDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.UserDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.UserDomain, dto); err != nil {
+		return err
+	}
+
+	session, err := c.authService.Login(ctx.Context(), dto.Login, dto.Password)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(SessionToDto(*session))
+}
+
+func (c *AuthController) logout(ctx *fiber.Ctx) error {
+	if err := c.authService.Logout(ctx.Context()); err != nil {
+		return err
+	}
+
+	if err := c.userCacheService.InvalidateToken(ctx.Context(), domain.TokenFromContext(ctx.Context())); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func (c *AuthController) SetupRoutes(r fiber.Router) {
+	authGroup := r.Group("/auth")
+	authGroup.Post("/register", c.register)
+	authGroup.Post("/login", c.login)
+	authGroup.Post("/logout", c.authMiddleware.Handler, c.logout)
+}
+
+func NewAuthController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	authService AuthService,
+	userCacheService UserCacheService,
+) *AuthController {
+	return &AuthController{
+		validate:         validate,
+		authMiddleware:   authMiddleware,
+		authService:      authService,
+		userCacheService: userCacheService,
+	}
+}
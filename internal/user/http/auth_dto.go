@@ -0,0 +1,41 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/http"
+)
+
+type RegisterDto struct {
+	Email     string `json:"email" validate:"required,email,lte=255"`
+	Username  string `json:"username" validate:"required,gte=3,lte=32,username"`
+	FirstName string `json:"firstName" validate:"omitempty,name,lte=255"`
+	LastName  string `json:"lastName" validate:"omitempty,name,lte=255"`
+	Password  string `json:"password" validate:"required,password"`
+}
+
+type LoginDto struct {
+	// Login is the email or the username.
+	Login    string `json:"login" validate:"required,lte=255"`
+	Password string `json:"password" validate:"required,lte=255"`
+}
+
+type SessionDto struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+	User      http.UserDto `json:"user"`
+}
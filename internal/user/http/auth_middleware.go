@@ -19,15 +19,41 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
-	"chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/errors"
 )
 
 const (
 	authTokenQueryParam = "token"
 	headerAuthorization = "Authorization"
 	bearerTokenType     = "Bearer"
+	botTokenType        = "Bot"
 )
 
+// ParseAuthorization splits an Authorization value, "Bearer <token>" or
+// "Bot <token>", into the token and whether it's a bot token.
+func ParseAuthorization(authorization string) (string, bool, error) {
+	tokenType, token, ok := strings.Cut(authorization, " ")
+	if !ok || token == "" {
+		return "", false, errors.NewUnauthorizedError("invalid token")
+	}
+
+	isBot := strings.EqualFold(tokenType, botTokenType)
+	if !isBot && !strings.EqualFold(tokenType, bearerTokenType) {
+		return "", false, errors.NewUnauthorizedError("invalid token type")
+	}
+
+	return token, isBot, nil
+}
+
+// FormatAuthorization returns the Authorization value of the token.
+func FormatAuthorization(token string, isBot bool) string {
+	if isBot {
+		return botTokenType + " " + token
+	}
+
+	return bearerTokenType + " " + token
+}
+
 type AuthMiddleware struct {
 	userService UserService
 }
@@ -80,19 +106,16 @@ func (m *AuthMiddleware) getTokenFromHeader(ctx *fiber.Ctx) (string, error) {
 		return "", errors.NewUnauthorizedError("invalid token")
 	}
 
-	tokenParts := strings.Split(authHeader, " ")
-
-	if len(tokenParts) < 2 {
-		return "", errors.NewUnauthorizedError("invalid token")
+	// bot tokens are handled by the BotAuthMiddleware ahead of this one.
+	authToken, isBot, err := ParseAuthorization(authHeader)
+	if err != nil {
+		return "", err
 	}
 
-	tokenPrefix := strings.ToLower(tokenParts[0])
-	if !strings.EqualFold(tokenPrefix, bearerTokenType) {
+	if isBot {
 		return "", errors.NewUnauthorizedError("invalid token type")
 	}
 
-	authToken := tokenParts[1]
-
 	return authToken, nil
 }
 
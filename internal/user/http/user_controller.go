@@ -20,13 +20,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/samber/lo"
 
-	"chat-go/internal/common/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/http"
-	"chat-go/internal/infrastructure/api"
-	"chat-go/internal/infrastructure/validator"
-	"chat-go/internal/user/constants"
-	usererrors "chat-go/internal/user/errors"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/user/constants"
+	usererrors "github.com/microcoretech/chat-go/internal/user/errors"
 )
 
 type UserController struct {
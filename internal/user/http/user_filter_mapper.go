@@ -15,10 +15,10 @@
 package http
 
 import (
-	"chat-go/internal/common/domain"
-	"chat-go/internal/common/errors"
-	"chat-go/internal/common/http"
-	"chat-go/internal/user/constants"
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/user/constants"
 )
 
 var userSortFields = []string{
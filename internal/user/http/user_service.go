@@ -17,10 +17,15 @@ package http
 import (
 	"context"
 
-	"chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/domain"
 )
 
 type UserService interface {
 	GetCurrentUser(ctx context.Context) (*domain.User, error)
 	GetUsers(ctx context.Context, filter *domain.UserFilter) ([]domain.User, uint64, error)
 }
+
+type UserCacheService interface {
+	InvalidateUsers(ctx context.Context, ids []uint64) error
+	InvalidateToken(ctx context.Context, token string) error
+}
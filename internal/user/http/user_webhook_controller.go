@@ -0,0 +1,103 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/hmac"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/user/constants"
+	webhookdomain "github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+// userWebhookTolerance is how far the timestamp of a webhook may be off,
+// older webhooks are treated as replays.
+const userWebhookTolerance = 5 * time.Minute
+
+// UserWebhookController receives webhooks of the user service. They are
+// signed the same way as the webhooks this service sends.
+type UserWebhookController struct {
+	cfg              *configs.Config
+	validate         validator.Validate
+	userCacheService UserCacheService
+}
+
+func (c *UserWebhookController) usersChanged(ctx *fiber.Ctx) error {
+	if err := c.verifySignature(ctx); err != nil {
+		return err
+	}
+
+	var dto UsersChangedDto
+
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.UserDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.UserDomain, &dto); err != nil {
+		return err
+	}
+
+	if err := c.userCacheService.InvalidateUsers(ctx.Context(), dto.UserIDs); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func (c *UserWebhookController) verifySignature(ctx *fiber.Ctx) error {
+	if c.cfg.UserWebhookSecret == "" {
+		return errors.NewUnauthorizedError("user webhooks are disabled")
+	}
+
+	timestamp, err := strconv.ParseInt(ctx.Get(webhookdomain.TimestampHeader), 10, 64)
+	if err != nil {
+		return errors.NewUnauthorizedError("invalid webhook timestamp")
+	}
+
+	if time.Since(time.Unix(timestamp, 0)).Abs() > userWebhookTolerance {
+		return errors.NewUnauthorizedError("expired webhook timestamp")
+	}
+
+	signature := webhookdomain.Sign(c.cfg.UserWebhookSecret, timestamp, ctx.Body())
+
+	if !hmac.Equal([]byte(signature), []byte(ctx.Get(webhookdomain.SignatureHeader))) {
+		return errors.NewUnauthorizedError("invalid webhook signature")
+	}
+
+	return nil
+}
+
+func (c *UserWebhookController) SetupRoutes(r fiber.Router) {
+	r.Post("/user-webhooks/users-changed", c.usersChanged)
+}
+
+func NewUserWebhookController(
+	cfg *configs.Config,
+	validate validator.Validate,
+	userCacheService UserCacheService,
+) *UserWebhookController {
+	return &UserWebhookController{
+		cfg:              cfg,
+		validate:         validate,
+		userCacheService: userCacheService,
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+const (
+	userTableName    = "users"
+	sessionTableName = "sessions"
+)
+
+const (
+	userFields = `u.id, u.email, u.username, u.first_name, u.last_name, u.about_me, u.image_url, u.password_hash,
+		u.created_at, u.updated_at`
+	sessionFields = `s.id, s.user_id, s.token_hash, s.expires_at, s.created_at`
+)
+
+// userFieldsMapping maps sort fields of the users endpoint to columns.
+var userFieldsMapping = map[string]string{
+	"id":        "u.id",
+	"email":     "u.email",
+	"username":  "u.username",
+	"firstName": "u.first_name",
+	"lastName":  "u.last_name",
+}
@@ -0,0 +1,345 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	commondomain "github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/user/constants"
+	"github.com/microcoretech/chat-go/internal/user/domain"
+)
+
+type LocalUserRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *LocalUserRepoImpl) scan(rows *sql.Rows) ([]domain.LocalUser, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	users := make([]domain.LocalUser, 0)
+
+	for rows.Next() {
+		var user domain.LocalUser
+
+		var fields = []any{
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&user.AboutMe,
+			&user.ImageURL,
+			&user.PasswordHash,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *LocalUserRepoImpl) scanSessions(rows *sql.Rows) ([]domain.Session, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	sessions := make([]domain.Session, 0)
+
+	for rows.Next() {
+		var session domain.Session
+
+		var fields = []any{
+			&session.ID,
+			&session.UserID,
+			&session.TokenHash,
+			&session.ExpiresAt,
+			&session.CreatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (r *LocalUserRepoImpl) buildFilter(filter commondomain.UserFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.IDs) > 0 {
+		var params []string
+		for _, id := range filter.IDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"u.id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Emails) > 0 {
+		var params []string
+		for _, email := range filter.Emails {
+			values = append(values, strings.ToLower(email))
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"LOWER(u.email) IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Usernames) > 0 {
+		var params []string
+		for _, username := range filter.Usernames {
+			values = append(values, strings.ToLower(username))
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"LOWER(u.username) IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Search) > 0 {
+		values = append(values, fmt.Sprintf("%%%s%%", filter.Search))
+		where = append(where, fmt.Sprintf(
+			"CONCAT_WS(' ', u.username, u.email, u.first_name, u.last_name) ILIKE $%d ", len(values)))
+	}
+
+	return values, where
+}
+
+func (r *LocalUserRepoImpl) getUser(ctx context.Context, condition string, value any) (*domain.LocalUser, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS u WHERE %s LIMIT 1`, userFields, userTableName, condition)
+
+	rows, err := r.db.QueryContext(ctx, query, value)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	defer rows.Close()
+
+	users, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+func (r *LocalUserRepoImpl) GetUser(ctx context.Context, id uint64) (*domain.LocalUser, error) {
+	return r.getUser(ctx, "u.id = $1", id)
+}
+
+func (r *LocalUserRepoImpl) GetUserByLogin(ctx context.Context, login string) (*domain.LocalUser, error) {
+	return r.getUser(ctx, "LOWER(u.email) = $1 OR LOWER(u.username) = $1", strings.ToLower(login))
+}
+
+func (r *LocalUserRepoImpl) GetUsers(ctx context.Context, filter *commondomain.UserFilter) ([]domain.LocalUser, error) {
+	if filter == nil {
+		filter = &commondomain.UserFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT %s FROM %s AS u", userFields, userTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	orderBy := "u.id"
+	if filter.Sort != nil {
+		if column, ok := userFieldsMapping[filter.Sort.SortBy]; ok {
+			orderBy = fmt.Sprintf("%s %s", column, filter.Sort.SortDir)
+		}
+	}
+
+	query = fmt.Sprintf(`%s
+		ORDER BY %s`, query, orderBy)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s
+		LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s
+		OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	defer rows.Close()
+
+	users, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	return users, nil
+}
+
+func (r *LocalUserRepoImpl) GetUsersCount(ctx context.Context, filter *commondomain.UserFilter) (uint64, error) {
+	if filter == nil {
+		filter = &commondomain.UserFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS u", userTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.UserDomain, err, "error on query users count")
+	}
+
+	return count, nil
+}
+
+func (r *LocalUserRepoImpl) CreateUser(ctx context.Context, user domain.LocalUser) (*domain.LocalUser, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS u (email, username, first_name, last_name, about_me, image_url, password_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+		RETURNING %s
+	`, userTableName, userFields)
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		query,
+		user.Email,
+		user.Username,
+		user.FirstName,
+		user.LastName,
+		user.AboutMe,
+		user.ImageURL,
+		user.PasswordHash,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	defer rows.Close()
+
+	users, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+func (r *LocalUserRepoImpl) GetSession(ctx context.Context, id uint64) (*domain.Session, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS s WHERE s.id = $1`, sessionFields, sessionTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	defer rows.Close()
+
+	sessions, err := r.scanSessions(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+
+	return &sessions[0], nil
+}
+
+func (r *LocalUserRepoImpl) CreateSession(ctx context.Context, session domain.Session) (*domain.Session, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS s (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING %s
+	`, sessionTableName, sessionFields)
+
+	rows, err := r.db.QueryContext(ctx, query, session.UserID, session.TokenHash, session.ExpiresAt)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	defer rows.Close()
+
+	sessions, err := r.scanSessions(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+
+	return &sessions[0], nil
+}
+
+func (r *LocalUserRepoImpl) DeleteSession(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, sessionTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	return nil
+}
+
+func (r *LocalUserRepoImpl) DeleteExpiredSessions(ctx context.Context, userID uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1 AND expires_at < $2`, sessionTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, userID, time.Now().UTC()); err != nil {
+		return errors.NewDatabaseError(constants.UserDomain, err)
+	}
+
+	return nil
+}
+
+func NewLocalUserRepoImpl(db *sql.DB) *LocalUserRepoImpl {
+	return &LocalUserRepoImpl{db: db}
+}
@@ -0,0 +1,59 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeliveryStatus uint8
+
+func (s DeliveryStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	PendingDeliveryStatus   DeliveryStatus = 1
+	SucceededDeliveryStatus DeliveryStatus = 2
+	FailedDeliveryStatus    DeliveryStatus = 3
+)
+
+type Delivery struct {
+	ID        uint64
+	WebhookID uint64
+	// EventID is the ID of the outbox event, an event is delivered to a
+	// webhook once.
+	EventID       uint64
+	EventType     string
+	Payload       json.RawMessage
+	Status        DeliveryStatus
+	Attempts      uint
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	DeliveryAttempts []DeliveryAttempt
+}
+
+type DeliveryAttempt struct {
+	ID         uint64
+	DeliveryID uint64
+	Attempt    uint
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
@@ -0,0 +1,250 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/dialer"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+var ErrDeliveryWorkerAlreadyStarted = errors.New("delivery worker already started")
+
+const (
+	maxRetryBackoff = 6 * time.Hour
+
+	// maxErrorSize caps the response body kept in a delivery attempt.
+	maxErrorSize = 1024
+
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+type DeliveryWorker struct {
+	cfg          *configs.Config
+	log          logger.Logger
+	baseRepo     repository.BaseRepo
+	webhookRepo  WebhookRepo
+	deliveryRepo DeliveryRepo
+	client       *http.Client
+	wakeup       <-chan struct{}
+
+	isStarted atomic.Bool
+}
+
+func (w *DeliveryWorker) Start(ctx context.Context) error {
+	if !w.isStarted.CompareAndSwap(false, true) {
+		return ErrDeliveryWorkerAlreadyStarted
+	}
+	defer w.isStarted.Store(false)
+
+	for {
+		w.process(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.wakeup:
+		case <-time.After(w.cfg.WebhookPollInterval):
+		}
+	}
+}
+
+func (w *DeliveryWorker) process(ctx context.Context) {
+	for {
+		count, err := w.processBatch(ctx)
+		if err != nil {
+			w.log.Errorf("error on processing webhook deliveries: %s", err)
+			return
+		}
+
+		if count < w.cfg.WebhookBatchSize {
+			return
+		}
+	}
+}
+
+// processBatch claims due deliveries and sends them with no transaction
+// open. The claim outlasts sending the whole batch, if the worker dies the
+// deliveries are picked up again once it expires.
+func (w *DeliveryWorker) processBatch(ctx context.Context) (uint64, error) {
+	claimTimeout := w.cfg.WebhookTimeout * time.Duration(w.cfg.WebhookBatchSize+1)
+
+	deliveries, err := w.deliveryRepo.ClaimPendingDeliveries(
+		ctx,
+		w.cfg.WebhookBatchSize,
+		time.Now().UTC().Add(claimTimeout),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	webhooks, err := w.webhookRepo.GetWebhooks(ctx, &WebhookFilter{
+		IDs: lo.Uniq(lo.Map(deliveries, func(delivery Delivery, _ int) uint64 {
+			return delivery.WebhookID
+		})),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	webhooksMap := lo.KeyBy(webhooks, func(webhook Webhook) uint64 {
+		return webhook.ID
+	})
+
+	for _, delivery := range deliveries {
+		webhook, ok := webhooksMap[delivery.WebhookID]
+		if !ok {
+			continue
+		}
+
+		attempt := w.send(ctx, webhook, delivery)
+
+		if err := w.complete(ctx, delivery, attempt); err != nil {
+			return 0, err
+		}
+	}
+
+	return uint64(len(deliveries)), nil
+}
+
+// complete records the attempt and schedules the next one if needed.
+func (w *DeliveryWorker) complete(ctx context.Context, delivery Delivery, attempt DeliveryAttempt) error {
+	tx, err := w.baseRepo.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	delivery.Attempts++
+	attempt.DeliveryID = delivery.ID
+	attempt.Attempt = delivery.Attempts
+
+	if err := w.deliveryRepo.CreateDeliveryAttempt(ctx, attempt, tx); err != nil {
+		return err
+	}
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = SucceededDeliveryStatus
+	case delivery.Attempts >= w.cfg.WebhookMaxAttempts:
+		delivery.Status = FailedDeliveryStatus
+		w.log.Warnf("Webhook delivery failed id=%d webhookId=%d attempts=%d error=%s",
+			delivery.ID, delivery.WebhookID, delivery.Attempts, attempt.Error)
+	default:
+		delivery.NextAttemptAt = time.Now().UTC().Add(w.retryBackoff(delivery.Attempts))
+	}
+
+	if err := w.deliveryRepo.UpdateDelivery(ctx, delivery, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// send posts the delivery and reports the outcome as an attempt, an empty
+// Error means the receiver answered with 2xx.
+func (w *DeliveryWorker) send(ctx context.Context, webhook Webhook, delivery Delivery) (attempt DeliveryAttempt) {
+	startedAt := time.Now()
+	defer func() {
+		attempt.Duration = time.Since(startedAt)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	attempt.StatusCode = res.StatusCode
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorSize))
+		attempt.Error = fmt.Sprintf("unexpected status code %d: %s", res.StatusCode, body)
+	}
+
+	return attempt
+}
+
+func (w *DeliveryWorker) retryBackoff(attempts uint) time.Duration {
+	backoff := w.cfg.WebhookRetryBackoff
+	for i := uint(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxRetryBackoff)
+}
+
+func NewDeliveryWorker(
+	cfg *configs.Config,
+	log logger.Logger,
+	baseRepo repository.BaseRepo,
+	webhookRepo WebhookRepo,
+	deliveryRepo DeliveryRepo,
+	wakeup <-chan struct{},
+) *DeliveryWorker {
+	return &DeliveryWorker{
+		cfg:          cfg,
+		log:          log,
+		baseRepo:     baseRepo,
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client: &http.Client{
+			Timeout:   cfg.WebhookTimeout,
+			Transport: dialer.NewTransport(cfg.WebhookTimeout, cfg.WebhookAllowPrivateNetworks),
+		},
+		wakeup: wakeup,
+	}
+}
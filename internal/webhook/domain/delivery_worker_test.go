@@ -0,0 +1,205 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+)
+
+type stubLogger struct {
+	logger.Logger
+}
+
+func (stubLogger) Warnf(string, ...any) {}
+
+type stubTx struct {
+	repository.Tx
+}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
+type stubBaseRepo struct {
+	repository.BaseRepo
+}
+
+func (stubBaseRepo) BeginContext(context.Context) (repository.Tx, error) {
+	return stubTx{}, nil
+}
+
+func (r *stubDeliveryRepo) ClaimPendingDeliveries(_ context.Context, limit uint64, _ time.Time) ([]Delivery, error) {
+	deliveries := r.deliveries[:min(limit, uint64(len(r.deliveries)))]
+	r.deliveries = r.deliveries[len(deliveries):]
+
+	return deliveries, nil
+}
+
+func (r *stubDeliveryRepo) CreateDeliveryAttempt(_ context.Context, attempt DeliveryAttempt, _ repository.Tx) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *stubDeliveryRepo) UpdateDelivery(_ context.Context, delivery Delivery, _ repository.Tx) error {
+	r.updated = append(r.updated, delivery)
+	return nil
+}
+
+// receivedRequest is a request the webhook receiver got.
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, statusCode int) (*httptest.Server, func() []receivedRequest) {
+	t.Helper()
+
+	var (
+		mtx      sync.Mutex
+		requests []receivedRequest
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mtx.Lock()
+		requests = append(requests, receivedRequest{header: r.Header.Clone(), body: body})
+		mtx.Unlock()
+
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte("receiver says no"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedRequest {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		return requests
+	}
+}
+
+func TestDeliveryWorkerProcessBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		attempts     uint
+		wantStatus   DeliveryStatus
+		wantRetry    bool
+		wantAttempts uint
+	}{
+		{name: "delivered", statusCode: http.StatusNoContent, wantStatus: SucceededDeliveryStatus, wantAttempts: 1},
+		{name: "retried", statusCode: http.StatusBadGateway, attempts: 1, wantStatus: PendingDeliveryStatus, wantRetry: true, wantAttempts: 2},
+		{name: "failed after the last attempt", statusCode: http.StatusNotFound, attempts: 2, wantStatus: FailedDeliveryStatus, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newWebhookReceiver(t, tt.statusCode)
+
+			payload := json.RawMessage(`{"chatId":1}`)
+			deliveryRepo := &stubDeliveryRepo{deliveries: []Delivery{{
+				ID:        7,
+				WebhookID: 1,
+				EventType: "message.created",
+				Payload:   payload,
+				Status:    PendingDeliveryStatus,
+				Attempts:  tt.attempts,
+			}}}
+			webhookRepo := &stubWebhookRepo{webhooks: []Webhook{{ID: 1, URL: server.URL, Secret: "secret"}}}
+
+			worker := NewDeliveryWorker(&configs.Config{
+				WebhookBatchSize:    10,
+				WebhookMaxAttempts:  3,
+				WebhookRetryBackoff: time.Minute,
+				WebhookTimeout:      time.Second,
+			}, stubLogger{}, stubBaseRepo{}, webhookRepo, deliveryRepo, nil)
+			worker.client = server.Client()
+
+			count, err := worker.processBatch(context.Background())
+			if err != nil || count != 1 {
+				t.Fatalf("processBatch() = %d, %v, want 1 delivery", count, err)
+			}
+
+			requests := received()
+			if len(requests) != 1 {
+				t.Fatalf("receiver got %d requests, want 1", len(requests))
+			}
+
+			request := requests[0]
+			timestamp, err := strconv.ParseInt(request.header.Get(TimestampHeader), 10, 64)
+			if err != nil {
+				t.Fatalf("invalid timestamp header %q", request.header.Get(TimestampHeader))
+			}
+			if got, want := request.header.Get(SignatureHeader), Sign("secret", timestamp, request.body); got != want {
+				t.Errorf("signature header %s, want %s", got, want)
+			}
+			if string(request.body) != string(payload) || request.header.Get(EventHeader) != "message.created" ||
+				request.header.Get(DeliveryHeader) != "7" {
+				t.Errorf("receiver got %s with headers %v", request.body, request.header)
+			}
+
+			if len(deliveryRepo.attempts) != 1 || len(deliveryRepo.updated) != 1 {
+				t.Fatalf("recorded %d attempts and %d updates, want 1", len(deliveryRepo.attempts), len(deliveryRepo.updated))
+			}
+
+			attempt := deliveryRepo.attempts[0]
+			if attempt.StatusCode != tt.statusCode || attempt.Attempt != tt.wantAttempts ||
+				(attempt.Error == "") != (tt.wantStatus == SucceededDeliveryStatus) {
+				t.Errorf("attempt %+v, want status code %d as attempt %d", attempt, tt.statusCode, tt.wantAttempts)
+			}
+
+			delivery := deliveryRepo.updated[0]
+			if delivery.Status != tt.wantStatus || delivery.Attempts != tt.wantAttempts {
+				t.Errorf("delivery status %d after %d attempts, want %d after %d",
+					delivery.Status, delivery.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if retried := delivery.NextAttemptAt.After(time.Now()); retried != tt.wantRetry {
+				t.Errorf("delivery retried at %s, want retry %t", delivery.NextAttemptAt, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestDeliveryWorkerRetryBackoff(t *testing.T) {
+	worker := NewDeliveryWorker(&configs.Config{WebhookRetryBackoff: 10 * time.Second}, stubLogger{}, nil, nil, nil, nil)
+
+	tests := []struct {
+		attempts uint
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 5, want: 160 * time.Second},
+		{attempts: 20, want: maxRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := worker.retryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
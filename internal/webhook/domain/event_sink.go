@@ -0,0 +1,133 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+)
+
+const EventSinkName = "webhook"
+
+// EventSink turns outbox events into webhook deliveries. It only records
+// them, the DeliveryWorker sends them.
+type EventSink struct {
+	webhookRepo         WebhookRepo
+	deliveryRepo        DeliveryRepo
+	chatServiceContract ChatServiceContract
+}
+
+type deliveryBody struct {
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (s *EventSink) Name() string {
+	return EventSinkName
+}
+
+func (s *EventSink) Deliver(ctx context.Context, event outboxdomain.Event, tx repository.Tx) error {
+	if !slices.Contains(EventTypes, event.Type) {
+		return nil
+	}
+
+	webhooks, err := s.webhookRepo.GetWebhooks(ctx, &WebhookFilter{
+		EventTypes: []string{event.Type},
+		IsActive:   lo.ToPtr(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	memberIDs, err := s.getMemberIDs(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(deliveryBody{
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	// A webhook only receives events of chats its owner is a member of.
+	var deliveries []Delivery
+	for _, webhook := range webhooks {
+		if !lo.Contains(memberIDs, webhook.CreatedBy) {
+			continue
+		}
+
+		deliveries = append(deliveries, Delivery{
+			WebhookID: webhook.ID,
+			EventID:   event.ID,
+			EventType: event.Type,
+			Payload:   body,
+		})
+	}
+
+	return s.deliveryRepo.CreateDeliveries(ctx, deliveries, tx)
+}
+
+func (s *EventSink) getMemberIDs(ctx context.Context, event outboxdomain.Event) ([]uint64, error) {
+	if event.Type == chatdomain.ChatCreatedEventType {
+		var chat chatdomain.Chat
+		if err := json.Unmarshal(event.Payload, &chat); err != nil {
+			return nil, err
+		}
+
+		return lo.Map(chat.UserChats, func(userChat chatdomain.UserChat, _ int) uint64 {
+			return userChat.UserID
+		}), nil
+	}
+
+	// Member and message payloads both carry the chat ID.
+	var payload struct {
+		ChatID uint64 `json:"chatId"`
+	}
+
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	return s.chatServiceContract.GetChatMemberIDs(ctx, payload.ChatID)
+}
+
+func NewEventSink(
+	webhookRepo WebhookRepo,
+	deliveryRepo DeliveryRepo,
+	chatServiceContract ChatServiceContract,
+) *EventSink {
+	return &EventSink{
+		webhookRepo:         webhookRepo,
+		deliveryRepo:        deliveryRepo,
+		chatServiceContract: chatServiceContract,
+	}
+}
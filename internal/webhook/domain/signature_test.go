@@ -0,0 +1,54 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	const (
+		secret    = "secret"
+		timestamp = int64(1700000000)
+	)
+
+	body := []byte(`{"id":1}`)
+
+	// HMAC-SHA256 of "1700000000.{"id":1}" keyed by "secret".
+	want := "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11"
+	if got := Sign(secret, timestamp, body); got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+	}{
+		{name: "other secret", secret: "other", timestamp: timestamp, body: body},
+		{name: "other timestamp", secret: secret, timestamp: timestamp + 1, body: body},
+		{name: "other body", secret: secret, timestamp: timestamp, body: []byte(`{"id":2}`)},
+		{name: "timestamp moved into the body", secret: secret, timestamp: 170000000, body: []byte(`0.{"id":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, tt.body); got == want {
+				t.Errorf("Sign() = %s, want a different signature", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+)
+
+type WebhookRepo interface {
+	GetWebhook(ctx context.Context, id uint64) (*Webhook, error)
+	GetWebhooks(ctx context.Context, filter *WebhookFilter) ([]Webhook, error)
+	GetWebhooksCount(ctx context.Context, filter *WebhookFilter) (uint64, error)
+	CreateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, id uint64) error
+}
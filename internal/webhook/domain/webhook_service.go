@@ -0,0 +1,194 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/webhook/constants"
+)
+
+const secretSize = 32
+
+type WebhookServiceImpl struct {
+	webhookRepo  WebhookRepo
+	deliveryRepo DeliveryRepo
+}
+
+func (s *WebhookServiceImpl) getOwnWebhook(ctx context.Context, id uint64) (*Webhook, error) {
+	user := domain.UserFromContext(ctx)
+
+	webhook, err := s.webhookRepo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, errors.NewNotFoundError(constants.WebhookDomain)
+	}
+
+	if webhook.CreatedBy != user.ID {
+		return nil, errors.NewForbiddenError()
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhook(ctx context.Context, id uint64) (*Webhook, error) {
+	return s.getOwnWebhook(ctx, id)
+}
+
+func (s *WebhookServiceImpl) GetWebhooks(ctx context.Context, filter *WebhookFilter) ([]Webhook, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &WebhookFilter{}
+	}
+
+	filter.CreatedByIDs = []uint64{user.ID}
+
+	count, err := s.webhookRepo.GetWebhooksCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	webhooks, err := s.webhookRepo.GetWebhooks(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	user := domain.UserFromContext(ctx)
+
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.NewUndefinedError(err, "error on generating webhook secret")
+	}
+
+	webhook.Secret = hex.EncodeToString(secret)
+	webhook.EventTypes = lo.Uniq(webhook.EventTypes)
+	webhook.CreatedBy = user.ID
+
+	return s.webhookRepo.CreateWebhook(ctx, webhook)
+}
+
+func (s *WebhookServiceImpl) UpdateWebhook(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	if _, err := s.getOwnWebhook(ctx, webhook.ID); err != nil {
+		return nil, err
+	}
+
+	webhook.EventTypes = lo.Uniq(webhook.EventTypes)
+
+	updatedWebhook, err := s.webhookRepo.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedWebhook == nil {
+		return nil, errors.NewNotFoundError(constants.WebhookDomain)
+	}
+
+	return updatedWebhook, nil
+}
+
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, id uint64) error {
+	if _, err := s.getOwnWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookServiceImpl) GetDeliveries(ctx context.Context, filter *DeliveryFilter) ([]Delivery, uint64, error) {
+	user := domain.UserFromContext(ctx)
+
+	if filter == nil {
+		filter = &DeliveryFilter{}
+	}
+
+	for _, webhookID := range filter.WebhookIDs {
+		if _, err := s.getOwnWebhook(ctx, webhookID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Without webhooks in the filter the deliveries of all webhooks of the
+	// user are returned, never the ones of others.
+	if len(filter.WebhookIDs) == 0 {
+		webhooks, err := s.webhookRepo.GetWebhooks(ctx, &WebhookFilter{CreatedByIDs: []uint64{user.ID}})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(webhooks) == 0 {
+			return nil, 0, nil
+		}
+
+		filter.WebhookIDs = lo.Map(webhooks, func(webhook Webhook, _ int) uint64 {
+			return webhook.ID
+		})
+	}
+
+	count, err := s.deliveryRepo.GetDeliveriesCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return nil, 0, nil
+	}
+
+	deliveries, err := s.deliveryRepo.GetDeliveries(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	attempts, err := s.deliveryRepo.GetDeliveryAttempts(ctx, lo.Map(deliveries, func(delivery Delivery, _ int) uint64 {
+		return delivery.ID
+	}))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	attemptsMap := lo.GroupBy(attempts, func(attempt DeliveryAttempt) uint64 {
+		return attempt.DeliveryID
+	})
+
+	for index := range deliveries {
+		deliveries[index].DeliveryAttempts = attemptsMap[deliveries[index].ID]
+	}
+
+	return deliveries, count, nil
+}
+
+func NewWebhookServiceImpl(webhookRepo WebhookRepo, deliveryRepo DeliveryRepo) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/domain"
+	commonerrors "github.com/microcoretech/chat-go/internal/common/errors"
+)
+
+type stubWebhookRepo struct {
+	WebhookRepo
+
+	webhooks []Webhook
+}
+
+func (r *stubWebhookRepo) GetWebhook(_ context.Context, id uint64) (*Webhook, error) {
+	webhook, ok := lo.Find(r.webhooks, func(webhook Webhook) bool {
+		return webhook.ID == id
+	})
+	if !ok {
+		return nil, nil
+	}
+
+	return &webhook, nil
+}
+
+func (r *stubWebhookRepo) GetWebhooks(_ context.Context, filter *WebhookFilter) ([]Webhook, error) {
+	return lo.Filter(r.webhooks, func(webhook Webhook, _ int) bool {
+		return (len(filter.IDs) == 0 || lo.Contains(filter.IDs, webhook.ID)) &&
+			(len(filter.CreatedByIDs) == 0 || lo.Contains(filter.CreatedByIDs, webhook.CreatedBy))
+	}), nil
+}
+
+type stubDeliveryRepo struct {
+	DeliveryRepo
+
+	deliveries []Delivery
+	attempts   []DeliveryAttempt
+	updated    []Delivery
+}
+
+func (r *stubDeliveryRepo) filter(filter *DeliveryFilter) []Delivery {
+	return lo.Filter(r.deliveries, func(delivery Delivery, _ int) bool {
+		return len(filter.WebhookIDs) == 0 || lo.Contains(filter.WebhookIDs, delivery.WebhookID)
+	})
+}
+
+func (r *stubDeliveryRepo) GetDeliveries(_ context.Context, filter *DeliveryFilter) ([]Delivery, error) {
+	return r.filter(filter), nil
+}
+
+func (r *stubDeliveryRepo) GetDeliveriesCount(_ context.Context, filter *DeliveryFilter) (uint64, error) {
+	return uint64(len(r.filter(filter))), nil
+}
+
+func (r *stubDeliveryRepo) GetDeliveryAttempts(context.Context, []uint64) ([]DeliveryAttempt, error) {
+	return nil, nil
+}
+
+func TestGetDeliveries(t *testing.T) {
+	service := NewWebhookServiceImpl(
+		&stubWebhookRepo{webhooks: []Webhook{
+			{ID: 1, CreatedBy: 1},
+			{ID: 2, CreatedBy: 1},
+			{ID: 3, CreatedBy: 2},
+		}},
+		&stubDeliveryRepo{deliveries: []Delivery{
+			{ID: 1, WebhookID: 1},
+			{ID: 2, WebhookID: 2},
+			{ID: 3, WebhookID: 3},
+		}},
+	)
+
+	tests := []struct {
+		name            string
+		userID          uint64
+		webhookIDs      []uint64
+		wantDeliveryIDs []uint64
+		forbidden       bool
+	}{
+		{name: "own webhook", userID: 1, webhookIDs: []uint64{1}, wantDeliveryIDs: []uint64{1}},
+		{name: "webhook of another user", userID: 1, webhookIDs: []uint64{3}, forbidden: true},
+		{name: "all own webhooks", userID: 1, wantDeliveryIDs: []uint64{1, 2}},
+		{name: "user without webhooks", userID: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: tt.userID})
+
+			deliveries, count, err := service.GetDeliveries(ctx, &DeliveryFilter{WebhookIDs: tt.webhookIDs})
+
+			var forbiddenErr *commonerrors.ForbiddenError
+			if errors.As(err, &forbiddenErr) != tt.forbidden {
+				t.Fatalf("GetDeliveries() error = %v, want forbidden %t", err, tt.forbidden)
+			}
+
+			deliveryIDs := lo.Map(deliveries, func(delivery Delivery, _ int) uint64 {
+				return delivery.ID
+			})
+			if !lo.ElementsMatch(deliveryIDs, tt.wantDeliveryIDs) || count != uint64(len(tt.wantDeliveryIDs)) {
+				t.Errorf("GetDeliveries() = %v, %d, want %v", deliveryIDs, count, tt.wantDeliveryIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeliveryDto struct {
+	ID               uint64               `json:"id"`
+	WebhookID        uint64               `json:"webhookId"`
+	EventType        string               `json:"eventType"`
+	Payload          json.RawMessage      `json:"payload"`
+	Status           uint8                `json:"status"`
+	Attempts         uint                 `json:"attempts"`
+	NextAttemptAt    time.Time            `json:"nextAttemptAt"`
+	DeliveryAttempts []DeliveryAttemptDto `json:"deliveryAttempts"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+}
+
+type DeliveryAttemptDto struct {
+	Attempt    uint      `json:"attempt"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
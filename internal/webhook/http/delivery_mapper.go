@@ -0,0 +1,48 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+func DeliveryToDto(delivery domain.Delivery) DeliveryDto {
+	return DeliveryDto{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        delivery.Status.ToUint8(),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		DeliveryAttempts: lo.Map(delivery.DeliveryAttempts, func(attempt domain.DeliveryAttempt, _ int) DeliveryAttemptDto {
+			return DeliveryAttemptToDto(attempt)
+		}),
+		CreatedAt: delivery.CreatedAt,
+		UpdatedAt: delivery.UpdatedAt,
+	}
+}
+
+func DeliveryAttemptToDto(attempt domain.DeliveryAttempt) DeliveryAttemptDto {
+	return DeliveryAttemptDto{
+		Attempt:    attempt.Attempt,
+		StatusCode: attempt.StatusCode,
+		Error:      attempt.Error,
+		DurationMs: attempt.Duration.Milliseconds(),
+		CreatedAt:  attempt.CreatedAt,
+	}
+}
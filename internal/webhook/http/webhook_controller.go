@@ -0,0 +1,200 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	"github.com/microcoretech/chat-go/internal/webhook/constants"
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+type WebhookController struct {
+	validate       validator.Validate
+	authMiddleware api.Middleware
+	webhookService WebhookService
+}
+
+func (c *WebhookController) SetupRoutes(r fiber.Router) {
+	webhookGroup := r.Group("/webhooks", c.authMiddleware.Handler)
+	webhookGroup.Get("", c.getWebhooks)
+	webhookGroup.Get("/:id", c.getWebhook)
+	webhookGroup.Get("/:id/deliveries", c.getDeliveries)
+	webhookGroup.Put("/:id", c.update)
+	webhookGroup.Post("", c.create)
+	webhookGroup.Delete("/:id", c.delete)
+}
+
+func (c *WebhookController) getWebhooks(ctx *fiber.Ctx) error {
+	var query WebhookQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.WebhookDomain, &query); err != nil {
+		return errors.NewValidationError(constants.WebhookDomain, err, nil)
+	}
+
+	webhookFilter := WebhookFilterFromQuery(query)
+
+	webhooks, count, err := c.webhookService.GetWebhooks(ctx.Context(), &webhookFilter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(webhooks, func(webhook domain.Webhook, _ int) WebhookDto {
+			return WebhookToDto(webhook)
+		}),
+		count,
+	))
+}
+
+func (c *WebhookController) getWebhook(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, map[string]any{"id": idStr})
+	}
+
+	webhook, err := c.webhookService.GetWebhook(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(WebhookToDto(*webhook))
+}
+
+func (c *WebhookController) getDeliveries(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, map[string]any{"id": idStr})
+	}
+
+	var query DeliveryQuery
+
+	if err := ctx.QueryParser(&query); err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.WebhookDomain, &query); err != nil {
+		return errors.NewValidationError(constants.WebhookDomain, err, nil)
+	}
+
+	deliveries, count, err := c.webhookService.GetDeliveries(ctx.Context(), &domain.DeliveryFilter{
+		WebhookIDs: []uint64{id},
+		Statuses:   query.Statuses,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(commonhttp.NewPage(
+		lo.Map(deliveries, func(delivery domain.Delivery, _ int) DeliveryDto {
+			return DeliveryToDto(delivery)
+		}),
+		count,
+	))
+}
+
+func (c *WebhookController) update(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, map[string]any{"id": idStr})
+	}
+
+	dto := UpdateWebhookDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.WebhookDomain, dto); err != nil {
+		return err
+	}
+
+	webhook := WebhookFromUpdateDto(dto)
+	webhook.ID = id
+
+	updatedWebhook, err := c.webhookService.UpdateWebhook(ctx.Context(), webhook)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(WebhookToDto(*updatedWebhook))
+}
+
+func (c *WebhookController) create(ctx *fiber.Ctx) error {
+	dto := CreateWebhookDto{}
+	if err := ctx.BodyParser(&dto); err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, nil)
+	}
+
+	if err := c.validate.Struct(constants.WebhookDomain, dto); err != nil {
+		return err
+	}
+
+	createdWebhook, err := c.webhookService.CreateWebhook(ctx.Context(), WebhookFromCreateDto(dto))
+	if err != nil {
+		return err
+	}
+
+	webhookDto := WebhookToDto(*createdWebhook)
+	webhookDto.Secret = createdWebhook.Secret
+
+	return ctx.JSON(webhookDto)
+}
+
+func (c *WebhookController) delete(ctx *fiber.Ctx) error {
+	idStr := ctx.Params("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return errors.NewBadRequestError(constants.WebhookDomain, err, map[string]any{"id": idStr})
+	}
+
+	if err := c.webhookService.DeleteWebhook(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(http.StatusOK)
+}
+
+func NewWebhookController(
+	validate validator.Validate,
+	authMiddleware api.Middleware,
+	webhookService WebhookService,
+) *WebhookController {
+	return &WebhookController{
+		validate:       validate,
+		authMiddleware: authMiddleware,
+		webhookService: webhookService,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"time"
+)
+
+type CreateWebhookDto struct {
+	URL        string   `json:"url" validate:"required,http_url,lte=2048"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1,dive,oneof=chat.created member.added message.created message.updated message.deleted"`
+	IsActive   *bool    `json:"isActive"`
+}
+
+type UpdateWebhookDto struct {
+	URL        string   `json:"url" validate:"required,http_url,lte=2048"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1,dive,oneof=chat.created member.added message.created message.updated message.deleted"`
+	IsActive   bool     `json:"isActive"`
+}
+
+type WebhookDto struct {
+	ID  uint64 `json:"id"`
+	URL string `json:"url"`
+	// Secret is only returned once, on creation.
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"eventTypes"`
+	IsActive   bool      `json:"isActive"`
+	CreatedBy  uint64    `json:"createdBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
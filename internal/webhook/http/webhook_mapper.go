@@ -0,0 +1,49 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+func WebhookFromCreateDto(dto CreateWebhookDto) domain.Webhook {
+	return domain.Webhook{
+		URL:        dto.URL,
+		EventTypes: dto.EventTypes,
+		IsActive:   lo.FromPtrOr(dto.IsActive, true),
+	}
+}
+
+func WebhookFromUpdateDto(dto UpdateWebhookDto) domain.Webhook {
+	return domain.Webhook{
+		URL:        dto.URL,
+		EventTypes: dto.EventTypes,
+		IsActive:   dto.IsActive,
+	}
+}
+
+func WebhookToDto(webhook domain.Webhook) WebhookDto {
+	return WebhookDto{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		IsActive:   webhook.IsActive,
+		CreatedBy:  webhook.CreatedBy,
+		CreatedAt:  webhook.CreatedAt,
+		UpdatedAt:  webhook.UpdatedAt,
+	}
+}
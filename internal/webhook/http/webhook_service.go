@@ -0,0 +1,30 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+type WebhookService interface {
+	GetWebhook(ctx context.Context, id uint64) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context, filter *domain.WebhookFilter) ([]domain.Webhook, uint64, error)
+	CreateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uint64) error
+	GetDeliveries(ctx context.Context, filter *domain.DeliveryFilter) ([]domain.Delivery, uint64, error)
+}
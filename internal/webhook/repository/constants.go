@@ -0,0 +1,27 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+const (
+	webhookTableName         = "webhooks"
+	deliveryTableName        = "webhook_deliveries"
+	deliveryAttemptTableName = "webhook_delivery_attempts"
+)
+
+const (
+	webhookFields         = `w.id, w.url, w.secret, w.event_types, w.is_active, w.created_by, w.created_at, w.updated_at`
+	deliveryFields        = `d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.created_at, d.updated_at`
+	deliveryAttemptFields = `a.id, a.delivery_id, a.attempt, a.status_code, a.error, a.duration_ms, a.created_at`
+)
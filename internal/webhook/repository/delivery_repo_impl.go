@@ -0,0 +1,396 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/webhook/constants"
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+type DeliveryRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *DeliveryRepoImpl) scan(rows *sql.Rows) ([]domain.Delivery, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	deliveries := make([]domain.Delivery, 0)
+
+	for rows.Next() {
+		var delivery domain.Delivery
+
+		var fields = []any{
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventID,
+			&delivery.EventType,
+			(*[]byte)(&delivery.Payload),
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) buildFilter(filter domain.DeliveryFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.IDs) > 0 {
+		var params []string
+		for _, id := range filter.IDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"d.id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.WebhookIDs) > 0 {
+		var params []string
+		for _, webhookID := range filter.WebhookIDs {
+			values = append(values, webhookID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"d.webhook_id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.Statuses) > 0 {
+		var params []string
+		for _, status := range filter.Statuses {
+			values = append(values, status)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"d.status IN (%s) ", strings.Join(params, ",")))
+	}
+
+	return values, where
+}
+
+func (r *DeliveryRepoImpl) GetDeliveries(ctx context.Context, filter *domain.DeliveryFilter) ([]domain.Delivery, error) {
+	if filter == nil {
+		filter = &domain.DeliveryFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT %s FROM %s AS d", deliveryFields, deliveryTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	query = fmt.Sprintf(`%s
+		ORDER BY d.id DESC`, query)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s
+		LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s
+		OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	deliveries, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) GetDeliveriesCount(ctx context.Context, filter *domain.DeliveryFilter) (uint64, error) {
+	if filter == nil {
+		filter = &domain.DeliveryFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS d", deliveryTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.WebhookDomain, err, "error on query deliveries count")
+	}
+
+	return count, nil
+}
+
+func (r *DeliveryRepoImpl) GetDeliveryAttempts(ctx context.Context, deliveryIDs []uint64) ([]domain.DeliveryAttempt, error) {
+	if len(deliveryIDs) == 0 {
+		return nil, nil
+	}
+
+	var (
+		params []string
+		values []any
+	)
+
+	for _, id := range deliveryIDs {
+		values = append(values, id)
+		params = append(params, fmt.Sprintf("$%d", len(values)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s AS a
+		WHERE a.delivery_id IN (%s)
+		ORDER BY a.delivery_id, a.attempt
+	`,
+		deliveryAttemptFields,
+		deliveryAttemptTableName,
+		strings.Join(params, ","),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	attempts := make([]domain.DeliveryAttempt, 0)
+
+	for rows.Next() {
+		var (
+			attempt    domain.DeliveryAttempt
+			durationMs int64
+		)
+
+		if err := rows.Scan(
+			&attempt.ID,
+			&attempt.DeliveryID,
+			&attempt.Attempt,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&durationMs,
+			&attempt.CreatedAt,
+		); err != nil {
+			return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+		}
+
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
+
+func (r *DeliveryRepoImpl) CreateDeliveries(ctx context.Context, deliveries []domain.Delivery, tx repository.Tx) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var (
+		placeholders []string
+		values       []any
+	)
+
+	const colsNum = 4
+
+	for i, delivery := range deliveries {
+		var indexes []any
+
+		for j := 1; j <= colsNum; j++ {
+			indexes = append(indexes, i*colsNum+j)
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d,$%d,$%d,$%d)", indexes...))
+
+		values = append(values,
+			delivery.WebhookID,
+			delivery.EventID,
+			delivery.EventType,
+			[]byte(delivery.Payload),
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (webhook_id, event_id, event_type, payload)
+		VALUES %s
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`,
+		deliveryTableName,
+		strings.Join(placeholders, ","),
+	)
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	notifyQuery := "SELECT pg_notify($1, '')"
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, notifyQuery, constants.NotificationChannel)
+	} else {
+		_, err = r.db.ExecContext(ctx, notifyQuery, constants.NotificationChannel)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.WebhookDomain, err, "error on notify delivery workers")
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepoImpl) ClaimPendingDeliveries(
+	ctx context.Context,
+	limit uint64,
+	claimedUntil time.Time,
+) ([]domain.Delivery, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS d
+		SET next_attempt_at = $1, updated_at = NOW()
+		FROM (
+			SELECT pd.id
+			FROM %s AS pd
+			INNER JOIN %s AS w ON w.id = pd.webhook_id AND w.is_active
+			WHERE pd.status = $2 AND pd.next_attempt_at <= NOW()
+			ORDER BY pd.id
+			LIMIT %d
+			FOR UPDATE OF pd SKIP LOCKED
+		) AS c
+		WHERE d.id = c.id
+		RETURNING %s
+	`,
+		deliveryTableName,
+		deliveryTableName,
+		webhookTableName,
+		limit,
+		deliveryFields,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, claimedUntil, domain.PendingDeliveryStatus)
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	deliveries, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryRepoImpl) UpdateDelivery(ctx context.Context, delivery domain.Delivery, tx repository.Tx) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, attempts = $2, next_attempt_at = $3, updated_at = NOW()
+		WHERE id = $4
+	`, deliveryTableName)
+
+	values := []any{
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ID,
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepoImpl) CreateDeliveryAttempt(ctx context.Context, attempt domain.DeliveryAttempt, tx repository.Tx) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (delivery_id, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)
+	`, deliveryAttemptTableName)
+
+	values := []any{
+		attempt.DeliveryID,
+		attempt.Attempt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.Duration.Milliseconds(),
+	}
+
+	var err error
+
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, values...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, values...)
+	}
+
+	if err != nil {
+		return errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return nil
+}
+
+func NewDeliveryRepoImpl(db *sql.DB) *DeliveryRepoImpl {
+	return &DeliveryRepoImpl{db: db}
+}
@@ -0,0 +1,265 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/microcoretech/chat-go/internal/common/errors"
+	"github.com/microcoretech/chat-go/internal/webhook/constants"
+	"github.com/microcoretech/chat-go/internal/webhook/domain"
+)
+
+type WebhookRepoImpl struct {
+	db *sql.DB
+}
+
+func (r *WebhookRepoImpl) scan(rows *sql.Rows) ([]domain.Webhook, error) {
+	if rows == nil {
+		return nil, nil
+	}
+
+	webhooks := make([]domain.Webhook, 0)
+
+	for rows.Next() {
+		var webhook domain.Webhook
+
+		var fields = []any{
+			&webhook.ID,
+			&webhook.URL,
+			&webhook.Secret,
+			pq.Array(&webhook.EventTypes),
+			&webhook.IsActive,
+			&webhook.CreatedBy,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+		}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepoImpl) buildFilter(filter domain.WebhookFilter) ([]any, []string) {
+	values := make([]any, 0)
+	where := make([]string, 0)
+
+	if len(filter.IDs) > 0 {
+		var params []string
+		for _, id := range filter.IDs {
+			values = append(values, id)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"w.id IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.CreatedByIDs) > 0 {
+		var params []string
+		for _, createdByID := range filter.CreatedByIDs {
+			values = append(values, createdByID)
+			params = append(params, fmt.Sprintf("$%d", len(values)))
+		}
+		where = append(where, fmt.Sprintf(
+			"w.created_by IN (%s) ", strings.Join(params, ",")))
+	}
+
+	if len(filter.EventTypes) > 0 {
+		values = append(values, pq.Array(filter.EventTypes))
+		where = append(where, fmt.Sprintf(
+			"w.event_types && $%d::VARCHAR[] ", len(values)))
+	}
+
+	if filter.IsActive != nil {
+		values = append(values, *filter.IsActive)
+		where = append(where, fmt.Sprintf(
+			"w.is_active = $%d ", len(values)))
+	}
+
+	return values, where
+}
+
+func (r *WebhookRepoImpl) GetWebhook(ctx context.Context, id uint64) (*domain.Webhook, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s AS w WHERE w.id = $1`, webhookFields, webhookTableName)
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	webhooks, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	return &webhooks[0], nil
+}
+
+func (r *WebhookRepoImpl) GetWebhooks(ctx context.Context, filter *domain.WebhookFilter) ([]domain.Webhook, error) {
+	if filter == nil {
+		filter = &domain.WebhookFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT %s FROM %s AS w", webhookFields, webhookTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	query = fmt.Sprintf(`%s
+		ORDER BY w.id`, query)
+
+	if filter.Limit != nil {
+		query = fmt.Sprintf(`%s
+		LIMIT %d`, query, *filter.Limit)
+	}
+
+	if filter.Offset != nil {
+		query = fmt.Sprintf(`%s
+		OFFSET %d`, query, *filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	webhooks, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepoImpl) GetWebhooksCount(ctx context.Context, filter *domain.WebhookFilter) (uint64, error) {
+	if filter == nil {
+		filter = &domain.WebhookFilter{}
+	}
+
+	values, where := r.buildFilter(*filter)
+
+	query := fmt.Sprintf("SELECT COUNT(*) AS count FROM %s AS w", webhookTableName)
+
+	if len(where) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(where, " AND "))
+	}
+
+	var count uint64
+
+	if err := r.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return 0, errors.NewDatabaseError(constants.WebhookDomain, err, "error on query webhooks count")
+	}
+
+	return count, nil
+}
+
+func (r *WebhookRepoImpl) CreateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS w (url, secret, event_types, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING %s
+	`, webhookTableName, webhookFields)
+
+	rows, err := r.db.QueryContext(ctx, query,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.EventTypes),
+		webhook.IsActive,
+		webhook.CreatedBy,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	webhooks, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	return &webhooks[0], nil
+}
+
+func (r *WebhookRepoImpl) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s AS w
+		SET url = $1, event_types = $2, is_active = $3, updated_at = NOW()
+		WHERE w.id = $4
+		RETURNING %s
+	`, webhookTableName, webhookFields)
+
+	rows, err := r.db.QueryContext(ctx, query,
+		webhook.URL,
+		pq.Array(webhook.EventTypes),
+		webhook.IsActive,
+		webhook.ID,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	defer rows.Close()
+
+	webhooks, err := r.scan(rows)
+	if err != nil {
+		return nil, errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	return &webhooks[0], nil
+}
+
+func (r *WebhookRepoImpl) DeleteWebhook(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, webhookTableName)
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return errors.NewDatabaseError(constants.WebhookDomain, err)
+	}
+
+	return nil
+}
+
+func NewWebhookRepoImpl(db *sql.DB) *WebhookRepoImpl {
+	return &WebhookRepoImpl{db: db}
+}
@@ -0,0 +1,21 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS message_mentions (
+    message_id BIGINT NOT NULL REFERENCES messages ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL,
+    PRIMARY KEY ("message_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS message_mentions_user_id_idx ON message_mentions ("user_id");
\ No newline at end of file
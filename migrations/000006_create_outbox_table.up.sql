@@ -0,0 +1,42 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    type            VARCHAR   NOT NULL,
+    payload         JSONB     NOT NULL DEFAULT '{}'::JSONB,
+    origin          VARCHAR   NOT NULL DEFAULT '',
+    status          SMALLINT  NOT NULL DEFAULT 1,
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    last_error      VARCHAR   NOT NULL DEFAULT '',
+    delivered_sinks VARCHAR[] NOT NULL DEFAULT '{}',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox ("next_attempt_at") WHERE status = 1;
+
+CREATE TABLE IF NOT EXISTS outbox_broadcasts
+(
+    id         BIGSERIAL PRIMARY KEY,
+    event_id   BIGINT    NOT NULL,
+    type       VARCHAR   NOT NULL,
+    payload    JSONB     NOT NULL DEFAULT '{}'::JSONB,
+    origin     VARCHAR   NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_broadcasts_created_at_idx ON outbox_broadcasts ("created_at");
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id          BIGSERIAL PRIMARY KEY,
+    url         VARCHAR   NOT NULL,
+    secret      VARCHAR   NOT NULL,
+    event_types VARCHAR[] NOT NULL DEFAULT '{}',
+    is_active   BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_by  BIGINT    NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_created_by_idx ON webhooks ("created_by");
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_id      BIGINT    NOT NULL REFERENCES webhooks ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    event_id        BIGINT    NOT NULL,
+    event_type      VARCHAR   NOT NULL,
+    payload         JSONB     NOT NULL DEFAULT '{}'::JSONB,
+    status          SMALLINT  NOT NULL DEFAULT 1,
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE ("webhook_id", "event_id")
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries ("next_attempt_at") WHERE status = 1;
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts
+(
+    id          BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT    NOT NULL REFERENCES webhook_deliveries ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    attempt     INTEGER   NOT NULL,
+    status_code INTEGER   NOT NULL DEFAULT 0,
+    error       VARCHAR   NOT NULL DEFAULT '',
+    duration_ms BIGINT    NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts ("delivery_id");
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+-- Bot IDs start at 2^62 (see domain.BotIDOffset) to never collide with user IDs.
+CREATE SEQUENCE IF NOT EXISTS bots_id_seq START WITH 4611686018427387904;
+
+CREATE TABLE IF NOT EXISTS bots
+(
+    id         BIGINT PRIMARY KEY DEFAULT nextval('bots_id_seq'),
+    username   VARCHAR   NOT NULL,
+    name       VARCHAR   NOT NULL DEFAULT '',
+    token_hash VARCHAR   NOT NULL,
+    created_by BIGINT    NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER SEQUENCE bots_id_seq OWNED BY bots.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bots_username_idx ON bots (LOWER("username"));
+CREATE INDEX IF NOT EXISTS bots_created_by_idx ON bots ("created_by");
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS commands
+(
+    id          BIGSERIAL PRIMARY KEY,
+    chat_id     BIGINT    NOT NULL REFERENCES chats ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    name        VARCHAR   NOT NULL,
+    description VARCHAR   NOT NULL DEFAULT '',
+    url         VARCHAR   NOT NULL,
+    token       VARCHAR   NOT NULL,
+    created_by  BIGINT    NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS commands_chat_id_name_idx ON commands ("chat_id", "name");
+CREATE INDEX IF NOT EXISTS commands_created_by_idx ON commands ("created_by");
\ No newline at end of file
@@ -0,0 +1,77 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT    NOT NULL,
+    chat_id    BIGINT    NOT NULL REFERENCES chats ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    message_id BIGINT    NOT NULL REFERENCES messages ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    text       VARCHAR   NOT NULL DEFAULT '',
+    is_mention BOOLEAN   NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS notifications_created_at_idx ON notifications ("created_at");
+
+CREATE TABLE IF NOT EXISTS notification_devices
+(
+    id                      BIGSERIAL PRIMARY KEY,
+    user_id                 BIGINT    NOT NULL,
+    channel                 VARCHAR   NOT NULL,
+    token                   VARCHAR   NOT NULL,
+    platform                VARCHAR   NOT NULL DEFAULT '',
+    -- Email devices are confirmed with a code sent to the address.
+    confirmed_at            TIMESTAMP NULL,
+    confirmation_code       VARCHAR   NOT NULL DEFAULT '',
+    confirmation_expires_at TIMESTAMP NULL,
+    confirmation_attempts   INTEGER   NOT NULL DEFAULT 0,
+    created_at              TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS notification_devices_channel_token_idx ON notification_devices ("channel", "token");
+CREATE INDEX IF NOT EXISTS notification_devices_user_id_idx ON notification_devices ("user_id");
+
+CREATE TABLE IF NOT EXISTS notification_deliveries
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    user_id             BIGINT    NOT NULL,
+    device_id           BIGINT    NOT NULL,
+    channel             VARCHAR   NOT NULL,
+    notifications_count INTEGER   NOT NULL DEFAULT 0,
+    digest              JSONB     NOT NULL DEFAULT '{}'::JSONB,
+    status              SMALLINT  NOT NULL,
+    error               VARCHAR   NOT NULL DEFAULT '',
+    attempts            INTEGER   NOT NULL DEFAULT 0,
+    next_attempt_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS notification_deliveries_user_id_idx ON notification_deliveries ("user_id");
+CREATE INDEX IF NOT EXISTS notification_deliveries_pending_idx ON notification_deliveries ("next_attempt_at") WHERE status = 3;
+
+-- Presences are the chats users follow on live connections, every instance
+-- refreshes its own rows.
+CREATE TABLE IF NOT EXISTS presences
+(
+    instance_id VARCHAR   NOT NULL,
+    user_id     BIGINT    NOT NULL,
+    chat_id     BIGINT    NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY ("instance_id", "user_id", "chat_id")
+);
+
+CREATE INDEX IF NOT EXISTS presences_chat_id_idx ON presences ("chat_id");
\ No newline at end of file
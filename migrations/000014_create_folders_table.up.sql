@@ -0,0 +1,28 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS folders
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT      NOT NULL,
+    name          VARCHAR(64) NOT NULL,
+    chat_ids      BIGINT[]    NOT NULL DEFAULT '{}',
+    chat_types    SMALLINT[]  NOT NULL DEFAULT '{}',
+    unread_only   BOOLEAN     NOT NULL DEFAULT FALSE,
+    exclude_muted BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMP   NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS folders_user_id_idx ON folders ("user_id");
\ No newline at end of file
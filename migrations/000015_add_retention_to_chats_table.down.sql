@@ -0,0 +1,19 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+DROP INDEX IF EXISTS messages_created_at_idx;
+
+ALTER TABLE chats DROP COLUMN IF EXISTS legal_hold;
+ALTER TABLE chats DROP COLUMN IF EXISTS retention_max_messages;
+ALTER TABLE chats DROP COLUMN IF EXISTS retention_days;
\ No newline at end of file
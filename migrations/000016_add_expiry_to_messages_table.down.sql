@@ -0,0 +1,25 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+ALTER TABLE chats DROP COLUMN IF EXISTS default_expire_from;
+ALTER TABLE chats DROP COLUMN IF EXISTS default_expires_in;
+
+DROP TABLE IF EXISTS message_reads;
+
+DROP INDEX IF EXISTS messages_expires_at_idx;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS is_view_once;
+ALTER TABLE messages DROP COLUMN IF EXISTS expire_from;
+ALTER TABLE messages DROP COLUMN IF EXISTS expires_in;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS expires_in INTEGER NULL;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS expire_from SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS is_view_once BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS messages_expires_at_idx ON messages ("expires_at") WHERE expires_at IS NOT NULL;
+
+-- Reads are kept per member. A message counted from read expires for every
+-- member on its own, and for all once every member has read it.
+CREATE TABLE IF NOT EXISTS message_reads
+(
+    message_id BIGINT    NOT NULL REFERENCES messages ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id    BIGINT    NOT NULL,
+    expires_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("message_id", "user_id")
+);
+
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS default_expires_in INTEGER NULL;
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS default_expire_from SMALLINT NOT NULL DEFAULT 1;
\ No newline at end of file
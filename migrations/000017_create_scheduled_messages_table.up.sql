@@ -0,0 +1,34 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS scheduled_messages
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    chat_id            BIGINT    NOT NULL REFERENCES chats ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    text               TEXT      NOT NULL,
+    entities           JSONB     NOT NULL DEFAULT '[]',
+    mentioned_user_ids BIGINT[]  NOT NULL DEFAULT '{}',
+    expires_in         INTEGER   NULL,
+    expire_from        SMALLINT  NOT NULL DEFAULT 1,
+    is_view_once       BOOLEAN   NOT NULL DEFAULT FALSE,
+    is_undo_send       BOOLEAN   NOT NULL DEFAULT FALSE,
+    creator            JSONB     NULL,
+    created_by         BIGINT    NOT NULL,
+    send_at            TIMESTAMP NOT NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_messages_send_at_idx ON scheduled_messages ("send_at");
+CREATE INDEX IF NOT EXISTS scheduled_messages_created_by_idx ON scheduled_messages ("created_by");
\ No newline at end of file
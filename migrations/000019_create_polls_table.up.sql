@@ -0,0 +1,49 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS kind SMALLINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS polls
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    message_id         BIGINT    NOT NULL UNIQUE REFERENCES messages ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    question           TEXT      NOT NULL,
+    is_multiple_choice BOOLEAN   NOT NULL DEFAULT FALSE,
+    is_anonymous       BOOLEAN   NOT NULL DEFAULT FALSE,
+    closes_at          TIMESTAMP NULL,
+    closed_at          TIMESTAMP NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS poll_options
+(
+    id       BIGSERIAL PRIMARY KEY,
+    poll_id  BIGINT   NOT NULL REFERENCES polls ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    text     TEXT     NOT NULL,
+    position SMALLINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS poll_options_poll_id_idx ON poll_options ("poll_id");
+
+CREATE TABLE IF NOT EXISTS poll_votes
+(
+    poll_id    BIGINT    NOT NULL REFERENCES polls ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    option_id  BIGINT    NOT NULL REFERENCES poll_options ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id    BIGINT    NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (option_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS poll_votes_poll_id_user_id_idx ON poll_votes ("poll_id", "user_id");
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+DROP INDEX IF EXISTS messages_is_unfurl_pending_idx;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS unfurl_claimed_until;
+ALTER TABLE messages DROP COLUMN IF EXISTS is_unfurl_pending;
+ALTER TABLE messages DROP COLUMN IF EXISTS link_previews;
+
+DROP TABLE IF EXISTS link_previews;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+CREATE TABLE IF NOT EXISTS link_previews
+(
+    url_hash    BYTEA PRIMARY KEY,
+    url         TEXT      NOT NULL,
+    title       TEXT      NOT NULL DEFAULT '',
+    description TEXT      NOT NULL DEFAULT '',
+    image_url   TEXT      NOT NULL DEFAULT '',
+    site_name   TEXT      NOT NULL DEFAULT '',
+    is_found    BOOLEAN   NOT NULL DEFAULT FALSE,
+    fetched_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS link_previews JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS is_unfurl_pending BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS unfurl_claimed_until TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS messages_is_unfurl_pending_idx ON messages ("id") WHERE is_unfurl_pending;
\ No newline at end of file
@@ -0,0 +1,42 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+-- Users of the local auth mode, other modes keep them in an external service.
+CREATE TABLE IF NOT EXISTS users
+(
+    id            BIGSERIAL PRIMARY KEY,
+    email         VARCHAR   NOT NULL,
+    username      VARCHAR   NOT NULL,
+    first_name    VARCHAR   NOT NULL DEFAULT '',
+    last_name     VARCHAR   NOT NULL DEFAULT '',
+    about_me      TEXT      NOT NULL DEFAULT '',
+    image_url     VARCHAR   NOT NULL DEFAULT '',
+    password_hash VARCHAR   NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (LOWER("email"));
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_idx ON users (LOWER("username"));
+
+CREATE TABLE IF NOT EXISTS sessions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT    NOT NULL REFERENCES users ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+    token_hash VARCHAR   NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions ("user_id");
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+ALTER TABLE user_chats ADD COLUMN IF NOT EXISTS role SMALLINT NOT NULL DEFAULT 1;
+
+UPDATE user_chats AS uc
+SET role = 3
+FROM chats AS c
+WHERE c.id = uc.chat_id AND c.created_by = uc.user_id;
\ No newline at end of file
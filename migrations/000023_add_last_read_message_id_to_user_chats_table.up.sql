@@ -0,0 +1,27 @@
+-- Copyright 2025 MicroCore Tech
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+-- Read state is kept per member, the last message a member has read in the
+-- chat. Existing members start from the last read message of the chat.
+ALTER TABLE user_chats ADD COLUMN IF NOT EXISTS last_read_message_id BIGINT NOT NULL DEFAULT 0;
+
+UPDATE user_chats AS uc
+SET last_read_message_id = m.id
+FROM (
+    SELECT chat_id, MAX(id) AS id
+    FROM messages
+    WHERE status = 3
+    GROUP BY chat_id
+) AS m
+WHERE m.chat_id = uc.chat_id;
\ No newline at end of file
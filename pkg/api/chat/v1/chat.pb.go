@@ -0,0 +1,2407 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: chat/v1/chat.proto
+
+package chatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Image struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Base64        string                 `protobuf:"bytes,2,opt,name=base64,proto3" json:"base64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Image) ProtoMessage() {}
+
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Image) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Image) GetBase64() string {
+	if x != nil {
+		return x.Base64
+	}
+	return ""
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	FirstName     string                 `protobuf:"bytes,4,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	AboutMe       string                 `protobuf:"bytes,6,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
+	Image         *Image                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	IsBot         bool                   `protobuf:"varint,8,opt,name=is_bot,json=isBot,proto3" json:"is_bot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetAboutMe() string {
+	if x != nil {
+		return x.AboutMe
+	}
+	return ""
+}
+
+func (x *User) GetImage() *Image {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *User) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChatId        uint64                 `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Member) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Member) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *Member) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ChatSettings struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MutedUntil        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
+	IsArchived        bool                   `protobuf:"varint,2,opt,name=is_archived,json=isArchived,proto3" json:"is_archived,omitempty"`
+	PinOrder          *uint32                `protobuf:"varint,3,opt,name=pin_order,json=pinOrder,proto3,oneof" json:"pin_order,omitempty"`
+	NotificationLevel uint32                 `protobuf:"varint,4,opt,name=notification_level,json=notificationLevel,proto3" json:"notification_level,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChatSettings) Reset() {
+	*x = ChatSettings{}
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSettings) ProtoMessage() {}
+
+func (x *ChatSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSettings.ProtoReflect.Descriptor instead.
+func (*ChatSettings) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChatSettings) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
+}
+
+func (x *ChatSettings) GetIsArchived() bool {
+	if x != nil {
+		return x.IsArchived
+	}
+	return false
+}
+
+func (x *ChatSettings) GetPinOrder() uint32 {
+	if x != nil && x.PinOrder != nil {
+		return *x.PinOrder
+	}
+	return 0
+}
+
+func (x *ChatSettings) GetNotificationLevel() uint32 {
+	if x != nil {
+		return x.NotificationLevel
+	}
+	return 0
+}
+
+// RetentionPolicy falls back to the global policy for unset fields, zero
+// disables a rule.
+type RetentionPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          *uint32                `protobuf:"varint,1,opt,name=days,proto3,oneof" json:"days,omitempty"`
+	MaxMessages   *uint32                `protobuf:"varint,2,opt,name=max_messages,json=maxMessages,proto3,oneof" json:"max_messages,omitempty"`
+	LegalHold     bool                   `protobuf:"varint,3,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RetentionPolicy) GetDays() uint32 {
+	if x != nil && x.Days != nil {
+		return *x.Days
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetMaxMessages() uint32 {
+	if x != nil && x.MaxMessages != nil {
+		return *x.MaxMessages
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetLegalHold() bool {
+	if x != nil {
+		return x.LegalHold
+	}
+	return false
+}
+
+// MessageExpiry is the default timer of new messages, in seconds.
+type MessageExpiry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresIn     *uint32                `protobuf:"varint,1,opt,name=expires_in,json=expiresIn,proto3,oneof" json:"expires_in,omitempty"`
+	ExpireFrom    uint32                 `protobuf:"varint,2,opt,name=expire_from,json=expireFrom,proto3" json:"expire_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageExpiry) Reset() {
+	*x = MessageExpiry{}
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageExpiry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageExpiry) ProtoMessage() {}
+
+func (x *MessageExpiry) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageExpiry.ProtoReflect.Descriptor instead.
+func (*MessageExpiry) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MessageExpiry) GetExpiresIn() uint32 {
+	if x != nil && x.ExpiresIn != nil {
+		return *x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *MessageExpiry) GetExpireFrom() uint32 {
+	if x != nil {
+		return x.ExpireFrom
+	}
+	return 0
+}
+
+// Chat types and other numeric enums use the values of the REST API.
+type Chat struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type        uint32                 `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Image       *Image                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	LastMessage *Message               `protobuf:"bytes,5,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	CreatedBy   uint64                 `protobuf:"varint,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Creator     *User                  `protobuf:"bytes,7,opt,name=creator,proto3" json:"creator,omitempty"`
+	Members     []*Member              `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+	// settings are the preferences of the caller, unset when the caller isn't
+	// a member.
+	Settings      *ChatSettings          `protobuf:"bytes,9,opt,name=settings,proto3" json:"settings,omitempty"`
+	Retention     *RetentionPolicy       `protobuf:"bytes,10,opt,name=retention,proto3" json:"retention,omitempty"`
+	Expiry        *MessageExpiry         `protobuf:"bytes,11,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Chat) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Chat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chat) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Chat) GetImage() *Image {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *Chat) GetLastMessage() *Message {
+	if x != nil {
+		return x.LastMessage
+	}
+	return nil
+}
+
+func (x *Chat) GetCreatedBy() uint64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Chat) GetCreator() *User {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *Chat) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Chat) GetSettings() *ChatSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *Chat) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Chat) GetExpiry() *MessageExpiry {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *Chat) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Chat) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type MessageEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          uint32                 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int32                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	UserId        *uint64                `protobuf:"varint,4,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageEntity) Reset() {
+	*x = MessageEntity{}
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEntity) ProtoMessage() {}
+
+func (x *MessageEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEntity.ProtoReflect.Descriptor instead.
+func (*MessageEntity) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MessageEntity) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetUserId() uint64 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *MessageEntity) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type MessageForward struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     uint64                 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChatId        uint64                 `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	CreatedBy     uint64                 `protobuf:"varint,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageForward) Reset() {
+	*x = MessageForward{}
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageForward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageForward) ProtoMessage() {}
+
+func (x *MessageForward) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageForward.ProtoReflect.Descriptor instead.
+func (*MessageForward) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MessageForward) GetMessageId() uint64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessageForward) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *MessageForward) GetCreatedBy() uint64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+type LinkPreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	SiteName      string                 `protobuf:"bytes,5,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreview) ProtoMessage() {}
+
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LinkPreview) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetSiteName() string {
+	if x != nil {
+		return x.SiteName
+	}
+	return ""
+}
+
+type Message struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text       string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Status     uint32                 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	ChatId     uint64                 `protobuf:"varint,4,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Entities   []*MessageEntity       `protobuf:"bytes,5,rep,name=entities,proto3" json:"entities,omitempty"`
+	CreatedBy  uint64                 `protobuf:"varint,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	IsBot      bool                   `protobuf:"varint,7,opt,name=is_bot,json=isBot,proto3" json:"is_bot,omitempty"`
+	Creator    *User                  `protobuf:"bytes,8,opt,name=creator,proto3" json:"creator,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpiresIn  *uint32                `protobuf:"varint,11,opt,name=expires_in,json=expiresIn,proto3,oneof" json:"expires_in,omitempty"`
+	ExpireFrom uint32                 `protobuf:"varint,12,opt,name=expire_from,json=expireFrom,proto3" json:"expire_from,omitempty"`
+	IsViewOnce bool                   `protobuf:"varint,13,opt,name=is_view_once,json=isViewOnce,proto3" json:"is_view_once,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// scheduled_message_id and send_at are set for a message held back for
+	// the undo-send window.
+	ScheduledMessageId uint64                 `protobuf:"varint,15,opt,name=scheduled_message_id,json=scheduledMessageId,proto3" json:"scheduled_message_id,omitempty"`
+	SendAt             *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	ForwardedFrom      *MessageForward        `protobuf:"bytes,17,opt,name=forwarded_from,json=forwardedFrom,proto3" json:"forwarded_from,omitempty"`
+	Kind               uint32                 `protobuf:"varint,18,opt,name=kind,proto3" json:"kind,omitempty"`
+	LinkPreviews       []*LinkPreview         `protobuf:"bytes,19,rep,name=link_previews,json=linkPreviews,proto3" json:"link_previews,omitempty"`
+	// is_ephemeral marks a command reply meant for the caller only, it has no
+	// id and isn't stored.
+	IsEphemeral   bool `protobuf:"varint,20,opt,name=is_ephemeral,json=isEphemeral,proto3" json:"is_ephemeral,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Message) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Message) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *Message) GetEntities() []*MessageEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *Message) GetCreatedBy() uint64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Message) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
+func (x *Message) GetCreator() *User {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Message) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Message) GetExpiresIn() uint32 {
+	if x != nil && x.ExpiresIn != nil {
+		return *x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *Message) GetExpireFrom() uint32 {
+	if x != nil {
+		return x.ExpireFrom
+	}
+	return 0
+}
+
+func (x *Message) GetIsViewOnce() bool {
+	if x != nil {
+		return x.IsViewOnce
+	}
+	return false
+}
+
+func (x *Message) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Message) GetScheduledMessageId() uint64 {
+	if x != nil {
+		return x.ScheduledMessageId
+	}
+	return 0
+}
+
+func (x *Message) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+func (x *Message) GetForwardedFrom() *MessageForward {
+	if x != nil {
+		return x.ForwardedFrom
+	}
+	return nil
+}
+
+func (x *Message) GetKind() uint32 {
+	if x != nil {
+		return x.Kind
+	}
+	return 0
+}
+
+func (x *Message) GetLinkPreviews() []*LinkPreview {
+	if x != nil {
+		return x.LinkPreviews
+	}
+	return nil
+}
+
+func (x *Message) GetIsEphemeral() bool {
+	if x != nil {
+		return x.IsEphemeral
+	}
+	return false
+}
+
+type GetChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatRequest) Reset() {
+	*x = GetChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatRequest) ProtoMessage() {}
+
+func (x *GetChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatRequest.ProtoReflect.Descriptor instead.
+func (*GetChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetChatRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListChatsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Ids          []uint64               `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Types        []uint32               `protobuf:"varint,2,rep,packed,name=types,proto3" json:"types,omitempty"`
+	CreatedByIds []uint64               `protobuf:"varint,3,rep,packed,name=created_by_ids,json=createdByIds,proto3" json:"created_by_ids,omitempty"`
+	Search       string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	IsArchived   *bool                  `protobuf:"varint,5,opt,name=is_archived,json=isArchived,proto3,oneof" json:"is_archived,omitempty"`
+	IsPinned     *bool                  `protobuf:"varint,6,opt,name=is_pinned,json=isPinned,proto3,oneof" json:"is_pinned,omitempty"`
+	IsMuted      *bool                  `protobuf:"varint,7,opt,name=is_muted,json=isMuted,proto3,oneof" json:"is_muted,omitempty"`
+	PinnedFirst  bool                   `protobuf:"varint,8,opt,name=pinned_first,json=pinnedFirst,proto3" json:"pinned_first,omitempty"`
+	FolderId     *uint64                `protobuf:"varint,9,opt,name=folder_id,json=folderId,proto3,oneof" json:"folder_id,omitempty"`
+	Limit        *uint64                `protobuf:"varint,10,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset       *uint64                `protobuf:"varint,11,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	// sort is "<field>,<asc|desc>" as in the REST API.
+	Sort          string `protobuf:"bytes,12,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChatsRequest) Reset() {
+	*x = ListChatsRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatsRequest) ProtoMessage() {}
+
+func (x *ListChatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatsRequest.ProtoReflect.Descriptor instead.
+func (*ListChatsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListChatsRequest) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListChatsRequest) GetTypes() []uint32 {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListChatsRequest) GetCreatedByIds() []uint64 {
+	if x != nil {
+		return x.CreatedByIds
+	}
+	return nil
+}
+
+func (x *ListChatsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListChatsRequest) GetIsArchived() bool {
+	if x != nil && x.IsArchived != nil {
+		return *x.IsArchived
+	}
+	return false
+}
+
+func (x *ListChatsRequest) GetIsPinned() bool {
+	if x != nil && x.IsPinned != nil {
+		return *x.IsPinned
+	}
+	return false
+}
+
+func (x *ListChatsRequest) GetIsMuted() bool {
+	if x != nil && x.IsMuted != nil {
+		return *x.IsMuted
+	}
+	return false
+}
+
+func (x *ListChatsRequest) GetPinnedFirst() bool {
+	if x != nil {
+		return x.PinnedFirst
+	}
+	return false
+}
+
+func (x *ListChatsRequest) GetFolderId() uint64 {
+	if x != nil && x.FolderId != nil {
+		return *x.FolderId
+	}
+	return 0
+}
+
+func (x *ListChatsRequest) GetLimit() uint64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListChatsRequest) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListChatsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListChatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChatsResponse) Reset() {
+	*x = ListChatsResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatsResponse) ProtoMessage() {}
+
+func (x *ListChatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatsResponse.ProtoReflect.Descriptor instead.
+func (*ListChatsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListChatsResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *ListChatsResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CreateChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          uint32                 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Image         *Image                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	UserIds       []uint64               `protobuf:"varint,4,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatRequest) Reset() {
+	*x = CreateChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatRequest) ProtoMessage() {}
+
+func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
+func (*CreateChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChatRequest) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *CreateChatRequest) GetImage() *Image {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *CreateChatRequest) GetUserIds() []uint64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type UpdateChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Image         *Image                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChatRequest) Reset() {
+	*x = UpdateChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChatRequest) ProtoMessage() {}
+
+func (x *UpdateChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChatRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateChatRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateChatRequest) GetImage() *Image {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type DeleteChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChatRequest) Reset() {
+	*x = DeleteChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChatRequest) ProtoMessage() {}
+
+func (x *DeleteChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChatRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteChatRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChatResponse) Reset() {
+	*x = DeleteChatResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChatResponse) ProtoMessage() {}
+
+func (x *DeleteChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChatResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{17}
+}
+
+type AddChatMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddChatMemberRequest) Reset() {
+	*x = AddChatMemberRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddChatMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChatMemberRequest) ProtoMessage() {}
+
+func (x *AddChatMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChatMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddChatMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddChatMemberRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *AddChatMemberRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UpdateChatSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Settings      *ChatSettings          `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChatSettingsRequest) Reset() {
+	*x = UpdateChatSettingsRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChatSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChatSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateChatSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChatSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChatSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateChatSettingsRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *UpdateChatSettingsRequest) GetSettings() *ChatSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateChatRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Retention     *RetentionPolicy       `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChatRetentionRequest) Reset() {
+	*x = UpdateChatRetentionRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChatRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChatRetentionRequest) ProtoMessage() {}
+
+func (x *UpdateChatRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChatRetentionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChatRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateChatRetentionRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *UpdateChatRetentionRequest) GetRetention() *RetentionPolicy {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type UpdateChatExpiryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Expiry        *MessageExpiry         `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChatExpiryRequest) Reset() {
+	*x = UpdateChatExpiryRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChatExpiryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChatExpiryRequest) ProtoMessage() {}
+
+func (x *UpdateChatExpiryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChatExpiryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChatExpiryRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateChatExpiryRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *UpdateChatExpiryRequest) GetExpiry() *MessageExpiry {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatIds       []uint64               `protobuf:"varint,1,rep,packed,name=chat_ids,json=chatIds,proto3" json:"chat_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StreamEventsRequest) GetChatIds() []uint64 {
+	if x != nil {
+		return x.ChatIds
+	}
+	return nil
+}
+
+// Event is an event of the websocket API, data is its JSON payload.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          uint64                 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_chat_v1_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Event) GetType() uint64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Event) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Ids           []uint64               `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Statuses      []uint32               `protobuf:"varint,3,rep,packed,name=statuses,proto3" json:"statuses,omitempty"`
+	Search        string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	Limit         *uint64                `protobuf:"varint,5,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset        *uint64                `protobuf:"varint,6,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Sort          string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListMessagesRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListMessagesRequest) GetStatuses() []uint32 {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListMessagesRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetLimit() uint64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesResponse) ProtoMessage() {}
+
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListMessagesResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CreateMessageRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ChatId     uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Text       string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	ExpiresIn  *uint32                `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3,oneof" json:"expires_in,omitempty"`
+	ExpireFrom uint32                 `protobuf:"varint,4,opt,name=expire_from,json=expireFrom,proto3" json:"expire_from,omitempty"`
+	IsViewOnce bool                   `protobuf:"varint,5,opt,name=is_view_once,json=isViewOnce,proto3" json:"is_view_once,omitempty"`
+	// undo_send_delay overrides the configured undo-send window in seconds.
+	UndoSendDelay *uint32 `protobuf:"varint,6,opt,name=undo_send_delay,json=undoSendDelay,proto3,oneof" json:"undo_send_delay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMessageRequest) Reset() {
+	*x = CreateMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMessageRequest) ProtoMessage() {}
+
+func (x *CreateMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMessageRequest.ProtoReflect.Descriptor instead.
+func (*CreateMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateMessageRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *CreateMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateMessageRequest) GetExpiresIn() uint32 {
+	if x != nil && x.ExpiresIn != nil {
+		return *x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *CreateMessageRequest) GetExpireFrom() uint32 {
+	if x != nil {
+		return x.ExpireFrom
+	}
+	return 0
+}
+
+func (x *CreateMessageRequest) GetIsViewOnce() bool {
+	if x != nil {
+		return x.IsViewOnce
+	}
+	return false
+}
+
+func (x *CreateMessageRequest) GetUndoSendDelay() uint32 {
+	if x != nil && x.UndoSendDelay != nil {
+		return *x.UndoSendDelay
+	}
+	return 0
+}
+
+type ForwardMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        uint64                 `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageIds    []uint64               `protobuf:"varint,2,rep,packed,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	ToChatIds     []uint64               `protobuf:"varint,3,rep,packed,name=to_chat_ids,json=toChatIds,proto3" json:"to_chat_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardMessagesRequest) Reset() {
+	*x = ForwardMessagesRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardMessagesRequest) ProtoMessage() {}
+
+func (x *ForwardMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ForwardMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ForwardMessagesRequest) GetChatId() uint64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *ForwardMessagesRequest) GetMessageIds() []uint64 {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *ForwardMessagesRequest) GetToChatIds() []uint64 {
+	if x != nil {
+		return x.ToChatIds
+	}
+	return nil
+}
+
+type ForwardMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardMessagesResponse) Reset() {
+	*x = ForwardMessagesResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardMessagesResponse) ProtoMessage() {}
+
+func (x *ForwardMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ForwardMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ForwardMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+var File_chat_v1_chat_proto protoreflect.FileDescriptor
+
+const file_chat_v1_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x12chat/v1/chat.proto\x12\achat.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"1\n" +
+	"\x05Image\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06base64\x18\x02 \x01(\tR\x06base64\"\xdc\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x04 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x19\n" +
+	"\babout_me\x18\x06 \x01(\tR\aaboutMe\x12$\n" +
+	"\x05image\x18\a \x01(\v2\x0e.chat.v1.ImageR\x05image\x12\x15\n" +
+	"\x06is_bot\x18\b \x01(\bR\x05isBot\"]\n" +
+	"\x06Member\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x04R\x06chatId\x12!\n" +
+	"\x04user\x18\x03 \x01(\v2\r.chat.v1.UserR\x04user\"\xcb\x01\n" +
+	"\fChatSettings\x12;\n" +
+	"\vmuted_until\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil\x12\x1f\n" +
+	"\vis_archived\x18\x02 \x01(\bR\n" +
+	"isArchived\x12 \n" +
+	"\tpin_order\x18\x03 \x01(\rH\x00R\bpinOrder\x88\x01\x01\x12-\n" +
+	"\x12notification_level\x18\x04 \x01(\rR\x11notificationLevelB\f\n" +
+	"\n" +
+	"_pin_order\"\x8b\x01\n" +
+	"\x0fRetentionPolicy\x12\x17\n" +
+	"\x04days\x18\x01 \x01(\rH\x00R\x04days\x88\x01\x01\x12&\n" +
+	"\fmax_messages\x18\x02 \x01(\rH\x01R\vmaxMessages\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"legal_hold\x18\x03 \x01(\bR\tlegalHoldB\a\n" +
+	"\x05_daysB\x0f\n" +
+	"\r_max_messages\"c\n" +
+	"\rMessageExpiry\x12\"\n" +
+	"\n" +
+	"expires_in\x18\x01 \x01(\rH\x00R\texpiresIn\x88\x01\x01\x12\x1f\n" +
+	"\vexpire_from\x18\x02 \x01(\rR\n" +
+	"expireFromB\r\n" +
+	"\v_expires_in\"\x9d\x04\n" +
+	"\x04Chat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\rR\x04type\x12$\n" +
+	"\x05image\x18\x04 \x01(\v2\x0e.chat.v1.ImageR\x05image\x123\n" +
+	"\flast_message\x18\x05 \x01(\v2\x10.chat.v1.MessageR\vlastMessage\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\x04R\tcreatedBy\x12'\n" +
+	"\acreator\x18\a \x01(\v2\r.chat.v1.UserR\acreator\x12)\n" +
+	"\amembers\x18\b \x03(\v2\x0f.chat.v1.MemberR\amembers\x121\n" +
+	"\bsettings\x18\t \x01(\v2\x15.chat.v1.ChatSettingsR\bsettings\x126\n" +
+	"\tretention\x18\n" +
+	" \x01(\v2\x18.chat.v1.RetentionPolicyR\tretention\x12.\n" +
+	"\x06expiry\x18\v \x01(\v2\x16.chat.v1.MessageExpiryR\x06expiry\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x99\x01\n" +
+	"\rMessageEntity\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\rR\x04type\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x05R\x06length\x12\x1c\n" +
+	"\auser_id\x18\x04 \x01(\x04H\x00R\x06userId\x88\x01\x01\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguageB\n" +
+	"\n" +
+	"\b_user_id\"g\n" +
+	"\x0eMessageForward\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x04R\tmessageId\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x04R\x06chatId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\x04R\tcreatedBy\"\x91\x01\n" +
+	"\vLinkPreview\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x1b\n" +
+	"\tsite_name\x18\x05 \x01(\tR\bsiteName\"\xb1\x06\n" +
+	"\aMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\rR\x06status\x12\x17\n" +
+	"\achat_id\x18\x04 \x01(\x04R\x06chatId\x122\n" +
+	"\bentities\x18\x05 \x03(\v2\x16.chat.v1.MessageEntityR\bentities\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\x04R\tcreatedBy\x12\x15\n" +
+	"\x06is_bot\x18\a \x01(\bR\x05isBot\x12'\n" +
+	"\acreator\x18\b \x01(\v2\r.chat.v1.UserR\acreator\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\"\n" +
+	"\n" +
+	"expires_in\x18\v \x01(\rH\x00R\texpiresIn\x88\x01\x01\x12\x1f\n" +
+	"\vexpire_from\x18\f \x01(\rR\n" +
+	"expireFrom\x12 \n" +
+	"\fis_view_once\x18\r \x01(\bR\n" +
+	"isViewOnce\x129\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x120\n" +
+	"\x14scheduled_message_id\x18\x0f \x01(\x04R\x12scheduledMessageId\x123\n" +
+	"\asend_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\x12>\n" +
+	"\x0eforwarded_from\x18\x11 \x01(\v2\x17.chat.v1.MessageForwardR\rforwardedFrom\x12\x12\n" +
+	"\x04kind\x18\x12 \x01(\rR\x04kind\x129\n" +
+	"\rlink_previews\x18\x13 \x03(\v2\x14.chat.v1.LinkPreviewR\flinkPreviews\x12!\n" +
+	"\fis_ephemeral\x18\x14 \x01(\bR\visEphemeralB\r\n" +
+	"\v_expires_in\" \n" +
+	"\x0eGetChatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\xbf\x03\n" +
+	"\x10ListChatsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x04R\x03ids\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\rR\x05types\x12$\n" +
+	"\x0ecreated_by_ids\x18\x03 \x03(\x04R\fcreatedByIds\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12$\n" +
+	"\vis_archived\x18\x05 \x01(\bH\x00R\n" +
+	"isArchived\x88\x01\x01\x12 \n" +
+	"\tis_pinned\x18\x06 \x01(\bH\x01R\bisPinned\x88\x01\x01\x12\x1e\n" +
+	"\bis_muted\x18\a \x01(\bH\x02R\aisMuted\x88\x01\x01\x12!\n" +
+	"\fpinned_first\x18\b \x01(\bR\vpinnedFirst\x12 \n" +
+	"\tfolder_id\x18\t \x01(\x04H\x03R\bfolderId\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x04H\x04R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\v \x01(\x04H\x05R\x06offset\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\f \x01(\tR\x04sortB\x0e\n" +
+	"\f_is_archivedB\f\n" +
+	"\n" +
+	"_is_pinnedB\v\n" +
+	"\t_is_mutedB\f\n" +
+	"\n" +
+	"_folder_idB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offset\"N\n" +
+	"\x11ListChatsResponse\x12#\n" +
+	"\x05chats\x18\x01 \x03(\v2\r.chat.v1.ChatR\x05chats\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"|\n" +
+	"\x11CreateChatRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\rR\x04type\x12$\n" +
+	"\x05image\x18\x03 \x01(\v2\x0e.chat.v1.ImageR\x05image\x12\x19\n" +
+	"\buser_ids\x18\x04 \x03(\x04R\auserIds\"]\n" +
+	"\x11UpdateChatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12$\n" +
+	"\x05image\x18\x03 \x01(\v2\x0e.chat.v1.ImageR\x05image\"#\n" +
+	"\x11DeleteChatRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x14\n" +
+	"\x12DeleteChatResponse\"H\n" +
+	"\x14AddChatMemberRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\"g\n" +
+	"\x19UpdateChatSettingsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x121\n" +
+	"\bsettings\x18\x02 \x01(\v2\x15.chat.v1.ChatSettingsR\bsettings\"m\n" +
+	"\x1aUpdateChatRetentionRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x126\n" +
+	"\tretention\x18\x02 \x01(\v2\x18.chat.v1.RetentionPolicyR\tretention\"b\n" +
+	"\x17UpdateChatExpiryRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x12.\n" +
+	"\x06expiry\x18\x02 \x01(\v2\x16.chat.v1.MessageExpiryR\x06expiry\"0\n" +
+	"\x13StreamEventsRequest\x12\x19\n" +
+	"\bchat_ids\x18\x01 \x03(\x04R\achatIds\"/\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x04R\x04type\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\xd5\x01\n" +
+	"\x13ListMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x04R\x03ids\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\rR\bstatuses\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12\x19\n" +
+	"\x05limit\x18\x05 \x01(\x04H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x06 \x01(\x04H\x01R\x06offset\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sortB\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_offset\"Z\n" +
+	"\x14ListMessagesResponse\x12,\n" +
+	"\bmessages\x18\x01 \x03(\v2\x10.chat.v1.MessageR\bmessages\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xfa\x01\n" +
+	"\x14CreateMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\"\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\rH\x00R\texpiresIn\x88\x01\x01\x12\x1f\n" +
+	"\vexpire_from\x18\x04 \x01(\rR\n" +
+	"expireFrom\x12 \n" +
+	"\fis_view_once\x18\x05 \x01(\bR\n" +
+	"isViewOnce\x12+\n" +
+	"\x0fundo_send_delay\x18\x06 \x01(\rH\x01R\rundoSendDelay\x88\x01\x01B\r\n" +
+	"\v_expires_inB\x12\n" +
+	"\x10_undo_send_delay\"r\n" +
+	"\x16ForwardMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x04R\x06chatId\x12\x1f\n" +
+	"\vmessage_ids\x18\x02 \x03(\x04R\n" +
+	"messageIds\x12\x1e\n" +
+	"\vto_chat_ids\x18\x03 \x03(\x04R\ttoChatIds\"G\n" +
+	"\x17ForwardMessagesResponse\x12,\n" +
+	"\bmessages\x18\x01 \x03(\v2\x10.chat.v1.MessageR\bmessages2\x95\x05\n" +
+	"\vChatService\x121\n" +
+	"\aGetChat\x12\x17.chat.v1.GetChatRequest\x1a\r.chat.v1.Chat\x12B\n" +
+	"\tListChats\x12\x19.chat.v1.ListChatsRequest\x1a\x1a.chat.v1.ListChatsResponse\x127\n" +
+	"\n" +
+	"CreateChat\x12\x1a.chat.v1.CreateChatRequest\x1a\r.chat.v1.Chat\x127\n" +
+	"\n" +
+	"UpdateChat\x12\x1a.chat.v1.UpdateChatRequest\x1a\r.chat.v1.Chat\x12E\n" +
+	"\n" +
+	"DeleteChat\x12\x1a.chat.v1.DeleteChatRequest\x1a\x1b.chat.v1.DeleteChatResponse\x12=\n" +
+	"\rAddChatMember\x12\x1d.chat.v1.AddChatMemberRequest\x1a\r.chat.v1.Chat\x12G\n" +
+	"\x12UpdateChatSettings\x12\".chat.v1.UpdateChatSettingsRequest\x1a\r.chat.v1.Chat\x12I\n" +
+	"\x13UpdateChatRetention\x12#.chat.v1.UpdateChatRetentionRequest\x1a\r.chat.v1.Chat\x12C\n" +
+	"\x10UpdateChatExpiry\x12 .chat.v1.UpdateChatExpiryRequest\x1a\r.chat.v1.Chat\x12>\n" +
+	"\fStreamEvents\x12\x1c.chat.v1.StreamEventsRequest\x1a\x0e.chat.v1.Event0\x012\xf5\x01\n" +
+	"\x0eMessageService\x12K\n" +
+	"\fListMessages\x12\x1c.chat.v1.ListMessagesRequest\x1a\x1d.chat.v1.ListMessagesResponse\x12@\n" +
+	"\rCreateMessage\x12\x1d.chat.v1.CreateMessageRequest\x1a\x10.chat.v1.Message\x12T\n" +
+	"\x0fForwardMessages\x12\x1f.chat.v1.ForwardMessagesRequest\x1a .chat.v1.ForwardMessagesResponseB9Z7github.com/microcoretech/chat-go/pkg/api/chat/v1;chatv1b\x06proto3"
+
+var (
+	file_chat_v1_chat_proto_rawDescOnce sync.Once
+	file_chat_v1_chat_proto_rawDescData []byte
+)
+
+func file_chat_v1_chat_proto_rawDescGZIP() []byte {
+	file_chat_v1_chat_proto_rawDescOnce.Do(func() {
+		file_chat_v1_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)))
+	})
+	return file_chat_v1_chat_proto_rawDescData
+}
+
+var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_chat_v1_chat_proto_goTypes = []any{
+	(*Image)(nil),                      // 0: chat.v1.Image
+	(*User)(nil),                       // 1: chat.v1.User
+	(*Member)(nil),                     // 2: chat.v1.Member
+	(*ChatSettings)(nil),               // 3: chat.v1.ChatSettings
+	(*RetentionPolicy)(nil),            // 4: chat.v1.RetentionPolicy
+	(*MessageExpiry)(nil),              // 5: chat.v1.MessageExpiry
+	(*Chat)(nil),                       // 6: chat.v1.Chat
+	(*MessageEntity)(nil),              // 7: chat.v1.MessageEntity
+	(*MessageForward)(nil),             // 8: chat.v1.MessageForward
+	(*LinkPreview)(nil),                // 9: chat.v1.LinkPreview
+	(*Message)(nil),                    // 10: chat.v1.Message
+	(*GetChatRequest)(nil),             // 11: chat.v1.GetChatRequest
+	(*ListChatsRequest)(nil),           // 12: chat.v1.ListChatsRequest
+	(*ListChatsResponse)(nil),          // 13: chat.v1.ListChatsResponse
+	(*CreateChatRequest)(nil),          // 14: chat.v1.CreateChatRequest
+	(*UpdateChatRequest)(nil),          // 15: chat.v1.UpdateChatRequest
+	(*DeleteChatRequest)(nil),          // 16: chat.v1.DeleteChatRequest
+	(*DeleteChatResponse)(nil),         // 17: chat.v1.DeleteChatResponse
+	(*AddChatMemberRequest)(nil),       // 18: chat.v1.AddChatMemberRequest
+	(*UpdateChatSettingsRequest)(nil),  // 19: chat.v1.UpdateChatSettingsRequest
+	(*UpdateChatRetentionRequest)(nil), // 20: chat.v1.UpdateChatRetentionRequest
+	(*UpdateChatExpiryRequest)(nil),    // 21: chat.v1.UpdateChatExpiryRequest
+	(*StreamEventsRequest)(nil),        // 22: chat.v1.StreamEventsRequest
+	(*Event)(nil),                      // 23: chat.v1.Event
+	(*ListMessagesRequest)(nil),        // 24: chat.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),       // 25: chat.v1.ListMessagesResponse
+	(*CreateMessageRequest)(nil),       // 26: chat.v1.CreateMessageRequest
+	(*ForwardMessagesRequest)(nil),     // 27: chat.v1.ForwardMessagesRequest
+	(*ForwardMessagesResponse)(nil),    // 28: chat.v1.ForwardMessagesResponse
+	(*timestamppb.Timestamp)(nil),      // 29: google.protobuf.Timestamp
+}
+var file_chat_v1_chat_proto_depIdxs = []int32{
+	0,  // 0: chat.v1.User.image:type_name -> chat.v1.Image
+	1,  // 1: chat.v1.Member.user:type_name -> chat.v1.User
+	29, // 2: chat.v1.ChatSettings.muted_until:type_name -> google.protobuf.Timestamp
+	0,  // 3: chat.v1.Chat.image:type_name -> chat.v1.Image
+	10, // 4: chat.v1.Chat.last_message:type_name -> chat.v1.Message
+	1,  // 5: chat.v1.Chat.creator:type_name -> chat.v1.User
+	2,  // 6: chat.v1.Chat.members:type_name -> chat.v1.Member
+	3,  // 7: chat.v1.Chat.settings:type_name -> chat.v1.ChatSettings
+	4,  // 8: chat.v1.Chat.retention:type_name -> chat.v1.RetentionPolicy
+	5,  // 9: chat.v1.Chat.expiry:type_name -> chat.v1.MessageExpiry
+	29, // 10: chat.v1.Chat.created_at:type_name -> google.protobuf.Timestamp
+	29, // 11: chat.v1.Chat.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 12: chat.v1.Message.entities:type_name -> chat.v1.MessageEntity
+	1,  // 13: chat.v1.Message.creator:type_name -> chat.v1.User
+	29, // 14: chat.v1.Message.created_at:type_name -> google.protobuf.Timestamp
+	29, // 15: chat.v1.Message.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 16: chat.v1.Message.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 17: chat.v1.Message.send_at:type_name -> google.protobuf.Timestamp
+	8,  // 18: chat.v1.Message.forwarded_from:type_name -> chat.v1.MessageForward
+	9,  // 19: chat.v1.Message.link_previews:type_name -> chat.v1.LinkPreview
+	6,  // 20: chat.v1.ListChatsResponse.chats:type_name -> chat.v1.Chat
+	0,  // 21: chat.v1.CreateChatRequest.image:type_name -> chat.v1.Image
+	0,  // 22: chat.v1.UpdateChatRequest.image:type_name -> chat.v1.Image
+	3,  // 23: chat.v1.UpdateChatSettingsRequest.settings:type_name -> chat.v1.ChatSettings
+	4,  // 24: chat.v1.UpdateChatRetentionRequest.retention:type_name -> chat.v1.RetentionPolicy
+	5,  // 25: chat.v1.UpdateChatExpiryRequest.expiry:type_name -> chat.v1.MessageExpiry
+	10, // 26: chat.v1.ListMessagesResponse.messages:type_name -> chat.v1.Message
+	10, // 27: chat.v1.ForwardMessagesResponse.messages:type_name -> chat.v1.Message
+	11, // 28: chat.v1.ChatService.GetChat:input_type -> chat.v1.GetChatRequest
+	12, // 29: chat.v1.ChatService.ListChats:input_type -> chat.v1.ListChatsRequest
+	14, // 30: chat.v1.ChatService.CreateChat:input_type -> chat.v1.CreateChatRequest
+	15, // 31: chat.v1.ChatService.UpdateChat:input_type -> chat.v1.UpdateChatRequest
+	16, // 32: chat.v1.ChatService.DeleteChat:input_type -> chat.v1.DeleteChatRequest
+	18, // 33: chat.v1.ChatService.AddChatMember:input_type -> chat.v1.AddChatMemberRequest
+	19, // 34: chat.v1.ChatService.UpdateChatSettings:input_type -> chat.v1.UpdateChatSettingsRequest
+	20, // 35: chat.v1.ChatService.UpdateChatRetention:input_type -> chat.v1.UpdateChatRetentionRequest
+	21, // 36: chat.v1.ChatService.UpdateChatExpiry:input_type -> chat.v1.UpdateChatExpiryRequest
+	22, // 37: chat.v1.ChatService.StreamEvents:input_type -> chat.v1.StreamEventsRequest
+	24, // 38: chat.v1.MessageService.ListMessages:input_type -> chat.v1.ListMessagesRequest
+	26, // 39: chat.v1.MessageService.CreateMessage:input_type -> chat.v1.CreateMessageRequest
+	27, // 40: chat.v1.MessageService.ForwardMessages:input_type -> chat.v1.ForwardMessagesRequest
+	6,  // 41: chat.v1.ChatService.GetChat:output_type -> chat.v1.Chat
+	13, // 42: chat.v1.ChatService.ListChats:output_type -> chat.v1.ListChatsResponse
+	6,  // 43: chat.v1.ChatService.CreateChat:output_type -> chat.v1.Chat
+	6,  // 44: chat.v1.ChatService.UpdateChat:output_type -> chat.v1.Chat
+	17, // 45: chat.v1.ChatService.DeleteChat:output_type -> chat.v1.DeleteChatResponse
+	6,  // 46: chat.v1.ChatService.AddChatMember:output_type -> chat.v1.Chat
+	6,  // 47: chat.v1.ChatService.UpdateChatSettings:output_type -> chat.v1.Chat
+	6,  // 48: chat.v1.ChatService.UpdateChatRetention:output_type -> chat.v1.Chat
+	6,  // 49: chat.v1.ChatService.UpdateChatExpiry:output_type -> chat.v1.Chat
+	23, // 50: chat.v1.ChatService.StreamEvents:output_type -> chat.v1.Event
+	25, // 51: chat.v1.MessageService.ListMessages:output_type -> chat.v1.ListMessagesResponse
+	10, // 52: chat.v1.MessageService.CreateMessage:output_type -> chat.v1.Message
+	28, // 53: chat.v1.MessageService.ForwardMessages:output_type -> chat.v1.ForwardMessagesResponse
+	41, // [41:54] is the sub-list for method output_type
+	28, // [28:41] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_chat_v1_chat_proto_init() }
+func file_chat_v1_chat_proto_init() {
+	if File_chat_v1_chat_proto != nil {
+		return
+	}
+	file_chat_v1_chat_proto_msgTypes[3].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[4].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[5].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[7].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[10].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[12].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[24].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[26].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_chat_v1_chat_proto_goTypes,
+		DependencyIndexes: file_chat_v1_chat_proto_depIdxs,
+		MessageInfos:      file_chat_v1_chat_proto_msgTypes,
+	}.Build()
+	File_chat_v1_chat_proto = out.File
+	file_chat_v1_chat_proto_goTypes = nil
+	file_chat_v1_chat_proto_depIdxs = nil
+}
@@ -0,0 +1,115 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatapi
+
+import (
+	"time"
+)
+
+type CreateChatDto struct {
+	Name      string        `json:"name" validate:"lte=255"`
+	Type      uint8         `json:"type" validate:"required,oneof=1 2"`
+	Image     Image         `json:"image"`
+	UserChats []UserChatDto `json:"users" validate:"dive,gte=0"`
+}
+
+type UpdateChatDto struct {
+	Name  string `json:"name" validate:"lte=255"`
+	Image Image  `json:"image"`
+}
+
+type UpdateChatSettingsDto struct {
+	MutedUntil        *time.Time `json:"mutedUntil"`
+	IsArchived        bool       `json:"isArchived"`
+	PinOrder          *uint      `json:"pinOrder"`
+	NotificationLevel uint8      `json:"notificationLevel" validate:"omitempty,oneof=1 2 3"`
+}
+
+type ChatSettingsDto struct {
+	MutedUntil        *time.Time `json:"mutedUntil"`
+	IsArchived        bool       `json:"isArchived"`
+	PinOrder          *uint      `json:"pinOrder"`
+	NotificationLevel uint8      `json:"notificationLevel"`
+}
+
+type RetentionDto struct {
+	// Days and MaxMessages fall back to the global policy when null and
+	// disable the rule when zero.
+	Days        *uint `json:"days" validate:"omitempty,lte=36500"`
+	MaxMessages *uint `json:"maxMessages"`
+	LegalHold   bool  `json:"legalHold"`
+}
+
+type MessageExpiryDto struct {
+	// ExpiresIn is in seconds, null keeps new messages forever.
+	ExpiresIn  *uint `json:"expiresIn" validate:"omitempty,lte=31536000"`
+	ExpireFrom uint8 `json:"expireFrom" validate:"omitempty,oneof=1 2"`
+}
+
+type ChatDto struct {
+	ID          uint64        `json:"id"`
+	Name        string        `json:"name"`
+	Type        uint8         `json:"type"`
+	Image       Image         `json:"image"`
+	LastMessage *MessageDto   `json:"lastMessage"`
+	CreatedBy   uint64        `json:"createdBy"`
+	Creator     *UserDto      `json:"creator"`
+	UserChats   []UserChatDto `json:"userChats"`
+	// Settings are the preferences of the current user, null when the user
+	// isn't a member.
+	Settings  *ChatSettingsDto `json:"settings"`
+	Retention RetentionDto     `json:"retention"`
+	Expiry    MessageExpiryDto `json:"expiry"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type AddChatMemberDto struct {
+	UserID uint64 `json:"userId" validate:"required"`
+}
+
+// UpdateChatMemberDto sets the role of a member, 1 is a member and 2 an
+// admin. Ownership can't be handed over.
+type UpdateChatMemberDto struct {
+	Role uint8 `json:"role" validate:"required,oneof=1 2"`
+}
+
+type UserChatDto struct {
+	UserID uint64 `json:"userId"`
+	ChatID uint64 `json:"chatId"`
+	Role   uint8  `json:"role"`
+
+	User *UserDto `json:"user"`
+}
+
+type ChatQuery struct {
+	IDs          []uint64 `query:"id" validate:"omitempty,dive,gte=0"`
+	Types        []uint8  `query:"types" validate:"omitempty,dive,oneof=1 2"`
+	CreatedByIDs []uint64 `query:"createdByIds" validate:"omitempty,dive,gte=0"`
+
+	Search string `query:"search"`
+
+	IsArchived  *bool `query:"isArchived"`
+	IsPinned    *bool `query:"isPinned"`
+	IsMuted     *bool `query:"isMuted"`
+	PinnedFirst bool  `query:"pinnedFirst"`
+
+	FolderID *uint64 `query:"folderId"`
+
+	Limit  *uint64 `query:"limit"`
+	Offset *uint64 `query:"offset"`
+
+	Sort string `query:"sort"`
+}
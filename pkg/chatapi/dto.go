@@ -0,0 +1,37 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chatapi holds the DTOs the chat service exchanges with its
+// clients over the REST API and the websocket. The service and the Go
+// client share them, the package only depends on the standard library.
+package chatapi
+
+type Page[T any] struct {
+	Items []T    `json:"items"`
+	Count uint64 `json:"count"`
+}
+
+type Image struct {
+	URL    string `json:"url,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+}
+
+// ErrorData is the body of error responses, e.g. ErrorType "not_found".
+// DevDetails are only set in development.
+type ErrorData struct {
+	Domain     string         `json:"domain"`
+	ErrorType  string         `json:"type"`
+	Data       map[string]any `json:"data"`
+	DevDetails []string       `json:"devDetails"`
+}
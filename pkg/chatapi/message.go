@@ -0,0 +1,103 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatapi
+
+import (
+	"time"
+)
+
+type CreateMessageDto struct {
+	Text string `json:"text" validate:"required"`
+
+	ExpiresIn  *uint `json:"expiresIn" validate:"omitempty,lte=31536000"`
+	ExpireFrom uint8 `json:"expireFrom" validate:"omitempty,oneof=1 2"`
+	IsViewOnce bool  `json:"isViewOnce"`
+
+	// UndoSendDelay overrides the configured undo-send window in seconds.
+	UndoSendDelay *uint `json:"undoSendDelay" validate:"omitempty,lte=30"`
+}
+
+type MessageEntityDto struct {
+	Type   uint8   `json:"type"`
+	Offset int     `json:"offset"`
+	Length int     `json:"length"`
+	UserID *uint64 `json:"userId,omitempty"`
+
+	Language string `json:"language,omitempty"`
+}
+
+type LinkPreviewDto struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+	SiteName    string `json:"siteName,omitempty"`
+}
+
+type MessageForwardDto struct {
+	MessageID uint64 `json:"messageId"`
+	ChatID    uint64 `json:"chatId"`
+	CreatedBy uint64 `json:"createdBy"`
+}
+
+type MessageDto struct {
+	ID        uint64             `json:"id"`
+	Text      string             `json:"text"`
+	Status    uint8              `json:"status"`
+	ChatID    uint64             `json:"chatId"`
+	Entities  []MessageEntityDto `json:"entities"`
+	CreatedBy uint64             `json:"createdBy"`
+	IsBot     bool               `json:"isBot"`
+	Creator   *UserDto           `json:"creator"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+
+	IsEphemeral bool `json:"isEphemeral,omitempty"`
+
+	ExpiresIn  *uint      `json:"expiresIn,omitempty"`
+	ExpireFrom uint8      `json:"expireFrom,omitempty"`
+	IsViewOnce bool       `json:"isViewOnce,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+
+	ScheduledMessageID uint64     `json:"scheduledMessageId,omitempty"`
+	SendAt             *time.Time `json:"sendAt,omitempty"`
+
+	ForwardedFrom *MessageForwardDto `json:"forwardedFrom,omitempty"`
+
+	Kind uint8    `json:"kind"`
+	Poll *PollDto `json:"poll,omitempty"`
+
+	LinkPreviews []LinkPreviewDto `json:"linkPreviews,omitempty"`
+}
+
+// ForwardMessagesDto forwards at most 100 copies, messages times chats.
+type ForwardMessagesDto struct {
+	MessageIDs []uint64 `json:"messageIds" validate:"required,min=1,max=100,dive,gt=0"`
+	ChatIDs    []uint64 `json:"chatIds" validate:"required,min=1,max=10,dive,gt=0"`
+}
+
+type MessageQuery struct {
+	IDs          []uint64 `query:"id" validate:"omitempty,gte=0"`
+	ChatIDs      []uint64 `query:"chatId" validate:"omitempty,gte=0"`
+	Statuses     []uint8  `query:"statuses" validate:"omitempty,oneof=1 2 3"`
+	CreatedByIDs []uint64 `query:"id" validate:"omitempty,gte=0"`
+
+	Search string `query:"search"`
+
+	Limit  *uint64 `query:"limit"`
+	Offset *uint64 `query:"offset"`
+
+	Sort string `query:"sort"`
+}
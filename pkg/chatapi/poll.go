@@ -0,0 +1,44 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatapi
+
+import (
+	"time"
+)
+
+type PollOptionDto struct {
+	ID        uint64   `json:"id"`
+	Text      string   `json:"text"`
+	VoteCount uint64   `json:"voteCount"`
+	VoterIDs  []uint64 `json:"voterIds,omitempty"`
+}
+
+type PollDto struct {
+	ID               uint64          `json:"id"`
+	MessageID        uint64          `json:"messageId"`
+	ChatID           uint64          `json:"chatId"`
+	Question         string          `json:"question"`
+	Options          []PollOptionDto `json:"options"`
+	IsMultipleChoice bool            `json:"isMultipleChoice"`
+	IsAnonymous      bool            `json:"isAnonymous"`
+	IsClosed         bool            `json:"isClosed"`
+	ClosesAt         *time.Time      `json:"closesAt,omitempty"`
+	ClosedAt         *time.Time      `json:"closedAt,omitempty"`
+	VoterCount       uint64          `json:"voterCount"`
+	VotedOptionIDs   []uint64        `json:"votedOptionIds"`
+	CreatedBy        uint64          `json:"createdBy"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+}
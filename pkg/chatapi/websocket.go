@@ -0,0 +1,195 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a frame of the websocket. Requests of a client may carry a
+// RequestID, the service echoes it on the reply and on the ErrorEvent of a
+// request it failed to handle. A request without a reply of its own is
+// acknowledged with an event of its type and no data.
+type Event struct {
+	Type      uint64          `json:"type"`
+	RequestID string          `json:"requestId,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+const (
+	SubscribeChatsEventType       = 1
+	UnsubscribeChatsEventType     = 2
+	SetCurrentChatEventType       = 3
+	UnsetCurrentChatEventType     = 4
+	CreateMessageEventType        = 5
+	EditMessageEventType          = 6
+	DeleteMessageEventType        = 7
+	UpdateMessagesStatusEventType = 8
+	MentionEventType              = 9
+	ChatCreatedEventType          = 10
+	ChatUpdatedEventType          = 11
+	ChatDeletedEventType          = 12
+	MemberAddedEventType          = 13
+	CommandReplyEventType         = 14
+	MessageHeldEventType          = 15
+	VotePollEventType             = 16
+	RetractPollVoteEventType      = 17
+	PollUpdatedEventType          = 18
+	AuthenticateEventType         = 19
+	AuthenticatedEventType        = 20
+	RefreshTokenEventType         = 21
+	TokenRefreshedEventType       = 22
+	ErrorEventType                = 23
+)
+
+// Subprotocol is negotiated by clients passing their token as a
+// subprotocol, see BearerSubprotocolPrefix and BotSubprotocolPrefix.
+const (
+	Subprotocol             = "chat-go"
+	BearerSubprotocolPrefix = "bearer."
+	BotSubprotocolPrefix    = "bot."
+)
+
+// Close codes sent to websocket clients in the 4000-4999 range reserved
+// for applications, they mirror the matching HTTP statuses.
+const (
+	// CloseAuthenticationFailed is sent when the credentials of a
+	// connection are missing or invalid.
+	CloseAuthenticationFailed = 4401
+	// CloseAuthenticationTimeout is sent when a client doesn't authenticate
+	// in time after the upgrade.
+	CloseAuthenticationTimeout = 4408
+	// CloseCredentialsExpired is sent when the token of an open connection
+	// expires or is revoked.
+	CloseCredentialsExpired = 4410
+)
+
+// MessageEventDto is a message sent over the websocket, it carries the UUID
+// the client created it with.
+type MessageEventDto struct {
+	UUID      string             `json:"uuid"`
+	ID        uint64             `json:"id"`
+	Text      string             `json:"text"`
+	Status    uint8              `json:"status"`
+	ChatID    uint64             `json:"chatId"`
+	Entities  []MessageEntityDto `json:"entities"`
+	Creator   *UserDto           `json:"creator"`
+	CreatedBy uint64             `json:"createdBy"`
+	IsBot     bool               `json:"isBot"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+
+	IsEphemeral bool `json:"isEphemeral,omitempty"`
+
+	ExpiresIn  *uint      `json:"expiresIn,omitempty" validate:"omitempty,lte=31536000"`
+	ExpireFrom uint8      `json:"expireFrom,omitempty"`
+	IsViewOnce bool       `json:"isViewOnce,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+
+	// UndoSendDelay is only read on create, it overrides the configured
+	// undo-send window in seconds.
+	UndoSendDelay      *uint      `json:"undoSendDelay,omitempty" validate:"omitempty,lte=30"`
+	ScheduledMessageID uint64     `json:"scheduledMessageId,omitempty"`
+	SendAt             *time.Time `json:"sendAt,omitempty"`
+
+	ForwardedFrom *MessageForwardDto `json:"forwardedFrom,omitempty"`
+
+	Kind uint8         `json:"kind,omitempty"`
+	Poll *PollEventDto `json:"poll,omitempty"`
+
+	LinkPreviews []LinkPreviewDto `json:"linkPreviews,omitempty"`
+}
+
+type ChatEventDto struct {
+	ID        uint64             `json:"id"`
+	Name      string             `json:"name"`
+	Type      uint8              `json:"type"`
+	Image     Image              `json:"image"`
+	CreatedBy uint64             `json:"createdBy"`
+	UserChats []UserChatEventDto `json:"userChats"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+type UserChatEventDto struct {
+	UserID uint64 `json:"userId"`
+	ChatID uint64 `json:"chatId"`
+	Role   uint8  `json:"role"`
+}
+
+type PollEventDto struct {
+	ID               uint64          `json:"id"`
+	MessageID        uint64          `json:"messageId"`
+	ChatID           uint64          `json:"chatId"`
+	Question         string          `json:"question"`
+	Options          []PollOptionDto `json:"options"`
+	IsMultipleChoice bool            `json:"isMultipleChoice"`
+	IsAnonymous      bool            `json:"isAnonymous"`
+	IsClosed         bool            `json:"isClosed"`
+	ClosesAt         *time.Time      `json:"closesAt,omitempty"`
+	ClosedAt         *time.Time      `json:"closedAt,omitempty"`
+	VoterCount       uint64          `json:"voterCount"`
+	CreatedBy        uint64          `json:"createdBy"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+
+	// VotedOptionIDs are only sent to the voter in reply to a vote.
+	VotedOptionIDs []uint64 `json:"votedOptionIds,omitempty"`
+}
+
+type MessagesStatusDto struct {
+	Status     uint8    `json:"status" validate:"required,oneof=2 3"`
+	MessageIDs []uint64 `json:"messageIds" validate:"required,gte=0"`
+}
+
+type AuthenticateEventData struct {
+	Token string `json:"token" validate:"required"`
+	IsBot bool   `json:"isBot"`
+}
+
+type AuthenticatedEventData struct {
+	UserID uint64 `json:"userId"`
+}
+
+type RefreshTokenEventData struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type EditMessageEventData struct {
+	MessageID uint64 `json:"messageId" validate:"required"`
+	Text      string `json:"text" validate:"required"`
+}
+
+type VotePollEventData struct {
+	PollID    uint64   `json:"pollId" validate:"required"`
+	OptionIDs []uint64 `json:"optionIds" validate:"required,min=1,unique,dive,gt=0"`
+}
+
+type RetractPollVoteEventData struct {
+	PollID uint64 `json:"pollId" validate:"required"`
+}
+
+type DeleteMessageEventData struct {
+	MessageID uint64 `json:"messageId" validate:"required"`
+	ChatID    uint64 `json:"chatId"`
+}
+
+// ErrorEventData is sent in reply to a request the service failed to
+// handle, with the status and error data the REST API would respond with.
+type ErrorEventData struct {
+	Status int `json:"status"`
+	ErrorData
+}
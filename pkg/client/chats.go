@@ -0,0 +1,93 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+func (c *Client) GetChats(ctx context.Context, query ChatQuery) (*Page[ChatDto], error) {
+	var page Page[ChatDto]
+	if err := c.do(ctx, http.MethodGet, "/chats", queryValues(query), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func (c *Client) GetChat(ctx context.Context, id uint64) (*ChatDto, error) {
+	var chat ChatDto
+	if err := c.do(ctx, http.MethodGet, idPath("/chats/%s", id), nil, nil, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (c *Client) CreateChat(ctx context.Context, dto CreateChatDto) (*ChatDto, error) {
+	var chat ChatDto
+	if err := c.do(ctx, http.MethodPost, "/chats", nil, dto, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (c *Client) UpdateChat(ctx context.Context, id uint64, dto UpdateChatDto) (*ChatDto, error) {
+	var chat ChatDto
+	if err := c.do(ctx, http.MethodPut, idPath("/chats/%s", id), nil, dto, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+// UpdateChatSettings updates the preferences of the current user in a chat.
+func (c *Client) UpdateChatSettings(ctx context.Context, id uint64, dto UpdateChatSettingsDto) (*ChatDto, error) {
+	var chat ChatDto
+	if err := c.do(ctx, http.MethodPut, idPath("/chats/%s/settings", id), nil, dto, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (c *Client) AddChatMember(ctx context.Context, id uint64, userID uint64) (*ChatDto, error) {
+	var chat ChatDto
+	dto := chatapi.AddChatMemberDto{UserID: userID}
+	if err := c.do(ctx, http.MethodPost, idPath("/chats/%s/members", id), nil, dto, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+// UpdateChatMemberRole sets the role of a member, see UpdateChatMemberDto.
+func (c *Client) UpdateChatMemberRole(ctx context.Context, id uint64, userID uint64, role uint8) (*ChatDto, error) {
+	var chat ChatDto
+	dto := chatapi.UpdateChatMemberDto{Role: role}
+	if err := c.do(ctx, http.MethodPut, idPath("/chats/%s/members/%s", id, userID), nil, dto, &chat); err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (c *Client) DeleteChat(ctx context.Context, id uint64) error {
+	return c.do(ctx, http.MethodDelete, idPath("/chats/%s", id), nil, nil, nil)
+}
@@ -0,0 +1,239 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client is the Go client of the chat service. Client calls the REST
+// API, Session keeps a websocket connection open to receive events and send
+// messages. The request and response types are the DTOs of the service,
+// shared with it by the chatapi package.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	maxBodySize    = 10 << 20
+)
+
+type Config struct {
+	// BaseURL is the address of the service, e.g. http://localhost:8080.
+	BaseURL string
+	// Token authenticates a user, BotToken a bot, only one of them is used
+	// with Token taking precedence.
+	Token    string
+	BotToken string
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	credentials
+}
+
+// Error is returned for responses with an error status and for error events
+// of the websocket, it carries the error data of the service, e.g. Type
+// "not_found".
+type Error struct {
+	StatusCode int
+	ErrorData
+}
+
+func (e *Error) Error() string {
+	if e.ErrorType == "" {
+		return fmt.Sprintf("chat service responded with status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("chat service responded with status %d: %s %s", e.StatusCode, e.Domain, e.ErrorType)
+}
+
+func newError(statusCode int, body []byte) *Error {
+	err := &Error{StatusCode: statusCode}
+	_ = json.Unmarshal(body, &err.ErrorData)
+
+	return err
+}
+
+func newEventError(data []byte) *Error {
+	var eventData chatapi.ErrorEventData
+	_ = json.Unmarshal(data, &eventData)
+
+	return &Error{StatusCode: eventData.Status, ErrorData: eventData.ErrorData}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	reqURL := c.baseURL.JoinPath(path)
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization := c.authorization(); authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp.StatusCode, data)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// credentials are shared by the client and its sessions, a session
+// replaces the token once it's refreshed.
+type credentials struct {
+	token    string
+	botToken string
+}
+
+func (c credentials) authorization() string {
+	switch {
+	case c.token != "":
+		return "Bearer " + c.token
+	case c.botToken != "":
+		return "Bot " + c.botToken
+	}
+
+	return ""
+}
+
+// queryValues encodes a query DTO by its query tags, nil pointers and zero
+// values are left out.
+func queryValues(query any) url.Values {
+	values := url.Values{}
+
+	v := reflect.Indirect(reflect.ValueOf(query))
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		} else if field.IsZero() {
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			for j := range field.Len() {
+				values.Add(name, queryValue(field.Index(j)))
+			}
+			continue
+		}
+
+		values.Add(name, queryValue(field))
+	}
+
+	return values
+}
+
+func queryValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func idPath(format string, ids ...uint64) string {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = strconv.FormatUint(id, 10)
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+func New(cfg Config) (*Client, error) {
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, errors.New("base url must be an http or https url")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		credentials: credentials{
+			token:    cfg.Token,
+			botToken: cfg.BotToken,
+		},
+	}, nil
+}
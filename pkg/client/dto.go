@@ -0,0 +1,51 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+type (
+	Page[T any] = chatapi.Page[T]
+	UserDto     = chatapi.UserDto
+	Image       = chatapi.Image
+	ErrorData   = chatapi.ErrorData
+)
+
+type (
+	ChatDto               = chatapi.ChatDto
+	ChatQuery             = chatapi.ChatQuery
+	CreateChatDto         = chatapi.CreateChatDto
+	UpdateChatDto         = chatapi.UpdateChatDto
+	UpdateChatSettingsDto = chatapi.UpdateChatSettingsDto
+	UserChatDto           = chatapi.UserChatDto
+	MessageDto            = chatapi.MessageDto
+	MessageQuery          = chatapi.MessageQuery
+	CreateMessageDto      = chatapi.CreateMessageDto
+	MessageEntityDto      = chatapi.MessageEntityDto
+	UserQuery             = chatapi.UserQuery
+)
+
+// The websocket sends its own DTOs, e.g. messages carry the UUID the client
+// created them with.
+type (
+	MessageEventDto        = chatapi.MessageEventDto
+	ChatEventDto           = chatapi.ChatEventDto
+	UserChatEventDto       = chatapi.UserChatEventDto
+	PollEventDto           = chatapi.PollEventDto
+	MessagesStatusDto      = chatapi.MessagesStatusDto
+	DeleteMessageEventData = chatapi.DeleteMessageEventData
+)
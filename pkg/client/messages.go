@@ -0,0 +1,63 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+func (c *Client) GetMessages(ctx context.Context, chatID uint64, query MessageQuery) (*Page[MessageDto], error) {
+	var page Page[MessageDto]
+	if err := c.do(ctx, http.MethodGet, idPath("/chats/%s/messages", chatID), queryValues(query), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// GetMentions returns the messages mentioning the current user, latest
+// first unless the query sorts them.
+func (c *Client) GetMentions(ctx context.Context, query MessageQuery) (*Page[MessageDto], error) {
+	var page Page[MessageDto]
+	if err := c.do(ctx, http.MethodGet, "/chats/mentions", queryValues(query), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func (c *Client) CreateMessage(ctx context.Context, chatID uint64, dto CreateMessageDto) (*MessageDto, error) {
+	var message MessageDto
+	if err := c.do(ctx, http.MethodPost, idPath("/chats/%s/messages", chatID), nil, dto, &message); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// ForwardMessages forwards messages of a chat to other chats and returns the
+// created copies.
+func (c *Client) ForwardMessages(ctx context.Context, chatID uint64, messageIDs, chatIDs []uint64) ([]MessageDto, error) {
+	var messages []MessageDto
+	dto := chatapi.ForwardMessagesDto{MessageIDs: messageIDs, ChatIDs: chatIDs}
+	if err := c.do(ctx, http.MethodPost, idPath("/chats/%s/messages/forward", chatID), nil, dto, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,592 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+
+	"github.com/microcoretech/chat-go/pkg/chatapi"
+)
+
+const (
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+	closeWriteTimeout        = time.Second
+)
+
+var (
+	ErrNotConnected = errors.New("session is not connected")
+	// ErrDisconnected is returned by requests waiting for their reply when
+	// the connection is lost, the request may or may not have been handled.
+	ErrDisconnected = errors.New("session disconnected before the reply arrived")
+	ErrClosed       = errors.New("session is closed")
+)
+
+// Handlers are called with the events of the session, one at a time in the
+// order they arrive. Nil handlers are skipped.
+type Handlers struct {
+	OnConnected func()
+	// OnDisconnected is called with the error the connection was lost with.
+	OnDisconnected func(err error)
+	// OnError is called with the errors of events sent without waiting for
+	// a reply, e.g. SubscribeChats with a chat the user isn't a member of.
+	OnError func(err *Error)
+
+	OnMessageCreated        func(message MessageEventDto)
+	OnMessageEdited         func(message MessageEventDto)
+	OnMessageDeleted        func(data DeleteMessageEventData)
+	OnMessagesStatusUpdated func(status MessagesStatusDto)
+	OnMention               func(message MessageEventDto)
+	// OnCommandReply is called with the ephemeral replies of bot commands,
+	// they are only sent to the user who ran the command.
+	OnCommandReply func(message MessageEventDto)
+	// OnMessageHeld is called for messages that are sent once the undo-send
+	// window is over.
+	OnMessageHeld func(message MessageEventDto)
+
+	OnChatCreated func(chat ChatEventDto)
+	OnChatUpdated func(chat ChatEventDto)
+	OnChatDeleted func(chat ChatEventDto)
+	OnMemberAdded func(userChat UserChatEventDto)
+
+	OnPollUpdated func(poll PollEventDto)
+}
+
+type SessionConfig struct {
+	Handlers
+
+	// ReconnectDelay is the delay before the first reconnect attempt, it
+	// doubles with every failed attempt up to MaxReconnectDelay. Defaults to
+	// 1 and 30 seconds.
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+}
+
+// Session is a websocket connection to /chats/ws that reconnects when it's
+// lost. The subscribed and current chats are restored after reconnecting.
+// It stops once the service rejects its credentials, see Done and Err.
+//
+// Requests carry a request ID and wait for the event the service replies
+// with, the service echoes the ID on the reply or on the error event of a
+// request it failed to handle. Replies are passed to the handlers as well.
+type Session struct {
+	client *Client
+	cfg    SessionConfig
+	dialer *websocket.Dialer
+
+	mtx             sync.Mutex
+	conn            *websocket.Conn
+	credentials     credentials
+	subscribedChats []uint64
+	currentChat     *uint64
+	pending         map[string]chan chatapi.Event
+
+	// writeMtx serializes writes, requests may be sent from any goroutine.
+	writeMtx sync.Mutex
+
+	closeChan chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+	err       error
+}
+
+// Connect opens a session, it returns an error if the first connection
+// attempt fails.
+func (c *Client) Connect(ctx context.Context, cfg SessionConfig) (*Session, error) {
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = defaultReconnectDelay
+	}
+	if cfg.MaxReconnectDelay < cfg.ReconnectDelay {
+		cfg.MaxReconnectDelay = max(defaultMaxReconnectDelay, cfg.ReconnectDelay)
+	}
+
+	if c.authorization() == "" {
+		return nil, errors.New("session needs a token or bot token")
+	}
+
+	s := &Session{
+		client:      c,
+		cfg:         cfg,
+		dialer:      &websocket.Dialer{Proxy: http.ProxyFromEnvironment, HandshakeTimeout: defaultTimeout},
+		credentials: c.credentials,
+		pending:     map[string]chan chatapi.Event{},
+		closeChan:   make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setConn(conn)
+
+	go s.run(conn)
+
+	return s, nil
+}
+
+// Done is closed once the session stopped, either by Close or because the
+// service rejected its credentials.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns why the session stopped, nil while it's running or after
+// Close.
+func (s *Session) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+func (s *Session) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closeChan)
+
+		s.mtx.Lock()
+		conn := s.conn
+		s.mtx.Unlock()
+
+		if conn != nil {
+			s.writeMtx.Lock()
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(closeWriteTimeout))
+			s.writeMtx.Unlock()
+			_ = conn.Close()
+		}
+	})
+
+	<-s.done
+
+	return nil
+}
+
+// SubscribeChats replaces the chats the session receives events of besides
+// the current chat.
+func (s *Session) SubscribeChats(chatIDs []uint64) error {
+	s.mtx.Lock()
+	s.subscribedChats = slices.Clone(chatIDs)
+	s.mtx.Unlock()
+
+	return s.send("", chatapi.SubscribeChatsEventType, chatIDs)
+}
+
+// SetCurrentChat sets the chat messages sent with SendMessage go to.
+func (s *Session) SetCurrentChat(chatID uint64) error {
+	s.mtx.Lock()
+	s.currentChat = &chatID
+	s.mtx.Unlock()
+
+	return s.send("", chatapi.SetCurrentChatEventType, chatID)
+}
+
+func (s *Session) UnsetCurrentChat() error {
+	s.mtx.Lock()
+	s.currentChat = nil
+	s.mtx.Unlock()
+
+	return s.send("", chatapi.UnsetCurrentChatEventType, nil)
+}
+
+// SendMessage sends a message to the current chat and returns it once it's
+// created. Depending on the message the reply is a created, held or command
+// reply message, a UUID is generated unless the message has one.
+func (s *Session) SendMessage(ctx context.Context, message MessageEventDto) (*MessageEventDto, error) {
+	if message.UUID == "" {
+		message.UUID = uuid.NewString()
+	}
+
+	var created MessageEventDto
+	if err := s.request(ctx, chatapi.CreateMessageEventType, message, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (s *Session) EditMessage(ctx context.Context, messageID uint64, text string) (*MessageEventDto, error) {
+	data := chatapi.EditMessageEventData{MessageID: messageID, Text: text}
+
+	var edited MessageEventDto
+	if err := s.request(ctx, chatapi.EditMessageEventType, data, &edited); err != nil {
+		return nil, err
+	}
+
+	return &edited, nil
+}
+
+func (s *Session) DeleteMessage(ctx context.Context, messageID uint64) error {
+	data := DeleteMessageEventData{MessageID: messageID}
+
+	return s.request(ctx, chatapi.DeleteMessageEventType, data, nil)
+}
+
+// UpdateMessagesStatus marks messages of the current chat as delivered or
+// read.
+func (s *Session) UpdateMessagesStatus(status uint8, messageIDs []uint64) error {
+	return s.send("", chatapi.UpdateMessagesStatusEventType, MessagesStatusDto{Status: status, MessageIDs: messageIDs})
+}
+
+func (s *Session) VotePoll(ctx context.Context, pollID uint64, optionIDs []uint64) (*PollEventDto, error) {
+	data := chatapi.VotePollEventData{PollID: pollID, OptionIDs: optionIDs}
+
+	var poll PollEventDto
+	if err := s.request(ctx, chatapi.VotePollEventType, data, &poll); err != nil {
+		return nil, err
+	}
+
+	return &poll, nil
+}
+
+func (s *Session) RetractPollVote(ctx context.Context, pollID uint64) (*PollEventDto, error) {
+	data := chatapi.RetractPollVoteEventData{PollID: pollID}
+
+	var poll PollEventDto
+	if err := s.request(ctx, chatapi.RetractPollVoteEventType, data, &poll); err != nil {
+		return nil, err
+	}
+
+	return &poll, nil
+}
+
+// RefreshToken replaces the token of the connection before it expires, the
+// new token is also used when reconnecting.
+func (s *Session) RefreshToken(ctx context.Context, token string) error {
+	data := chatapi.RefreshTokenEventData{Token: token}
+	if err := s.request(ctx, chatapi.RefreshTokenEventType, data, nil); err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	if s.credentials.token != "" {
+		s.credentials.token = token
+	} else {
+		s.credentials.botToken = token
+	}
+	s.mtx.Unlock()
+
+	return nil
+}
+
+// request sends an event with a new request ID and waits for the reply
+// carrying the ID.
+func (s *Session) request(ctx context.Context, eventType uint64, data, result any) error {
+	requestID := uuid.NewString()
+	reply := make(chan chatapi.Event, 1)
+
+	s.mtx.Lock()
+	s.pending[requestID] = reply
+	s.mtx.Unlock()
+
+	defer func() {
+		s.mtx.Lock()
+		delete(s.pending, requestID)
+		s.mtx.Unlock()
+	}()
+
+	if err := s.send(requestID, eventType, data); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.closeChan:
+		return ErrClosed
+	case event, ok := <-reply:
+		if !ok {
+			return ErrDisconnected
+		}
+		if event.Type == chatapi.ErrorEventType {
+			return newEventError(event.Data)
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(event.Data, result)
+	}
+}
+
+func (s *Session) send(requestID string, eventType uint64, data any) error {
+	event := chatapi.Event{Type: eventType, RequestID: requestID}
+
+	var err error
+	if event.Data, err = json.Marshal(data); err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	conn := s.conn
+	s.mtx.Unlock()
+
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	s.writeMtx.Lock()
+	defer s.writeMtx.Unlock()
+
+	return conn.WriteJSON(event)
+}
+
+func (s *Session) run(conn *websocket.Conn) {
+	defer close(s.done)
+
+	for {
+		err := s.read(conn)
+
+		s.setConn(nil)
+		s.failPending()
+
+		if s.isClosed() {
+			return
+		}
+
+		if s.cfg.OnDisconnected != nil {
+			s.cfg.OnDisconnected(err)
+		}
+
+		if isCredentialsError(err) {
+			s.err = err
+			return
+		}
+
+		if conn, err = s.reconnect(); conn == nil {
+			s.err = err
+			return
+		}
+	}
+}
+
+// reconnect dials until a connection is established, it gives up once the
+// session is closed or the credentials are rejected.
+func (s *Session) reconnect() (*websocket.Conn, error) {
+	delay := s.cfg.ReconnectDelay
+
+	for {
+		select {
+		case <-s.closeChan:
+			return nil, nil
+		case <-time.After(delay):
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-s.closeChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		conn, err := s.dial(ctx)
+		cancel()
+
+		if err == nil {
+			s.setConn(conn)
+			return conn, nil
+		}
+
+		if s.isClosed() {
+			return nil, nil
+		}
+
+		var respErr *Error
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusUnauthorized {
+			return nil, err
+		}
+
+		delay = min(delay*2, s.cfg.MaxReconnectDelay)
+	}
+}
+
+func (s *Session) dial(ctx context.Context) (*websocket.Conn, error) {
+	wsURL := *s.client.baseURL.JoinPath("/chats/ws")
+	if wsURL.Scheme == "https" {
+		wsURL.Scheme = "wss"
+	} else {
+		wsURL.Scheme = "ws"
+	}
+
+	s.mtx.Lock()
+	authorization := s.credentials.authorization()
+	s.mtx.Unlock()
+
+	header := http.Header{}
+	if authorization != "" {
+		header.Set("Authorization", authorization)
+	}
+
+	conn, resp, err := s.dialer.DialContext(ctx, wsURL.String(), header)
+	if err != nil {
+		if resp != nil {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+			return nil, newError(resp.StatusCode, body)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// setConn switches to a new connection and restores the chats of the
+// session on it.
+func (s *Session) setConn(conn *websocket.Conn) {
+	s.mtx.Lock()
+	s.conn = conn
+	subscribedChats := s.subscribedChats
+	currentChat := s.currentChat
+	s.mtx.Unlock()
+
+	if conn == nil {
+		return
+	}
+
+	if subscribedChats != nil {
+		_ = s.send("", chatapi.SubscribeChatsEventType, subscribedChats)
+	}
+	if currentChat != nil {
+		_ = s.send("", chatapi.SetCurrentChatEventType, *currentChat)
+	}
+
+	if s.cfg.OnConnected != nil {
+		s.cfg.OnConnected()
+	}
+}
+
+func (s *Session) read(conn *websocket.Conn) error {
+	defer conn.Close()
+
+	for {
+		var event chatapi.Event
+		if err := conn.ReadJSON(&event); err != nil {
+			return err
+		}
+
+		s.dispatch(event)
+	}
+}
+
+func (s *Session) dispatch(event chatapi.Event) {
+	if event.RequestID != "" && s.resolve(event) {
+		// Errors of requests are returned by the requests themselves.
+		if event.Type == chatapi.ErrorEventType {
+			return
+		}
+	}
+
+	switch event.Type {
+	case chatapi.CreateMessageEventType:
+		dispatch(event, s.cfg.OnMessageCreated)
+	case chatapi.CommandReplyEventType:
+		dispatch(event, s.cfg.OnCommandReply)
+	case chatapi.MessageHeldEventType:
+		dispatch(event, s.cfg.OnMessageHeld)
+	case chatapi.MentionEventType:
+		dispatch(event, s.cfg.OnMention)
+	case chatapi.EditMessageEventType:
+		dispatch(event, s.cfg.OnMessageEdited)
+	case chatapi.DeleteMessageEventType:
+		dispatch(event, s.cfg.OnMessageDeleted)
+	case chatapi.UpdateMessagesStatusEventType:
+		dispatch(event, s.cfg.OnMessagesStatusUpdated)
+	case chatapi.ChatCreatedEventType:
+		dispatch(event, s.cfg.OnChatCreated)
+	case chatapi.ChatUpdatedEventType:
+		dispatch(event, s.cfg.OnChatUpdated)
+	case chatapi.ChatDeletedEventType:
+		dispatch(event, s.cfg.OnChatDeleted)
+	case chatapi.MemberAddedEventType:
+		dispatch(event, s.cfg.OnMemberAdded)
+	case chatapi.PollUpdatedEventType:
+		dispatch(event, s.cfg.OnPollUpdated)
+	case chatapi.ErrorEventType:
+		if s.cfg.OnError != nil {
+			s.cfg.OnError(newEventError(event.Data))
+		}
+	}
+}
+
+// dispatch decodes the data of an event and calls the handler with it.
+func dispatch[T any](event chatapi.Event, handler func(T)) {
+	if handler == nil {
+		return
+	}
+
+	var data T
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return
+	}
+
+	handler(data)
+}
+
+// resolve passes a reply to the request waiting for it, it reports whether
+// a request was waiting.
+func (s *Session) resolve(event chatapi.Event) bool {
+	s.mtx.Lock()
+	reply, ok := s.pending[event.RequestID]
+	delete(s.pending, event.RequestID)
+	s.mtx.Unlock()
+
+	if ok {
+		reply <- event
+	}
+
+	return ok
+}
+
+func (s *Session) failPending() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for key, reply := range s.pending {
+		close(reply)
+		delete(s.pending, key)
+	}
+}
+
+func (s *Session) isClosed() bool {
+	select {
+	case <-s.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCredentialsError reports whether the service closed the connection
+// because of its credentials, reconnecting with them is pointless.
+func isCredentialsError(err error) bool {
+	return websocket.IsCloseError(err,
+		chatapi.CloseAuthenticationFailed,
+		chatapi.CloseAuthenticationTimeout,
+		chatapi.CloseCredentialsExpired,
+	)
+}
@@ -0,0 +1,47 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) GetCurrentUser(ctx context.Context) (*UserDto, error) {
+	var user UserDto
+	if err := c.do(ctx, http.MethodGet, "/users/current", nil, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (c *Client) GetUser(ctx context.Context, id uint64) (*UserDto, error) {
+	var user UserDto
+	if err := c.do(ctx, http.MethodGet, idPath("/users/%s", id), nil, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (c *Client) GetUsers(ctx context.Context, query UserQuery) (*Page[UserDto], error) {
+	var page Page[UserDto]
+	if err := c.do(ctx, http.MethodGet, "/users", queryValues(query), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
@@ -27,12 +27,12 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
-	chatdomain "chat-go/internal/chat/domain"
-	chathttp "chat-go/internal/chat/http"
-	"chat-go/internal/common/constants"
-	commonhttp "chat-go/internal/common/http"
-	"chat-go/internal/infrastructure/api"
-	"chat-go/test/helpers"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	"github.com/microcoretech/chat-go/internal/common/constants"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/test/helpers"
 )
 
 var _ = ginkgo.Describe("Chat", func() {
@@ -24,7 +24,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 
-	"chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/helpers"
 )
 
 var (
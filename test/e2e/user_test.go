@@ -23,8 +23,8 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
-	commonhttp "chat-go/internal/common/http"
-	"chat-go/test/helpers"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
+	"github.com/microcoretech/chat-go/test/helpers"
 )
 
 var _ = ginkgo.Describe("User", func() {
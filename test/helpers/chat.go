@@ -24,8 +24,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/onsi/gomega"
 
-	chathttp "chat-go/internal/chat/http"
-	commonhttp "chat-go/internal/common/http"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	commonhttp "github.com/microcoretech/chat-go/internal/common/http"
 )
 
 func GetChats(client HTTPClient, baseURL string, token string) commonhttp.Page[chathttp.ChatDto] {
@@ -145,3 +145,99 @@ func deleteChat(client HTTPClient, baseURL string, token string, id uint64, stat
 
 	gomega.ExpectWithOffset(2, resp.StatusCode).To(gomega.Equal(status))
 }
+
+func AddChatMember(client HTTPClient, baseURL string, token string, chatID uint64, userID uint64) *chathttp.ChatDto {
+	requestBody, err := json.Marshal(&chathttp.AddChatMemberDto{UserID: userID})
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/chats/%d/members", baseURL, chatID), bytes.NewBuffer(requestBody))
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+	defer resp.Body.Close()
+
+	gomega.ExpectWithOffset(1, resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	responseBody, err := io.ReadAll(resp.Body)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	var chat chathttp.ChatDto
+	gomega.ExpectWithOffset(1, json.Unmarshal(responseBody, &chat)).To(gomega.Succeed())
+
+	return &chat
+}
+
+func UpdateChatRetention(client HTTPClient, baseURL string, token string, chatID uint64, retention *chathttp.RetentionDto) *chathttp.ChatDto {
+	requestBody, err := json.Marshal(retention)
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/chats/%d/retention", baseURL, chatID), bytes.NewBuffer(requestBody))
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+	defer resp.Body.Close()
+
+	gomega.ExpectWithOffset(1, resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	responseBody, err := io.ReadAll(resp.Body)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	var chat chathttp.ChatDto
+	gomega.ExpectWithOffset(1, json.Unmarshal(responseBody, &chat)).To(gomega.Succeed())
+
+	return &chat
+}
+
+func CreateMessage(client HTTPClient, baseURL string, token string, chatID uint64, createMessageRequest *chathttp.CreateMessageDto) *chathttp.MessageDto {
+	requestBody, err := json.Marshal(createMessageRequest)
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/chats/%d/messages", baseURL, chatID), bytes.NewBuffer(requestBody))
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+	defer resp.Body.Close()
+
+	gomega.ExpectWithOffset(1, resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	responseBody, err := io.ReadAll(resp.Body)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	var message chathttp.MessageDto
+	gomega.ExpectWithOffset(1, json.Unmarshal(responseBody, &message)).To(gomega.Succeed())
+
+	return &message
+}
+
+func GetMentions(client HTTPClient, baseURL string, token string) commonhttp.Page[chathttp.MessageDto] {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/chats/mentions", baseURL), nil)
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := client.Do(req)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+	defer resp.Body.Close()
+
+	gomega.ExpectWithOffset(1, resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	responseBody, err := io.ReadAll(resp.Body)
+	gomega.ExpectWithOffset(1, err).ToNot(gomega.HaveOccurred())
+
+	var messages commonhttp.Page[chathttp.MessageDto]
+	gomega.ExpectWithOffset(1, json.Unmarshal(responseBody, &messages)).To(gomega.Succeed())
+
+	return messages
+}
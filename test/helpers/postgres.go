@@ -24,7 +24,7 @@ import (
 	testcontainerspostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 
-	"chat-go/internal/infrastructure/database/postgres"
+	"github.com/microcoretech/chat-go/internal/infrastructure/database/postgres"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
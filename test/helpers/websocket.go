@@ -0,0 +1,97 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/onsi/gomega"
+
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+)
+
+// BearerSubprotocols offers the token as a subprotocol next to chat-go.
+func BearerSubprotocols(token string) []string {
+	return []string{
+		chatwebsocket.Subprotocol,
+		chatwebsocket.BearerSubprotocolPrefix + base64.RawURLEncoding.EncodeToString([]byte(token)),
+	}
+}
+
+func DialWebsocket(url string, subprotocols []string) (*websocket.Conn, *http.Response, error) {
+	dialer := &websocket.Dialer{
+		HandshakeTimeout: Timeout,
+		Subprotocols:     subprotocols,
+	}
+
+	return dialer.Dial(url, nil)
+}
+
+func SendWebsocketEvent(conn *websocket.Conn, eventType uint64, data any) {
+	rawData, err := json.Marshal(data)
+	gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+	gomega.ExpectWithOffset(1, conn.WriteJSON(connector.Event{Type: eventType, Data: rawData})).To(gomega.Succeed())
+}
+
+// ReadWebsocketEvent reads the next event within timeout.
+func ReadWebsocketEvent(conn *websocket.Conn, timeout time.Duration) connector.Event {
+	gomega.ExpectWithOffset(1, conn.SetReadDeadline(time.Now().Add(timeout))).To(gomega.Succeed())
+
+	var event connector.Event
+	gomega.ExpectWithOffset(1, conn.ReadJSON(&event)).To(gomega.Succeed())
+
+	return event
+}
+
+// ReadWebsocketEventOfType reads events until one of the type comes within
+// timeout, events of earlier requests may still be delivered.
+func ReadWebsocketEventOfType(conn *websocket.Conn, timeout time.Duration, eventType uint64) connector.Event {
+	gomega.ExpectWithOffset(1, conn.SetReadDeadline(time.Now().Add(timeout))).To(gomega.Succeed())
+
+	for {
+		var event connector.Event
+		gomega.ExpectWithOffset(1, conn.ReadJSON(&event)).To(gomega.Succeed())
+
+		if event.Type == eventType {
+			return event
+		}
+	}
+}
+
+// ExpectWebsocketClose waits until the server closes the connection and
+// checks the close code.
+func ExpectWebsocketClose(conn *websocket.Conn, timeout time.Duration, code int) {
+	gomega.ExpectWithOffset(1, conn.SetReadDeadline(time.Now().Add(timeout))).To(gomega.Succeed())
+
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+
+		var closeErr *websocket.CloseError
+		gomega.ExpectWithOffset(1, errors.As(err, &closeErr)).To(gomega.BeTrue(), "unexpected error: %v", err)
+		gomega.ExpectWithOffset(1, closeErr.Code).To(gomega.Equal(code))
+
+		return
+	}
+}
@@ -21,10 +21,10 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
-	chatdomain "chat-go/internal/chat/domain"
-	chathttp "chat-go/internal/chat/http"
-	"chat-go/test/helpers"
-	"chat-go/test/integration/framework"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	"github.com/microcoretech/chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/integration/framework"
 )
 
 var _ = ginkgo.Describe("Chat", ginkgo.Ordered, ginkgo.ContinueOnFailure, func() {
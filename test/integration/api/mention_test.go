@@ -0,0 +1,118 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/samber/lo"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/integration/framework"
+)
+
+var _ = ginkgo.Describe("Mentions", ginkgo.Ordered, ginkgo.ContinueOnFailure, func() {
+	var (
+		httpClient helpers.HTTPClient
+		chat       *chathttp.ChatDto
+	)
+
+	ginkgo.BeforeAll(func() {
+		httpClient = framework.NewTestHTTPClient(fwk).WithTimeout(helpers.Timeout)
+
+		chat = helpers.CreateChat(httpClient, "", helpers.AdminToken, &chathttp.CreateChatDto{
+			Name: "Mentions",
+			Type: uint8(chatdomain.GroupChatType),
+		})
+		helpers.AddChatMember(httpClient, "", helpers.AdminToken, chat.ID, helpers.UserID)
+	})
+
+	ginkgo.AfterAll(func() {
+		helpers.DeleteChat(httpClient, "", helpers.AdminToken, chat.ID)
+	})
+
+	ginkgo.Context("create message endpoint", func() {
+		ginkgo.It("should store mentions of members as entities", func() {
+			message := helpers.CreateMessage(httpClient, "", helpers.AdminToken, chat.ID, &chathttp.CreateMessageDto{
+				Text: "@user and @all, not @nobody or `@user`",
+			})
+
+			gomega.Expect(message.Entities).To(gomega.ConsistOf(
+				chathttp.MessageEntityDto{
+					Type:   uint8(chatdomain.MentionMessageEntityType),
+					Offset: 0,
+					Length: 5,
+					UserID: lo.ToPtr(uint64(helpers.UserID)),
+				},
+				chathttp.MessageEntityDto{
+					Type:   uint8(chatdomain.MentionAllMessageEntityType),
+					Offset: 10,
+					Length: 4,
+				},
+				chathttp.MessageEntityDto{
+					Type:   uint8(chatdomain.CodeMessageEntityType),
+					Offset: 31,
+					Length: 5,
+				},
+			))
+		})
+	})
+
+	ginkgo.Context("mentions endpoint", func() {
+		ginkgo.It("should return the messages mentioning the user", func() {
+			message := helpers.CreateMessage(httpClient, "", helpers.AdminToken, chat.ID, &chathttp.CreateMessageDto{
+				Text: "ping @user",
+			})
+
+			mentions := helpers.GetMentions(httpClient, "", helpers.UserToken)
+			gomega.Expect(mentions.Items).NotTo(gomega.BeEmpty())
+			gomega.Expect(mentions.Items[0].ID).To(gomega.Equal(message.ID))
+		})
+
+		ginkgo.It("shouldn't return the mentions of the author", func() {
+			helpers.CreateMessage(httpClient, "", helpers.UserToken, chat.ID, &chathttp.CreateMessageDto{
+				Text: "@user @admin",
+			})
+
+			mentions := helpers.GetMentions(httpClient, "", helpers.UserToken)
+			gomega.Expect(mentions.Items).NotTo(gomega.BeEmpty())
+			gomega.Expect(mentions.Items[0].CreatedBy).To(gomega.Equal(uint64(helpers.AdminID)))
+		})
+	})
+
+	ginkgo.Context("mention event", func() {
+		ginkgo.It("should reach the user without a subscription to the chat", func() {
+			conn, _, err := helpers.DialWebsocket(fwk.WebsocketURL("/chats/ws"), helpers.BearerSubprotocols(helpers.UserToken))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			message := helpers.CreateMessage(httpClient, "", helpers.AdminToken, chat.ID, &chathttp.CreateMessageDto{
+				Text: "hey @user",
+			})
+
+			event := helpers.ReadWebsocketEventOfType(conn, helpers.Timeout, chatwebsocket.MentionEventType)
+
+			var mentioned chatwebsocket.MessageDto
+			gomega.Expect(json.Unmarshal(event.Data, &mentioned)).To(gomega.Succeed())
+			gomega.Expect(mentioned.ID).To(gomega.Equal(message.ID))
+			gomega.Expect(mentioned.ChatID).To(gomega.Equal(chat.ID))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/samber/lo"
+
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	"github.com/microcoretech/chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/integration/framework"
+)
+
+var _ = ginkgo.Describe("Retention", ginkgo.Ordered, ginkgo.ContinueOnFailure, func() {
+	var (
+		httpClient helpers.HTTPClient
+		chats      []*chathttp.ChatDto
+	)
+
+	// createChatWithMessages creates a chat with the retention and returns
+	// the IDs of its messages, oldest first.
+	createChatWithMessages := func(retention *chathttp.RetentionDto, count int) (*chathttp.ChatDto, []uint64) {
+		chat := helpers.CreateChat(httpClient, "", helpers.AdminToken, &chathttp.CreateChatDto{
+			Name: "Retention",
+			Type: uint8(chatdomain.GroupChatType),
+		})
+		chats = append(chats, chat)
+
+		if retention != nil {
+			helpers.UpdateChatRetention(httpClient, "", helpers.AdminToken, chat.ID, retention)
+		}
+
+		messageIDs := make([]uint64, 0, count)
+		for i := 0; i < count; i++ {
+			message := helpers.CreateMessage(httpClient, "", helpers.AdminToken, chat.ID, &chathttp.CreateMessageDto{
+				Text: "message",
+			})
+			messageIDs = append(messageIDs, message.ID)
+		}
+
+		return chat, messageIDs
+	}
+
+	// expiredMessageIDs returns the IDs of the messages of the chat that are
+	// due for purging under the global policy.
+	expiredMessageIDs := func(chatID uint64, global chatdomain.RetentionPolicy) []uint64 {
+		messages, err := fwk.GetMessageRepo().GetExpiredMessages(context.Background(), global, 1000, nil)
+		gomega.ExpectWithOffset(1, err).NotTo(gomega.HaveOccurred())
+
+		return lo.FilterMap(messages, func(message chatdomain.Message, _ int) (uint64, bool) {
+			return message.ID, message.ChatID == chatID
+		})
+	}
+
+	ginkgo.BeforeAll(func() {
+		httpClient = framework.NewTestHTTPClient(fwk).WithTimeout(helpers.Timeout)
+	})
+
+	ginkgo.AfterAll(func() {
+		for _, chat := range chats {
+			helpers.DeleteChat(httpClient, "", helpers.AdminToken, chat.ID)
+		}
+	})
+
+	ginkgo.It("should select all but the last messages of the chat", func() {
+		chat, messageIDs := createChatWithMessages(&chathttp.RetentionDto{MaxMessages: lo.ToPtr(uint(1))}, 3)
+
+		gomega.Expect(expiredMessageIDs(chat.ID, chatdomain.RetentionPolicy{})).To(gomega.ConsistOf(messageIDs[:2]))
+	})
+
+	ginkgo.It("should fall back to the global policy", func() {
+		chat, messageIDs := createChatWithMessages(nil, 3)
+
+		global := chatdomain.RetentionPolicy{MaxMessages: lo.ToPtr(uint(2))}
+		gomega.Expect(expiredMessageIDs(chat.ID, global)).To(gomega.ConsistOf(messageIDs[:1]))
+	})
+
+	ginkgo.It("should let the chat disable the global policy", func() {
+		chat, _ := createChatWithMessages(&chathttp.RetentionDto{MaxMessages: lo.ToPtr(uint(0))}, 3)
+
+		global := chatdomain.RetentionPolicy{MaxMessages: lo.ToPtr(uint(1))}
+		gomega.Expect(expiredMessageIDs(chat.ID, global)).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should keep recent messages under a days policy", func() {
+		chat, _ := createChatWithMessages(&chathttp.RetentionDto{Days: lo.ToPtr(uint(1))}, 2)
+
+		gomega.Expect(expiredMessageIDs(chat.ID, chatdomain.RetentionPolicy{})).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should skip chats under legal hold", func() {
+		chat, _ := createChatWithMessages(&chathttp.RetentionDto{MaxMessages: lo.ToPtr(uint(1)), LegalHold: true}, 3)
+
+		global := chatdomain.RetentionPolicy{MaxMessages: lo.ToPtr(uint(1))}
+		gomega.Expect(expiredMessageIDs(chat.ID, global)).To(gomega.BeEmpty())
+	})
+})
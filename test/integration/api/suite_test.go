@@ -21,7 +21,7 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
-	"chat-go/test/integration/framework"
+	"github.com/microcoretech/chat-go/test/integration/framework"
 )
 
 var (
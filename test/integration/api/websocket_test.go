@@ -0,0 +1,153 @@
+// Copyright MicroCore Tech
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/integration/framework"
+)
+
+var _ = ginkgo.Describe("Chat websocket", func() {
+	var wsURL string
+
+	ginkgo.BeforeEach(func() {
+		wsURL = fwk.WebsocketURL("/chats/ws")
+	})
+
+	expectAuthenticated := func(event connector.Event, eventType uint64, userID uint64) {
+		gomega.ExpectWithOffset(1, event.Type).To(gomega.Equal(eventType))
+
+		var data chatwebsocket.AuthenticatedEventData
+		gomega.ExpectWithOffset(1, json.Unmarshal(event.Data, &data)).To(gomega.Succeed())
+		gomega.ExpectWithOffset(1, data.UserID).To(gomega.Equal(userID))
+	}
+
+	ginkgo.Context("token query param", func() {
+		ginkgo.It("should be refused", func() {
+			conn, resp, err := helpers.DialWebsocket(wsURL+"?token="+helpers.AdminToken, nil)
+			if conn != nil {
+				conn.Close()
+			}
+
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(resp).NotTo(gomega.BeNil())
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusUnauthorized))
+		})
+	})
+
+	ginkgo.Context("subprotocol", func() {
+		ginkgo.It("should authenticate and only select chat-go", func() {
+			conn, resp, err := helpers.DialWebsocket(wsURL, helpers.BearerSubprotocols(helpers.AdminToken))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			gomega.Expect(resp.Header.Get("Sec-WebSocket-Protocol")).To(gomega.Equal(chatwebsocket.Subprotocol))
+			gomega.Expect(conn.Subprotocol()).To(gomega.Equal(chatwebsocket.Subprotocol))
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.RefreshTokenEventType, chatwebsocket.RefreshTokenEventData{
+				Token: helpers.AdminToken,
+			})
+
+			expectAuthenticated(helpers.ReadWebsocketEvent(conn, helpers.Timeout), chatwebsocket.TokenRefreshedEventType, helpers.AdminID)
+		})
+
+		ginkgo.It("should refuse invalid tokens", func() {
+			conn, resp, err := helpers.DialWebsocket(wsURL, helpers.BearerSubprotocols("invalid"))
+			if conn != nil {
+				conn.Close()
+			}
+
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(resp).NotTo(gomega.BeNil())
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusUnauthorized))
+		})
+	})
+
+	ginkgo.Context("first frame", func() {
+		ginkgo.It("should authenticate with the authenticate event", func() {
+			conn, _, err := helpers.DialWebsocket(wsURL, []string{chatwebsocket.Subprotocol})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.AuthenticateEventType, chatwebsocket.AuthenticateEventData{
+				Token: helpers.UserToken,
+			})
+
+			expectAuthenticated(helpers.ReadWebsocketEvent(conn, helpers.Timeout), chatwebsocket.AuthenticatedEventType, helpers.UserID)
+		})
+
+		ginkgo.It("should close with 4401 on invalid credentials", func() {
+			conn, _, err := helpers.DialWebsocket(wsURL, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.AuthenticateEventType, chatwebsocket.AuthenticateEventData{
+				Token: "invalid",
+			})
+
+			helpers.ExpectWebsocketClose(conn, helpers.Timeout, connector.CloseAuthenticationFailed)
+		})
+
+		ginkgo.It("should close with 4401 when another event comes first", func() {
+			conn, _, err := helpers.DialWebsocket(wsURL, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.RefreshTokenEventType, chatwebsocket.RefreshTokenEventData{
+				Token: helpers.AdminToken,
+			})
+
+			helpers.ExpectWebsocketClose(conn, helpers.Timeout, connector.CloseAuthenticationFailed)
+		})
+
+		ginkgo.It("should close with 4408 when no event comes in time", func() {
+			conn, _, err := helpers.DialWebsocket(wsURL, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			helpers.ExpectWebsocketClose(conn, framework.WebsocketAuthTimeout+helpers.Timeout, connector.CloseAuthenticationTimeout)
+		})
+	})
+
+	ginkgo.Context("token refresh", func() {
+		ginkgo.It("should keep the credentials when the token belongs to another user", func() {
+			conn, _, err := helpers.DialWebsocket(wsURL, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer conn.Close()
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.AuthenticateEventType, chatwebsocket.AuthenticateEventData{
+				Token: helpers.AdminToken,
+			})
+			expectAuthenticated(helpers.ReadWebsocketEvent(conn, helpers.Timeout), chatwebsocket.AuthenticatedEventType, helpers.AdminID)
+
+			helpers.SendWebsocketEvent(conn, chatwebsocket.RefreshTokenEventType, chatwebsocket.RefreshTokenEventData{
+				Token: helpers.UserToken,
+			})
+			helpers.SendWebsocketEvent(conn, chatwebsocket.RefreshTokenEventType, chatwebsocket.RefreshTokenEventData{
+				Token: helpers.AdminToken,
+			})
+
+			expectAuthenticated(helpers.ReadWebsocketEvent(conn, helpers.Timeout), chatwebsocket.TokenRefreshedEventType, helpers.AdminID)
+		})
+	})
+})
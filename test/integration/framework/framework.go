@@ -17,29 +17,42 @@ package framework
 import (
 	"context"
 	"database/sql"
+	"net"
+	"time"
 
+	"github.com/caarlos0/env/v11"
 	"github.com/gofiber/fiber/v2"
 	"github.com/onsi/ginkgo/v2/dsl/core"
 	"github.com/sirupsen/logrus"
 
-	chatdomain "chat-go/internal/chat/domain"
-	chathttp "chat-go/internal/chat/http"
-	chatrepository "chat-go/internal/chat/repository"
-	chatwebsocket "chat-go/internal/chat/websocket"
-	"chat-go/internal/common/repository"
-	"chat-go/internal/infrastructure/api"
-	"chat-go/internal/infrastructure/configs"
-	"chat-go/internal/infrastructure/connector"
-	"chat-go/internal/infrastructure/database/postgres"
-	"chat-go/internal/infrastructure/logger"
-	loggerlogrus "chat-go/internal/infrastructure/logger/logrus"
-	"chat-go/internal/infrastructure/validator"
-	usercontract "chat-go/internal/user/contract"
-	userdomain "chat-go/internal/user/domain"
-	userhttp "chat-go/internal/user/http"
-	"chat-go/test/helpers"
+	botdomain "github.com/microcoretech/chat-go/internal/bot/domain"
+	botrepository "github.com/microcoretech/chat-go/internal/bot/repository"
+	chatdomain "github.com/microcoretech/chat-go/internal/chat/domain"
+	chathttp "github.com/microcoretech/chat-go/internal/chat/http"
+	chatrepository "github.com/microcoretech/chat-go/internal/chat/repository"
+	chatwebsocket "github.com/microcoretech/chat-go/internal/chat/websocket"
+	"github.com/microcoretech/chat-go/internal/common/repository"
+	"github.com/microcoretech/chat-go/internal/infrastructure/api"
+	"github.com/microcoretech/chat-go/internal/infrastructure/configs"
+	"github.com/microcoretech/chat-go/internal/infrastructure/connector"
+	"github.com/microcoretech/chat-go/internal/infrastructure/database/postgres"
+	"github.com/microcoretech/chat-go/internal/infrastructure/logger"
+	loggerlogrus "github.com/microcoretech/chat-go/internal/infrastructure/logger/logrus"
+	"github.com/microcoretech/chat-go/internal/infrastructure/validator"
+	outboxconstants "github.com/microcoretech/chat-go/internal/outbox/constants"
+	outboxcontract "github.com/microcoretech/chat-go/internal/outbox/contract"
+	outboxdomain "github.com/microcoretech/chat-go/internal/outbox/domain"
+	outboxrepository "github.com/microcoretech/chat-go/internal/outbox/repository"
+	usercontract "github.com/microcoretech/chat-go/internal/user/contract"
+	userdomain "github.com/microcoretech/chat-go/internal/user/domain"
+	userhttp "github.com/microcoretech/chat-go/internal/user/http"
+	"github.com/microcoretech/chat-go/test/helpers"
 )
 
+// WebsocketAuthTimeout is short, so the timeout of the first frame can be
+// tested.
+const WebsocketAuthTimeout = time.Second
+
 type Framework struct {
 	*helpers.Infrastructure
 
@@ -48,13 +61,24 @@ type Framework struct {
 	dbConn   *sql.DB
 	validate validator.Validate
 
-	baseRepo     *repository.BaseRepoImpl
-	userChatRepo *chatrepository.UserChatRepoImpl
-	chatRepo     *chatrepository.ChatRepoImpl
+	baseRepo             *repository.BaseRepoImpl
+	userChatRepo         *chatrepository.UserChatRepoImpl
+	chatRepo             *chatrepository.ChatRepoImpl
+	messageRepo          *chatrepository.MessageRepoImpl
+	scheduledMessageRepo *chatrepository.ScheduledMessageRepoImpl
+	pollRepo             *chatrepository.PollRepoImpl
+	externalCommandRepo  *chatrepository.ExternalCommandRepoImpl
+	outboxRepo           *outboxrepository.OutboxRepoImpl
+	botRepo              *botrepository.BotRepoImpl
+	folderRepo           *chatrepository.FolderRepoImpl
+
+	eventPublisher *outboxcontract.EventPublisherContractImpl
 
 	userService    *userdomain.UserServiceImpl
+	botService     *botdomain.BotServiceImpl
 	chatService    *chatdomain.ChatServiceImpl
 	messageService *chatdomain.MessageServiceImpl
+	pollService    *chatdomain.PollServiceImpl
 
 	userServiceContract *usercontract.UserServiceContractImpl
 
@@ -65,7 +89,11 @@ type Framework struct {
 	chatController *chathttp.ChatController
 	eventHandler   *chatwebsocket.EventHandler
 
-	app *fiber.App
+	app      *fiber.App
+	listener net.Listener
+
+	// cancel stops the outbox workers delivering events to the websocket.
+	cancel context.CancelFunc
 }
 
 func NewFramework() *Framework {
@@ -80,10 +108,15 @@ func (f *Framework) Setup(ctx context.Context) error {
 		return err
 	}
 
-	f.cfg = &configs.Config{
-		LogLevel: logger.DebugLevel,
+	// Defaults of the env tags, tests override what they depend on.
+	f.cfg = &configs.Config{}
+	if err := env.Parse(f.cfg); err != nil {
+		return err
 	}
 
+	f.cfg.LogLevel = logger.DebugLevel
+	f.cfg.WebsocketAuthTimeout = WebsocketAuthTimeout
+
 	f.log, err = loggerlogrus.NewLogger(f.cfg.LogLevel)
 	if err != nil {
 		return err
@@ -118,19 +151,112 @@ func (f *Framework) Setup(ctx context.Context) error {
 	f.baseRepo = repository.NewBaseRepoImpl(f.dbConn)
 	f.chatRepo = chatrepository.NewChatRepoImpl(f.dbConn)
 	f.userChatRepo = chatrepository.NewUserChatRepoImpl(f.dbConn)
+	f.messageRepo = chatrepository.NewMessageRepoImpl(f.dbConn)
+	f.scheduledMessageRepo = chatrepository.NewScheduledMessageRepoImpl(f.dbConn)
+	f.pollRepo = chatrepository.NewPollRepoImpl(f.dbConn)
+	f.externalCommandRepo = chatrepository.NewExternalCommandRepoImpl(f.dbConn)
 	f.userService = userdomain.NewUserServiceImpl(f.cfg)
-	f.chatService = chatdomain.NewChatServiceImpl(f.baseRepo, f.chatRepo, f.userChatRepo, f.userService)
-	f.userServiceContract = usercontract.NewUserServiceContractImpl(f.userService)
+	f.outboxRepo = outboxrepository.NewOutboxRepoImpl(f.dbConn)
+	f.eventPublisher = outboxcontract.NewEventPublisherContractImpl(f.outboxRepo)
+	f.folderRepo = chatrepository.NewFolderRepoImpl(f.dbConn)
+	f.botRepo = botrepository.NewBotRepoImpl(f.dbConn)
+	f.botService = botdomain.NewBotServiceImpl(f.botRepo)
+	f.userServiceContract = usercontract.NewUserServiceContractImpl(f.userService, f.botService)
+	f.chatService = chatdomain.NewChatServiceImpl(
+		f.baseRepo,
+		f.chatRepo,
+		f.userChatRepo,
+		f.folderRepo,
+		f.userServiceContract,
+		f.eventPublisher,
+	)
+
+	commandRouter := chatdomain.NewCommandRouter(f.externalCommandRepo, chatdomain.NewExternalCommandCallerImpl(f.cfg, f.log))
+
+	f.messageService = chatdomain.NewMessageServiceImpl(
+		f.cfg,
+		f.baseRepo,
+		f.chatRepo,
+		f.userChatRepo,
+		f.messageRepo,
+		f.scheduledMessageRepo,
+		f.pollRepo,
+		f.userServiceContract,
+		f.eventPublisher,
+		commandRouter,
+	)
+	f.pollService = chatdomain.NewPollServiceImpl(f.baseRepo, f.chatRepo, f.messageRepo, f.pollRepo, f.eventPublisher)
 	f.authMiddleware = userhttp.NewAuthMiddleware(f.userService)
+	f.eventHandler = chatwebsocket.NewEventHandler(f.validate, f.messageService, f.pollService, f.userServiceContract)
 	f.connector = connector.NewConnector(f.log, f.eventHandler)
 	f.userController = userhttp.NewUserController(f.validate, f.authMiddleware, f.userService)
-	f.chatController = chathttp.NewChatController(f.validate, f.authMiddleware, f.chatService, f.messageService, f.connector)
+	f.chatController = chathttp.NewChatController(f.cfg, f.validate, f.authMiddleware, f.chatService, f.messageService, f.connector, f.userServiceContract)
 	f.app = api.NewApp(f.cfg, f.log, f.userController, f.chatController)
-	f.eventHandler = chatwebsocket.NewEventHandler(f.validate, f.messageService)
+
+	// Websockets can't go through App().Test, they need a real listener.
+	f.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		_ = f.app.Listener(f.listener)
+	}()
+
+	return f.startOutbox()
+}
+
+// startOutbox runs the outbox dispatcher and receiver, so events reach the
+// websocket connections as they do in the service.
+func (f *Framework) startOutbox() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+
+	wakeup, err := postgres.NewListener(ctx, f.cfg.PostgresURI, outboxconstants.NotificationChannel)
+	if err != nil {
+		return err
+	}
+
+	broadcasts, err := postgres.NewPayloadListener(ctx, f.cfg.PostgresURI, outboxconstants.BroadcastNotificationChannel)
+	if err != nil {
+		return err
+	}
+
+	dispatcher := outboxdomain.NewDispatcher(f.cfg, f.log, f.baseRepo, f.outboxRepo, wakeup,
+		outboxdomain.NewBroadcastSink(f.outboxRepo))
+	receiver := outboxdomain.NewReceiver(f.cfg, f.log, f.outboxRepo, broadcasts,
+		chatwebsocket.NewEventSink(f.log, f.connector))
+
+	go func() {
+		_ = dispatcher.Start(ctx)
+	}()
+
+	go func() {
+		_ = receiver.Start(ctx)
+	}()
 
 	return nil
 }
 
+func (f *Framework) Teardown(ctx context.Context) error {
+	if f.cancel != nil {
+		f.cancel()
+	}
+
+	if f.app != nil {
+		if err := f.app.ShutdownWithContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return f.Infrastructure.Teardown(ctx)
+}
+
+// WebsocketURL returns the URL of the path on the running server.
+func (f *Framework) WebsocketURL(path string) string {
+	return "ws://" + f.listener.Addr().String() + path
+}
+
 func (f *Framework) App() *fiber.App {
 	return f.app
 }
@@ -138,3 +264,7 @@ func (f *Framework) App() *fiber.App {
 func (f *Framework) GetChatController() *chathttp.ChatController {
 	return f.chatController
 }
+
+func (f *Framework) GetMessageRepo() chatdomain.MessageRepo {
+	return f.messageRepo
+}
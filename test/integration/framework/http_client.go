@@ -18,7 +18,7 @@ import (
 	"net/http"
 	"time"
 
-	"chat-go/test/helpers"
+	"github.com/microcoretech/chat-go/test/helpers"
 )
 
 var _ helpers.HTTPClient = (*TestHTTPClient)(nil)